@@ -0,0 +1,418 @@
+// Локальный фейк Telegram Bot API для разработки NotificationService без настоящего бота
+//
+// Поддерживает методы getMe, sendMessage, answerCallbackQuery, setWebhook, deleteWebhook и getUpdates.
+// Отправленные ботом сообщения доступны через GET /messages,
+// входящее сообщение пользователя имитируется через POST /updates:
+//
+//	curl -X POST localhost:8086/updates -d '{"user_id": 123456789, "text": "/start"}'
+//
+// Нажатие inline кнопки - поле callback_data, отправка геопозиции - latitude и longitude:
+//
+//	curl -X POST localhost:8086/updates -d '{"user_id": 123456789, "callback_data": "cars:add"}'
+//	curl -X POST localhost:8086/updates -d '{"user_id": 123456789, "latitude": 55.75, "longitude": 37.62}'
+//
+// Если бот установил webhook, обновление отправляется на него с secret_token из setWebhook
+// в заголовке X-Telegram-Bot-Api-Secret-Token, иначе ждёт getUpdates.
+// Повторная доставка обновления на webhook, как при таймауте ответа, имитируется через
+// POST /updates/redeliver?update_id=N.
+// Пользователи из FAKE_TELEGRAM_BLOCKED_USERS (через запятую) считаются заблокировавшими бота.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxPollTimeout = 50 * time.Second
+
+type apiResponse struct {
+	OK          bool        `json:"ok"`
+	Result      interface{} `json:"result,omitempty"`
+	ErrorCode   int         `json:"error_code,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+type user struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	UserName  string `json:"username,omitempty"`
+}
+
+type chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type entity struct {
+	Type   string `json:"type"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+}
+
+type message struct {
+	MessageID int       `json:"message_id"`
+	From      *user     `json:"from,omitempty"`
+	Chat      chat      `json:"chat"`
+	Date      int64     `json:"date"`
+	Text      string    `json:"text"`
+	Entities  []entity  `json:"entities,omitempty"`
+	Location  *location `json:"location,omitempty"`
+	ParseMode string    `json:"parse_mode,omitempty"` // только в фейке: разметка, с которой бот отправил текст
+
+	ReplyMarkup json.RawMessage `json:"reply_markup,omitempty"` // inline клавиатура, как её передал бот
+}
+
+type location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type callbackQuery struct {
+	ID      string   `json:"id"`
+	From    *user    `json:"from"`
+	Message *message `json:"message,omitempty"`
+	Data    string   `json:"data"`
+}
+
+type update struct {
+	UpdateID      int            `json:"update_id"`
+	Message       *message       `json:"message,omitempty"`
+	CallbackQuery *callbackQuery `json:"callback_query,omitempty"`
+}
+
+// server хранит состояние фейка в памяти
+type server struct {
+	bot     user
+	blocked map[int64]bool
+
+	mu           sync.Mutex
+	webhookURL   string
+	secretToken  string
+	nextUpdateID int
+	nextMsgID    int
+	pending      []update
+	history      map[int]update // все обновления по update_id для повторной доставки
+	sent         []message
+	notify       chan struct{} // закрывается при появлении нового обновления
+}
+
+func main() {
+	addr := flag.String("addr", ":8086", "listen address")
+	flag.Parse()
+
+	s := &server{
+		bot:          user{ID: 1, IsBot: true, FirstName: "SMC", UserName: "smc_fake_bot"},
+		blocked:      parseBlocked(os.Getenv("FAKE_TELEGRAM_BLOCKED_USERS")),
+		nextUpdateID: 1,
+		nextMsgID:    1,
+		history:      make(map[int]update),
+		notify:       make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/messages", s.handleMessages)
+	mux.HandleFunc("/updates", s.handleInjectUpdate)
+	mux.HandleFunc("/updates/redeliver", s.handleRedeliver)
+	mux.HandleFunc("/", s.handleBotAPI)
+
+	log.Printf("Fake Telegram Bot API listening on %s (api_endpoint = \"http://localhost%s/bot%%s/%%s\")", *addr, *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// handleBotAPI обрабатывает /bot<token>/<method>
+func (s *server) handleBotAPI(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "bot") {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		respondError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+
+	switch parts[1] {
+	case "getMe":
+		respondOK(w, s.bot)
+	case "sendMessage":
+		s.sendMessage(w, r)
+	case "answerCallbackQuery":
+		log.Printf("answerCallbackQuery: id=%s text=%q", r.FormValue("callback_query_id"), r.FormValue("text"))
+		respondOK(w, true)
+	case "setWebhook":
+		s.mu.Lock()
+		s.webhookURL = r.FormValue("url")
+		s.secretToken = r.FormValue("secret_token")
+		s.mu.Unlock()
+		log.Printf("setWebhook: %s (secret_token set: %t)", r.FormValue("url"), r.FormValue("secret_token") != "")
+		respondOK(w, true)
+	case "deleteWebhook":
+		s.mu.Lock()
+		s.webhookURL = ""
+		s.secretToken = ""
+		s.mu.Unlock()
+		respondOK(w, true)
+	case "getUpdates":
+		s.getUpdates(w, r)
+	default:
+		respondError(w, http.StatusNotFound, "Not Found: method "+parts[1]+" is not supported by the fake")
+	}
+}
+
+func (s *server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.FormValue("chat_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Bad Request: chat not found")
+		return
+	}
+	if s.blocked[chatID] {
+		respondError(w, http.StatusForbidden, "Forbidden: bot was blocked by the user")
+		return
+	}
+
+	s.mu.Lock()
+	msg := message{
+		MessageID: s.nextMsgID,
+		From:      &s.bot,
+		Chat:      chat{ID: chatID, Type: "private"},
+		Date:      time.Now().Unix(),
+		Text:      r.FormValue("text"),
+		ParseMode: r.FormValue("parse_mode"),
+	}
+	if markup := r.FormValue("reply_markup"); json.Valid([]byte(markup)) {
+		msg.ReplyMarkup = json.RawMessage(markup)
+	}
+	s.nextMsgID++
+	s.sent = append(s.sent, msg)
+	s.mu.Unlock()
+
+	log.Printf("sendMessage: chat_id=%d parse_mode=%q text=%q", chatID, msg.ParseMode, msg.Text)
+	respondOK(w, msg)
+}
+
+// getUpdates отдаёт накопленные обновления, ожидая новые не дольше timeout
+func (s *server) getUpdates(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.FormValue("offset"))
+	timeout, _ := strconv.Atoi(r.FormValue("timeout"))
+	wait := min(time.Duration(timeout)*time.Second, maxPollTimeout)
+	deadline := time.After(wait)
+
+	for {
+		s.mu.Lock()
+		if s.webhookURL != "" {
+			s.mu.Unlock()
+			respondError(w, http.StatusConflict, "Conflict: can't use getUpdates method while webhook is active")
+			return
+		}
+
+		// Подтверждённые через offset обновления больше не отдаём
+		kept := s.pending[:0]
+		for _, u := range s.pending {
+			if u.UpdateID >= offset {
+				kept = append(kept, u)
+			}
+		}
+		s.pending = kept
+
+		if len(s.pending) > 0 || wait == 0 {
+			result := append([]update(nil), s.pending...)
+			s.mu.Unlock()
+			respondOK(w, result)
+			return
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-deadline:
+			wait = 0
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleInjectUpdate имитирует сообщение пользователя боту
+func (s *server) handleInjectUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID       int64    `json:"user_id"`
+		FirstName    string   `json:"first_name"`
+		Text         string   `json:"text"`
+		CallbackData string   `json:"callback_data"`
+		Latitude     *float64 `json:"latitude"`
+		Longitude    *float64 `json:"longitude"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	hasLocation := req.Latitude != nil && req.Longitude != nil
+	if err != nil || req.UserID == 0 || (req.Text == "" && req.CallbackData == "" && !hasLocation) {
+		http.Error(w, "expected {\"user_id\": ..., \"text\" | \"callback_data\" | \"latitude\" and \"longitude\": ...}", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	from := &user{ID: req.UserID, FirstName: req.FirstName}
+	msg := &message{
+		MessageID: s.nextMsgID,
+		From:      from,
+		Chat:      chat{ID: req.UserID, Type: "private"},
+		Date:      time.Now().Unix(),
+		Text:      req.Text,
+	}
+	u := update{UpdateID: s.nextUpdateID}
+	switch {
+	case req.CallbackData != "":
+		// Кнопка считается нажатой под последним сообщением бота в чате, вместе с его клавиатурой
+		msg.From = &s.bot
+		for i := len(s.sent) - 1; i >= 0; i-- {
+			if s.sent[i].Chat.ID == req.UserID {
+				sent := s.sent[i]
+				msg = &sent
+				break
+			}
+		}
+		u.CallbackQuery = &callbackQuery{
+			ID:      strconv.Itoa(s.nextUpdateID),
+			From:    from,
+			Message: msg,
+			Data:    req.CallbackData,
+		}
+	case hasLocation:
+		msg.Location = &location{Latitude: *req.Latitude, Longitude: *req.Longitude}
+		u.Message = msg
+	default:
+		if strings.HasPrefix(req.Text, "/") {
+			command := strings.Fields(req.Text)[0]
+			msg.Entities = []entity{{Type: "bot_command", Offset: 0, Length: len(command)}}
+		}
+		u.Message = msg
+	}
+	s.nextUpdateID++
+	s.nextMsgID++
+	s.history[u.UpdateID] = u
+	webhookURL, secretToken := s.webhookURL, s.secretToken
+	if webhookURL == "" {
+		s.pending = append(s.pending, u)
+		close(s.notify)
+		s.notify = make(chan struct{})
+	}
+	s.mu.Unlock()
+
+	if webhookURL != "" {
+		if err := deliverWebhook(webhookURL, secretToken, u); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, u)
+}
+
+// handleRedeliver повторно отправляет обновление на webhook
+// В режиме long polling неподтверждённые обновления повторяются сами после рестарта бота
+func (s *server) handleRedeliver(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	updateID, _ := strconv.Atoi(r.URL.Query().Get("update_id"))
+
+	s.mu.Lock()
+	u, ok := s.history[updateID]
+	webhookURL, secretToken := s.webhookURL, s.secretToken
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "update not found", http.StatusNotFound)
+		return
+	}
+	if webhookURL == "" {
+		http.Error(w, "webhook is not set", http.StatusConflict)
+		return
+	}
+	if err := deliverWebhook(webhookURL, secretToken, u); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, u)
+}
+
+// deliverWebhook отправляет обновление на webhook бота, как это делает Telegram
+func deliverWebhook(webhookURL, secretToken string, u update) error {
+	body, _ := json.Marshal(u)
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook delivery failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secretToken != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secretToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery failed: %v", err)
+	}
+	resp.Body.Close()
+	log.Printf("update %d delivered to webhook: status=%d", u.UpdateID, resp.StatusCode)
+	return nil
+}
+
+// handleMessages возвращает сообщения, отправленные ботом; ?chat_id= фильтрует по чату
+func (s *server) handleMessages(w http.ResponseWriter, r *http.Request) {
+	chatID, _ := strconv.ParseInt(r.URL.Query().Get("chat_id"), 10, 64)
+
+	s.mu.Lock()
+	result := make([]message, 0, len(s.sent))
+	for _, msg := range s.sent {
+		if chatID == 0 || msg.Chat.ID == chatID {
+			result = append(result, msg)
+		}
+	}
+	s.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func parseBlocked(value string) map[int64]bool {
+	blocked := make(map[int64]bool)
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+			blocked[id] = true
+		}
+	}
+	return blocked
+}
+
+func respondOK(w http.ResponseWriter, result interface{}) {
+	respondJSON(w, http.StatusOK, apiResponse{OK: true, Result: result})
+}
+
+func respondError(w http.ResponseWriter, status int, description string) {
+	respondJSON(w, status, apiResponse{OK: false, ErrorCode: status, Description: description})
+}
+
+func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // часовые пояса серий не зависят от zoneinfo образа
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/gorilla/mux"
@@ -17,22 +21,68 @@ import (
 
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/cancel_batch_notification"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/cancel_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/confirm_unsubscribe"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_batch_notification"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_recurring_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/delete_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/discard_dead_letter"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/erase_user_data"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/export_user_data"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_channel_settings"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_notification_settings"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_recurring_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_template_versions"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_vapid_public_key"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/health"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_dead_letters"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_templates"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/preview_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/requeue_dead_letter"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/save_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/subscribe_push"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/telegram_webhook"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/unsubscribe_by_token"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/unsubscribe_push"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_channel_settings"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_notification_settings"
 	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/channels/email"
+	"github.com/m04kA/SMC-NotificationService/internal/channels/sink"
+	telegramChannel "github.com/m04kA/SMC-NotificationService/internal/channels/telegram"
+	"github.com/m04kA/SMC-NotificationService/internal/channels/webpush"
 	"github.com/m04kA/SMC-NotificationService/internal/config"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/channelsettings"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/conversation"
 	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/pushsubscription"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/servicenonce"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/telegramlimit"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/telegramupdate"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/template"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/priceservice"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/sellerservice"
 	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters"
+	"github.com/m04kA/SMC-NotificationService/internal/service/delivery"
 	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
 	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/bot_dialog"
 	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/update_dedup"
 	"github.com/m04kA/SMC-NotificationService/internal/worker"
 	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/jwtauth"
 	"github.com/m04kA/SMC-NotificationService/pkg/logger"
 	"github.com/m04kA/SMC-NotificationService/pkg/metrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/svcauth"
+	"github.com/m04kA/SMC-NotificationService/pkg/unsubscribe"
 )
 
 func main() {
@@ -83,15 +133,46 @@ func main() {
 	log.Info("Successfully connected to database (host=%s, port=%d, db=%s)",
 		cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
 
-	// Инициализируем repository
+	// Инициализируем repositories
 	var notificationRepo *notification.Repository
+	var settingsRepo *channelsettings.Repository
+	var subscriptionRepo *pushsubscription.Repository
+	var templateRepo *template.Repository
+	var preferencesRepo *preferences.Repository
+	var conversationRepo *conversation.Repository
+	var processedUpdatesRepo *telegramupdate.Repository
+	var serviceNonceRepo *servicenonce.Repository
+	var rateLimitRepo *telegramlimit.Repository
+
+	// Лимиты Bot API действуют на токен бота, поэтому хранятся в БД и общие для всех экземпляров
+	rateLimits := domain.TelegramRateLimits{
+		GlobalRate: cfg.Telegram.RateLimit.GlobalRate,
+		ChatRate:   cfg.Telegram.RateLimit.ChatRate,
+		GroupRate:  cfg.Telegram.RateLimit.GroupRatePerMinute / 60,
+	}
 
 	if cfg.Metrics.Enabled {
 		wrappedDB = dbmetrics.WrapWithDefault(db, metricsCollector, cfg.Metrics.ServiceName, stopMetricsCh)
 		log.Info("Database metrics collection started")
 		notificationRepo = notification.NewRepository(wrappedDB)
+		settingsRepo = channelsettings.NewRepository(wrappedDB)
+		subscriptionRepo = pushsubscription.NewRepository(wrappedDB)
+		templateRepo = template.NewRepository(wrappedDB)
+		preferencesRepo = preferences.NewRepository(wrappedDB)
+		conversationRepo = conversation.NewRepository(wrappedDB)
+		processedUpdatesRepo = telegramupdate.NewRepository(wrappedDB)
+		serviceNonceRepo = servicenonce.NewRepository(wrappedDB)
+		rateLimitRepo = telegramlimit.NewRepository(wrappedDB, rateLimits)
 	} else {
 		notificationRepo = notification.NewRepository(db)
+		settingsRepo = channelsettings.NewRepository(db)
+		subscriptionRepo = pushsubscription.NewRepository(db)
+		templateRepo = template.NewRepository(db)
+		preferencesRepo = preferences.NewRepository(db)
+		conversationRepo = conversation.NewRepository(db)
+		processedUpdatesRepo = telegramupdate.NewRepository(db)
+		serviceNonceRepo = servicenonce.NewRepository(db)
+		rateLimitRepo = telegramlimit.NewRepository(db, rateLimits)
 	}
 
 	// Создаём контекст с возможностью отмены для управления жизненным циклом горутин
@@ -102,11 +183,18 @@ func main() {
 	userServiceClient := userservice.NewClient(
 		cfg.UserService.URL,
 		time.Duration(cfg.UserService.Timeout)*time.Second,
+		cfg.InternalAuth.Credentials(),
 	)
 	log.Info("UserService client initialized (url=%s)", cfg.UserService.URL)
 
+	// Инициализируем интеграции с SellerService и PriceService для команд бота /washes и /price
+	sellerServiceClient := sellerservice.NewClient(cfg.SellerService.URL, time.Duration(cfg.SellerService.Timeout)*time.Second)
+	priceServiceClient := priceservice.NewClient(cfg.PriceService.URL, time.Duration(cfg.PriceService.Timeout)*time.Second)
+	log.Info("SellerService and PriceService clients initialized (seller=%s, price=%s)", cfg.SellerService.URL, cfg.PriceService.URL)
+
 	// Инициализируем Telegram Bot API
-	bot, err := tgbotapi.NewBotAPI(cfg.Telegram.BotToken)
+	// api_endpoint позволяет работать с локальным фейком Bot API (cmd/faketelegram)
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint(cfg.Telegram.BotToken, cfg.Telegram.APIEndpoint)
 	if err != nil {
 		log.Fatal("Failed to initialize Telegram Bot API: %v", err)
 	}
@@ -116,16 +204,126 @@ func main() {
 	telegramSvc := telegram.NewService(bot)
 	log.Info("Telegram service initialized")
 
+	// Инициализируем каналы доставки
+	// Каналы в режиме sink пишут сообщения в stdout или файл вместо реальной отправки
+	messageSink, err := sink.Open(cfg.Channels.Sink)
+	if err != nil {
+		log.Fatal("Failed to open channels sink: %v", err)
+	}
+	defer messageSink.Close()
+
+	// Ограничение скорости Telegram: общий лимит бота, лимит личного чата и более строгий лимит группы
+	var limiterMetrics telegramChannel.LimiterMetrics
+	var rateLimitMetrics worker.RateLimitMetrics
+	if metricsCollector != nil {
+		limiterMetrics = metricsCollector
+		rateLimitMetrics = metricsCollector
+	}
+	log.Info("Telegram rate limit (shared by all instances): global=%.1f/s, chat=%.1f/s, group=%.1f/min",
+		cfg.Telegram.RateLimit.GlobalRate, cfg.Telegram.RateLimit.ChatRate, cfg.Telegram.RateLimit.GroupRatePerMinute)
+
+	maxWait := time.Duration(cfg.Telegram.RateLimit.MaxWait) * time.Millisecond
+	enabledChannels := []channels.Channel{telegramChannel.New(telegramSvc, rateLimitRepo, maxWait, limiterMetrics)}
+
+	switch cfg.Channels.Email.Mode {
+	case config.ChannelModeSMTP:
+		enabledChannels = append(enabledChannels, email.New(email.Config{
+			Host:     cfg.Channels.Email.Host,
+			Port:     cfg.Channels.Email.Port,
+			Username: cfg.Channels.Email.Username,
+			Password: cfg.Channels.Email.Password,
+			From:     cfg.Channels.Email.From,
+			Subject:  cfg.Channels.Email.Subject,
+		}))
+	case config.ChannelModeSink:
+		enabledChannels = append(enabledChannels, sink.NewChannel(domain.ChannelEmail, messageSink))
+	}
+	log.Info("Email channel mode: %s", cfg.Channels.Email.Mode)
+
+	if cfg.Channels.SMS.Mode == config.ChannelModeSink {
+		enabledChannels = append(enabledChannels, sink.NewChannel(domain.ChannelSMS, messageSink))
+	}
+	log.Info("SMS channel mode: %s", cfg.Channels.SMS.Mode)
+
+	var vapidPublicKey string
+	switch cfg.Channels.WebPush.Mode {
+	case config.ChannelModeVAPID:
+		webPushChannel, err := webpush.New(webpush.Config{
+			PublicKey:  cfg.Channels.WebPush.VAPIDPublicKey,
+			PrivateKey: cfg.Channels.WebPush.VAPIDPrivateKey,
+			Subject:    cfg.Channels.WebPush.Subject,
+			Title:      cfg.Channels.WebPush.Title,
+			TTL:        cfg.Channels.WebPush.TTL,
+		}, subscriptionRepo)
+		if err != nil {
+			log.Fatal("Failed to initialize Web Push channel: %v", err)
+		}
+		vapidPublicKey = webPushChannel.PublicKey()
+		enabledChannels = append(enabledChannels, webPushChannel)
+	case config.ChannelModeSink:
+		enabledChannels = append(enabledChannels, sink.NewChannel(domain.ChannelWebPush, messageSink))
+	}
+	log.Info("Web Push channel mode: %s", cfg.Channels.WebPush.Mode)
+
+	// Ссылки отписки в один клик: без секрета сообщения отправляются без ссылки, а /unsubscribe отвечает 404
+	var unsubscribeLinks delivery.UnsubscribeLinks
+	var unsubscribeTokens notifications.UnsubscribeTokens
+	if cfg.Unsubscribe.Secret != "" {
+		signer, err := unsubscribe.NewSigner(cfg.Unsubscribe.Secret, strings.TrimRight(cfg.Unsubscribe.PublicURL, "/")+"/unsubscribe")
+		if err != nil {
+			log.Fatal("Failed to initialize unsubscribe links: %v", err)
+		}
+		unsubscribeLinks, unsubscribeTokens = signer, signer
+		log.Info("Unsubscribe links enabled (public_url=%s)", cfg.Unsubscribe.PublicURL)
+	} else {
+		log.Warn("Unsubscribe secret is not set, unsubscribe links are disabled")
+	}
+
+	deliverySvc := delivery.NewService(settingsRepo, subscriptionRepo, preferencesRepo, userServiceClient, unsubscribeLinks, enabledChannels...)
+	log.Info("Delivery service initialized (sink=%s)", cfg.Channels.Sink)
+
+	// Инициализируем Notifications Service
+	defaultChannels := make([]domain.Channel, len(cfg.Channels.Default))
+	for i, name := range cfg.Channels.Default {
+		defaultChannels[i] = domain.Channel(name)
+	}
+	templateSvc := templates.NewService(templateRepo)
+	deadLetterSvc := deadletters.NewService(notificationRepo)
+	notificationSvc := notifications.NewService(notificationRepo, notificationRepo, settingsRepo, preferencesRepo, subscriptionRepo, conversationRepo, userServiceClient, templateSvc, unsubscribeTokens, rateLimitRepo, defaultChannels)
+	log.Info("Notification service initialized (default channels=%v)", cfg.Channels.Default)
+
 	// Инициализируем use case для обработки /start
 	startMessageUC := start_message.New(telegramSvc, userServiceClient)
 	log.Info("Start message use case initialized")
 
+	// Инициализируем бота: команды, кнопки и диалоги; /start обрабатывает startMessageUC
+	botUC := bot_dialog.New(
+		telegramSvc,
+		startMessageUC,
+		conversationRepo,
+		userServiceClient,
+		sellerServiceClient,
+		priceServiceClient,
+		notificationSvc,
+		bot_dialog.Config{
+			ConversationTTL: time.Duration(cfg.Bot.ConversationTTL) * time.Minute,
+			SearchRadiusKm:  cfg.Bot.SearchRadiusKm,
+			SearchLimit:     cfg.Bot.SearchLimit,
+		},
+	)
+	log.Info("Bot use case initialized (conversation_ttl=%dm, search_radius=%gkm)", cfg.Bot.ConversationTTL, cfg.Bot.SearchRadiusKm)
+
+	// Повторно доставленные обновления (webhook и long polling) обрабатываются один раз
+	updateHandler := update_dedup.New(botUC, processedUpdatesRepo)
+	updatesCleaner := worker.NewUpdatesCleaner(processedUpdatesRepo, log)
+	rateLimitCleaner := worker.NewRateLimitCleaner(rateLimitRepo, rateLimitMetrics, log)
+
 	// Определяем режим работы: Webhook или Long Polling
 	if cfg.Telegram.WebhookURL != "" {
 		// Режим Webhook
 		log.Info("Using Webhook mode")
 
-		if err := telegramSvc.SetWebhook(cfg.Telegram.WebhookURL); err != nil {
+		if err := telegramSvc.SetWebhook(cfg.Telegram.WebhookURL, cfg.Telegram.WebhookSecret); err != nil {
 			log.Fatal("Failed to set Telegram webhook: %v", err)
 		}
 		log.Info("Telegram webhook set to %s", cfg.Telegram.WebhookURL)
@@ -138,7 +336,7 @@ func main() {
 		}
 
 		// Создаём polling handler
-		pollingHandler := worker.NewPollingHandler(startMessageUC, log)
+		pollingHandler := worker.NewPollingHandler(updateHandler, log)
 
 		// Запускаем long polling в фоне
 		updatesChan := telegramSvc.GetUpdatesChan(0)
@@ -146,19 +344,38 @@ func main() {
 		log.Info("Telegram long polling started")
 	}
 
-	// Инициализируем Notifications Service
-	notificationSvc := notifications.NewService(notificationRepo, userServiceClient)
-	log.Info("Notification service initialized")
-
 	// Инициализируем Worker компоненты
-	scheduler := worker.NewScheduler(notificationRepo, telegramSvc, log)
+	retryPolicy := worker.RetryPolicy{
+		MaxAttempts: cfg.Worker.MaxAttempts,
+		BaseDelay:   time.Duration(cfg.Worker.RetryBaseDelay) * time.Second,
+		MaxDelay:    time.Duration(cfg.Worker.RetryMaxDelay) * time.Second,
+	}
+	// Идентификатор экземпляра уникален для каждого запуска: после рестарта аренды прошлого запуска
+	// не продлеваются и истекают, даже если hostname не изменился
+	instanceID := fmt.Sprintf("%s-%s", cfg.Worker.InstanceName, randomSuffix())
+	lease := worker.NewLease(notificationRepo, instanceID, time.Duration(cfg.Worker.LeaseDuration)*time.Second, log)
+	var queueMetrics worker.QueueMetrics
+	if metricsCollector != nil {
+		queueMetrics = metricsCollector
+	}
+	scheduler := worker.NewScheduler(notificationRepo, deliverySvc, retryPolicy, lease, log)
 	processor := worker.NewProcessor(
 		notificationRepo,
-		telegramSvc,
+		deliverySvc,
+		retryPolicy,
+		lease,
+		queueMetrics,
 		log,
 		time.Duration(cfg.Worker.ProcessorInterval)*time.Second,
 		cfg.Worker.ProcessorBatchSize,
 	)
+	materializer := worker.NewMaterializer(
+		notificationRepo,
+		scheduler,
+		log,
+		time.Duration(cfg.Worker.SeriesInterval)*time.Second,
+		time.Duration(cfg.Worker.SeriesHorizon)*time.Hour,
+	)
 
 	// КРИТИЧНО: Запускаем scheduler ПЕРЕД загрузкой notifications
 	scheduler.Start()
@@ -173,17 +390,71 @@ func main() {
 
 	// Запускаем processor в фоне
 	go processor.Start()
+	go lease.Start()
+	go materializer.Start()
+	go updatesCleaner.Start()
+	go rateLimitCleaner.Start()
+	log.Info("Worker started: instance=%s, lease=%ds", instanceID, cfg.Worker.LeaseDuration)
 	log.Info("Notification processor started (interval=%ds, batch=%d)",
 		cfg.Worker.ProcessorInterval, cfg.Worker.ProcessorBatchSize)
+	log.Info("Series materializer started (interval=%ds, horizon=%dh)", cfg.Worker.SeriesInterval, cfg.Worker.SeriesHorizon)
 
 	// Инициализируем handlers
 	healthHandler := health.NewHandler()
 	createNotificationHandler := create_notification.NewHandler(notificationSvc, scheduler, log)
 	createBatchNotificationHandler := create_batch_notification.NewHandler(notificationSvc, scheduler, log)
+	createRecurringNotificationHandler := create_recurring_notification.NewHandler(notificationSvc, log)
+	getRecurringNotificationHandler := get_recurring_notification.NewHandler(notificationSvc, log)
 	listNotificationsHandler := list_notifications.NewHandler(notificationSvc, log)
+	getNotificationHandler := get_notification.NewHandler(notificationSvc, log)
 	cancelNotificationHandler := cancel_notification.NewHandler(notificationSvc, scheduler, log)
 	cancelBatchNotificationHandler := cancel_batch_notification.NewHandler(notificationSvc, log)
-	telegramWebhookHandler := telegram_webhook.NewHandler(startMessageUC, log)
+	telegramWebhookHandler := telegram_webhook.NewHandler(updateHandler, cfg.Telegram.WebhookSecret, log)
+	exportUserDataHandler := export_user_data.NewHandler(notificationSvc, log)
+	eraseUserDataHandler := erase_user_data.NewHandler(notificationSvc, log)
+	getChannelSettingsHandler := get_channel_settings.NewHandler(notificationSvc, log)
+	updateChannelSettingsHandler := update_channel_settings.NewHandler(notificationSvc, log)
+	getNotificationSettingsHandler := get_notification_settings.NewHandler(notificationSvc, log)
+	updateNotificationSettingsHandler := update_notification_settings.NewHandler(notificationSvc, log)
+	confirmUnsubscribeHandler := confirm_unsubscribe.NewHandler(notificationSvc, log)
+	unsubscribeHandler := unsubscribe_by_token.NewHandler(notificationSvc, log)
+	subscribePushHandler := subscribe_push.NewHandler(notificationSvc, log)
+	unsubscribePushHandler := unsubscribe_push.NewHandler(notificationSvc, log)
+	getVAPIDPublicKeyHandler := get_vapid_public_key.NewHandler(vapidPublicKey)
+	listTemplatesHandler := list_templates.NewHandler(templateSvc, log)
+	getTemplateVersionsHandler := get_template_versions.NewHandler(templateSvc, log)
+	saveTemplateHandler := save_template.NewHandler(templateSvc, log)
+	deleteTemplateHandler := delete_template.NewHandler(templateSvc, log)
+	previewTemplateHandler := preview_template.NewHandler(templateSvc, log)
+	listDeadLettersHandler := list_dead_letters.NewHandler(deadLetterSvc, log)
+	requeueDeadLetterHandler := requeue_dead_letter.NewHandler(deadLetterSvc, scheduler, log)
+	discardDeadLetterHandler := discard_dead_letter.NewHandler(deadLetterSvc, log)
+
+	// Инициализируем аутентификацию пользователей (управление шаблонами и dead-letter доступно только superuser)
+	authenticator, err := jwtauth.New(cfg.Auth.JWTAuth())
+	if err != nil {
+		log.Fatal("Failed to initialize authentication: %v", err)
+	}
+	defer authenticator.Close()
+	authMiddleware := middleware.NewAuthMiddleware(authenticator)
+	if authenticator.Mode() == jwtauth.ModeHeader {
+		log.Warn("Authentication mode is 'header': X-User-ID/X-User-Role are trusted without verification (local development only)")
+	} else {
+		log.Info("Authentication mode is 'jwt' (issuer=%s)", cfg.Auth.Issuer)
+	}
+
+	// Инициализируем проверку межсервисных запросов к /internal/users; nonce хранятся в БД, общей для всех экземпляров
+	verifierConfig := cfg.InternalAuth.Verifier()
+	verifierConfig.Nonces = serviceNonceRepo
+	serviceVerifier, err := svcauth.NewVerifier(verifierConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize internal authentication: %v", err)
+	}
+	if serviceVerifier.Mode() == svcauth.ModeNone {
+		log.Warn("Internal authentication mode is 'none': /internal/users routes are not protected (local development only)")
+	} else {
+		log.Info("Internal authentication mode is '%s' (%d services)", serviceVerifier.Mode(), len(cfg.InternalAuth.Keys))
+	}
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
@@ -200,7 +471,11 @@ func main() {
 
 	// Публичные endpoints
 	r.HandleFunc("/health", healthHandler.Handle).Methods(http.MethodGet)
-	r.HandleFunc("/webhook/telegram", telegramWebhookHandler.Handle).Methods(http.MethodPost)
+	if cfg.Telegram.WebhookURL != "" {
+		r.HandleFunc("/webhook/telegram", telegramWebhookHandler.Handle).Methods(http.MethodPost)
+	}
+	r.HandleFunc("/unsubscribe", confirmUnsubscribeHandler.Handle).Methods(http.MethodGet)
+	r.HandleFunc("/unsubscribe", unsubscribeHandler.Handle).Methods(http.MethodPost)
 
 	// Metrics endpoint (публичный)
 	if cfg.Metrics.Enabled {
@@ -214,10 +489,53 @@ func main() {
 	// Notifications endpoints
 	api.HandleFunc("/notifications", createNotificationHandler.Handle).Methods(http.MethodPost)
 	api.HandleFunc("/notifications/batch", createBatchNotificationHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/notifications/recurring", createRecurringNotificationHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/notifications/recurring/{span_id}", getRecurringNotificationHandler.Handle).Methods(http.MethodGet)
 	api.HandleFunc("/notifications", listNotificationsHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/notifications/{id}", getNotificationHandler.Handle).Methods(http.MethodGet)
 	api.HandleFunc("/notifications/{id}", cancelNotificationHandler.Handle).Methods(http.MethodDelete)
 	api.HandleFunc("/notifications/batch/{span_id}", cancelBatchNotificationHandler.Handle).Methods(http.MethodDelete)
 
+	api.HandleFunc("/push/vapid-public-key", getVAPIDPublicKeyHandler.Handle).Methods(http.MethodGet)
+
+	// Endpoints текущего пользователя: пользователь берётся из токена, а не из пути,
+	// чтобы никто не мог подменить чужой email или подписку Web Push и получать чужие уведомления
+	me := api.PathPrefix("/users/me").Subrouter()
+	me.Use(authMiddleware.Auth)
+
+	// Channels endpoints (порядок fallback, email и подписки Web Push)
+	me.HandleFunc("/channels", getChannelSettingsHandler.Handle).Methods(http.MethodGet)
+	me.HandleFunc("/channels", updateChannelSettingsHandler.Handle).Methods(http.MethodPut)
+	me.HandleFunc("/push-subscriptions", subscribePushHandler.Handle).Methods(http.MethodPost)
+	me.HandleFunc("/push-subscriptions", unsubscribePushHandler.Handle).Methods(http.MethodDelete)
+
+	// Notification settings endpoints (категории, предпочтительный канал, тихие часы)
+	me.HandleFunc("/notification-settings", getNotificationSettingsHandler.Handle).Methods(http.MethodGet)
+	me.HandleFunc("/notification-settings", updateNotificationSettingsHandler.Handle).Methods(http.MethodPut)
+
+	// Templates endpoints: предпросмотр доступен вызывающим сервисам, управление - только superuser
+	api.HandleFunc("/templates/{name}/preview", previewTemplateHandler.Handle).Methods(http.MethodPost)
+
+	protected := api.PathPrefix("/templates").Subrouter()
+	protected.Use(authMiddleware.Auth)
+	protected.HandleFunc("", listTemplatesHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/{name}", getTemplateVersionsHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/{name}", deleteTemplateHandler.Handle).Methods(http.MethodDelete)
+	protected.HandleFunc("/{name}/{locale}", saveTemplateHandler.Handle).Methods(http.MethodPut)
+
+	// Dead-letter endpoints: уведомления, которые не удалось доставить (только superuser)
+	deadLetters := api.PathPrefix("/dead-letters").Subrouter()
+	deadLetters.Use(authMiddleware.Auth)
+	deadLetters.HandleFunc("", listDeadLettersHandler.Handle).Methods(http.MethodGet)
+	deadLetters.HandleFunc("/{id:[0-9]+}/requeue", requeueDeadLetterHandler.Handle).Methods(http.MethodPost)
+	deadLetters.HandleFunc("/{id:[0-9]+}", discardDeadLetterHandler.Handle).Methods(http.MethodDelete)
+
+	// Internal endpoints (выгрузка и удаление персональных данных по запросу UserService)
+	internalUsers := r.PathPrefix("/internal/users").Subrouter()
+	internalUsers.Use(serviceVerifier.Middleware)
+	internalUsers.HandleFunc("/{tg_user_id:[0-9]+}/export", exportUserDataHandler.Handle).Methods(http.MethodGet)
+	internalUsers.HandleFunc("/{tg_user_id:[0-9]+}", eraseUserDataHandler.Handle).Methods(http.MethodDelete)
+
 	// Создаем HTTP сервер
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	srv := &http.Server{
@@ -243,9 +561,21 @@ func main() {
 
 	log.Info("Shutting down server...")
 
+	// Останавливаем long polling
+	cancelCtx()
+	if cfg.Telegram.WebhookURL == "" {
+		telegramSvc.StopReceivingUpdates()
+	}
+
 	// КРИТИЧНО: Останавливаем Worker ПЕРЕД сервером
+	materializer.Stop()
+	updatesCleaner.Stop()
+	rateLimitCleaner.Stop()
 	processor.Stop()
 	scheduler.Stop()
+	leaseCtx, leaseCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	lease.Stop(leaseCtx)
+	leaseCancel()
 	log.Info("Worker components stopped")
 
 	// Останавливаем сбор метрик
@@ -268,6 +598,13 @@ func main() {
 	log.Info("Server stopped gracefully")
 }
 
+// randomSuffix возвращает случайную часть идентификатора экземпляра
+func randomSuffix() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 // corsMiddleware добавляет CORS headers к ответам
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,17 @@
+package cancel_batch_notification
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	CancelBatch(ctx context.Context, spanID string) (*models.CancelBatchResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,43 @@
+package cancel_batch_notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle DELETE /api/v1/notifications/batch/{span_id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	spanID := mux.Vars(r)["span_id"]
+
+	result, err := h.service.CancelBatch(r.Context(), spanID)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			h.logger.Warn("DELETE /notifications/batch/{span_id} - Invalid span_id: %s", spanID)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("DELETE /notifications/batch/{span_id} - Failed to cancel batch: span_id=%s, error=%v", spanID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("DELETE /notifications/batch/{span_id} - Batch cancelled: span_id=%s, cancelled=%d", spanID, result.Cancelled)
+	handlers.RespondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,21 @@
+package cancel_notification
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	Cancel(ctx context.Context, id int64) (*models.NotificationResponse, error)
+}
+
+type Scheduler interface {
+	Cancel(id int64)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
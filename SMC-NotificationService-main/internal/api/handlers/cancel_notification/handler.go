@@ -0,0 +1,63 @@
+package cancel_notification
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+const (
+	msgInvalidID  = "invalid notification ID"
+	msgNotFound   = "notification not found"
+	msgNotPending = "notification is already sent or cancelled"
+)
+
+type Handler struct {
+	service   NotificationService
+	scheduler Scheduler
+	logger    Logger
+}
+
+func NewHandler(service NotificationService, scheduler Scheduler, logger Logger) *Handler {
+	return &Handler{
+		service:   service,
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle DELETE /api/v1/notifications/{id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("DELETE /notifications/{id} - Invalid notification ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	notification, err := h.service.Cancel(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrNotificationNotFound):
+			h.logger.Warn("DELETE /notifications/{id} - Notification not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+		case errors.Is(err, notifications.ErrNotPending):
+			h.logger.Warn("DELETE /notifications/{id} - Notification is not pending: id=%d", id)
+			handlers.RespondConflict(w, msgNotPending)
+		default:
+			h.logger.Error("DELETE /notifications/{id} - Failed to cancel notification: id=%d, error=%v", id, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.scheduler.Cancel(id)
+
+	h.logger.Info("DELETE /notifications/{id} - Notification cancelled: id=%d", id)
+	handlers.RespondJSON(w, http.StatusOK, notification)
+}
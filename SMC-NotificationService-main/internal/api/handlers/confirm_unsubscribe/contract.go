@@ -0,0 +1,17 @@
+package confirm_unsubscribe
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	UnsubscribePreview(ctx context.Context, token string) (*models.UnsubscribeResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,66 @@
+package confirm_unsubscribe
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+const (
+	msgInvalidToken        = "invalid unsubscribe link"
+	msgUnsubscribeDisabled = "unsubscribe links are disabled"
+)
+
+// confirmPage страница подтверждения: форма без action отправляет POST на адрес страницы вместе с токеном
+var confirmPage = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>Отписка от уведомлений</title></head>
+<body>
+<p>Отписаться от уведомлений категории «{{.Category}}»?</p>
+<form method="post">
+<button type="submit">Отписаться</button>
+</form>
+</body>
+</html>
+`))
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /unsubscribe?token=
+// Только показывает подтверждение: ссылку открывают превью мессенджеров и сканеры ссылок в почте,
+// и GET не должен отписывать пользователя. Отписка - POST на тот же адрес
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.UnsubscribePreview(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidUnsubscribeToken):
+			h.logger.Warn("GET /unsubscribe - Invalid token")
+			handlers.RespondBadRequest(w, msgInvalidToken)
+		case errors.Is(err, notifications.ErrUnsubscribeDisabled):
+			handlers.RespondNotFound(w, msgUnsubscribeDisabled)
+		default:
+			h.logger.Error("GET /unsubscribe - Failed to check unsubscribe link: error=%v", err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	if !handlers.AcceptsHTML(r) {
+		handlers.RespondJSON(w, http.StatusOK, result)
+		return
+	}
+	handlers.RespondHTML(w, http.StatusOK, confirmPage, result)
+}
@@ -0,0 +1,22 @@
+package create_batch_notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	CreateBatch(ctx context.Context, req *models.CreateBatchNotificationRequest) (*models.BatchNotificationResponse, error)
+}
+
+type Scheduler interface {
+	Schedule(id int64, at time.Time)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,73 @@
+package create_batch_notification
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgTemplateNotFound   = "template not found"
+	msgNoRecipients       = "none of the recipients were found"
+)
+
+type Handler struct {
+	service   NotificationService
+	scheduler Scheduler
+	logger    Logger
+}
+
+func NewHandler(service NotificationService, scheduler Scheduler, logger Logger) *Handler {
+	return &Handler{
+		service:   service,
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle POST /api/v1/notifications/batch
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateBatchNotificationRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("POST /notifications/batch - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	batch, err := h.service.CreateBatch(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidInput):
+			h.logger.Warn("POST /notifications/batch - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, notifications.ErrTemplateNotFound):
+			h.logger.Warn("POST /notifications/batch - Template not found: template=%s", *req.Template)
+			handlers.RespondUnprocessable(w, msgTemplateNotFound)
+		case errors.Is(err, notifications.ErrNoRecipients):
+			h.logger.Warn("POST /notifications/batch - No recipients found: user_ids=%d", len(req.UserIDs))
+			handlers.RespondUnprocessable(w, msgNoRecipients)
+		default:
+			h.logger.Error("POST /notifications/batch - Failed to create notifications: error=%v", err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	now := time.Now()
+	for _, notification := range batch.Notifications {
+		scheduledAt := now
+		if notification.ScheduledAt != nil {
+			scheduledAt = *notification.ScheduledAt
+		}
+		h.scheduler.Schedule(notification.ID, scheduledAt)
+	}
+
+	h.logger.Info("POST /notifications/batch - Notifications created: span_id=%s, count=%d, skipped=%d",
+		batch.SpanID, len(batch.Notifications), len(batch.SkippedUserIDs))
+	handlers.RespondJSON(w, http.StatusCreated, batch)
+}
@@ -0,0 +1,70 @@
+package create_notification
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgTemplateNotFound   = "template not found"
+	msgUserNotFound       = "recipient not found"
+)
+
+type Handler struct {
+	service   NotificationService
+	scheduler Scheduler
+	logger    Logger
+}
+
+func NewHandler(service NotificationService, scheduler Scheduler, logger Logger) *Handler {
+	return &Handler{
+		service:   service,
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle POST /api/v1/notifications
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateNotificationRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("POST /notifications - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	notification, err := h.service.Create(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidInput):
+			h.logger.Warn("POST /notifications - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, notifications.ErrTemplateNotFound):
+			h.logger.Warn("POST /notifications - Template not found: template=%s", *req.Template)
+			handlers.RespondUnprocessable(w, msgTemplateNotFound)
+		case errors.Is(err, notifications.ErrUserNotFound):
+			h.logger.Warn("POST /notifications - Recipient not found: user_id=%d", req.UserID)
+			handlers.RespondUnprocessable(w, msgUserNotFound)
+		default:
+			h.logger.Error("POST /notifications - Failed to create notification: user_id=%d, error=%v", req.UserID, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	// Немедленные уведомления тоже проходят через scheduler, чтобы не ждать следующего прохода processor
+	scheduledAt := time.Now()
+	if notification.ScheduledAt != nil {
+		scheduledAt = *notification.ScheduledAt
+	}
+	h.scheduler.Schedule(notification.ID, scheduledAt)
+
+	h.logger.Info("POST /notifications - Notification created: id=%d, user_id=%d", notification.ID, notification.UserID)
+	handlers.RespondJSON(w, http.StatusCreated, notification)
+}
@@ -0,0 +1,17 @@
+package create_recurring_notification
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	CreateRecurring(ctx context.Context, req *models.CreateRecurringNotificationRequest) (*models.RecurringNotificationResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
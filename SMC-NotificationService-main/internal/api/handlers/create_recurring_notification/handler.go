@@ -0,0 +1,62 @@
+package create_recurring_notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgTemplateNotFound   = "template not found"
+	msgNoRecipients       = "none of the recipients were found"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle POST /api/v1/notifications/recurring
+// Повторения создаются фоновым materializer, поэтому таймеры здесь не ставятся
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateRecurringNotificationRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("POST /notifications/recurring - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	series, err := h.service.CreateRecurring(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidInput):
+			h.logger.Warn("POST /notifications/recurring - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, notifications.ErrTemplateNotFound):
+			h.logger.Warn("POST /notifications/recurring - Template not found: template=%s", *req.Template)
+			handlers.RespondUnprocessable(w, msgTemplateNotFound)
+		case errors.Is(err, notifications.ErrNoRecipients):
+			h.logger.Warn("POST /notifications/recurring - No recipients found: user_ids=%d", len(req.UserIDs))
+			handlers.RespondUnprocessable(w, msgNoRecipients)
+		default:
+			h.logger.Error("POST /notifications/recurring - Failed to create recurring notification: error=%v", err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("POST /notifications/recurring - Recurring notification created: span_id=%s, recipients=%d, skipped=%d",
+		series.SpanID, len(series.UserIDs), len(series.SkippedUserIDs))
+	handlers.RespondJSON(w, http.StatusCreated, series)
+}
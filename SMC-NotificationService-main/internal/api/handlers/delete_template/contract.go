@@ -0,0 +1,13 @@
+package delete_template
+
+import "context"
+
+type TemplateService interface {
+	Delete(ctx context.Context, name string, userRole string) error
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
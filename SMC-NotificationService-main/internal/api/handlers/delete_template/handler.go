@@ -0,0 +1,61 @@
+package delete_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgForbidden        = "access denied"
+	msgMissingUserRole  = "missing user role"
+	msgTemplateNotFound = "template not found"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle DELETE /api/v1/templates/{name}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	if err := h.service.Delete(r.Context(), name, userRole); err != nil {
+		switch {
+		case errors.Is(err, templates.ErrOnlySuperuser):
+			h.logger.Warn("DELETE /templates/{name} - Access denied: role=%s", userRole)
+			handlers.RespondForbidden(w, msgForbidden)
+		case errors.Is(err, templates.ErrInvalidInput):
+			h.logger.Warn("DELETE /templates/{name} - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, templates.ErrTemplateNotFound):
+			h.logger.Warn("DELETE /templates/{name} - Template not found: name=%s", name)
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+		default:
+			h.logger.Error("DELETE /templates/{name} - Failed to delete template: name=%s, error=%v", name, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("DELETE /templates/{name} - Template deleted: name=%s", name)
+	w.WriteHeader(http.StatusNoContent)
+}
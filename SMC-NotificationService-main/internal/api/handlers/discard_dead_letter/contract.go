@@ -0,0 +1,13 @@
+package discard_dead_letter
+
+import "context"
+
+type DeadLetterService interface {
+	Discard(ctx context.Context, id int64, userRole string) error
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
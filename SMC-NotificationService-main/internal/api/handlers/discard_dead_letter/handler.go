@@ -0,0 +1,66 @@
+package discard_dead_letter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters"
+)
+
+const (
+	msgForbidden       = "access denied"
+	msgMissingUserRole = "missing user role"
+	msgInvalidID       = "invalid dead letter ID"
+	msgNotFound        = "dead letter not found"
+)
+
+type Handler struct {
+	service DeadLetterService
+	logger  Logger
+}
+
+func NewHandler(service DeadLetterService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle DELETE /api/v1/dead-letters/{id}
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("DELETE /dead-letters/{id} - Invalid dead letter ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	if err := h.service.Discard(r.Context(), id, userRole); err != nil {
+		switch {
+		case errors.Is(err, deadletters.ErrOnlySuperuser):
+			h.logger.Warn("DELETE /dead-letters/{id} - Access denied: role=%s", userRole)
+			handlers.RespondForbidden(w, msgForbidden)
+		case errors.Is(err, deadletters.ErrDeadLetterNotFound):
+			h.logger.Warn("DELETE /dead-letters/{id} - Dead letter not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+		default:
+			h.logger.Error("DELETE /dead-letters/{id} - Failed to discard dead letter: id=%d, error=%v", id, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("DELETE /dead-letters/{id} - Dead letter discarded: id=%d", id)
+	w.WriteHeader(http.StatusNoContent)
+}
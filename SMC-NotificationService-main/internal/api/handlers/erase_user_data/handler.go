@@ -0,0 +1,46 @@
+package erase_user_data
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+)
+
+const (
+	msgInvalidUserID = "invalid user ID"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle DELETE /internal/users/{tg_user_id}
+// Вызывается UserService при анонимизации аккаунта; операция идемпотентна
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["tg_user_id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("DELETE /internal/users/{tg_user_id} - Invalid user ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidUserID)
+		return
+	}
+
+	result, err := h.service.EraseUserData(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("DELETE /internal/users/{tg_user_id} - Failed to erase user data: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("DELETE /internal/users/{tg_user_id} - User data erased: user_id=%d, notifications_deleted=%d", userID, result.NotificationsDeleted)
+	handlers.RespondJSON(w, http.StatusOK, result)
+}
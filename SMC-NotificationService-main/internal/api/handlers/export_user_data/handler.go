@@ -0,0 +1,46 @@
+package export_user_data
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+)
+
+const (
+	msgInvalidUserID = "invalid user ID"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /internal/users/{tg_user_id}/export
+// Вызывается UserService при формировании выгрузки персональных данных
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["tg_user_id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("GET /internal/users/{tg_user_id}/export - Invalid user ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidUserID)
+		return
+	}
+
+	export, err := h.service.ExportUserData(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("GET /internal/users/{tg_user_id}/export - Failed to export user data: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("GET /internal/users/{tg_user_id}/export - User data exported: user_id=%d, notifications=%d", userID, len(export.Notifications))
+	handlers.RespondJSON(w, http.StatusOK, export)
+}
@@ -0,0 +1,17 @@
+package get_channel_settings
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	GetChannelSettings(ctx context.Context, userID int64) (*models.ChannelSettingsResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
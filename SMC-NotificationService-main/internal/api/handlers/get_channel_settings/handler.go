@@ -0,0 +1,42 @@
+package get_channel_settings
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+)
+
+const (
+	msgMissingUserID = "missing user ID"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/users/me/channels
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	settings, err := h.service.GetChannelSettings(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("GET /users/me/channels - Failed to get channel settings: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,54 @@
+package get_notification
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+const (
+	msgInvalidID = "invalid notification ID"
+	msgNotFound  = "notification not found"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/notifications/{id}
+// Возвращает уведомление со статусом доставки по каждому каналу
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("GET /notifications/{id} - Invalid notification ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	notification, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, notifications.ErrNotificationNotFound) {
+			h.logger.Warn("GET /notifications/{id} - Notification not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+			return
+		}
+		h.logger.Error("GET /notifications/{id} - Failed to get notification: id=%d, error=%v", id, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, notification)
+}
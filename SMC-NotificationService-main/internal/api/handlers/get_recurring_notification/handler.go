@@ -0,0 +1,49 @@
+package get_recurring_notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+const msgNotFound = "recurring notification not found"
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/notifications/recurring/{span_id}
+// Возвращает расписание серии и её ближайшие повторения; сами повторения - GET /notifications?span_id=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	spanID := mux.Vars(r)["span_id"]
+
+	series, err := h.service.GetRecurring(r.Context(), spanID)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidInput):
+			h.logger.Warn("GET /notifications/recurring/{span_id} - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, notifications.ErrSeriesNotFound):
+			h.logger.Warn("GET /notifications/recurring/{span_id} - Recurring notification not found: span_id=%s", spanID)
+			handlers.RespondNotFound(w, msgNotFound)
+		default:
+			h.logger.Error("GET /notifications/recurring/{span_id} - Failed to get recurring notification: span_id=%s, error=%v", spanID, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, series)
+}
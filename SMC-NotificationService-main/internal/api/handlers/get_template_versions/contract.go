@@ -0,0 +1,17 @@
+package get_template_versions
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+type TemplateService interface {
+	ListVersions(ctx context.Context, name string, userRole string) (*models.TemplateListResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
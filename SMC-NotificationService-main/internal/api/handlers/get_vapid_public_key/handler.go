@@ -0,0 +1,31 @@
+package get_vapid_public_key
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+)
+
+const (
+	msgWebPushDisabled = "web push is not configured"
+)
+
+type Handler struct {
+	publicKey string
+}
+
+// NewHandler создает handler; пустой ключ означает, что Web Push через VAPID отключён
+func NewHandler(publicKey string) *Handler {
+	return &Handler{publicKey: publicKey}
+}
+
+// Handle GET /api/v1/push/vapid-public-key
+// Ключ передаётся фронтендом в PushManager.subscribe({applicationServerKey})
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.publicKey == "" {
+		handlers.RespondNotFound(w, msgWebPushDisabled)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, map[string]string{"public_key": h.publicKey})
+}
@@ -0,0 +1,18 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+)
+
+type Handler struct{}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Handle GET /health
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	handlers.RespondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
@@ -0,0 +1,17 @@
+package list_dead_letters
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters/models"
+)
+
+type DeadLetterService interface {
+	List(ctx context.Context, req *models.DeadLetterFilterRequest, userRole string) (*models.DeadLetterListResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
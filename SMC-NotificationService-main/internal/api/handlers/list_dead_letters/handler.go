@@ -0,0 +1,98 @@
+package list_dead_letters
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters/models"
+)
+
+const (
+	msgForbidden         = "access denied"
+	msgMissingUserRole   = "missing user role"
+	msgInvalidUserID     = "invalid user_id parameter"
+	msgInvalidPageParam  = "invalid page parameter"
+	msgInvalidLimitParam = "invalid limit parameter"
+)
+
+type Handler struct {
+	service DeadLetterService
+	logger  Logger
+}
+
+func NewHandler(service DeadLetterService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/dead-letters?user_id=&reason=&page=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var req models.DeadLetterFilterRequest
+
+	if userIDStr := query.Get("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil {
+			h.logger.Warn("GET /dead-letters - Invalid user_id parameter: %v", err)
+			handlers.RespondBadRequest(w, msgInvalidUserID)
+			return
+		}
+		req.UserID = &userID
+	}
+
+	if reason := query.Get("reason"); reason != "" {
+		req.Reason = &reason
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			h.logger.Warn("GET /dead-letters - Invalid page parameter: %s", pageStr)
+			handlers.RespondBadRequest(w, msgInvalidPageParam)
+			return
+		}
+		req.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			h.logger.Warn("GET /dead-letters - Invalid limit parameter: %s", limitStr)
+			handlers.RespondBadRequest(w, msgInvalidLimitParam)
+			return
+		}
+		req.Limit = limit
+	}
+
+	response, err := h.service.List(r.Context(), &req, userRole)
+	if err != nil {
+		switch {
+		case errors.Is(err, deadletters.ErrOnlySuperuser):
+			h.logger.Warn("GET /dead-letters - Access denied: role=%s", userRole)
+			handlers.RespondForbidden(w, msgForbidden)
+		case errors.Is(err, deadletters.ErrInvalidInput):
+			h.logger.Warn("GET /dead-letters - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		default:
+			h.logger.Error("GET /dead-letters - Failed to list dead letters: error=%v", err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("GET /dead-letters - Dead letters listed successfully: count=%d", len(response.DeadLetters))
+	handlers.RespondJSON(w, http.StatusOK, response)
+}
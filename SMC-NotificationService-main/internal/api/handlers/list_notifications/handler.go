@@ -0,0 +1,89 @@
+package list_notifications
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgInvalidUserID     = "invalid user_id parameter"
+	msgInvalidPageParam  = "invalid page parameter"
+	msgInvalidLimitParam = "invalid limit parameter"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/notifications?user_id=&span_id=&status=&page=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var req models.NotificationFilterRequest
+
+	if userIDStr := query.Get("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil {
+			h.logger.Warn("GET /notifications - Invalid user_id parameter: %v", err)
+			handlers.RespondBadRequest(w, msgInvalidUserID)
+			return
+		}
+		req.UserID = &userID
+	}
+
+	if spanID := query.Get("span_id"); spanID != "" {
+		req.SpanID = &spanID
+	}
+
+	if status := query.Get("status"); status != "" {
+		req.Status = &status
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			h.logger.Warn("GET /notifications - Invalid page parameter: %s", pageStr)
+			handlers.RespondBadRequest(w, msgInvalidPageParam)
+			return
+		}
+		req.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			h.logger.Warn("GET /notifications - Invalid limit parameter: %s", limitStr)
+			handlers.RespondBadRequest(w, msgInvalidLimitParam)
+			return
+		}
+		req.Limit = limit
+	}
+
+	response, err := h.service.List(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			h.logger.Warn("GET /notifications - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("GET /notifications - Failed to list notifications: error=%v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("GET /notifications - Notifications listed successfully: count=%d", len(response.Notifications))
+	handlers.RespondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,50 @@
+package list_templates
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgForbidden       = "access denied"
+	msgMissingUserRole = "missing user role"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /api/v1/templates
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	list, err := h.service.List(r.Context(), userRole)
+	if err != nil {
+		if errors.Is(err, templates.ErrOnlySuperuser) {
+			h.logger.Warn("GET /templates - Access denied: role=%s", userRole)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+		h.logger.Error("GET /templates - Failed to list templates: error=%v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, list)
+}
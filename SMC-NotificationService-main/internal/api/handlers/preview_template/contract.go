@@ -0,0 +1,17 @@
+package preview_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+type TemplateService interface {
+	Preview(ctx context.Context, name string, req *models.PreviewTemplateRequest) (*models.PreviewResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
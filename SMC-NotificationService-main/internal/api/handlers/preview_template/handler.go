@@ -0,0 +1,60 @@
+package preview_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgTemplateNotFound   = "template not found"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle POST /api/v1/templates/{name}/preview
+// Рендерит шаблон с параметрами без создания уведомления
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	var req models.PreviewTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("POST /templates/{name}/preview - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	preview, err := h.service.Preview(r.Context(), name, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, templates.ErrInvalidInput), errors.Is(err, templates.ErrInvalidTemplate), errors.Is(err, templates.ErrRender):
+			h.logger.Warn("POST /templates/{name}/preview - Failed to render: name=%s, error=%v", name, err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, templates.ErrTemplateNotFound):
+			h.logger.Warn("POST /templates/{name}/preview - Template not found: name=%s", name)
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+		default:
+			h.logger.Error("POST /templates/{name}/preview - Failed to preview template: name=%s, error=%v", name, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, preview)
+}
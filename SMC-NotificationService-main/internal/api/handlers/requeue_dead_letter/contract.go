@@ -0,0 +1,22 @@
+package requeue_dead_letter
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters/models"
+)
+
+type DeadLetterService interface {
+	Requeue(ctx context.Context, id int64, userRole string) (*models.RequeueResponse, error)
+}
+
+type Scheduler interface {
+	Schedule(id int64, at time.Time)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
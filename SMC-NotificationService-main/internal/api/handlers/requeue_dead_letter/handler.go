@@ -0,0 +1,73 @@
+package requeue_dead_letter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters"
+)
+
+const (
+	msgForbidden       = "access denied"
+	msgMissingUserRole = "missing user role"
+	msgInvalidID       = "invalid dead letter ID"
+	msgNotFound        = "dead letter not found"
+)
+
+type Handler struct {
+	service   DeadLetterService
+	scheduler Scheduler
+	logger    Logger
+}
+
+func NewHandler(service DeadLetterService, scheduler Scheduler, logger Logger) *Handler {
+	return &Handler{
+		service:   service,
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle POST /api/v1/dead-letters/{id}/requeue
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.Warn("POST /dead-letters/{id}/requeue - Invalid dead letter ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	response, err := h.service.Requeue(r.Context(), id, userRole)
+	if err != nil {
+		switch {
+		case errors.Is(err, deadletters.ErrOnlySuperuser):
+			h.logger.Warn("POST /dead-letters/{id}/requeue - Access denied: role=%s", userRole)
+			handlers.RespondForbidden(w, msgForbidden)
+		case errors.Is(err, deadletters.ErrDeadLetterNotFound):
+			h.logger.Warn("POST /dead-letters/{id}/requeue - Dead letter not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+		default:
+			h.logger.Error("POST /dead-letters/{id}/requeue - Failed to requeue dead letter: id=%d, error=%v", id, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	// Повторная отправка сразу, не дожидаясь прохода processor
+	h.scheduler.Schedule(response.NotificationID, time.Now())
+
+	h.logger.Info("POST /dead-letters/{id}/requeue - Notification requeued: id=%d, notification_id=%d", id, response.NotificationID)
+	handlers.RespondJSON(w, http.StatusOK, response)
+}
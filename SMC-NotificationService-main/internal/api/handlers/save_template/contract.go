@@ -0,0 +1,17 @@
+package save_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+type TemplateService interface {
+	Save(ctx context.Context, name, locale string, userID int64, userRole string, req *models.SaveTemplateRequest) (*models.TemplateResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
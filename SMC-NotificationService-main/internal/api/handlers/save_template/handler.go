@@ -0,0 +1,80 @@
+package save_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgForbidden          = "access denied"
+	msgMissingUserID      = "missing user ID"
+	msgMissingUserRole    = "missing user role"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle PUT /api/v1/templates/{name}/{locale}
+// Каждое сохранение создаёт новую версию варианта шаблона
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	vars := mux.Vars(r)
+	name, locale := vars["name"], vars["locale"]
+
+	var req models.SaveTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("PUT /templates/{name}/{locale} - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	template, err := h.service.Save(r.Context(), name, locale, userID, userRole, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, templates.ErrOnlySuperuser):
+			h.logger.Warn("PUT /templates/{name}/{locale} - Access denied: user_id=%d", userID)
+			handlers.RespondForbidden(w, msgForbidden)
+		case errors.Is(err, templates.ErrInvalidInput), errors.Is(err, templates.ErrInvalidTemplate):
+			h.logger.Warn("PUT /templates/{name}/{locale} - Invalid template: name=%s, locale=%s, error=%v", name, locale, err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, templates.ErrVersionConflict):
+			h.logger.Warn("PUT /templates/{name}/{locale} - Version conflict: name=%s, locale=%s", name, locale)
+			handlers.RespondConflict(w, err.Error())
+		default:
+			h.logger.Error("PUT /templates/{name}/{locale} - Failed to save template: name=%s, locale=%s, error=%v", name, locale, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("PUT /templates/{name}/{locale} - Template saved: name=%s, locale=%s, version=%d, user_id=%d", template.Name, template.Locale, template.Version, userID)
+	handlers.RespondJSON(w, http.StatusCreated, template)
+}
@@ -0,0 +1,17 @@
+package subscribe_push
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	SubscribePush(ctx context.Context, userID int64, req *models.PushSubscriptionRequest) (*models.PushSubscriptionResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,60 @@
+package subscribe_push
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgMissingUserID      = "missing user ID"
+	msgInvalidRequestBody = "invalid request body"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle POST /api/v1/users/me/push-subscriptions
+// Тело - результат PushSubscription.toJSON() в браузере; повторная подписка обновляет ключи
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	var req models.PushSubscriptionRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("POST /users/me/push-subscriptions - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	subscription, err := h.service.SubscribePush(r.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			h.logger.Warn("POST /users/me/push-subscriptions - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("POST /users/me/push-subscriptions - Failed to save subscription: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("POST /users/me/push-subscriptions - Subscription saved: user_id=%d, id=%d", userID, subscription.ID)
+	handlers.RespondJSON(w, http.StatusCreated, subscription)
+}
@@ -0,0 +1,17 @@
+package telegram_webhook
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update) error
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,66 @@
+package telegram_webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/update_dedup"
+)
+
+const (
+	// secretTokenHeader заголовок с secret_token, переданным в setWebhook
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	msgInvalidUpdate      = "invalid update"
+	msgInvalidSecretToken = "invalid secret token"
+)
+
+type Handler struct {
+	handler     UpdateHandler
+	secretToken string
+	logger      Logger
+}
+
+func NewHandler(handler UpdateHandler, secretToken string, logger Logger) *Handler {
+	return &Handler{
+		handler:     handler,
+		secretToken: secretToken,
+		logger:      logger,
+	}
+}
+
+// Handle POST /webhook/telegram
+// Запросы без secret_token, зарегистрированного в setWebhook, отклоняются: адрес webhook публичный
+// Необработанное обновление (не удалось отметить или обработка завершилась ошибкой и отметка снята)
+// отвечает 500, и Telegram доставляет его повторно. Если снять отметку не удалось, повтор всё равно
+// будет пропущен, поэтому ошибка только логируется
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(secretTokenHeader)
+	if h.secretToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.secretToken)) != 1 {
+		h.logger.Warn("POST /webhook/telegram - Invalid secret token: remote_addr=%s", r.RemoteAddr)
+		handlers.RespondUnauthorized(w, msgInvalidSecretToken)
+		return
+	}
+
+	var update tgbotapi.Update
+	if err := handlers.DecodeJSON(r, &update); err != nil {
+		h.logger.Warn("POST /webhook/telegram - Invalid update: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidUpdate)
+		return
+	}
+
+	if err := h.handler.HandleUpdate(r.Context(), update); err != nil {
+		if errors.Is(err, update_dedup.ErrClaimUpdate) || errors.Is(err, update_dedup.ErrHandleUpdate) {
+			h.logger.Error("POST /webhook/telegram - Failed to handle update, Telegram will retry: update_id=%d, error=%v", update.UpdateID, err)
+			handlers.RespondInternalError(w)
+			return
+		}
+		h.logger.Error("POST /webhook/telegram - Failed to handle update: update_id=%d, error=%v", update.UpdateID, err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
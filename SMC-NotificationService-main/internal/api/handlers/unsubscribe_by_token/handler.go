@@ -0,0 +1,63 @@
+package unsubscribe_by_token
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+)
+
+const (
+	msgInvalidToken        = "invalid unsubscribe link"
+	msgUnsubscribeDisabled = "unsubscribe links are disabled"
+)
+
+// donePage страница результата для отписки из формы страницы подтверждения
+var donePage = template.Must(template.New("done").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><meta name="robots" content="noindex"><title>Отписка от уведомлений</title></head>
+<body>
+<p>Вы отписались от уведомлений категории «{{.Category}}». Включить их снова можно в настройках уведомлений.</p>
+</body>
+</html>
+`))
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle POST /unsubscribe?token=
+// Отписка в один клик по RFC 8058 или из формы страницы подтверждения: авторизацией служит подпись токена
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Unsubscribe(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidUnsubscribeToken):
+			h.logger.Warn("POST /unsubscribe - Invalid token")
+			handlers.RespondBadRequest(w, msgInvalidToken)
+		case errors.Is(err, notifications.ErrUnsubscribeDisabled):
+			handlers.RespondNotFound(w, msgUnsubscribeDisabled)
+		default:
+			h.logger.Error("POST /unsubscribe - Failed to unsubscribe: error=%v", err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("POST /unsubscribe - User unsubscribed: user_id=%d, category=%s", result.UserID, result.Category)
+	if handlers.AcceptsHTML(r) {
+		handlers.RespondHTML(w, http.StatusOK, donePage, result)
+		return
+	}
+	handlers.RespondJSON(w, http.StatusOK, result)
+}
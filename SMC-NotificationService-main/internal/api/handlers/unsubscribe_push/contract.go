@@ -0,0 +1,17 @@
+package unsubscribe_push
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	UnsubscribePush(ctx context.Context, userID int64, req *models.DeletePushSubscriptionRequest) error
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,63 @@
+package unsubscribe_push
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgMissingUserID      = "missing user ID"
+	msgInvalidRequestBody = "invalid request body"
+	msgNotFound           = "push subscription not found"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle DELETE /api/v1/users/me/push-subscriptions
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	var req models.DeletePushSubscriptionRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("DELETE /users/me/push-subscriptions - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	if err := h.service.UnsubscribePush(r.Context(), userID, &req); err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrInvalidInput):
+			h.logger.Warn("DELETE /users/me/push-subscriptions - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+		case errors.Is(err, notifications.ErrSubscriptionNotFound):
+			h.logger.Warn("DELETE /users/me/push-subscriptions - Subscription not found: user_id=%d", userID)
+			handlers.RespondNotFound(w, msgNotFound)
+		default:
+			h.logger.Error("DELETE /users/me/push-subscriptions - Failed to delete subscription: user_id=%d, error=%v", userID, err)
+			handlers.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.logger.Info("DELETE /users/me/push-subscriptions - Subscription deleted: user_id=%d", userID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package update_channel_settings
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgMissingUserID      = "missing user ID"
+	msgInvalidRequestBody = "invalid request body"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle PUT /api/v1/users/me/channels
+// Полностью заменяет порядок fallback и email пользователя
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	var req models.ChannelSettingsRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("PUT /users/me/channels - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	settings, err := h.service.UpdateChannelSettings(r.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			h.logger.Warn("PUT /users/me/channels - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("PUT /users/me/channels - Failed to update channel settings: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("PUT /users/me/channels - Channel settings updated: user_id=%d, order=%v", userID, settings.ChannelOrder)
+	handlers.RespondJSON(w, http.StatusOK, settings)
+}
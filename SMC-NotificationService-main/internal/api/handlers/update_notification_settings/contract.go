@@ -0,0 +1,17 @@
+package update_notification_settings
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+type NotificationService interface {
+	UpdateNotificationSettings(ctx context.Context, userID int64, req *models.NotificationSettingsRequest) (*models.NotificationSettingsResponse, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
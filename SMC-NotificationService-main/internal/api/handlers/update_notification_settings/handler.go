@@ -0,0 +1,61 @@
+package update_notification_settings
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	msgMissingUserID      = "missing user ID"
+	msgInvalidRequestBody = "invalid request body"
+)
+
+type Handler struct {
+	service NotificationService
+	logger  Logger
+}
+
+func NewHandler(service NotificationService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle PUT /api/v1/users/me/notification-settings
+// Полностью заменяет категории, предпочтительный канал, часовой пояс и тихие часы пользователя
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	var req models.NotificationSettingsRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("PUT /users/me/notification-settings - Invalid request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	settings, err := h.service.UpdateNotificationSettings(r.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			h.logger.Warn("PUT /users/me/notification-settings - Invalid input: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("PUT /users/me/notification-settings - Failed to update notification settings: user_id=%d, error=%v", userID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("PUT /users/me/notification-settings - Notification settings updated: user_id=%d, categories=%v, timezone=%s",
+		userID, settings.Categories, settings.Timezone)
+	handlers.RespondJSON(w, http.StatusOK, settings)
+}
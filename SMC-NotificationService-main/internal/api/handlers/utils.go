@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ErrorResponse структура для ответа с ошибкой
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// RespondJSON отправляет JSON ответ
+func RespondJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if payload != nil {
+		json.NewEncoder(w).Encode(payload)
+	}
+}
+
+// RespondHTML отправляет HTML страницу, отрендеренную из шаблона
+func RespondHTML(w http.ResponseWriter, status int, page *template.Template, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	page.Execute(w, data)
+}
+
+// AcceptsHTML проверяет, что запрос пришёл из браузера и ждёт HTML, а не JSON
+func AcceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// RespondError отправляет ошибку в формате JSON
+func RespondError(w http.ResponseWriter, status int, message string) {
+	RespondJSON(w, status, ErrorResponse{
+		Code:    status,
+		Message: message,
+	})
+}
+
+// DecodeJSON парсит JSON из request body
+func DecodeJSON(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// RespondBadRequest отправляет ошибку 400
+func RespondBadRequest(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusBadRequest, message)
+}
+
+// RespondUnauthorized отправляет ошибку 401
+func RespondUnauthorized(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusUnauthorized, message)
+}
+
+// RespondForbidden отправляет ошибку 403
+func RespondForbidden(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusForbidden, message)
+}
+
+// RespondNotFound отправляет ошибку 404
+func RespondNotFound(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusNotFound, message)
+}
+
+// RespondInternalError отправляет ошибку 500
+func RespondInternalError(w http.ResponseWriter) {
+	RespondError(w, http.StatusInternalServerError, "internal server error")
+}
+
+// RespondConflict отправляет ошибку 409
+func RespondConflict(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusConflict, message)
+}
+
+// RespondUnprocessable отправляет ошибку 422
+func RespondUnprocessable(w http.ResponseWriter, message string) {
+	RespondError(w, http.StatusUnprocessableEntity, message)
+}
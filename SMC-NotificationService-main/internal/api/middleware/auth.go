@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/jwtauth"
+)
+
+type contextKey string
+
+const (
+	UserIDKey   contextKey = "user_id"
+	UserRoleKey contextKey = "user_role"
+)
+
+// Authenticator извлекает идентичность пользователя из запроса (Bearer токен или заголовки X-User-*)
+type Authenticator interface {
+	Authenticate(r *http.Request) (*jwtauth.Identity, error)
+}
+
+// AuthMiddleware middleware аутентификации пользователей
+type AuthMiddleware struct {
+	authenticator Authenticator
+}
+
+// NewAuthMiddleware создаёт middleware аутентификации
+func NewAuthMiddleware(authenticator Authenticator) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator}
+}
+
+// Auth проверяет аутентификацию и сохраняет user ID и роль в контекст
+func (m *AuthMiddleware) Auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				http.Error(w, "missing authentication credentials", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
+			return
+		}
+
+		if identity.Role == "" {
+			http.Error(w, "missing user role", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
+	})
+}
+
+// OptionalAuth сохраняет user ID и роль в контекст, если запрос аутентифицирован
+// В отличие от Auth, пропускает запросы без данных аутентификации, но отклоняет некорректные
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
+	})
+}
+
+// withIdentity сохраняет идентичность пользователя в контекст
+func withIdentity(ctx context.Context, identity *jwtauth.Identity) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, identity.UserID)
+	if identity.Role != "" {
+		ctx = context.WithValue(ctx, UserRoleKey, identity.Role)
+	}
+	return ctx
+}
+
+// GetUserID извлекает user ID из контекста
+func GetUserID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(UserIDKey).(int64)
+	return userID, ok
+}
+
+// GetUserRole извлекает user role из контекста
+func GetUserRole(ctx context.Context) (string, bool) {
+	userRole, ok := ctx.Value(UserRoleKey).(string)
+	return userRole, ok
+}
@@ -0,0 +1,65 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// Channel отправляет уведомление по одному каналу доставки
+// Ошибка означает, что канал не доставил сообщение и нужно перейти к следующему по порядку fallback
+type Channel interface {
+	Name() domain.Channel
+	Send(ctx context.Context, recipient Recipient, message Message) error
+}
+
+// Message содержимое уведомления
+type Message struct {
+	NotificationID int64
+	Text           string
+	ParseMode      domain.ParseMode // разметка Text; каналы без поддержки разметки используют PlainText
+	UnsubscribeURL string           // ссылка отписки в один клик; пусто - категорию нельзя отключить
+}
+
+// UnsubscribeLabel подпись ссылки и кнопки отписки
+const UnsubscribeLabel = "Отписаться"
+
+// PlainTextWithFooter возвращает PlainText со ссылкой отписки в конце, если она есть
+func (m Message) PlainTextWithFooter() string {
+	if m.UnsubscribeURL == "" {
+		return m.PlainText()
+	}
+	return m.PlainText() + "\n\n" + UnsubscribeLabel + ": " + m.UnsubscribeURL
+}
+
+// Recipient адреса пользователя во всех каналах
+type Recipient struct {
+	UserID            int64   // tg_user_id, он же chat_id личного чата с ботом
+	Email             *string // из настроек каналов
+	Phone             *string // подтверждённый номер из UserService
+	PushSubscriptions []domain.PushSubscription
+}
+
+// Address возвращает адрес получателя в канале
+// false - у пользователя нет адреса, канал пропускается
+func (r Recipient) Address(channel domain.Channel) (string, bool) {
+	switch channel {
+	case domain.ChannelTelegram:
+		return strconv.FormatInt(r.UserID, 10), true
+	case domain.ChannelEmail:
+		if r.Email != nil && *r.Email != "" {
+			return *r.Email, true
+		}
+	case domain.ChannelSMS:
+		if r.Phone != nil && *r.Phone != "" {
+			return *r.Phone, true
+		}
+	case domain.ChannelWebPush:
+		if len(r.PushSubscriptions) > 0 {
+			return fmt.Sprintf("%d subscription(s)", len(r.PushSubscriptions)), true
+		}
+	}
+	return "", false
+}
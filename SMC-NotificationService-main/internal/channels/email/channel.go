@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// ErrSendEmail возвращается, когда SMTP сервер не принял письмо
+var ErrSendEmail = errors.New("email: failed to send message")
+
+// base64LineLength длина строки тела письма в base64 (RFC 2045)
+const base64LineLength = 76
+
+// Config настройки SMTP сервера
+type Config struct {
+	Host     string
+	Port     int
+	Username string // пусто - без аутентификации
+	Password string
+	From     string
+	Subject  string
+}
+
+// Channel доставка письмом через SMTP
+type Channel struct {
+	cfg Config
+}
+
+// New создает канал email
+func New(cfg Config) *Channel {
+	return &Channel{cfg: cfg}
+}
+
+// Name возвращает имя канала
+func (c *Channel) Name() domain.Channel {
+	return domain.ChannelEmail
+}
+
+// Send отправляет письмо на адрес из настроек каналов пользователя
+func (c *Channel) Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error {
+	to, ok := recipient.Address(domain.ChannelEmail)
+	if !ok {
+		return channels.ErrNoAddress
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port))
+	if err := smtp.SendMail(addr, auth, c.cfg.From, []string{to}, c.buildMessage(to, message)); err != nil {
+		sendErr := fmt.Errorf("%w: %v", ErrSendEmail, err)
+		// Коды 5xx SMTP означают окончательный отказ (например, несуществующий ящик)
+		var smtpErr *textproto.Error
+		if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+			return channels.Permanent(sendErr)
+		}
+		return sendErr
+	}
+	return nil
+}
+
+// buildMessage формирует письмо text/plain в UTF-8
+// Ссылка отписки добавляется в текст и в заголовки List-Unsubscribe для отписки в один клик (RFC 8058)
+func (c *Channel) buildMessage(to string, message channels.Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("utf-8", c.cfg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if message.UnsubscribeURL != "" {
+		fmt.Fprintf(&buf, "List-Unsubscribe: <%s>\r\n", message.UnsubscribeURL)
+		buf.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(message.PlainTextWithFooter()))
+	for len(encoded) > base64LineLength {
+		buf.WriteString(encoded[:base64LineLength] + "\r\n")
+		encoded = encoded[base64LineLength:]
+	}
+	buf.WriteString(encoded + "\r\n")
+
+	return buf.Bytes()
+}
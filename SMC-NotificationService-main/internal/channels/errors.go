@@ -0,0 +1,65 @@
+package channels
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNoAddress возвращается, когда у получателя нет адреса в канале
+	ErrNoAddress = errors.New("channel: recipient has no address")
+
+	// ErrPermanent помечает ошибки, которые не исправятся повтором: бот заблокирован, адрес не существует
+	ErrPermanent = errors.New("channel: permanent delivery error")
+)
+
+// permanentError ошибка канала, которую не нужно повторять
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() []error { return []error{e.err, ErrPermanent} }
+
+// Permanent помечает ошибку отправки как постоянную; текст ошибки не меняется
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// RetryAfterError временная ошибка, после которой канал просит подождать перед повтором
+// Например, ответ Telegram 429 с retry_after
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// RetryAfter возвращает паузу, запрошенную каналом; 0 - канал паузу не запрашивал
+func RetryAfter(err error) time.Duration {
+	var retryErr *RetryAfterError
+	if errors.As(err, &retryErr) {
+		return retryErr.RetryAfter
+	}
+	return 0
+}
+
+// ThrottledError канал не отправлял сообщение: собственный ограничитель скорости отложил его до Until
+// Это не ошибка доставки - уведомление возвращается в очередь без траты попытки и без перехода к следующему каналу
+type ThrottledError struct {
+	Until time.Time
+}
+
+func (e *ThrottledError) Error() string {
+	return "channel: throttled until " + e.Until.Format(time.RFC3339Nano)
+}
+
+// Throttled возвращает время, до которого канал отложил отправку; false - ошибка не от ограничителя
+func Throttled(err error) (time.Time, bool) {
+	var throttled *ThrottledError
+	if errors.As(err, &throttled) {
+		return throttled.Until, true
+	}
+	return time.Time{}, false
+}
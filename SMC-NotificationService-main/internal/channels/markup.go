@@ -0,0 +1,90 @@
+package channels
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+var (
+	htmlLink = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*"([^"]*)"[^>]*>(.*?)</a>`)
+	htmlTag  = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// PlainText возвращает текст сообщения без разметки Telegram
+// Используется каналами, которые не поддерживают MarkdownV2 и HTML: email, SMS, Web Push
+func (m Message) PlainText() string {
+	switch m.ParseMode {
+	case domain.ParseModeHTML:
+		return stripHTML(m.Text)
+	case domain.ParseModeMarkdownV2:
+		return stripMarkdownV2(m.Text)
+	default:
+		return m.Text
+	}
+}
+
+// stripHTML убирает теги, ссылки превращает в "текст (url)" и раскрывает сущности
+func stripHTML(text string) string {
+	text = htmlLink.ReplaceAllStringFunc(text, func(link string) string {
+		parts := htmlLink.FindStringSubmatch(link)
+		return formatLink(htmlTag.ReplaceAllString(parts[2], ""), parts[1])
+	})
+	return html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+}
+
+// stripMarkdownV2 убирает символы форматирования и экранирование, ссылки превращает в "текст (url)"
+func stripMarkdownV2(text string) string {
+	var (
+		out       strings.Builder
+		linkStart = -1 // позиция начала текста ссылки в out
+		runes     = []rune(text)
+		lineStart = true
+	)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			out.WriteRune(runes[i])
+		case r == '>' && lineStart:
+			// маркер цитаты в начале строки
+		case r == '[':
+			linkStart = out.Len()
+		case r == ']' && linkStart >= 0 && i+1 < len(runes) && runes[i+1] == '(':
+			end := i + 2
+			for end < len(runes) && runes[end] != ')' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			url := strings.ReplaceAll(string(runes[i+2:min(end, len(runes))]), `\`, "")
+			label := out.String()[linkStart:]
+			rest := out.String()[:linkStart]
+			out.Reset()
+			out.WriteString(rest)
+			out.WriteString(formatLink(label, url))
+			linkStart = -1
+			i = end
+		case strings.ContainsRune("*_~|`", r):
+			// символы форматирования
+		default:
+			out.WriteRune(r)
+		}
+		lineStart = r == '\n'
+	}
+
+	return out.String()
+}
+
+// formatLink записывает ссылку как "текст (url)"; ссылка вида [url](url) остаётся одним url
+func formatLink(label, url string) string {
+	if label == "" || label == url {
+		return url
+	}
+	return label + " (" + url + ")"
+}
@@ -0,0 +1,110 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// Stdout цель sink, печатающая сообщения в стандартный вывод
+const Stdout = "stdout"
+
+// Entry сообщение, записанное вместо реальной отправки
+type Entry struct {
+	Time           time.Time      `json:"time"`
+	Channel        domain.Channel `json:"channel"`
+	NotificationID int64          `json:"notification_id"`
+	UserID         int64          `json:"user_id"`
+	Address        string         `json:"address"`
+	Text           string         `json:"text"`
+	UnsubscribeURL string         `json:"unsubscribe_url,omitempty"`
+}
+
+// Sink записывает сообщения построчно в JSON (stdout или файл) для локального запуска
+type Sink struct {
+	mu     sync.Mutex
+	out    io.Writer
+	closer io.Closer
+}
+
+// Open открывает sink: "stdout" или путь к файлу (дописывается)
+func Open(target string) (*Sink, error) {
+	if target == "" || target == Stdout {
+		return &Sink{out: os.Stdout}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create sink directory: %w", err)
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sink file: %w", err)
+	}
+
+	return &Sink{out: file, closer: file}, nil
+}
+
+// Close закрывает файл sink
+func (s *Sink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// Write записывает одно сообщение
+func (s *Sink) Write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.out.Write(append(line, '\n'))
+	return err
+}
+
+// Channel канал, который вместо отправки пишет сообщение в sink
+// Получатель без адреса в канале возвращает ErrNoAddress, как и настоящий канал
+type Channel struct {
+	name domain.Channel
+	sink *Sink
+}
+
+// NewChannel создает канал name, пишущий в sink
+func NewChannel(name domain.Channel, sink *Sink) *Channel {
+	return &Channel{name: name, sink: sink}
+}
+
+// Name возвращает имя канала
+func (c *Channel) Name() domain.Channel {
+	return c.name
+}
+
+// Send записывает сообщение в sink
+func (c *Channel) Send(_ context.Context, recipient channels.Recipient, message channels.Message) error {
+	address, ok := recipient.Address(c.name)
+	if !ok {
+		return channels.ErrNoAddress
+	}
+
+	return c.sink.Write(Entry{
+		Time:           time.Now(),
+		Channel:        c.name,
+		NotificationID: message.NotificationID,
+		UserID:         recipient.UserID,
+		Address:        address,
+		Text:           message.PlainText(),
+		UnsubscribeURL: message.UnsubscribeURL,
+	})
+}
@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// ErrSendSMS возвращается, когда провайдер не принял сообщение
+var ErrSendSMS = errors.New("sms: failed to send message")
+
+// Provider интерфейс SMS-шлюза
+// Для подключения конкретного провайдера достаточно реализовать Send
+type Provider interface {
+	Send(ctx context.Context, phone, text string) error
+}
+
+// Channel доставка SMS на подтверждённый номер пользователя
+type Channel struct {
+	provider Provider
+}
+
+// New создает канал SMS поверх провайдера
+func New(provider Provider) *Channel {
+	return &Channel{provider: provider}
+}
+
+// Name возвращает имя канала
+func (c *Channel) Name() domain.Channel {
+	return domain.ChannelSMS
+}
+
+// Send отправляет SMS через провайдера
+func (c *Channel) Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error {
+	phone, ok := recipient.Address(domain.ChannelSMS)
+	if !ok {
+		return channels.ErrNoAddress
+	}
+
+	if err := c.provider.Send(ctx, phone, message.PlainTextWithFooter()); err != nil {
+		return fmt.Errorf("%w: %v", ErrSendSMS, err)
+	}
+	return nil
+}
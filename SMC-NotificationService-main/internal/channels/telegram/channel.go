@@ -0,0 +1,117 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	telegramService "github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+// Sender отправляет сообщения в Telegram
+type Sender interface {
+	SendFormattedMessage(chatID int64, text string, parseMode domain.ParseMode, disablePreview bool, buttons ...telegramService.URLButton) error
+}
+
+// RateLimiter бронирует слоты отправки в пределах лимитов Bot API, общих для всех экземпляров сервиса
+type RateLimiter interface {
+	Reserve(ctx context.Context, notificationID, chatID int64) (*domain.SendSlot, error)
+	Release(ctx context.Context, notificationID int64) error
+}
+
+// LimiterMetrics метрики ограничения скорости отправки
+type LimiterMetrics interface {
+	RecordTelegramThrottled(scope string, deferred bool, wait time.Duration)
+}
+
+// Channel доставка в личный чат с ботом
+// Пользователь, который не запускал бота или заблокировал его, получает ошибку и переходит к следующему каналу
+type Channel struct {
+	sender  Sender
+	limiter RateLimiter
+	maxWait time.Duration  // пауза до слота, которую отправка ждёт сама
+	metrics LimiterMetrics // nil - метрики выключены
+}
+
+// New создает канал Telegram поверх сервиса Bot API
+// Отправка проходит через limiter: паузы до maxWait канал ждёт сам, длинные возвращает в очередь
+func New(sender Sender, limiter RateLimiter, maxWait time.Duration, metrics LimiterMetrics) *Channel {
+	return &Channel{sender: sender, limiter: limiter, maxWait: maxWait, metrics: metrics}
+}
+
+// Name возвращает имя канала
+func (c *Channel) Name() domain.Channel {
+	return domain.ChannelTelegram
+}
+
+// Send отправляет сообщение в чат с chat_id = tg_user_id с разметкой уведомления
+// Ссылка отписки отправляется кнопкой, чтобы не экранировать её под разметку текста
+// Если слот ограничителя дальше MaxWait, сообщение не отправляется и возвращается ThrottledError
+func (c *Channel) Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error {
+	if err := c.throttle(ctx, recipient.UserID, message.NotificationID); err != nil {
+		return err
+	}
+
+	var buttons []telegramService.URLButton
+	if message.UnsubscribeURL != "" {
+		buttons = append(buttons, telegramService.URLButton{Text: channels.UnsubscribeLabel, URL: message.UnsubscribeURL})
+	}
+	// Превью ссылок отключено в сообщениях с отпиской: краулер превью не должен открывать ссылки сообщения
+	disablePreview := message.UnsubscribeURL != ""
+	return classifyError(c.sender.SendFormattedMessage(recipient.UserID, message.Text, message.ParseMode, disablePreview, buttons...))
+}
+
+// throttle ждёт слот отправки в чат или возвращает ThrottledError, если ждать дольше maxWait
+// Слот бронируется за уведомлением: отложенное сообщение при повторе получает его без новой брони
+func (c *Channel) throttle(ctx context.Context, chatID, notificationID int64) error {
+	slot, err := c.limiter.Reserve(ctx, notificationID, chatID)
+	if err != nil {
+		return fmt.Errorf("reserve telegram send slot: %w", err)
+	}
+
+	deferred := slot.Wait > c.maxWait
+	if c.metrics != nil && slot.Scope != domain.RateScopeNone {
+		c.metrics.RecordTelegramThrottled(string(slot.Scope), deferred, slot.Wait)
+	}
+	if deferred {
+		return &channels.ThrottledError{Until: time.Now().Add(slot.Wait)}
+	}
+
+	// Бронь больше не нужна: слот получен. Ошибку снятия не возвращаем - неснятую бронь удалит worker
+	_ = c.limiter.Release(ctx, notificationID)
+
+	if slot.Wait > 0 {
+		timer := time.NewTimer(slot.Wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// classifyError отделяет постоянные ошибки Bot API от временных
+// Заблокированный бот, отсутствующий чат и отклонённое сообщение не исправятся повтором,
+// 429 повторяется не раньше retry_after, остальные ошибки (5xx, сеть) - по общей политике
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var rateLimit *telegramService.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		return &channels.RetryAfterError{Err: err, RetryAfter: rateLimit.RetryAfter}
+	case errors.Is(err, telegramService.ErrBotBlocked),
+		errors.Is(err, telegramService.ErrChatNotFound),
+		errors.Is(err, telegramService.ErrBadRequest):
+		return channels.Permanent(err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,224 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/netguard"
+)
+
+var (
+	// ErrInvalidVAPIDKeys возвращается при некорректной ключевой паре VAPID
+	ErrInvalidVAPIDKeys = errors.New("webpush: invalid VAPID keys")
+
+	// ErrSendPush возвращается, когда ни одна подписка пользователя не приняла сообщение
+	ErrSendPush = errors.New("webpush: failed to send message")
+
+	// ErrSubscriptionGone возвращается, когда push-сервис сообщил, что подписка больше не действует
+	ErrSubscriptionGone = errors.New("webpush: subscription expired or unsubscribed")
+)
+
+const (
+	defaultTTL     = 24 * 60 * 60 // секунды хранения сообщения push-сервисом, пока браузер офлайн
+	requestTimeout = 10 * time.Second
+)
+
+// Config настройки Web Push
+type Config struct {
+	PublicKey  string // VAPID публичный ключ, base64url (можно не задавать - выводится из приватного)
+	PrivateKey string // VAPID приватный ключ, base64url
+	Subject    string // контакт для push-сервиса: mailto:... или https://...
+	Title      string // заголовок уведомления в браузере
+	TTL        int    // секунды, 0 - сутки
+}
+
+// SubscriptionRemover удаляет подписки, которые push-сервис объявил недействительными
+type SubscriptionRemover interface {
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+}
+
+// payload содержимое push-сообщения, которое разбирает service worker фронтенда
+type payload struct {
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	NotificationID int64  `json:"notification_id"`
+	UnsubscribeURL string `json:"unsubscribe_url,omitempty"` // service worker показывает действие "Отписаться"
+}
+
+// Channel доставка Web Push во все браузеры пользователя
+type Channel struct {
+	keys          *vapidKeys
+	subject       string
+	title         string
+	ttl           int
+	client        *http.Client
+	subscriptions SubscriptionRemover
+}
+
+// New создает канал Web Push
+func New(cfg Config, subscriptions SubscriptionRemover) (*Channel, error) {
+	keys, err := parseVAPIDKeys(cfg.PublicKey, cfg.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	return &Channel{
+		keys:          keys,
+		subject:       cfg.Subject,
+		title:         cfg.Title,
+		ttl:           ttl,
+		client:        newClient(),
+		subscriptions: subscriptions,
+	}, nil
+}
+
+// newClient HTTP клиент для push-сервисов
+// Адрес подписки задаёт клиент, поэтому подключения к непубличным адресам запрещены и после резолва DNS,
+// а редиректы не выполняются: push-сервис отвечает на запрос сам
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: netguard.Control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// PublicKey возвращает VAPID публичный ключ для PushManager.subscribe на фронтенде
+func (c *Channel) PublicKey() string {
+	return c.keys.publicKey
+}
+
+// Name возвращает имя канала
+func (c *Channel) Name() domain.Channel {
+	return domain.ChannelWebPush
+}
+
+// Send отправляет сообщение во все подписки пользователя
+// Доставка считается успешной, если сообщение принял хотя бы один браузер
+func (c *Channel) Send(ctx context.Context, recipient channels.Recipient, message channels.Message) error {
+	if len(recipient.PushSubscriptions) == 0 {
+		return channels.ErrNoAddress
+	}
+
+	body, err := c.buildPayload(message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSendPush, err)
+	}
+
+	var errs []error
+	delivered, gone := 0, 0
+	for _, subscription := range recipient.PushSubscriptions {
+		err := c.push(ctx, subscription, body)
+		if err == nil {
+			delivered++
+			continue
+		}
+		if errors.Is(err, ErrSubscriptionGone) {
+			gone++
+			if c.subscriptions != nil {
+				if removeErr := c.subscriptions.DeleteByEndpoint(ctx, subscription.Endpoint); removeErr != nil {
+					err = errors.Join(err, removeErr)
+				}
+			}
+		}
+		errs = append(errs, err)
+	}
+
+	if delivered == 0 {
+		sendErr := fmt.Errorf("%w: %v", ErrSendPush, errors.Join(errs...))
+		// Все подписки недействительны и удалены - повтор не поможет
+		if gone == len(errs) {
+			return channels.Permanent(sendErr)
+		}
+		return sendErr
+	}
+	return nil
+}
+
+// buildPayload сериализует сообщение, укорачивая текст до лимита push-сервисов
+func (c *Channel) buildPayload(message channels.Message) ([]byte, error) {
+	p := payload{
+		Title:          c.title,
+		Body:           message.PlainText(),
+		NotificationID: message.NotificationID,
+		UnsubscribeURL: message.UnsubscribeURL,
+	}
+	for {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) <= maxPayloadSize {
+			return data, nil
+		}
+
+		// Обрезаем по символам, а не байтам, чтобы не разрезать UTF-8
+		excess := len(data) - maxPayloadSize
+		runes := []rune(p.Body)
+		cut := len(runes) - (excess/utf8.UTFMax + 2)
+		if cut <= 0 {
+			return nil, fmt.Errorf("payload exceeds %d bytes", maxPayloadSize)
+		}
+		p.Body = string(runes[:cut]) + "…"
+	}
+}
+
+// push шифрует и отправляет сообщение в одну подписку
+func (c *Channel) push(ctx context.Context, subscription domain.PushSubscription, body []byte) error {
+	encrypted, err := encrypt(body, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := c.keys.authorization(subscription.Endpoint, c.subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(encrypted))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(c.ttl))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	default:
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push service returned %d: %s", resp.StatusCode, bytes.TrimSpace(text))
+	}
+}
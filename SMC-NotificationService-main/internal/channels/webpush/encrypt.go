@@ -0,0 +1,112 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// recordSize размер записи aes128gcm; всё сообщение помещается в одну запись
+	recordSize = 4096
+
+	saltLength = 16
+
+	// headerSize salt || rs || idlen || keyid (несжатый ключ P-256)
+	headerSize = saltLength + 4 + 1 + 65
+
+	// maxPayloadSize push-сервисы принимают тело до 4096 байт: минус заголовок, тег GCM и разделитель
+	maxPayloadSize = 4096 - headerSize - 16 - 1
+)
+
+// encrypt шифрует payload для подписки браузера по RFC 8291 (Content-Encoding: aes128gcm)
+func encrypt(payload []byte, p256dh, auth string) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, fmt.Errorf("payload exceeds %d bytes", maxPayloadSize)
+	}
+
+	userAgentKey, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth: %w", err)
+	}
+
+	curve := ecdh.P256()
+	userAgentPublic, err := curve.NewPublicKey(userAgentKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+
+	// Одноразовая ключевая пара сервера приложения
+	serverPrivate, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate ephemeral key: %w", err)
+	}
+	serverPublic := serverPrivate.PublicKey().Bytes()
+
+	sharedSecret, err := serverPrivate.ECDH(userAgentPublic)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: %w", err)
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	prkKey, err := hkdf.Extract(sha256.New, sharedSecret, authSecret)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(userAgentKey) + string(serverPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	// Ключ и nonce содержимого (RFC 8188)
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Заголовок: salt || rs || idlen || keyid (публичный ключ сервера)
+	body := make([]byte, 0, headerSize+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(serverPublic)))
+	body = append(body, serverPublic...)
+
+	// 0x02 - разделитель последней записи
+	plaintext := make([]byte, 0, len(payload)+1)
+	plaintext = append(plaintext, payload...)
+	plaintext = append(plaintext, 0x02)
+
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
@@ -0,0 +1,84 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// vapidTokenTTL срок действия JWT VAPID (RFC 8292 ограничивает 24 часами)
+const vapidTokenTTL = 12 * time.Hour
+
+// vapidKeys ключевая пара VAPID (P-256)
+type vapidKeys struct {
+	private   *ecdsa.PrivateKey
+	publicKey string // несжатая точка, base64url без паддинга
+}
+
+// parseVAPIDKeys разбирает ключи в формате web-push (base64url)
+// Публичный ключ выводится из приватного; если он задан, проверяется соответствие
+func parseVAPIDKeys(publicKey, privateKey string) (*vapidKeys, error) {
+	d, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: private key: %v", ErrInvalidVAPIDKeys, err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: private key: %v", ErrInvalidVAPIDKeys, err)
+	}
+	point := ecdhKey.PublicKey().Bytes() // 0x04 || X || Y
+
+	if publicKey != "" {
+		expected, err := decodeBase64URL(publicKey)
+		if err != nil || !bytes.Equal(expected, point) {
+			return nil, fmt.Errorf("%w: public key does not match private key", ErrInvalidVAPIDKeys)
+		}
+	}
+
+	return &vapidKeys{
+		private: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(point[1:33]),
+				Y:     new(big.Int).SetBytes(point[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		publicKey: base64.RawURLEncoding.EncodeToString(point),
+	}, nil
+}
+
+// authorization формирует заголовок Authorization для push-сервиса endpoint (RFC 8292)
+func (k *vapidKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid subscription endpoint %q", endpoint)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		return "", fmt.Errorf("sign vapid token: %w", err)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, k.publicKey), nil
+}
+
+// decodeBase64URL декодирует base64url с паддингом или без
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
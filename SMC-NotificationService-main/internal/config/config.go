@@ -0,0 +1,711 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/jwtauth"
+	"github.com/m04kA/SMC-NotificationService/pkg/svcauth"
+)
+
+// webhookSecretPattern допустимые Telegram символы secret_token
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// Config представляет полную конфигурацию приложения
+type Config struct {
+	Logs          LogsConfig          `toml:"logs"`
+	Server        ServerConfig        `toml:"server"`
+	Database      DatabaseConfig      `toml:"database"`
+	Metrics       MetricsConfig       `toml:"metrics"`
+	Telegram      TelegramConfig      `toml:"telegram"`
+	UserService   UserServiceConfig   `toml:"userservice"`
+	SellerService SellerServiceConfig `toml:"sellerservice"`
+	PriceService  PriceServiceConfig  `toml:"priceservice"`
+	Bot           BotConfig           `toml:"bot"`
+	Worker        WorkerConfig        `toml:"worker"`
+	Channels      ChannelsConfig      `toml:"channels"`
+	Auth          AuthConfig          `toml:"auth"`
+	Unsubscribe   UnsubscribeConfig   `toml:"unsubscribe"`
+
+	InternalAuth InternalAuthConfig `toml:"internal_auth"`
+}
+
+// LogsConfig содержит настройки логирования
+type LogsConfig struct {
+	Level string `toml:"level"`
+	File  string `toml:"file"`
+}
+
+// ServerConfig содержит настройки HTTP сервера
+type ServerConfig struct {
+	HTTPPort        int `toml:"http_port"`
+	ReadTimeout     int `toml:"read_timeout"`
+	WriteTimeout    int `toml:"write_timeout"`
+	IdleTimeout     int `toml:"idle_timeout"`
+	ShutdownTimeout int `toml:"shutdown_timeout"`
+}
+
+// DatabaseConfig содержит настройки подключения к PostgreSQL
+type DatabaseConfig struct {
+	Host            string `toml:"host"`
+	Port            int    `toml:"port"`
+	User            string `toml:"user"`
+	Password        string `toml:"password"`
+	DBName          string `toml:"dbname"`
+	SSLMode         string `toml:"sslmode"`
+	MaxOpenConns    int    `toml:"max_open_conns"`
+	MaxIdleConns    int    `toml:"max_idle_conns"`
+	ConnMaxLifetime int    `toml:"conn_max_lifetime"`
+}
+
+// MetricsConfig содержит настройки метрик Prometheus
+type MetricsConfig struct {
+	Enabled     bool   `toml:"enabled"`
+	Path        string `toml:"path"`
+	ServiceName string `toml:"service_name"`
+}
+
+// TelegramConfig содержит настройки Telegram Bot API
+// Пустой webhook_url включает режим long polling
+type TelegramConfig struct {
+	BotToken      string `toml:"bot_token"`
+	WebhookURL    string `toml:"webhook_url"`
+	WebhookSecret string `toml:"webhook_secret"` // секрет заголовка X-Telegram-Bot-Api-Secret-Token, обязателен при webhook_url
+	APIEndpoint   string `toml:"api_endpoint"`   // шаблон адреса Bot API, например http://localhost:8086/bot%s/%s для локального фейка
+
+	RateLimit TelegramRateLimitConfig `toml:"rate_limit"`
+}
+
+// TelegramRateLimitConfig содержит лимиты отправки уведомлений в Telegram
+// Лимиты действуют на токен бота: состояние хранится в БД и общее для всех экземпляров
+type TelegramRateLimitConfig struct {
+	GlobalRate         float64 `toml:"global_rate"`           // сообщений в секунду всего
+	ChatRate           float64 `toml:"chat_rate"`             // сообщений в секунду в один личный чат
+	GroupRatePerMinute float64 `toml:"group_rate_per_minute"` // сообщений в минуту в одну группу
+	MaxWait            int     `toml:"max_wait"`              // миллисекунды, которые отправка ждёт сама; дольше - уведомление возвращается в очередь
+}
+
+// UserServiceConfig содержит настройки интеграции с UserService
+type UserServiceConfig struct {
+	URL     string `toml:"url"`
+	Timeout int    `toml:"timeout"` // секунды
+}
+
+// SellerServiceConfig содержит настройки интеграции с SellerService (поиск моек и их услуг для бота)
+type SellerServiceConfig struct {
+	URL     string `toml:"url"`
+	Timeout int    `toml:"timeout"` // секунды
+}
+
+// PriceServiceConfig содержит настройки интеграции с PriceService (расчёт цен для бота)
+type PriceServiceConfig struct {
+	URL     string `toml:"url"`
+	Timeout int    `toml:"timeout"` // секунды
+}
+
+// BotConfig содержит настройки диалогов Telegram бота
+type BotConfig struct {
+	ConversationTTL int     `toml:"conversation_ttl"` // минуты бездействия, после которых незавершённый диалог сбрасывается
+	SearchRadiusKm  float64 `toml:"search_radius_km"` // радиус поиска моек рядом с пользователем
+	SearchLimit     int     `toml:"search_limit"`     // максимум моек в ответе на /washes
+}
+
+// WorkerConfig содержит настройки фоновой отправки уведомлений
+type WorkerConfig struct {
+	ProcessorInterval  int    `toml:"processor_interval"`   // секунды между проходами processor
+	ProcessorBatchSize int    `toml:"processor_batch_size"` // уведомлений за один проход
+	MaxAttempts        int    `toml:"max_attempts"`         // попыток доставки, после которых уведомление уходит в dead-letter
+	RetryBaseDelay     int    `toml:"retry_base_delay"`     // секунды до первого повтора, дальше пауза удваивается
+	RetryMaxDelay      int    `toml:"retry_max_delay"`      // максимальная пауза между повторами (секунды)
+	InstanceName       string `toml:"instance_name"`        // префикс идентификатора экземпляра в locked_by; пусто - hostname
+	LeaseDuration      int    `toml:"lease_duration"`       // секунды аренды захваченного уведомления без heartbeat
+	SeriesInterval     int    `toml:"series_interval"`      // секунды между проходами materializer повторяющихся уведомлений
+	SeriesHorizon      int    `toml:"series_horizon"`       // часы вперёд, на которые заранее создаются повторения
+}
+
+// Режимы каналов доставки
+const (
+	ChannelModeSink     = "sink"     // запись в sink вместо отправки (локальный запуск)
+	ChannelModeDisabled = "disabled" // канал отключён, попытки помечаются как skipped
+	ChannelModeSMTP     = "smtp"     // email: отправка через SMTP сервер
+	ChannelModeVAPID    = "vapid"    // webpush: отправка в push-сервисы браузеров
+)
+
+// ChannelsConfig содержит настройки каналов доставки
+// Telegram включён всегда; email, sms и webpush настраиваются отдельно
+type ChannelsConfig struct {
+	Default []string      `toml:"default"` // каналы уведомления, если они не заданы в запросе, в порядке fallback
+	Sink    string        `toml:"sink"`    // stdout или путь к файлу для каналов в режиме sink
+	Email   EmailConfig   `toml:"email"`
+	SMS     SMSConfig     `toml:"sms"`
+	WebPush WebPushConfig `toml:"webpush"`
+}
+
+// EmailConfig содержит настройки канала email
+type EmailConfig struct {
+	Mode     string `toml:"mode"` // smtp | sink | disabled
+	Host     string `toml:"host"`
+	Port     int    `toml:"port"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+	From     string `toml:"from"`
+	Subject  string `toml:"subject"`
+}
+
+// SMSConfig содержит настройки канала SMS
+// Реальный провайдер подключается реализацией sms.Provider
+type SMSConfig struct {
+	Mode string `toml:"mode"` // sink | disabled
+}
+
+// WebPushConfig содержит настройки канала Web Push
+type WebPushConfig struct {
+	Mode            string `toml:"mode"`              // vapid | sink | disabled
+	VAPIDPublicKey  string `toml:"vapid_public_key"`  // base64url, можно не задавать
+	VAPIDPrivateKey string `toml:"vapid_private_key"` // base64url
+	Subject         string `toml:"subject"`           // mailto:... или https://...
+	Title           string `toml:"title"`             // заголовок уведомления в браузере
+	TTL             int    `toml:"ttl"`               // секунды хранения сообщения push-сервисом
+}
+
+// UnsubscribeConfig содержит настройки ссылок отписки в один клик
+// Пустой secret отключает ссылки отписки в сообщениях
+type UnsubscribeConfig struct {
+	Secret    string `toml:"secret"`     // ключ подписи токенов отписки
+	PublicURL string `toml:"public_url"` // внешний адрес сервиса, к которому добавляется /unsubscribe
+}
+
+// AuthConfig содержит настройки проверки access токенов
+// По умолчанию mode = "jwt"; mode = "header" оставляет аутентификацию по X-User-ID/X-User-Role (только для локальной разработки)
+type AuthConfig struct {
+	Mode                string `toml:"mode"`                  // jwt | header
+	JWTSecret           string `toml:"jwt_secret"`            // секрет HS256
+	PublicKeyFile       string `toml:"public_key_file"`       // PEM файл с публичным ключом RS256
+	JWKSFile            string `toml:"jwks_file"`             // локальный JWKS файл
+	JWKSURL             string `toml:"jwks_url"`              // JWKS endpoint
+	JWKSRefreshInterval int    `toml:"jwks_refresh_interval"` // секунды
+	Issuer              string `toml:"issuer"`
+	Leeway              int    `toml:"leeway"` // секунды
+}
+
+// InternalAuthConfig содержит учётные данные сервиса для запросов к /internal эндпоинтам других сервисов
+// и ключи сервисов, которым разрешено вызывать /internal/users эндпоинты NotificationService
+// mode = "none" отправляет запросы без подписи и не проверяет входящие (только для локальной разработки)
+type InternalAuthConfig struct {
+	Mode         string            `toml:"mode"`           // hmac | api_key | none
+	ServiceName  string            `toml:"service_name"`   // имя сервиса в конфигурации вызываемого сервиса
+	Secret       string            `toml:"secret"`         // общий секрет (hmac) или ключ (api_key)
+	MaxClockSkew int               `toml:"max_clock_skew"` // секунды, допустимое расхождение времени подписи
+	Keys         map[string]string `toml:"keys"`           // имя вызывающего сервиса -> секрет (hmac) или ключ (api_key)
+}
+
+// Credentials преобразует настройки в учётные данные пакета svcauth
+func (a InternalAuthConfig) Credentials() svcauth.Credentials {
+	return svcauth.Credentials{
+		Mode:        a.Mode,
+		ServiceName: a.ServiceName,
+		Secret:      a.Secret,
+	}
+}
+
+// Verifier преобразует настройки в конфигурацию проверки входящих запросов пакета svcauth
+func (a InternalAuthConfig) Verifier() svcauth.VerifierConfig {
+	return svcauth.VerifierConfig{
+		Mode:         a.Mode,
+		Keys:         a.Keys,
+		MaxClockSkew: time.Duration(a.MaxClockSkew) * time.Second,
+	}
+}
+
+// JWTAuth преобразует настройки в конфигурацию пакета jwtauth
+func (a AuthConfig) JWTAuth() jwtauth.Config {
+	return jwtauth.Config{
+		Mode:                a.Mode,
+		HMACSecret:          a.JWTSecret,
+		PublicKeyFile:       a.PublicKeyFile,
+		JWKSFile:            a.JWKSFile,
+		JWKSURL:             a.JWKSURL,
+		JWKSRefreshInterval: time.Duration(a.JWKSRefreshInterval) * time.Second,
+		Issuer:              a.Issuer,
+		Leeway:              time.Duration(a.Leeway) * time.Second,
+	}
+}
+
+// DSN формирует строку подключения к PostgreSQL
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		d.Host, d.Port, d.User, d.Password, d.DBName, d.SSLMode,
+	)
+}
+
+// Load загружает конфигурацию из TOML файла с поддержкой переменных окружения
+func Load(path string) (*Config, error) {
+	var cfg Config
+
+	// Читаем TOML файл
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode TOML config: %w", err)
+	}
+
+	// Переопределяем значения из переменных окружения (если они установлены)
+	overrideFromEnv(&cfg)
+
+	// Валидация конфигурации
+	if err := validate(&cfg); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// overrideFromEnv переопределяет значения из переменных окружения
+func overrideFromEnv(cfg *Config) {
+	// Database
+	if v := os.Getenv("DB_HOST"); v != "" {
+		cfg.Database.Host = v
+	}
+	if v := os.Getenv("DB_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Database.Port = port
+		}
+	}
+	if v := os.Getenv("DB_USER"); v != "" {
+		cfg.Database.User = v
+	}
+	if v := os.Getenv("DB_PASSWORD"); v != "" {
+		cfg.Database.Password = v
+	}
+	if v := os.Getenv("DB_NAME"); v != "" {
+		cfg.Database.DBName = v
+	}
+	if v := os.Getenv("DB_SSLMODE"); v != "" {
+		cfg.Database.SSLMode = v
+	}
+
+	// Server
+	if v := os.Getenv("HTTP_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Server.HTTPPort = port
+		}
+	}
+
+	// Telegram
+	if v := os.Getenv("TELEGRAM_BOT_TOKEN"); v != "" {
+		cfg.Telegram.BotToken = v
+	}
+	if v := os.Getenv("TELEGRAM_WEBHOOK_URL"); v != "" {
+		cfg.Telegram.WebhookURL = v
+	}
+	if v := os.Getenv("TELEGRAM_WEBHOOK_SECRET"); v != "" {
+		cfg.Telegram.WebhookSecret = v
+	}
+	if v := os.Getenv("TELEGRAM_API_ENDPOINT"); v != "" {
+		cfg.Telegram.APIEndpoint = v
+	}
+	if v := os.Getenv("TELEGRAM_RATE_LIMIT_GLOBAL"); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Telegram.RateLimit.GlobalRate = rate
+		}
+	}
+
+	// Worker
+	if v := os.Getenv("WORKER_INSTANCE_NAME"); v != "" {
+		cfg.Worker.InstanceName = v
+	}
+
+	// Channels
+	if v := os.Getenv("CHANNELS_SINK"); v != "" {
+		cfg.Channels.Sink = v
+	}
+	if v := os.Getenv("EMAIL_MODE"); v != "" {
+		cfg.Channels.Email.Mode = v
+	}
+	if v := os.Getenv("SMTP_HOST"); v != "" {
+		cfg.Channels.Email.Host = v
+	}
+	if v := os.Getenv("SMTP_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Channels.Email.Port = port
+		}
+	}
+	if v := os.Getenv("SMTP_USERNAME"); v != "" {
+		cfg.Channels.Email.Username = v
+	}
+	if v := os.Getenv("SMTP_PASSWORD"); v != "" {
+		cfg.Channels.Email.Password = v
+	}
+	if v := os.Getenv("SMTP_FROM"); v != "" {
+		cfg.Channels.Email.From = v
+	}
+	if v := os.Getenv("SMS_MODE"); v != "" {
+		cfg.Channels.SMS.Mode = v
+	}
+	if v := os.Getenv("WEBPUSH_MODE"); v != "" {
+		cfg.Channels.WebPush.Mode = v
+	}
+	if v := os.Getenv("VAPID_PUBLIC_KEY"); v != "" {
+		cfg.Channels.WebPush.VAPIDPublicKey = v
+	}
+	if v := os.Getenv("VAPID_PRIVATE_KEY"); v != "" {
+		cfg.Channels.WebPush.VAPIDPrivateKey = v
+	}
+
+	// UserService
+	if v := os.Getenv("USERSERVICE_URL"); v != "" {
+		cfg.UserService.URL = v
+	}
+
+	// SellerService
+	if v := os.Getenv("SELLERSERVICE_URL"); v != "" {
+		cfg.SellerService.URL = v
+	}
+
+	// PriceService
+	if v := os.Getenv("PRICESERVICE_URL"); v != "" {
+		cfg.PriceService.URL = v
+	}
+
+	// Internal auth
+	if v := os.Getenv("INTERNAL_AUTH_MODE"); v != "" {
+		cfg.InternalAuth.Mode = v
+	}
+	if v := os.Getenv("INTERNAL_AUTH_SECRET"); v != "" {
+		cfg.InternalAuth.Secret = v
+	}
+	// Формат: userservice=secret1,sellerservice=secret2
+	if v := os.Getenv("INTERNAL_AUTH_KEYS"); v != "" {
+		keys := make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			name, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && name != "" {
+				keys[name] = key
+			}
+		}
+		cfg.InternalAuth.Keys = keys
+	}
+
+	// Auth
+	if v := os.Getenv("AUTH_MODE"); v != "" {
+		cfg.Auth.Mode = v
+	}
+	if v := os.Getenv("JWT_SECRET"); v != "" {
+		cfg.Auth.JWTSecret = v
+	}
+	if v := os.Getenv("JWKS_URL"); v != "" {
+		cfg.Auth.JWKSURL = v
+	}
+
+	// Unsubscribe
+	if v := os.Getenv("UNSUBSCRIBE_SECRET"); v != "" {
+		cfg.Unsubscribe.Secret = v
+	}
+	if v := os.Getenv("PUBLIC_URL"); v != "" {
+		cfg.Unsubscribe.PublicURL = v
+	}
+
+	// Logs
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		cfg.Logs.Level = v
+	}
+	if v := os.Getenv("LOG_FILE"); v != "" {
+		cfg.Logs.File = v
+	}
+
+	// Metrics
+	if v := os.Getenv("METRICS_ENABLED"); v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			cfg.Metrics.Enabled = enabled
+		}
+	}
+	if v := os.Getenv("METRICS_PATH"); v != "" {
+		cfg.Metrics.Path = v
+	}
+	if v := os.Getenv("METRICS_SERVICE_NAME"); v != "" {
+		cfg.Metrics.ServiceName = v
+	}
+}
+
+// validate проверяет корректность конфигурации
+func validate(cfg *Config) error {
+	// Database validation
+	if cfg.Database.Host == "" {
+		return fmt.Errorf("database host is required")
+	}
+	if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
+		return fmt.Errorf("database port must be between 1 and 65535")
+	}
+	if cfg.Database.User == "" {
+		return fmt.Errorf("database user is required")
+	}
+	if cfg.Database.DBName == "" {
+		return fmt.Errorf("database name is required")
+	}
+
+	// Server validation
+	if cfg.Server.HTTPPort <= 0 || cfg.Server.HTTPPort > 65535 {
+		return fmt.Errorf("HTTP port must be between 1 and 65535")
+	}
+
+	// Logs validation
+	if cfg.Logs.Level == "" {
+		cfg.Logs.Level = "info" // default
+	}
+	if cfg.Logs.File == "" {
+		cfg.Logs.File = "./logs/app.log" // default
+	}
+
+	// Set defaults for timeouts if not specified
+	if cfg.Server.ReadTimeout == 0 {
+		cfg.Server.ReadTimeout = 15
+	}
+	if cfg.Server.WriteTimeout == 0 {
+		cfg.Server.WriteTimeout = 15
+	}
+	if cfg.Server.IdleTimeout == 0 {
+		cfg.Server.IdleTimeout = 60
+	}
+	if cfg.Server.ShutdownTimeout == 0 {
+		cfg.Server.ShutdownTimeout = 10
+	}
+
+	// Set defaults for database connection pool
+	if cfg.Database.MaxOpenConns == 0 {
+		cfg.Database.MaxOpenConns = 25
+	}
+	if cfg.Database.MaxIdleConns == 0 {
+		cfg.Database.MaxIdleConns = 5
+	}
+	if cfg.Database.ConnMaxLifetime == 0 {
+		cfg.Database.ConnMaxLifetime = 300 // 5 minutes
+	}
+
+	// Metrics validation and defaults
+	if cfg.Metrics.Path == "" {
+		cfg.Metrics.Path = "/metrics"
+	}
+	if cfg.Metrics.ServiceName == "" {
+		cfg.Metrics.ServiceName = "notificationservice"
+	}
+
+	// Telegram validation and defaults
+	if cfg.Telegram.BotToken == "" {
+		return fmt.Errorf("telegram bot_token is required")
+	}
+	if cfg.Telegram.APIEndpoint == "" {
+		cfg.Telegram.APIEndpoint = tgbotapi.APIEndpoint
+	}
+	if strings.Count(cfg.Telegram.APIEndpoint, "%s") != 2 {
+		return fmt.Errorf("telegram api_endpoint must contain two %%s placeholders (token and method)")
+	}
+	if cfg.Telegram.WebhookURL != "" && cfg.Telegram.WebhookSecret == "" {
+		return fmt.Errorf("telegram webhook_secret is required when webhook_url is set")
+	}
+	if cfg.Telegram.WebhookSecret != "" && !webhookSecretPattern.MatchString(cfg.Telegram.WebhookSecret) {
+		return fmt.Errorf("telegram webhook_secret must be 1-256 characters A-Z, a-z, 0-9, _ or -")
+	}
+	if cfg.Telegram.RateLimit.GlobalRate <= 0 {
+		cfg.Telegram.RateLimit.GlobalRate = 30
+	}
+	if cfg.Telegram.RateLimit.ChatRate <= 0 {
+		cfg.Telegram.RateLimit.ChatRate = 1
+	}
+	if cfg.Telegram.RateLimit.GroupRatePerMinute <= 0 {
+		cfg.Telegram.RateLimit.GroupRatePerMinute = 20
+	}
+	if cfg.Telegram.RateLimit.MaxWait <= 0 {
+		cfg.Telegram.RateLimit.MaxWait = 1000
+	}
+
+	// UserService validation and defaults
+	if cfg.UserService.URL == "" {
+		return fmt.Errorf("userservice url is required")
+	}
+	if cfg.UserService.Timeout == 0 {
+		cfg.UserService.Timeout = 10
+	}
+
+	// SellerService и PriceService defaults
+	if cfg.SellerService.URL == "" {
+		cfg.SellerService.URL = "http://localhost:8081"
+	}
+	if cfg.SellerService.Timeout == 0 {
+		cfg.SellerService.Timeout = 10
+	}
+	if cfg.PriceService.URL == "" {
+		cfg.PriceService.URL = "http://localhost:8082"
+	}
+	if cfg.PriceService.Timeout == 0 {
+		cfg.PriceService.Timeout = 10
+	}
+
+	// Bot defaults
+	if cfg.Bot.ConversationTTL <= 0 {
+		cfg.Bot.ConversationTTL = 30
+	}
+	if cfg.Bot.SearchRadiusKm <= 0 {
+		cfg.Bot.SearchRadiusKm = 10
+	}
+	if cfg.Bot.SearchRadiusKm > 100 {
+		return fmt.Errorf("bot search_radius_km must not exceed 100")
+	}
+	if cfg.Bot.SearchLimit <= 0 {
+		cfg.Bot.SearchLimit = 5
+	}
+
+	// Worker defaults
+	if cfg.Worker.ProcessorInterval <= 0 {
+		cfg.Worker.ProcessorInterval = 10
+	}
+	if cfg.Worker.ProcessorBatchSize <= 0 {
+		cfg.Worker.ProcessorBatchSize = 100
+	}
+	if cfg.Worker.MaxAttempts <= 0 {
+		cfg.Worker.MaxAttempts = 5
+	}
+	if cfg.Worker.RetryBaseDelay <= 0 {
+		cfg.Worker.RetryBaseDelay = 30
+	}
+	if cfg.Worker.RetryMaxDelay <= 0 {
+		cfg.Worker.RetryMaxDelay = 3600
+	}
+	if cfg.Worker.LeaseDuration <= 0 {
+		cfg.Worker.LeaseDuration = 60
+	}
+	if cfg.Worker.SeriesInterval <= 0 {
+		cfg.Worker.SeriesInterval = 60
+	}
+	if cfg.Worker.SeriesHorizon <= 0 {
+		cfg.Worker.SeriesHorizon = 168
+	}
+	if cfg.Worker.InstanceName == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "notificationservice"
+		}
+		cfg.Worker.InstanceName = hostname
+	}
+
+	// Channels validation and defaults
+	if err := validateChannels(&cfg.Channels); err != nil {
+		return fmt.Errorf("channels: %w", err)
+	}
+
+	// Auth validation and defaults
+	if err := validateAuth(&cfg.Auth); err != nil {
+		return fmt.Errorf("auth: %w", err)
+	}
+
+	// Internal auth validation and defaults
+	if cfg.InternalAuth.Mode == "" {
+		cfg.InternalAuth.Mode = svcauth.ModeNone
+	}
+	if cfg.InternalAuth.ServiceName == "" {
+		cfg.InternalAuth.ServiceName = "notificationservice"
+	}
+	if cfg.InternalAuth.MaxClockSkew == 0 {
+		cfg.InternalAuth.MaxClockSkew = 60
+	}
+	if err := cfg.InternalAuth.Credentials().Validate(); err != nil {
+		return fmt.Errorf("internal_auth: %w", err)
+	}
+
+	return nil
+}
+
+// validateAuth проверяет настройки аутентификации и заполняет значения по умолчанию
+func validateAuth(auth *AuthConfig) error {
+	// Режим header доверяет заголовкам клиента, поэтому включается только явно
+	if auth.Mode == "" {
+		auth.Mode = jwtauth.ModeJWT
+	}
+	if auth.Mode != jwtauth.ModeJWT && auth.Mode != jwtauth.ModeHeader {
+		return fmt.Errorf("auth mode must be %s or %s", jwtauth.ModeJWT, jwtauth.ModeHeader)
+	}
+	if auth.Mode == jwtauth.ModeJWT &&
+		auth.JWTSecret == "" && auth.PublicKeyFile == "" && auth.JWKSFile == "" && auth.JWKSURL == "" {
+		return fmt.Errorf("auth mode jwt requires jwt_secret, public_key_file, jwks_file or jwks_url")
+	}
+	if auth.Issuer == "" {
+		auth.Issuer = "smc-userservice"
+	}
+	if auth.JWKSRefreshInterval == 0 {
+		auth.JWKSRefreshInterval = 300 // 5 minutes
+	}
+	if auth.Leeway == 0 {
+		auth.Leeway = 30
+	}
+
+	return nil
+}
+
+// validateChannels проверяет настройки каналов и заполняет значения по умолчанию
+func validateChannels(c *ChannelsConfig) error {
+	if len(c.Default) == 0 {
+		c.Default = []string{"telegram"}
+	}
+	for _, name := range c.Default {
+		switch name {
+		case "telegram", "email", "sms", "webpush":
+		default:
+			return fmt.Errorf("unknown default channel %q", name)
+		}
+	}
+	if c.Sink == "" {
+		c.Sink = "stdout"
+	}
+
+	// Email
+	if c.Email.Mode == "" {
+		c.Email.Mode = ChannelModeSink
+	}
+	switch c.Email.Mode {
+	case ChannelModeSMTP:
+		if c.Email.Host == "" || c.Email.From == "" {
+			return fmt.Errorf("email host and from are required in smtp mode")
+		}
+		if c.Email.Port == 0 {
+			c.Email.Port = 587
+		}
+	case ChannelModeSink, ChannelModeDisabled:
+	default:
+		return fmt.Errorf("email mode must be smtp, sink or disabled")
+	}
+	if c.Email.Subject == "" {
+		c.Email.Subject = "Уведомление SMC"
+	}
+
+	// SMS
+	if c.SMS.Mode == "" {
+		c.SMS.Mode = ChannelModeSink
+	}
+	if c.SMS.Mode != ChannelModeSink && c.SMS.Mode != ChannelModeDisabled {
+		return fmt.Errorf("sms mode must be sink or disabled")
+	}
+
+	// Web Push
+	if c.WebPush.Mode == "" {
+		c.WebPush.Mode = ChannelModeSink
+	}
+	switch c.WebPush.Mode {
+	case ChannelModeVAPID:
+		if c.WebPush.VAPIDPrivateKey == "" || c.WebPush.Subject == "" {
+			return fmt.Errorf("webpush vapid_private_key and subject are required in vapid mode")
+		}
+	case ChannelModeSink, ChannelModeDisabled:
+	default:
+		return fmt.Errorf("webpush mode must be vapid, sink or disabled")
+	}
+	if c.WebPush.Title == "" {
+		c.WebPush.Title = "SMC"
+	}
+
+	return nil
+}
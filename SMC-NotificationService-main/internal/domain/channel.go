@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// Channel канал доставки уведомлений
+type Channel string
+
+const (
+	ChannelTelegram Channel = "telegram" // личный чат с ботом
+	ChannelEmail    Channel = "email"    // письмо через SMTP
+	ChannelSMS      Channel = "sms"      // SMS на подтверждённый номер из UserService
+	ChannelWebPush  Channel = "webpush"  // Web Push в браузер (VAPID)
+)
+
+// IsValid проверяет, что канал известен
+func (c Channel) IsValid() bool {
+	switch c {
+	case ChannelTelegram, ChannelEmail, ChannelSMS, ChannelWebPush:
+		return true
+	}
+	return false
+}
+
+// DeliveryStatus результат попытки доставки по одному каналу
+type DeliveryStatus string
+
+const (
+	DeliverySent    DeliveryStatus = "sent"    // канал принял сообщение
+	DeliveryFailed  DeliveryStatus = "failed"  // канал вернул ошибку
+	DeliverySkipped DeliveryStatus = "skipped" // канал отключён или у пользователя нет адреса
+)
+
+// Delivery попытка доставки уведомления по одному каналу
+type Delivery struct {
+	ID             int64
+	NotificationID int64
+	Channel        Channel
+	Status         DeliveryStatus
+	Error          *string
+	CreatedAt      time.Time
+}
+
+// CreateDeliveryInput входные данные для сохранения попытки доставки
+type CreateDeliveryInput struct {
+	NotificationID int64
+	Channel        Channel
+	Status         DeliveryStatus
+	Error          *string
+}
+
+// ChannelSettings пользовательские настройки каналов
+type ChannelSettings struct {
+	UserID       int64
+	ChannelOrder []Channel // предпочтительный порядок fallback, пустой - порядок из уведомления
+	Email        *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// PushSubscription подписка браузера на Web Push
+type PushSubscription struct {
+	ID        int64
+	UserID    int64
+	Endpoint  string
+	P256dh    string // публичный ключ браузера, base64url
+	Auth      string // секрет аутентификации, base64url
+	CreatedAt time.Time
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// ConversationState шаг диалога с ботом: чего бот ждёт от пользователя следующим сообщением
+type ConversationState string
+
+const (
+	ConversationIdle       ConversationState = "idle"        // ждёт команду
+	ConversationCarBrand   ConversationState = "car_brand"   // добавление автомобиля: марка
+	ConversationCarModel   ConversationState = "car_model"   // добавление автомобиля: модель
+	ConversationCarPlate   ConversationState = "car_plate"   // добавление автомобиля: номер
+	ConversationLocation   ConversationState = "location"    // поиск моек: геопозиция
+	ConversationQuietHours ConversationState = "quiet_hours" // настройки: тихие часы HH:MM-HH:MM
+)
+
+// Conversation диалог пользователя с ботом в личном чате
+type Conversation struct {
+	ChatID    int64
+	UserID    int64
+	State     ConversationState
+	Data      ConversationData
+	UpdatedAt time.Time
+}
+
+// ConversationData данные, собранные в диалоге
+// Черновик автомобиля сбрасывается вместе с шагом, выбранная мойка сохраняется для /price
+type ConversationData struct {
+	CarBrand    string `json:"car_brand,omitempty"`
+	CarModel    string `json:"car_model,omitempty"`
+	CompanyID   int64  `json:"company_id,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+}
+
+// Reset возвращает диалог к ожиданию команды, сохраняя выбранную мойку
+func (c *Conversation) Reset() {
+	c.State = ConversationIdle
+	c.Data.CarBrand = ""
+	c.Data.CarModel = ""
+}
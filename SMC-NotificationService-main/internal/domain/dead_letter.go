@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// DeadLetterReason причина, по которой уведомление попало в dead-letter
+type DeadLetterReason string
+
+const (
+	DeadLetterPermanent DeadLetterReason = "permanent" // постоянная ошибка: бот заблокирован, чат не найден
+	DeadLetterExhausted DeadLetterReason = "exhausted" // временные ошибки не прошли за max_attempts попыток
+	// Экземпляр сервиса остановился во время отправки: уведомление могло быть доставлено,
+	// поэтому автоматически не повторяется
+	DeadLetterLeaseExpired DeadLetterReason = "lease_expired"
+)
+
+// IsValid проверяет, что причина известна
+func (r DeadLetterReason) IsValid() bool {
+	return r == DeadLetterPermanent || r == DeadLetterExhausted || r == DeadLetterLeaseExpired
+}
+
+// DeadLetter уведомление, которое не удалось доставить
+// Запись живёт, пока её не вернут в очередь (requeue) или не удалят (discard)
+type DeadLetter struct {
+	ID             int64
+	NotificationID int64
+	UserID         int64
+	Message        string // текст уведомления для просмотра
+	Reason         DeadLetterReason
+	Attempts       int
+	Error          string // ошибки последней попытки по каналам
+	CreatedAt      time.Time
+}
+
+// DeadLetterFilter фильтры списка dead-letter
+type DeadLetterFilter struct {
+	UserID *int64
+	Reason *DeadLetterReason
+	Page   int
+	Limit  int
+}
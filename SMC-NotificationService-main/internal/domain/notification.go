@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// NotificationStatus статус уведомления
+type NotificationStatus string
+
+const (
+	StatusPending    NotificationStatus = "pending"    // ожидает отправки или повторной попытки
+	StatusProcessing NotificationStatus = "processing" // захвачено экземпляром сервиса на время аренды
+	StatusSent       NotificationStatus = "sent"       // доставлено хотя бы по одному каналу
+	StatusFailed     NotificationStatus = "failed"     // попытки исчерпаны или ошибка постоянная, см. dead-letter
+	StatusCancelled  NotificationStatus = "cancelled"  // отменено до отправки
+)
+
+// IsValid проверяет, что статус известен
+func (s NotificationStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusProcessing, StatusSent, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Notification представляет уведомление пользователю
+type Notification struct {
+	ID            int64
+	UserID        int64 // tg_user_id, он же chat_id личного чата с ботом
+	Message       string
+	ParseMode     ParseMode    // разметка Message для Telegram; остальные каналы получают текст без разметки
+	Channels      []Channel    // допустимые каналы доставки в порядке fallback
+	Template      *TemplateRef // nil - текст передан вызывающим сервисом
+	Category      Category
+	SpanID        *string    // идентификатор пакета, если уведомление создано через batch
+	ScheduledAt   *time.Time // nil - отправить как можно скорее
+	Status        NotificationStatus
+	Attempts      int        // количество начатых попыток отправки
+	NextAttemptAt *time.Time // время повторной попытки после временной ошибки
+	Error         *string    // текст последней ошибки отправки
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Deliveries    []Delivery // попытки доставки, заполняется только при получении по ID
+}
+
+// DueAt возвращает время ближайшей отправки: повторной попытки или scheduled_at
+// nil - отправить как можно скорее
+func (n *Notification) DueAt() *time.Time {
+	if n.NextAttemptAt != nil {
+		return n.NextAttemptAt
+	}
+	return n.ScheduledAt
+}
+
+// IsDue проверяет, что уведомление пора отправлять
+func (n *Notification) IsDue(now time.Time) bool {
+	dueAt := n.DueAt()
+	return dueAt == nil || !dueAt.After(now)
+}
+
+// CreateNotificationInput входные данные для создания уведомления
+type CreateNotificationInput struct {
+	UserID      int64
+	Message     string
+	ParseMode   ParseMode
+	Channels    []Channel
+	Template    *TemplateRef // nil - текст передан вызывающим сервисом
+	Category    Category
+	SpanID      *string
+	ScheduledAt *time.Time
+}
+
+// TemplateRef шаблон, из которого создан текст уведомления
+type TemplateRef struct {
+	Name    string
+	Locale  Locale
+	Version int
+}
+
+// NotificationFilter фильтры для списка уведомлений
+type NotificationFilter struct {
+	UserID *int64
+	SpanID *string
+	Status *NotificationStatus
+	Page   int
+	Limit  int
+}
+
+// PaginationResult результат с пагинацией
+type PaginationResult struct {
+	Page  int
+	Limit int
+	Total int
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+// Category категория уведомления: определяет, можно ли от неё отписаться и откладывать ли её в тихие часы
+type Category string
+
+const (
+	CategoryTransactional Category = "transactional" // подтверждения, коды, статусы записей; отправляются всегда
+	CategoryReminders     Category = "reminders"     // напоминания
+	CategoryMarketing     Category = "marketing"     // акции и рассылки
+)
+
+// IsValid проверяет, что категория известна
+func (c Category) IsValid() bool {
+	switch c {
+	case CategoryTransactional, CategoryReminders, CategoryMarketing:
+		return true
+	}
+	return false
+}
+
+// Optional проверяет, что от категории можно отписаться и она откладывается в тихие часы
+func (c Category) Optional() bool {
+	return c == CategoryReminders || c == CategoryMarketing
+}
+
+// MinutesPerDay количество минут в сутках: граница времени тихих часов
+const MinutesPerDay = 24 * 60
+
+// QuietHours тихие часы пользователя в минутах от полуночи по его часовому поясу
+// Start > End - интервал через полночь (22:00-08:00)
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+// NotificationPreferences пользовательские настройки уведомлений
+type NotificationPreferences struct {
+	UserID           int64
+	OptedOut         []Category // категории, от которых пользователь отписался
+	PreferredChannel *Channel   // nil - порядок каналов из настроек каналов и уведомления
+	Timezone         string     // IANA, время тихих часов
+	QuietHours       *QuietHours
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Allows проверяет, что пользователь не отписался от категории
+func (p *NotificationPreferences) Allows(category Category) bool {
+	if !category.Optional() {
+		return true
+	}
+	for _, optedOut := range p.OptedOut {
+		if optedOut == category {
+			return false
+		}
+	}
+	return true
+}
+
+// QuietUntil возвращает конец тихих часов, если now попадает в них
+func (p *NotificationPreferences) QuietUntil(now time.Time) (time.Time, bool) {
+	if p.QuietHours == nil || p.QuietHours.Start == p.QuietHours.End {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	start, end := p.QuietHours.Start, p.QuietHours.End
+
+	var quiet bool
+	if start < end {
+		quiet = minute >= start && minute < end
+	} else {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, loc)
+	}
+	return until, true
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// QueueStats состояние очереди уведомлений для метрик
+type QueueStats struct {
+	Due           int           // pending, время отправки наступило
+	Scheduled     int           // pending, отложены или ждут повтора
+	Processing    int           // захвачены обработчиками
+	ExpiredLeases int           // захвачены, но аренда истекла: обработчик остановился или завис
+	Lag           time.Duration // насколько самое старое наступившее уведомление ждёт отправки
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateScope бакет, ограничивший отправку сообщения
+type RateScope string
+
+const (
+	RateScopeNone   RateScope = ""       // отправка без ожидания
+	RateScopeGlobal RateScope = "global" // общий лимит бота
+	RateScopeChat   RateScope = "chat"   // лимит личного чата
+	RateScopeGroup  RateScope = "group"  // лимит группы
+)
+
+// TelegramRateLimits лимиты отправки сообщений ботом
+// Telegram допускает около 30 сообщений в секунду всего, 1 в секунду в один чат и 20 в минуту в группу;
+// лимиты действуют на токен бота, поэтому общие для всех экземпляров сервиса
+type TelegramRateLimits struct {
+	GlobalRate float64 // сообщений в секунду всего, он же допустимый всплеск
+	ChatRate   float64 // сообщений в секунду в личный чат
+	GroupRate  float64 // сообщений в секунду в группу
+}
+
+// GlobalInterval интервал между сообщениями при равномерной отправке с общим лимитом
+func (l TelegramRateLimits) GlobalInterval() time.Duration {
+	return rateInterval(l.GlobalRate)
+}
+
+// Tolerance насколько раньше равномерного расписания можно отправить сообщение: всплеск до GlobalRate сообщений
+func (l TelegramRateLimits) Tolerance() time.Duration {
+	return time.Duration(math.Max(l.GlobalRate-1, 0) * float64(l.GlobalInterval()))
+}
+
+// ChatInterval интервал между сообщениями в чат и бакет, который его задаёт; группы (chat_id < 0) ограничены строже
+func (l TelegramRateLimits) ChatInterval(chatID int64) (time.Duration, RateScope) {
+	if chatID < 0 {
+		return rateInterval(l.GroupRate), RateScopeGroup
+	}
+	return rateInterval(l.ChatRate), RateScopeChat
+}
+
+func rateInterval(rate float64) time.Duration {
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// SendSlot слот отправки сообщения уведомления
+type SendSlot struct {
+	Wait  time.Duration // пауза до слота по часам БД; 0 - отправлять сразу
+	Scope RateScope     // бакет, определивший паузу
+}
+
+// RateLimitStats заполненность лимитов отправки для метрик
+type RateLimitStats struct {
+	Backlog time.Duration // на сколько вперёд занят общий лимит; 0 - всплеск ещё доступен
+	Chats   int           // чаты, в которые нельзя отправить сообщение прямо сейчас
+}
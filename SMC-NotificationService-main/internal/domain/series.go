@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// ScheduleKind формат расписания серии
+type ScheduleKind string
+
+const (
+	ScheduleCron  ScheduleKind = "cron"  // cron выражение из 5 полей
+	ScheduleRRule ScheduleKind = "rrule" // RRULE по RFC 5545
+)
+
+// SeriesStatus статус серии повторяющихся уведомлений
+type SeriesStatus string
+
+const (
+	SeriesActive    SeriesStatus = "active"    // повторения создаются по расписанию
+	SeriesFinished  SeriesStatus = "finished"  // расписание закончилось: end_at, COUNT или UNTIL
+	SeriesCancelled SeriesStatus = "cancelled" // отменена вместе с неотправленными повторениями
+)
+
+// Series серия повторяющихся уведомлений
+// Повторения создаются заранее обычными уведомлениями с span_id серии
+type Series struct {
+	ID                int64
+	SpanID            string
+	UserIDs           []int64
+	Message           string
+	ParseMode         ParseMode
+	Channels          []Channel
+	Template          *TemplateRef
+	Category          Category
+	Kind              ScheduleKind
+	Expression        string
+	Timezone          string
+	StartAt           time.Time
+	EndAt             *time.Time // nil - без ограничения
+	MaterializedUntil time.Time  // повторения до этого момента включительно уже созданы
+	Status            SeriesStatus
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// CreateSeriesInput входные данные для создания серии
+type CreateSeriesInput struct {
+	SpanID            string
+	UserIDs           []int64
+	Message           string
+	ParseMode         ParseMode
+	Channels          []Channel
+	Template          *TemplateRef
+	Category          Category
+	Kind              ScheduleKind
+	Expression        string
+	Timezone          string
+	StartAt           time.Time
+	EndAt             *time.Time
+	MaterializedUntil time.Time
+}
+
+// SeriesOccurrences повторения серии, созданные за один проход
+type SeriesOccurrences struct {
+	SeriesID          int64
+	PrevMaterialized  time.Time   // materialized_until, от которого считались повторения
+	MaterializedUntil time.Time   // новое значение materialized_until
+	Finished          bool        // повторений больше не будет
+	ScheduledAt       []time.Time // моменты повторений
+}
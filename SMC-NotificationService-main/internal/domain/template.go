@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Locale язык варианта шаблона
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+
+	// DefaultLocale используется, если варианта на запрошенном языке нет
+	DefaultLocale = LocaleRU
+)
+
+// IsValid проверяет, что язык поддерживается
+func (l Locale) IsValid() bool {
+	return l == LocaleRU || l == LocaleEN
+}
+
+// ParseMode режим разметки текста в Telegram
+type ParseMode string
+
+const (
+	ParseModePlain      ParseMode = ""           // без разметки
+	ParseModeMarkdownV2 ParseMode = "MarkdownV2" // https://core.telegram.org/bots/api#markdownv2-style
+	ParseModeHTML       ParseMode = "HTML"       // https://core.telegram.org/bots/api#html-style
+)
+
+// IsValid проверяет, что режим разметки поддерживается
+func (m ParseMode) IsValid() bool {
+	return m == ParseModePlain || m == ParseModeMarkdownV2 || m == ParseModeHTML
+}
+
+// Template версия варианта шаблона уведомления
+// Версии неизменяемы: редактирование создаёт новую версию, рассылки используют последнюю
+type Template struct {
+	ID        int64
+	Name      string // например booking.confirmed
+	Locale    Locale
+	Version   int
+	ParseMode ParseMode
+	Body      string // text/template; значения параметров экранируются по ParseMode
+	CreatedBy int64  // tg_user_id суперпользователя
+	CreatedAt time.Time
+}
+
+// CreateTemplateInput входные данные для новой версии шаблона
+type CreateTemplateInput struct {
+	Name      string
+	Locale    Locale
+	ParseMode ParseMode
+	Body      string
+	CreatedBy int64
+}
+
+// RenderedTemplate результат подстановки параметров в шаблон
+type RenderedTemplate struct {
+	Name      string
+	Locale    Locale
+	Version   int
+	ParseMode ParseMode
+	Text      string
+}
@@ -0,0 +1,14 @@
+package channelsettings
+
+import "errors"
+
+var (
+	// ErrSettingsNotFound возвращается, когда пользователь не настраивал каналы
+	ErrSettingsNotFound = errors.New("repository: channel settings not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+)
@@ -0,0 +1,116 @@
+package channelsettings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const settingsColumns = "user_id, channel_order, email, created_at, updated_at"
+
+// Repository репозиторий пользовательских настроек каналов
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория настроек каналов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Get возвращает настройки пользователя или ErrSettingsNotFound
+func (r *Repository) Get(ctx context.Context, userID int64) (*domain.ChannelSettings, error) {
+	query, args, err := psqlbuilder.Select(settingsColumns).
+		From("channel_settings").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Get - build select query: %v", ErrBuildQuery, err)
+	}
+
+	settings, err := scanSettings(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSettingsNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - select settings: %v", ErrExecQuery, err)
+	}
+
+	return settings, nil
+}
+
+// Upsert создает или полностью заменяет настройки пользователя
+func (r *Repository) Upsert(ctx context.Context, settings domain.ChannelSettings) (*domain.ChannelSettings, error) {
+	order := make([]string, len(settings.ChannelOrder))
+	for i, channel := range settings.ChannelOrder {
+		order[i] = string(channel)
+	}
+
+	query, args, err := psqlbuilder.Insert("channel_settings").
+		Columns("user_id", "channel_order", "email").
+		Values(settings.UserID, pq.Array(order), settings.Email).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET channel_order = EXCLUDED.channel_order, email = EXCLUDED.email").
+		Suffix("RETURNING " + settingsColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upsert - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	saved, err := scanSettings(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upsert - upsert settings: %v", ErrExecQuery, err)
+	}
+
+	return saved, nil
+}
+
+// Delete удаляет настройки пользователя и возвращает количество удалённых строк
+func (r *Repository) Delete(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Delete("channel_settings").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - delete settings: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return deleted, nil
+}
+
+func scanSettings(row *sql.Row) (*domain.ChannelSettings, error) {
+	var (
+		settings domain.ChannelSettings
+		order    pq.StringArray
+		email    sql.NullString
+	)
+
+	if err := row.Scan(&settings.UserID, &order, &email, &settings.CreatedAt, &settings.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	settings.ChannelOrder = make([]domain.Channel, len(order))
+	for i, channel := range order {
+		settings.ChannelOrder[i] = domain.Channel(channel)
+	}
+	if email.Valid {
+		settings.Email = &email.String
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,14 @@
+package conversation
+
+import "errors"
+
+var (
+	// ErrConversationNotFound возвращается, когда пользователь ещё не писал боту
+	ErrConversationNotFound = errors.New("repository: conversation not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+)
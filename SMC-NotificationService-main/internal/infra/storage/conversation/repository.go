@@ -0,0 +1,118 @@
+package conversation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const conversationColumns = "chat_id, user_id, state, data, updated_at"
+
+// Repository репозиторий диалогов с ботом
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория диалогов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Get возвращает диалог чата или ErrConversationNotFound
+func (r *Repository) Get(ctx context.Context, chatID int64) (*domain.Conversation, error) {
+	query, args, err := psqlbuilder.Select(conversationColumns).
+		From("bot_conversations").
+		Where(squirrel.Eq{"chat_id": chatID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Get - build select query: %v", ErrBuildQuery, err)
+	}
+
+	conversation, err := scanConversation(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - select conversation: %v", ErrExecQuery, err)
+	}
+
+	return conversation, nil
+}
+
+// Save создает или заменяет диалог чата
+func (r *Repository) Save(ctx context.Context, conversation domain.Conversation) (*domain.Conversation, error) {
+	data, err := json.Marshal(conversation.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: Save - encode data: %v", ErrBuildQuery, err)
+	}
+
+	query, args, err := psqlbuilder.Insert("bot_conversations").
+		Columns("chat_id", "user_id", "state", "data").
+		Values(conversation.ChatID, conversation.UserID, conversation.State, data).
+		Suffix("ON CONFLICT (chat_id) DO UPDATE SET user_id = EXCLUDED.user_id, " +
+			"state = EXCLUDED.state, data = EXCLUDED.data").
+		Suffix("RETURNING " + conversationColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Save - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	saved, err := scanConversation(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: Save - upsert conversation: %v", ErrExecQuery, err)
+	}
+
+	return saved, nil
+}
+
+// DeleteByUserID удаляет диалоги пользователя и возвращает количество удалённых строк
+func (r *Repository) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Delete("bot_conversations").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - delete conversations: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return deleted, nil
+}
+
+func scanConversation(row *sql.Row) (*domain.Conversation, error) {
+	var (
+		conversation domain.Conversation
+		data         []byte
+	)
+
+	if err := row.Scan(
+		&conversation.ChatID,
+		&conversation.UserID,
+		&conversation.State,
+		&data,
+		&conversation.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &conversation.Data); err != nil {
+		return nil, fmt.Errorf("decode conversation data: %w", err)
+	}
+
+	return &conversation, nil
+}
@@ -0,0 +1,176 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+var deadLetterColumns = []string{
+	"dl.id", "dl.notification_id", "dl.user_id", "n.message", "dl.reason", "dl.attempts", "dl.error", "dl.created_at",
+}
+
+// MoveToDeadLetter отмечает уведомление как неотправленное, снимает аренду и переносит его в dead-letter одним запросом
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner
+func (r *Repository) MoveToDeadLetter(ctx context.Context, id int64, owner string, reason domain.DeadLetterReason, errText string) error {
+	failed := releaseLease(squirrel.Update("notifications")).
+		Set("status", domain.StatusFailed).
+		Set("next_attempt_at", nil).
+		Set("error", errText).
+		Where(ownedBy(id, owner)).
+		Suffix("RETURNING id, user_id, attempts")
+
+	query, args, err := deadLetterInsert(failed, reason, errText).ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: MoveToDeadLetter - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "MoveToDeadLetter", query, args)
+}
+
+// deadLetterInsert переносит в dead-letter уведомления, которые вернул UPDATE failed (id, user_id, attempts)
+func deadLetterInsert(failed squirrel.UpdateBuilder, reason domain.DeadLetterReason, errText string) squirrel.InsertBuilder {
+	return psqlbuilder.Insert("notification_dead_letters").
+		PrefixExpr(squirrel.ConcatExpr("WITH failed AS (", failed, ")")).
+		Columns("notification_id", "user_id", "reason", "attempts", "error").
+		Select(squirrel.Select("id", "user_id").
+			Column("?::varchar", reason).
+			Column("attempts").
+			Column("?::text", errText).
+			From("failed")).
+		Suffix("ON CONFLICT (notification_id) DO UPDATE SET " +
+			"reason = EXCLUDED.reason, attempts = EXCLUDED.attempts, error = EXCLUDED.error, created_at = NOW()")
+}
+
+// ListDeadLetters возвращает dead-letter по фильтру, новые первыми
+func (r *Repository) ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, *domain.PaginationResult, error) {
+	where := squirrel.And{}
+	if filter.UserID != nil {
+		where = append(where, squirrel.Eq{"dl.user_id": *filter.UserID})
+	}
+	if filter.Reason != nil {
+		where = append(where, squirrel.Eq{"dl.reason": *filter.Reason})
+	}
+
+	countQuery, countArgs, err := psqlbuilder.Select("COUNT(*)").
+		From("notification_dead_letters dl").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: ListDeadLetters - build count query: %v", ErrBuildQuery, err)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("%w: ListDeadLetters - count dead letters: %v", ErrExecQuery, err)
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	query, args, err := psqlbuilder.Select(deadLetterColumns...).
+		From("notification_dead_letters dl").
+		Join("notifications n ON n.id = dl.notification_id").
+		Where(where).
+		OrderBy("dl.created_at DESC", "dl.id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: ListDeadLetters - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: ListDeadLetters - query dead letters: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	deadLetters := make([]domain.DeadLetter, 0)
+	for rows.Next() {
+		var dl domain.DeadLetter
+		if err := rows.Scan(
+			&dl.ID,
+			&dl.NotificationID,
+			&dl.UserID,
+			&dl.Message,
+			&dl.Reason,
+			&dl.Attempts,
+			&dl.Error,
+			&dl.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("%w: ListDeadLetters - %v", ErrScanRow, err)
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: ListDeadLetters - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return deadLetters, &domain.PaginationResult{Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+// RequeueDeadLetter удаляет запись dead-letter и возвращает уведомление в pending со сброшенным счётчиком попыток
+// Возвращает ErrDeadLetterNotFound, если записи нет
+func (r *Repository) RequeueDeadLetter(ctx context.Context, id int64) (*domain.Notification, error) {
+	requeued := squirrel.Delete("notification_dead_letters").
+		Where(squirrel.Eq{"id": id}).
+		Suffix("RETURNING notification_id")
+
+	query, args, err := psqlbuilder.Update("notifications").
+		PrefixExpr(squirrel.ConcatExpr("WITH requeued AS (", requeued, ")")).
+		Set("status", domain.StatusPending).
+		Set("attempts", 0).
+		Set("next_attempt_at", nil).
+		Set("error", nil).
+		Set("locked_by", nil).
+		Set("locked_until", nil).
+		Set("sending_at", nil).
+		From("requeued").
+		Where("notifications.id = requeued.notification_id").
+		Suffix("RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: RequeueDeadLetter - build update query: %v", ErrBuildQuery, err)
+	}
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("%w: RequeueDeadLetter - update notification: %v", ErrExecQuery, err)
+	}
+
+	return notification, nil
+}
+
+// DeleteDeadLetter удаляет запись dead-letter; уведомление остаётся в статусе failed
+// Возвращает ErrDeadLetterNotFound, если записи нет
+func (r *Repository) DeleteDeadLetter(ctx context.Context, id int64) error {
+	query, args, err := psqlbuilder.Delete("notification_dead_letters").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: DeleteDeadLetter - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: DeleteDeadLetter - delete dead letter: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: DeleteDeadLetter - rows affected: %v", ErrExecQuery, err)
+	}
+	if deleted == 0 {
+		return ErrDeadLetterNotFound
+	}
+
+	return nil
+}
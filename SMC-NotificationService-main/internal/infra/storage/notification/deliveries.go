@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+var deliveryColumns = []string{
+	"id", "notification_id", "channel", "status", "error", "created_at",
+}
+
+// CreateDelivery сохраняет попытку доставки уведомления по одному каналу
+func (r *Repository) CreateDelivery(ctx context.Context, input domain.CreateDeliveryInput) error {
+	query, args, err := psqlbuilder.Insert("notification_deliveries").
+		Columns("notification_id", "channel", "status", "error").
+		Values(input.NotificationID, input.Channel, input.Status, input.Error).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: CreateDelivery - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: CreateDelivery - insert delivery: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// ListDeliveries возвращает попытки доставки уведомления в порядке их выполнения
+func (r *Repository) ListDeliveries(ctx context.Context, notificationID int64) ([]domain.Delivery, error) {
+	query, args, err := psqlbuilder.Select(deliveryColumns...).
+		From("notification_deliveries").
+		Where(squirrel.Eq{"notification_id": notificationID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListDeliveries - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListDeliveries - query deliveries: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.Delivery, 0)
+	for rows.Next() {
+		var (
+			delivery  domain.Delivery
+			errorText sql.NullString
+		)
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.NotificationID,
+			&delivery.Channel,
+			&delivery.Status,
+			&errorText,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%w: ListDeliveries - %v", ErrScanRow, err)
+		}
+		if errorText.Valid {
+			delivery.Error = &errorText.String
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListDeliveries - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return deliveries, nil
+}
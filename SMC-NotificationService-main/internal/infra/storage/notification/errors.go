@@ -0,0 +1,32 @@
+package notification
+
+import "errors"
+
+var (
+	// ErrNotificationNotFound возвращается, когда уведомление не найдено в БД
+	ErrNotificationNotFound = errors.New("repository: notification not found")
+
+	// ErrNotPending возвращается, когда уведомление уже отправлено, отменено или захвачено другим обработчиком
+	ErrNotPending = errors.New("repository: notification is not pending")
+
+	// ErrLeaseLost возвращается, когда аренда уведомления истекла и его забрал другой обработчик
+	ErrLeaseLost = errors.New("repository: notification lease lost")
+
+	// ErrDeadLetterNotFound возвращается, когда записи dead-letter нет в БД
+	ErrDeadLetterNotFound = errors.New("repository: dead letter not found")
+
+	// ErrSeriesNotFound возвращается, когда серии повторяющихся уведомлений нет в БД или она уже не активна
+	ErrSeriesNotFound = errors.New("repository: notification series not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки из БД
+	ErrScanRow = errors.New("repository: failed to scan row")
+
+	// ErrTransaction возвращается при ошибке работы с транзакцией
+	ErrTransaction = errors.New("repository: transaction error")
+)
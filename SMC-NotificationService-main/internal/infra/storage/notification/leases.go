@@ -0,0 +1,273 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// Захват уведомлений обработчиками
+//
+// Обработчик (экземпляр сервиса) захватывает уведомление на время аренды: status = processing,
+// locked_by = owner, locked_until = NOW() + lease. Аренду продлевает heartbeat, все переходы
+// из processing проверяют locked_by, поэтому опоздавший обработчик не перезапишет чужой результат.
+// Перед отправкой по каналам выставляется sending_at: уведомление с истекшей арендой
+// без sending_at забирает другой обработчик, а с sending_at - переносится в dead-letter,
+// так как оно могло быть уже доставлено. Время сравнивается с часами БД, общими для всех экземпляров
+
+const leaseExpiredError = "lease expired during delivery: notification may have been delivered"
+
+// Claim захватывает уведомление по id: pending или с истекшей арендой, отправка которого не начиналась
+// Возвращает ErrNotPending, если уведомление уже захвачено, отправлено или отменено
+func (r *Repository) Claim(ctx context.Context, id int64, owner string, lease time.Duration) (*domain.Notification, error) {
+	query, args, err := claimUpdate(owner, lease).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Or{
+			squirrel.Eq{"status": domain.StatusPending},
+			abandoned(),
+		}).
+		Suffix("RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Claim - build update query: %v", ErrBuildQuery, err)
+	}
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotPending
+		}
+		return nil, fmt.Errorf("%w: Claim - update notification: %v", ErrExecQuery, err)
+	}
+
+	return notification, nil
+}
+
+// ClaimDue захватывает до limit уведомлений, время отправки которых наступило, и уведомления с истекшей арендой
+// Строки, заблокированные параллельным ClaimDue другого экземпляра, пропускаются (SKIP LOCKED)
+func (r *Repository) ClaimDue(ctx context.Context, owner string, lease time.Duration, limit int) ([]domain.Notification, error) {
+	due := squirrel.Select("id").
+		From("notifications").
+		Where(squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{"status": domain.StatusPending},
+				squirrel.Or{
+					squirrel.Eq{dueAtColumn: nil},
+					squirrel.Expr(dueAtColumn + " <= NOW()"),
+				},
+			},
+			abandoned(),
+		}).
+		OrderBy("COALESCE("+dueAtColumn+", created_at)", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := claimUpdate(owner, lease).
+		Where(squirrel.Expr("id IN (?)", due)).
+		Suffix("RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ClaimDue - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryNotifications(ctx, "ClaimDue", query, args)
+}
+
+// ExtendLeases продлевает аренду всех уведомлений, захваченных owner, и возвращает их количество
+func (r *Repository) ExtendLeases(ctx context.Context, owner string, lease time.Duration) (int64, error) {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("locked_until", leaseUntil(lease)).
+		Where(squirrel.Eq{"locked_by": owner, "status": domain.StatusProcessing}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ExtendLeases - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: ExtendLeases - update notifications: %v", ErrExecQuery, err)
+	}
+
+	extended, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ExtendLeases - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return extended, nil
+}
+
+// MarkSending отмечает начало отправки по каналам; после неё уведомление не захватывается повторно
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner или аренда истекла
+func (r *Repository) MarkSending(ctx context.Context, id int64, owner string) error {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("sending_at", squirrel.Expr("NOW()")).
+		Where(ownedBy(id, owner)).
+		Where("locked_until > NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: MarkSending - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "MarkSending", query, args)
+}
+
+// ReleaseLeases возвращает в pending захваченные owner уведомления, отправка которых не начиналась
+// Вызывается при остановке экземпляра, чтобы другие не ждали истечения аренды
+func (r *Repository) ReleaseLeases(ctx context.Context, owner string) (int64, error) {
+	query, args, err := releaseLease(psqlbuilder.Update("notifications")).
+		Set("status", domain.StatusPending).
+		Set("attempts", squirrel.Expr("GREATEST(attempts - 1, 0)")).
+		Where(squirrel.Eq{"locked_by": owner, "status": domain.StatusProcessing, "sending_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReleaseLeases - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReleaseLeases - update notifications: %v", ErrExecQuery, err)
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReleaseLeases - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return released, nil
+}
+
+// ReapExpiredLeases переносит в dead-letter до limit уведомлений, аренда которых истекла во время отправки,
+// и возвращает их количество
+func (r *Repository) ReapExpiredLeases(ctx context.Context, limit int) (int64, error) {
+	expired := squirrel.Select("id").
+		From("notifications").
+		Where(squirrel.Eq{"status": domain.StatusProcessing}).
+		Where(squirrel.NotEq{"sending_at": nil}).
+		Where("locked_until < NOW()").
+		OrderBy("locked_until", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	failed := releaseLease(squirrel.Update("notifications")).
+		Set("status", domain.StatusFailed).
+		Set("next_attempt_at", nil).
+		Set("error", leaseExpiredError).
+		Where(squirrel.Expr("id IN (?)", expired)).
+		Suffix("RETURNING id, user_id, attempts")
+
+	query, args, err := deadLetterInsert(failed, domain.DeadLetterLeaseExpired, leaseExpiredError).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReapExpiredLeases - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReapExpiredLeases - insert dead letters: %v", ErrExecQuery, err)
+	}
+
+	reaped, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: ReapExpiredLeases - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return reaped, nil
+}
+
+// QueueStats возвращает глубину очереди и задержку самого старого наступившего уведомления
+func (r *Repository) QueueStats(ctx context.Context) (*domain.QueueStats, error) {
+	isDue := "status = ? AND (" + dueAtColumn + " IS NULL OR " + dueAtColumn + " <= NOW())"
+
+	query, args, err := psqlbuilder.Select().
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE "+isDue+")", domain.StatusPending)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ? AND "+dueAtColumn+" > NOW())", domain.StatusPending)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ?)", domain.StatusProcessing)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ? AND locked_until < NOW())", domain.StatusProcessing)).
+		Column(squirrel.Expr(
+			"COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(COALESCE("+dueAtColumn+", created_at)) FILTER (WHERE "+isDue+")), 0)",
+			domain.StatusPending,
+		)).
+		From("notifications").
+		Where(squirrel.Eq{"status": []domain.NotificationStatus{domain.StatusPending, domain.StatusProcessing}}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: QueueStats - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var (
+		stats      domain.QueueStats
+		lagSeconds float64
+	)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&stats.Due,
+		&stats.Scheduled,
+		&stats.Processing,
+		&stats.ExpiredLeases,
+		&lagSeconds,
+	); err != nil {
+		return nil, fmt.Errorf("%w: QueueStats - select stats: %v", ErrExecQuery, err)
+	}
+	stats.Lag = time.Duration(lagSeconds * float64(time.Second))
+
+	return &stats, nil
+}
+
+// claimUpdate захват уведомления owner на время lease
+// Счётчик попыток растёт только для pending: у забранного после истечения аренды попытка уже учтена
+func claimUpdate(owner string, lease time.Duration) squirrel.UpdateBuilder {
+	return psqlbuilder.Update("notifications").
+		Set("status", domain.StatusProcessing).
+		Set("attempts", squirrel.Expr("CASE WHEN status = ? THEN attempts + 1 ELSE attempts END", domain.StatusPending)).
+		Set("locked_by", owner).
+		Set("locked_until", leaseUntil(lease)).
+		Set("sending_at", nil)
+}
+
+// abandoned уведомления, захваченные остановившимся обработчиком до начала отправки
+func abandoned() squirrel.Sqlizer {
+	return squirrel.And{
+		squirrel.Eq{"status": domain.StatusProcessing, "sending_at": nil},
+		squirrel.Expr("locked_until < NOW()"),
+	}
+}
+
+// ownedBy условие перехода из processing: уведомление всё ещё принадлежит owner
+func ownedBy(id int64, owner string) squirrel.Eq {
+	return squirrel.Eq{"id": id, "locked_by": owner, "status": domain.StatusProcessing}
+}
+
+// releaseLease снимает аренду при выходе уведомления из processing
+func releaseLease(update squirrel.UpdateBuilder) squirrel.UpdateBuilder {
+	return update.
+		Set("locked_by", nil).
+		Set("locked_until", nil).
+		Set("sending_at", nil)
+}
+
+func leaseUntil(lease time.Duration) squirrel.Sqlizer {
+	return squirrel.Expr("NOW() + make_interval(secs => ?)", lease.Seconds())
+}
+
+// execOwned выполняет переход уведомления из processing и возвращает ErrLeaseLost, если строка не изменилась
+func (r *Repository) execOwned(ctx context.Context, op, query string, args []interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %s - update notification: %v", ErrExecQuery, op, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %s - rows affected: %v", ErrExecQuery, op, err)
+	}
+	if updated == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
@@ -0,0 +1,436 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+var notificationColumns = []string{
+	"id", "user_id", "message", "parse_mode", "channels", "template_name", "template_locale", "template_version", "category", "span_id", "scheduled_at", "status",
+	"attempts", "next_attempt_at", "error", "sent_at", "created_at", "updated_at",
+}
+
+// dueAtColumn время ближайшей отправки: повторной попытки или scheduled_at
+const dueAtColumn = "COALESCE(next_attempt_at, scheduled_at)"
+
+var insertColumns = []string{
+	"user_id", "message", "parse_mode", "channels", "template_name", "template_locale", "template_version", "category", "span_id", "scheduled_at",
+}
+
+// Repository репозиторий для работы с уведомлениями
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория уведомлений
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Create создает уведомление в статусе pending
+func (r *Repository) Create(ctx context.Context, input domain.CreateNotificationInput) (*domain.Notification, error) {
+	query, args, err := psqlbuilder.Insert("notifications").
+		Columns(insertColumns...).
+		Values(insertValues(input)...).
+		Suffix("RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Create - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: Create - insert notification: %v", ErrExecQuery, err)
+	}
+
+	return notification, nil
+}
+
+// CreateBatch создает несколько уведомлений одной вставкой
+func (r *Repository) CreateBatch(ctx context.Context, inputs []domain.CreateNotificationInput) ([]domain.Notification, error) {
+	if len(inputs) == 0 {
+		return []domain.Notification{}, nil
+	}
+
+	builder := psqlbuilder.Insert("notifications").
+		Columns(insertColumns...)
+	for _, input := range inputs {
+		builder = builder.Values(insertValues(input)...)
+	}
+
+	query, args, err := builder.Suffix("RETURNING " + columnList()).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateBatch - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryNotifications(ctx, "CreateBatch", query, args)
+}
+
+// GetByID получает уведомление по ID
+func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Notification, error) {
+	query, args, err := psqlbuilder.Select(notificationColumns...).
+		From("notifications").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("%w: GetByID - select notification: %v", ErrExecQuery, err)
+	}
+
+	return notification, nil
+}
+
+// List возвращает уведомления по фильтру, новые первыми
+func (r *Repository) List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, *domain.PaginationResult, error) {
+	where := squirrel.And{}
+	if filter.UserID != nil {
+		where = append(where, squirrel.Eq{"user_id": *filter.UserID})
+	}
+	if filter.SpanID != nil {
+		where = append(where, squirrel.Eq{"span_id": *filter.SpanID})
+	}
+	if filter.Status != nil {
+		where = append(where, squirrel.Eq{"status": *filter.Status})
+	}
+
+	countQuery, countArgs, err := psqlbuilder.Select("COUNT(*)").
+		From("notifications").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: List - build count query: %v", ErrBuildQuery, err)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, nil, fmt.Errorf("%w: List - count notifications: %v", ErrExecQuery, err)
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	query, args, err := psqlbuilder.Select(notificationColumns...).
+		From("notifications").
+		Where(where).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: List - build select query: %v", ErrBuildQuery, err)
+	}
+
+	notifications, err := r.queryNotifications(ctx, "List", query, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return notifications, &domain.PaginationResult{Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+// Cancel отменяет уведомление, если оно ещё не отправлено
+// Возвращает ErrNotificationNotFound или ErrNotPending
+func (r *Repository) Cancel(ctx context.Context, id int64) (*domain.Notification, error) {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("status", domain.StatusCancelled).
+		Where(squirrel.Eq{"id": id, "status": domain.StatusPending}).
+		Suffix("RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Cancel - build update query: %v", ErrBuildQuery, err)
+	}
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Различаем отсутствующее и уже обработанное уведомление
+			if _, getErr := r.GetByID(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, ErrNotPending
+		}
+		return nil, fmt.Errorf("%w: Cancel - update notification: %v", ErrExecQuery, err)
+	}
+
+	return notification, nil
+}
+
+// CancelBySpanID отменяет все ещё не отправленные уведомления пакета и возвращает их количество
+func (r *Repository) CancelBySpanID(ctx context.Context, spanID string) (int64, error) {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("status", domain.StatusCancelled).
+		Where(squirrel.Eq{"span_id": spanID, "status": domain.StatusPending}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: CancelBySpanID - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: CancelBySpanID - update notifications: %v", ErrExecQuery, err)
+	}
+
+	cancelled, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: CancelBySpanID - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return cancelled, nil
+}
+
+// GetScheduledAfter возвращает уведомления в статусе pending, запланированные или отложенные на повтор
+// позже указанного момента
+func (r *Repository) GetScheduledAfter(ctx context.Context, after time.Time) ([]domain.Notification, error) {
+	query, args, err := psqlbuilder.Select(notificationColumns...).
+		From("notifications").
+		Where(squirrel.Eq{"status": domain.StatusPending}).
+		Where(squirrel.Gt{dueAtColumn: after}).
+		OrderBy(dueAtColumn, "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetScheduledAfter - build select query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryNotifications(ctx, "GetScheduledAfter", query, args)
+}
+
+// MarkSent отмечает уведомление как отправленное и снимает аренду
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner
+func (r *Repository) MarkSent(ctx context.Context, id int64, owner string, sentAt time.Time) error {
+	query, args, err := releaseLease(psqlbuilder.Update("notifications")).
+		Set("status", domain.StatusSent).
+		Set("sent_at", sentAt).
+		Set("next_attempt_at", nil).
+		Set("error", nil).
+		Where(ownedBy(id, owner)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: MarkSent - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "MarkSent", query, args)
+}
+
+// ScheduleRetry возвращает уведомление в pending с временем повторной попытки и снимает аренду
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner
+func (r *Repository) ScheduleRetry(ctx context.Context, id int64, owner string, reason string, nextAttemptAt time.Time) error {
+	query, args, err := releaseLease(psqlbuilder.Update("notifications")).
+		Set("status", domain.StatusPending).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("error", reason).
+		Where(ownedBy(id, owner)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: ScheduleRetry - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "ScheduleRetry", query, args)
+}
+
+// Defer откладывает уведомление до until без попытки отправки и снимает аренду
+// Захват, после которого уведомление отложено, не считается попыткой доставки
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner
+func (r *Repository) Defer(ctx context.Context, id int64, owner string, until time.Time) error {
+	query, args, err := releaseLease(psqlbuilder.Update("notifications")).
+		Set("status", domain.StatusPending).
+		Set("attempts", squirrel.Expr("GREATEST(attempts - 1, 0)")).
+		Set("next_attempt_at", until).
+		Where(ownedBy(id, owner)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: Defer - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "Defer", query, args)
+}
+
+// Suppress отменяет уведомление без отправки, сохраняя причину, и снимает аренду
+// Возвращает ErrLeaseLost, если уведомление больше не принадлежит owner
+func (r *Repository) Suppress(ctx context.Context, id int64, owner string, reason string) error {
+	query, args, err := releaseLease(psqlbuilder.Update("notifications")).
+		Set("status", domain.StatusCancelled).
+		Set("attempts", squirrel.Expr("GREATEST(attempts - 1, 0)")).
+		Set("next_attempt_at", nil).
+		Set("error", reason).
+		Where(ownedBy(id, owner)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: Suppress - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execOwned(ctx, "Suppress", query, args)
+}
+
+// ListByUserID возвращает все уведомления пользователя, новые первыми
+func (r *Repository) ListByUserID(ctx context.Context, userID int64) ([]domain.Notification, error) {
+	query, args, err := psqlbuilder.Select(notificationColumns...).
+		From("notifications").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByUserID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryNotifications(ctx, "ListByUserID", query, args)
+}
+
+// DeleteByUserID удаляет все уведомления пользователя и возвращает их количество
+func (r *Repository) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Delete("notifications").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - delete notifications: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteByUserID - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return deleted, nil
+}
+
+func (r *Repository) queryNotifications(ctx context.Context, op, query string, args []interface{}) ([]domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s - query notifications: %v", ErrExecQuery, op, err)
+	}
+	defer rows.Close()
+
+	notifications := make([]domain.Notification, 0)
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s - %v", ErrScanRow, op, err)
+		}
+		notifications = append(notifications, *notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s - iterate rows: %v", ErrExecQuery, op, err)
+	}
+
+	return notifications, nil
+}
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNotification(row rowScanner) (*domain.Notification, error) {
+	var (
+		notification  domain.Notification
+		channels      pq.StringArray
+		templateName  sql.NullString
+		locale        sql.NullString
+		version       sql.NullInt64
+		spanID        sql.NullString
+		scheduledAt   sql.NullTime
+		nextAttemptAt sql.NullTime
+		errorText     sql.NullString
+		sentAt        sql.NullTime
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Message,
+		&notification.ParseMode,
+		&channels,
+		&templateName,
+		&locale,
+		&version,
+		&notification.Category,
+		&spanID,
+		&scheduledAt,
+		&notification.Status,
+		&notification.Attempts,
+		&nextAttemptAt,
+		&errorText,
+		&sentAt,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.Channels = make([]domain.Channel, len(channels))
+	for i, channel := range channels {
+		notification.Channels[i] = domain.Channel(channel)
+	}
+	if templateName.Valid {
+		notification.Template = &domain.TemplateRef{
+			Name:    templateName.String,
+			Locale:  domain.Locale(locale.String),
+			Version: int(version.Int64),
+		}
+	}
+	if spanID.Valid {
+		notification.SpanID = &spanID.String
+	}
+	if scheduledAt.Valid {
+		notification.ScheduledAt = &scheduledAt.Time
+	}
+	if nextAttemptAt.Valid {
+		notification.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if errorText.Valid {
+		notification.Error = &errorText.String
+	}
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+
+	return &notification, nil
+}
+
+func columnList() string {
+	return strings.Join(notificationColumns, ", ")
+}
+
+// insertValues значения колонок insertColumns
+func insertValues(input domain.CreateNotificationInput) []interface{} {
+	var templateName, locale, version interface{}
+	if input.Template != nil {
+		templateName = input.Template.Name
+		locale = input.Template.Locale
+		version = input.Template.Version
+	}
+
+	return []interface{}{
+		input.UserID, input.Message, input.ParseMode, channelArray(input.Channels),
+		templateName, locale, version, input.Category, input.SpanID, input.ScheduledAt,
+	}
+}
+
+// channelArray преобразует каналы в массив PostgreSQL
+func channelArray(channels []domain.Channel) interface{} {
+	values := make([]string, len(channels))
+	for i, channel := range channels {
+		values[i] = string(channel)
+	}
+	return pq.Array(values)
+}
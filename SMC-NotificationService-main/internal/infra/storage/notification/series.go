@@ -0,0 +1,273 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// Серии повторяющихся уведомлений
+//
+// Повторения создаются заранее обычными уведомлениями с span_id серии, поэтому доставка, повторы
+// и отмена по одному работают как для пакета. Продвижение materialized_until и вставка повторений
+// выполняются одним запросом: повторения отменённой серии не создаются, а уникальный индекс
+// (series_id, user_id, scheduled_at) не даёт создать повторение дважды, даже если его отменили
+
+var seriesColumns = []string{
+	"id", "span_id", "user_ids", "message", "parse_mode", "channels", "template_name", "template_locale", "template_version",
+	"category", "schedule_kind", "expression", "timezone", "start_at", "end_at", "materialized_until", "status", "created_at", "updated_at",
+}
+
+// CreateSeries создает активную серию
+func (r *Repository) CreateSeries(ctx context.Context, input domain.CreateSeriesInput) (*domain.Series, error) {
+	var templateName, locale, version interface{}
+	if input.Template != nil {
+		templateName = input.Template.Name
+		locale = input.Template.Locale
+		version = input.Template.Version
+	}
+
+	query, args, err := psqlbuilder.Insert("notification_series").
+		Columns(
+			"span_id", "user_ids", "message", "parse_mode", "channels", "template_name", "template_locale", "template_version",
+			"category", "schedule_kind", "expression", "timezone", "start_at", "end_at", "materialized_until",
+		).
+		Values(
+			input.SpanID, pq.Array(input.UserIDs), input.Message, input.ParseMode, channelArray(input.Channels),
+			templateName, locale, version,
+			input.Category, input.Kind, input.Expression, input.Timezone, input.StartAt, input.EndAt, input.MaterializedUntil,
+		).
+		Suffix("RETURNING " + seriesColumnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateSeries - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	series, err := scanSeries(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateSeries - insert series: %v", ErrExecQuery, err)
+	}
+
+	return series, nil
+}
+
+// GetSeriesBySpanID получает серию по span_id
+func (r *Repository) GetSeriesBySpanID(ctx context.Context, spanID string) (*domain.Series, error) {
+	query, args, err := psqlbuilder.Select(seriesColumns...).
+		From("notification_series").
+		Where(squirrel.Eq{"span_id": spanID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetSeriesBySpanID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	series, err := scanSeries(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("%w: GetSeriesBySpanID - select series: %v", ErrExecQuery, err)
+	}
+
+	return series, nil
+}
+
+// ListDueSeries возвращает до limit активных серий, повторения которых созданы не до horizon
+func (r *Repository) ListDueSeries(ctx context.Context, horizon time.Time, limit int) ([]domain.Series, error) {
+	query, args, err := psqlbuilder.Select(seriesColumns...).
+		From("notification_series").
+		Where(squirrel.Eq{"status": domain.SeriesActive}).
+		Where(squirrel.Lt{"materialized_until": horizon}).
+		OrderBy("materialized_until", "id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListDueSeries - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListDueSeries - query series: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	seriesList := make([]domain.Series, 0)
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ListDueSeries - %v", ErrScanRow, err)
+		}
+		seriesList = append(seriesList, *series)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListDueSeries - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return seriesList, nil
+}
+
+// MaterializeSeries продвигает materialized_until серии и создаёт повторения для всех её получателей одним запросом
+// Возвращает созданные уведомления. Ничего не создаётся, если серия уже не активна
+// или её параллельно продвинул другой экземпляр (materialized_until не совпал с PrevMaterialized)
+func (r *Repository) MaterializeSeries(ctx context.Context, occurrences domain.SeriesOccurrences) ([]domain.Notification, error) {
+	status := domain.SeriesActive
+	if occurrences.Finished {
+		status = domain.SeriesFinished
+	}
+
+	advanced := squirrel.Update("notification_series").
+		Set("materialized_until", occurrences.MaterializedUntil).
+		Set("status", status).
+		Where(squirrel.Eq{
+			"id":                 occurrences.SeriesID,
+			"status":             domain.SeriesActive,
+			"materialized_until": occurrences.PrevMaterialized,
+		}).
+		Suffix("RETURNING id, span_id, user_ids, message, parse_mode, channels, template_name, template_locale, template_version, category")
+
+	scheduledAt := make([]string, len(occurrences.ScheduledAt))
+	for i, at := range occurrences.ScheduledAt {
+		scheduledAt[i] = at.UTC().Format(time.RFC3339Nano)
+	}
+
+	query, args, err := psqlbuilder.Insert("notifications").
+		PrefixExpr(squirrel.ConcatExpr("WITH series AS (", advanced, ")")).
+		Columns(append(insertColumns, "series_id")...).
+		Select(squirrel.Select(
+			"u.user_id", "s.message", "s.parse_mode", "s.channels", "s.template_name", "s.template_locale", "s.template_version",
+			"s.category", "s.span_id", "o.scheduled_at", "s.id",
+		).
+			From("series s").
+			CrossJoin("unnest(s.user_ids) AS u(user_id)").
+			CrossJoin("unnest(?::timestamptz[]) AS o(scheduled_at)", pq.Array(scheduledAt))).
+		Suffix("ON CONFLICT DO NOTHING RETURNING " + columnList()).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: MaterializeSeries - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryNotifications(ctx, "MaterializeSeries", query, args)
+}
+
+// CancelSeries останавливает создание повторений серии
+// Возвращает ErrSeriesNotFound, если активной серии с таким span_id нет
+func (r *Repository) CancelSeries(ctx context.Context, spanID string) error {
+	query, args, err := psqlbuilder.Update("notification_series").
+		Set("status", domain.SeriesCancelled).
+		Where(squirrel.Eq{"span_id": spanID, "status": domain.SeriesActive}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: CancelSeries - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: CancelSeries - update series: %v", ErrExecQuery, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: CancelSeries - rows affected: %v", ErrExecQuery, err)
+	}
+	if updated == 0 {
+		return ErrSeriesNotFound
+	}
+
+	return nil
+}
+
+// RemoveUserFromSeries исключает пользователя из получателей всех серий
+// Серия без получателей отменяется. Возвращает количество изменённых серий
+func (r *Repository) RemoveUserFromSeries(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Update("notification_series").
+		Set("user_ids", squirrel.Expr("array_remove(user_ids, ?::bigint)", userID)).
+		Set("status", squirrel.Expr(
+			"CASE WHEN cardinality(user_ids) = 1 AND status = ? THEN ? ELSE status END",
+			domain.SeriesActive, domain.SeriesCancelled,
+		)).
+		Where(squirrel.Expr("?::bigint = ANY(user_ids)", userID)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveUserFromSeries - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveUserFromSeries - update series: %v", ErrExecQuery, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveUserFromSeries - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return updated, nil
+}
+
+func seriesColumnList() string {
+	return strings.Join(seriesColumns, ", ")
+}
+
+func scanSeries(row rowScanner) (*domain.Series, error) {
+	var (
+		series       domain.Series
+		userIDs      pq.Int64Array
+		channels     pq.StringArray
+		templateName sql.NullString
+		locale       sql.NullString
+		version      sql.NullInt64
+		endAt        sql.NullTime
+	)
+
+	err := row.Scan(
+		&series.ID,
+		&series.SpanID,
+		&userIDs,
+		&series.Message,
+		&series.ParseMode,
+		&channels,
+		&templateName,
+		&locale,
+		&version,
+		&series.Category,
+		&series.Kind,
+		&series.Expression,
+		&series.Timezone,
+		&series.StartAt,
+		&endAt,
+		&series.MaterializedUntil,
+		&series.Status,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	series.UserIDs = userIDs
+	series.Channels = make([]domain.Channel, len(channels))
+	for i, channel := range channels {
+		series.Channels[i] = domain.Channel(channel)
+	}
+	if templateName.Valid {
+		series.Template = &domain.TemplateRef{
+			Name:    templateName.String,
+			Locale:  domain.Locale(locale.String),
+			Version: int(version.Int64),
+		}
+	}
+	if endAt.Valid {
+		series.EndAt = &endAt.Time
+	}
+
+	return &series, nil
+}
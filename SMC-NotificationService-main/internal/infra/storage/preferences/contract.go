@@ -0,0 +1,8 @@
+package preferences
+
+import (
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+)
+
+// Переиспользуем интерфейс из dbmetrics (поддерживает *sql.DB и *dbmetrics.DB)
+type DBExecutor = dbmetrics.DBExecutor
@@ -0,0 +1,14 @@
+package preferences
+
+import "errors"
+
+var (
+	// ErrPreferencesNotFound возвращается, когда пользователь не настраивал уведомления
+	ErrPreferencesNotFound = errors.New("repository: notification preferences not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+)
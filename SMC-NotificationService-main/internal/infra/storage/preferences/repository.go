@@ -0,0 +1,172 @@
+package preferences
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const preferencesColumns = "user_id, opted_out, preferred_channel, timezone, quiet_hours_start, quiet_hours_end, created_at, updated_at"
+
+// Repository репозиторий пользовательских настроек уведомлений
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория настроек уведомлений
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Get возвращает настройки пользователя или ErrPreferencesNotFound
+func (r *Repository) Get(ctx context.Context, userID int64) (*domain.NotificationPreferences, error) {
+	query, args, err := psqlbuilder.Select(preferencesColumns).
+		From("notification_preferences").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Get - build select query: %v", ErrBuildQuery, err)
+	}
+
+	preferences, err := scanPreferences(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPreferencesNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - select preferences: %v", ErrExecQuery, err)
+	}
+
+	return preferences, nil
+}
+
+// Upsert создает или полностью заменяет настройки пользователя
+func (r *Repository) Upsert(ctx context.Context, preferences domain.NotificationPreferences) (*domain.NotificationPreferences, error) {
+	var quietStart, quietEnd interface{}
+	if preferences.QuietHours != nil {
+		quietStart = preferences.QuietHours.Start
+		quietEnd = preferences.QuietHours.End
+	}
+
+	query, args, err := psqlbuilder.Insert("notification_preferences").
+		Columns("user_id", "opted_out", "preferred_channel", "timezone", "quiet_hours_start", "quiet_hours_end").
+		Values(
+			preferences.UserID, categoryArray(preferences.OptedOut), preferences.PreferredChannel,
+			preferences.Timezone, quietStart, quietEnd,
+		).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET opted_out = EXCLUDED.opted_out, " +
+			"preferred_channel = EXCLUDED.preferred_channel, timezone = EXCLUDED.timezone, " +
+			"quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end").
+		Suffix("RETURNING " + preferencesColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upsert - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	saved, err := scanPreferences(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upsert - upsert preferences: %v", ErrExecQuery, err)
+	}
+
+	return saved, nil
+}
+
+// OptOut отписывает пользователя от категории, не меняя остальные настройки
+func (r *Repository) OptOut(ctx context.Context, userID int64, category domain.Category) (*domain.NotificationPreferences, error) {
+	query, args, err := psqlbuilder.Insert("notification_preferences").
+		Columns("user_id", "opted_out").
+		Values(userID, categoryArray([]domain.Category{category})).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET opted_out = CASE "+
+			"WHEN ?::varchar = ANY(notification_preferences.opted_out) THEN notification_preferences.opted_out "+
+			"ELSE array_append(notification_preferences.opted_out, ?::varchar) END", category, category).
+		Suffix("RETURNING " + preferencesColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: OptOut - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	saved, err := scanPreferences(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: OptOut - upsert preferences: %v", ErrExecQuery, err)
+	}
+
+	return saved, nil
+}
+
+// Delete удаляет настройки пользователя и возвращает количество удалённых строк
+func (r *Repository) Delete(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Delete("notification_preferences").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - delete preferences: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: Delete - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return deleted, nil
+}
+
+// categoryArray преобразует категории в массив PostgreSQL
+func categoryArray(categories []domain.Category) interface{} {
+	values := make([]string, len(categories))
+	for i, category := range categories {
+		values[i] = string(category)
+	}
+	return pq.Array(values)
+}
+
+func scanPreferences(row *sql.Row) (*domain.NotificationPreferences, error) {
+	var (
+		preferences      domain.NotificationPreferences
+		optedOut         pq.StringArray
+		preferredChannel sql.NullString
+		quietStart       sql.NullInt64
+		quietEnd         sql.NullInt64
+	)
+
+	err := row.Scan(
+		&preferences.UserID,
+		&optedOut,
+		&preferredChannel,
+		&preferences.Timezone,
+		&quietStart,
+		&quietEnd,
+		&preferences.CreatedAt,
+		&preferences.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences.OptedOut = make([]domain.Category, len(optedOut))
+	for i, category := range optedOut {
+		preferences.OptedOut[i] = domain.Category(category)
+	}
+	if preferredChannel.Valid {
+		channel := domain.Channel(preferredChannel.String)
+		preferences.PreferredChannel = &channel
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		preferences.QuietHours = &domain.QuietHours{
+			Start: int(quietStart.Int64),
+			End:   int(quietEnd.Int64),
+		}
+	}
+
+	return &preferences, nil
+}
@@ -0,0 +1,122 @@
+package pushsubscription
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const subscriptionColumns = "id, user_id, endpoint, p256dh, auth, created_at"
+
+// Repository репозиторий подписок браузеров на Web Push
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория подписок
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Upsert сохраняет подписку; повторная подписка того же браузера обновляет ключи и владельца
+func (r *Repository) Upsert(ctx context.Context, subscription domain.PushSubscription) (*domain.PushSubscription, error) {
+	query, args, err := psqlbuilder.Insert("push_subscriptions").
+		Columns("user_id", "endpoint", "p256dh", "auth").
+		Values(subscription.UserID, subscription.Endpoint, subscription.P256dh, subscription.Auth).
+		Suffix("ON CONFLICT (endpoint) DO UPDATE SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth").
+		Suffix("RETURNING " + subscriptionColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upsert - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	var saved domain.PushSubscription
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&saved.ID, &saved.UserID, &saved.Endpoint, &saved.P256dh, &saved.Auth, &saved.CreatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("%w: Upsert - upsert subscription: %v", ErrExecQuery, err)
+	}
+
+	return &saved, nil
+}
+
+// ListByUserID возвращает подписки пользователя, старые первыми
+func (r *Repository) ListByUserID(ctx context.Context, userID int64) ([]domain.PushSubscription, error) {
+	query, args, err := psqlbuilder.Select(subscriptionColumns).
+		From("push_subscriptions").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByUserID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByUserID - query subscriptions: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]domain.PushSubscription, 0)
+	for rows.Next() {
+		var s domain.PushSubscription
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Endpoint, &s.P256dh, &s.Auth, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: ListByUserID - %v", ErrScanRow, err)
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListByUserID - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return subscriptions, nil
+}
+
+// Delete удаляет подписку пользователя по endpoint
+// Возвращает ErrSubscriptionNotFound, если у пользователя нет такой подписки
+func (r *Repository) Delete(ctx context.Context, userID int64, endpoint string) error {
+	deleted, err := r.delete(ctx, "Delete", squirrel.Eq{"user_id": userID, "endpoint": endpoint})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// DeleteByEndpoint удаляет подписку, которую push-сервис объявил недействительной
+func (r *Repository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	_, err := r.delete(ctx, "DeleteByEndpoint", squirrel.Eq{"endpoint": endpoint})
+	return err
+}
+
+// DeleteByUserID удаляет все подписки пользователя и возвращает их количество
+func (r *Repository) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	return r.delete(ctx, "DeleteByUserID", squirrel.Eq{"user_id": userID})
+}
+
+func (r *Repository) delete(ctx context.Context, op string, where squirrel.Eq) (int64, error) {
+	query, args, err := psqlbuilder.Delete("push_subscriptions").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s - build delete query: %v", ErrBuildQuery, op, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s - delete subscriptions: %v", ErrExecQuery, op, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s - rows affected: %v", ErrExecQuery, op, err)
+	}
+
+	return deleted, nil
+}
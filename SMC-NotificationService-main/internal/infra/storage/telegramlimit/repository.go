@@ -0,0 +1,211 @@
+package telegramlimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const (
+	// globalBucket строка общего лимита бота
+	globalBucket = "global"
+
+	// slotTTL бронь, которую не использовали (уведомление отменено или ушло в dead-letter), перестаёт действовать
+	slotTTL = time.Minute
+)
+
+// Repository лимиты отправки в Telegram, общие для всех экземпляров сервиса
+// Бакеты хранятся как GCRA: время tat, с которого бакет свободен. Бронь слота меняет общий бакет и бакет чата
+// одним запросом; строки бакетов блокируются UPDATE и ON CONFLICT, поэтому параллельные экземпляры
+// получают разные слоты. Все времена считаются по часам БД
+type Repository struct {
+	db     DBExecutor
+	limits domain.TelegramRateLimits
+}
+
+// NewRepository создает новый экземпляр репозитория лимитов отправки
+func NewRepository(db DBExecutor, limits domain.TelegramRateLimits) *Repository {
+	return &Repository{db: db, limits: limits}
+}
+
+// Reserve бронирует слот отправки сообщения notificationID в chatID
+// Уведомление, уже забронировавшее слот (отложенное в очередь), получает его снова без новой брони
+// Бронь снимается Release после отправки
+func (r *Repository) Reserve(ctx context.Context, notificationID, chatID int64) (*domain.SendSlot, error) {
+	interval := r.limits.GlobalInterval().Seconds()
+	tolerance := r.limits.Tolerance().Seconds()
+	chatInterval, chatScope := r.limits.ChatInterval(chatID)
+
+	booked := squirrel.Select("send_at", "scope").
+		From("telegram_send_slots").
+		Where(squirrel.Eq{"notification_id": notificationID}).
+		Where(squirrel.Expr("send_at >= NOW() - make_interval(secs => ?)", slotTTL.Seconds()))
+
+	// Общий бакет: слот не раньше tat - tolerance, бакет сдвигается на интервал
+	global := squirrel.Insert("telegram_rate_buckets").
+		Columns("bucket", "tat").
+		Select(squirrel.Select().
+			Column(squirrel.Expr("?::text", globalBucket)).
+			Column(squirrel.Expr("NOW() + make_interval(secs => ?)", interval)).
+			Where("NOT EXISTS (SELECT 1 FROM booked)")).
+		Suffix("ON CONFLICT (bucket) DO UPDATE SET tat = GREATEST(telegram_rate_buckets.tat, NOW()) + make_interval(secs => ?) "+
+			"RETURNING GREATEST(NOW(), tat - make_interval(secs => ?)) AS slot",
+			interval, interval+tolerance)
+
+	// Бакет чата: слот не раньше времени, с которого чат свободен, следующий - через интервал чата
+	chat := squirrel.Insert("telegram_rate_buckets").
+		Columns("bucket", "tat").
+		Select(squirrel.Select().
+			Column(squirrel.Expr("?::text", chatBucket(chatID))).
+			Column(squirrel.Expr("g.slot + make_interval(secs => ?)", chatInterval.Seconds())).
+			From("global_bucket g")).
+		Suffix("ON CONFLICT (bucket) DO UPDATE SET tat = GREATEST(telegram_rate_buckets.tat, EXCLUDED.tat - make_interval(secs => ?)) + make_interval(secs => ?) "+
+			"RETURNING tat - make_interval(secs => ?) AS slot",
+			chatInterval.Seconds(), chatInterval.Seconds(), chatInterval.Seconds())
+
+	reserved := squirrel.Insert("telegram_send_slots").
+		Columns("notification_id", "send_at", "scope").
+		Select(squirrel.Select().
+			Column(squirrel.Expr("?::bigint", notificationID)).
+			Column("c.slot").
+			Column(squirrel.Expr("CASE WHEN c.slot > g.slot THEN ?::text WHEN g.slot > NOW() THEN ?::text ELSE ?::text END",
+				chatScope, domain.RateScopeGlobal, domain.RateScopeNone)).
+			From("global_bucket g, chat_bucket c")).
+		Suffix("ON CONFLICT (notification_id) DO UPDATE SET send_at = EXCLUDED.send_at, scope = EXCLUDED.scope RETURNING send_at, scope")
+
+	query, args, err := psqlbuilder.Select("EXTRACT(EPOCH FROM send_at - NOW())", "scope").
+		PrefixExpr(squirrel.ConcatExpr(
+			"WITH booked AS (", booked, "), global_bucket AS (", global, "), chat_bucket AS (", chat, "), reserved AS (", reserved, ")",
+		)).
+		From("(SELECT send_at, scope FROM reserved UNION ALL SELECT send_at, scope FROM booked) AS slot").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Reserve - build query: %v", ErrBuildQuery, err)
+	}
+
+	var (
+		wait  float64
+		scope string
+	)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&wait, &scope); err != nil {
+		return nil, fmt.Errorf("%w: Reserve - reserve slot: %v", ErrExecQuery, err)
+	}
+
+	slot := &domain.SendSlot{Scope: domain.RateScope(scope)}
+	if wait > 0 {
+		slot.Wait = time.Duration(wait * float64(time.Second))
+	}
+	return slot, nil
+}
+
+// Release снимает бронь слота после попытки отправки
+func (r *Repository) Release(ctx context.Context, notificationID int64) error {
+	query, args, err := psqlbuilder.Delete("telegram_send_slots").
+		Where(squirrel.Eq{"notification_id": notificationID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: Release - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Release - delete slot: %v", ErrExecQuery, err)
+	}
+	return nil
+}
+
+// EstimateCompletion оценивает, когда будет отправлено последнее из count сообщений в разные чаты, начиная со start
+// Учитываются слоты общего лимита, уже забронированные всеми экземплярами
+func (r *Repository) EstimateCompletion(ctx context.Context, count int, start time.Time) (time.Time, error) {
+	// Последнее сообщение уходит через count-1 интервалов после первого свободного слота, всплеск сдвигает его раньше
+	offset := float64(count-1)*r.limits.GlobalInterval().Seconds() - r.limits.Tolerance().Seconds()
+
+	globalTAT := squirrel.Select("tat").From("telegram_rate_buckets").Where(squirrel.Eq{"bucket": globalBucket})
+	query, args, err := psqlbuilder.Select().
+		Column(squirrel.ConcatExpr(
+			squirrel.Expr("GREATEST(NOW(), ?::timestamptz, GREATEST(COALESCE((", start),
+			globalTAT,
+			squirrel.Expr("), NOW()), NOW(), ?::timestamptz) + make_interval(secs => ?))", start, offset),
+		)).
+		ToSql()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: EstimateCompletion - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var completion time.Time
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&completion); err != nil {
+		return time.Time{}, fmt.Errorf("%w: EstimateCompletion - select estimate: %v", ErrExecQuery, err)
+	}
+	return completion, nil
+}
+
+// Stats возвращает заполненность лимитов отправки для метрик
+func (r *Repository) Stats(ctx context.Context) (*domain.RateLimitStats, error) {
+	query, args, err := psqlbuilder.Select().
+		Column(squirrel.Expr("COALESCE(EXTRACT(EPOCH FROM MAX(tat) FILTER (WHERE bucket = ?) - NOW()), 0)", globalBucket)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE bucket <> ? AND tat > NOW())", globalBucket)).
+		From("telegram_rate_buckets").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Stats - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var (
+		ahead float64
+		stats domain.RateLimitStats
+	)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&ahead, &stats.Chats); err != nil {
+		return nil, fmt.Errorf("%w: Stats - select buckets: %v", ErrExecQuery, err)
+	}
+
+	// Общий бакет занят вперёд на tat - now; первые tolerance секунд из них - ещё доступный всплеск
+	if backlog := time.Duration(ahead*float64(time.Second)) - r.limits.Tolerance(); backlog > 0 {
+		stats.Backlog = backlog
+	}
+	return &stats, nil
+}
+
+// DeleteExpired удаляет свободные бакеты чатов и брони, которые уже не будут использованы
+func (r *Repository) DeleteExpired(ctx context.Context) (int64, error) {
+	bucketsQuery, bucketsArgs, err := psqlbuilder.Delete("telegram_rate_buckets").
+		Where(squirrel.NotEq{"bucket": globalBucket}).
+		Where("tat < NOW()").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteExpired - build buckets delete query: %v", ErrBuildQuery, err)
+	}
+
+	slotsQuery, slotsArgs, err := psqlbuilder.Delete("telegram_send_slots").
+		Where(squirrel.Expr("send_at < NOW() - make_interval(secs => ?)", slotTTL.Seconds())).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteExpired - build slots delete query: %v", ErrBuildQuery, err)
+	}
+
+	buckets, err := r.execDelete(ctx, bucketsQuery, bucketsArgs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteExpired - delete buckets: %v", ErrExecQuery, err)
+	}
+	slots, err := r.execDelete(ctx, slotsQuery, slotsArgs)
+	if err != nil {
+		return buckets, fmt.Errorf("%w: DeleteExpired - delete slots: %v", ErrExecQuery, err)
+	}
+	return buckets + slots, nil
+}
+
+func (r *Repository) execDelete(ctx context.Context, query string, args []interface{}) (int64, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// chatBucket ключ бакета чата
+func chatBucket(chatID int64) string {
+	return fmt.Sprintf("chat:%d", chatID)
+}
@@ -0,0 +1,83 @@
+package telegramupdate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// Repository репозиторий обработанных обновлений Telegram
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория обработанных обновлений
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Claim отмечает обновление обработанным; false - обновление уже обработано этим или другим экземпляром
+func (r *Repository) Claim(ctx context.Context, updateID int) (bool, error) {
+	query, args, err := psqlbuilder.Insert("telegram_updates").
+		Columns("update_id").
+		Values(updateID).
+		Suffix("ON CONFLICT (update_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: Claim - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: Claim - insert update: %v", ErrExecQuery, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: Claim - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return inserted == 1, nil
+}
+
+// Release снимает отметку с обновления, чтобы повторная доставка обработала его снова
+func (r *Repository) Release(ctx context.Context, updateID int) error {
+	query, args, err := psqlbuilder.Delete("telegram_updates").
+		Where(squirrel.Eq{"update_id": updateID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: Release - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Release - delete update: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// DeleteProcessedBefore удаляет записи об обновлениях, обработанных раньше before
+func (r *Repository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query, args, err := psqlbuilder.Delete("telegram_updates").
+		Where(squirrel.Lt{"processed_at": before}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - delete updates: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,20 @@
+package template
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound возвращается, когда шаблон не найден в БД
+	ErrTemplateNotFound = errors.New("repository: template not found")
+
+	// ErrVersionConflict возвращается, когда версия шаблона создана параллельно другим запросом
+	ErrVersionConflict = errors.New("repository: template version conflict")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки из БД
+	ErrScanRow = errors.New("repository: failed to scan row")
+)
@@ -0,0 +1,172 @@
+package template
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+const (
+	templateColumns = "id, name, locale, version, parse_mode, body, created_by, created_at"
+
+	// uniqueViolation код ошибки PostgreSQL при нарушении UNIQUE
+	uniqueViolation = "23505"
+)
+
+// Repository репозиторий версий шаблонов уведомлений
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория шаблонов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateVersion сохраняет новую версию варианта шаблона с номером на единицу больше последнего
+// Возвращает ErrVersionConflict, если версия с тем же номером создана параллельно
+func (r *Repository) CreateVersion(ctx context.Context, input domain.CreateTemplateInput) (*domain.Template, error) {
+	nextVersion := squirrel.Expr(
+		"(SELECT COALESCE(MAX(version), 0) + 1 FROM notification_templates WHERE name = ? AND locale = ?)",
+		input.Name, input.Locale,
+	)
+
+	query, args, err := psqlbuilder.Insert("notification_templates").
+		Columns("name", "locale", "version", "parse_mode", "body", "created_by").
+		Values(input.Name, input.Locale, nextVersion, input.ParseMode, input.Body, input.CreatedBy).
+		Suffix("RETURNING " + templateColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateVersion - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanTemplate(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrVersionConflict
+		}
+		return nil, fmt.Errorf("%w: CreateVersion - insert template: %v", ErrExecQuery, err)
+	}
+
+	return template, nil
+}
+
+// GetLatest возвращает последнюю версию варианта шаблона
+func (r *Repository) GetLatest(ctx context.Context, name string, locale domain.Locale) (*domain.Template, error) {
+	query, args, err := psqlbuilder.Select(templateColumns).
+		From("notification_templates").
+		Where(squirrel.Eq{"name": name, "locale": locale}).
+		OrderBy("version DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetLatest - build select query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanTemplate(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("%w: GetLatest - select template: %v", ErrExecQuery, err)
+	}
+
+	return template, nil
+}
+
+// ListLatest возвращает последние версии всех вариантов шаблонов
+func (r *Repository) ListLatest(ctx context.Context) ([]domain.Template, error) {
+	query, args, err := psqlbuilder.Select(templateColumns).
+		Options("DISTINCT ON (name, locale)").
+		From("notification_templates").
+		OrderBy("name", "locale", "version DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListLatest - build select query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryTemplates(ctx, "ListLatest", query, args)
+}
+
+// ListVersions возвращает историю версий шаблона по всем языкам, новые первыми
+func (r *Repository) ListVersions(ctx context.Context, name string) ([]domain.Template, error) {
+	query, args, err := psqlbuilder.Select(templateColumns).
+		From("notification_templates").
+		Where(squirrel.Eq{"name": name}).
+		OrderBy("locale", "version DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListVersions - build select query: %v", ErrBuildQuery, err)
+	}
+
+	return r.queryTemplates(ctx, "ListVersions", query, args)
+}
+
+// Delete удаляет шаблон со всеми версиями и языками
+// Возвращает ErrTemplateNotFound, если шаблона нет
+func (r *Repository) Delete(ctx context.Context, name string) error {
+	query, args, err := psqlbuilder.Delete("notification_templates").
+		Where(squirrel.Eq{"name": name}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: Delete - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: Delete - delete template: %v", ErrExecQuery, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: Delete - rows affected: %v", ErrExecQuery, err)
+	}
+	if deleted == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) queryTemplates(ctx context.Context, op, query string, args []interface{}) ([]domain.Template, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s - query templates: %v", ErrExecQuery, op, err)
+	}
+	defer rows.Close()
+
+	templates := make([]domain.Template, 0)
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s - %v", ErrScanRow, op, err)
+		}
+		templates = append(templates, *template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s - iterate rows: %v", ErrExecQuery, op, err)
+	}
+
+	return templates, nil
+}
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(row rowScanner) (*domain.Template, error) {
+	var t domain.Template
+	if err := row.Scan(&t.ID, &t.Name, &t.Locale, &t.Version, &t.ParseMode, &t.Body, &t.CreatedBy, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,69 @@
+package priceservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Client клиент для работы с PriceService
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient создает новый экземпляр клиента PriceService
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Calculate рассчитывает цены услуг мойки для выбранного автомобиля пользователя
+func (c *Client) Calculate(ctx context.Context, companyID, tgUserID int64, serviceIDs []int64) ([]Price, error) {
+	url := fmt.Sprintf("%s/api/v1/prices/calculate", c.baseURL)
+
+	body, err := json.Marshal(CalculateRequest{
+		CompanyID:  companyID,
+		UserID:     &tgUserID,
+		ServiceIDs: serviceIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInternal, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	default:
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(respBody))
+	}
+
+	// Парсим ответ
+	var response CalculateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return response.Prices, nil
+}
@@ -0,0 +1,11 @@
+package priceservice
+
+import "errors"
+
+var (
+	// ErrInternal возвращается при внутренних ошибках клиента
+	ErrInternal = errors.New("priceservice client: internal error")
+
+	// ErrInvalidResponse возвращается при некорректном ответе от сервиса
+	ErrInvalidResponse = errors.New("priceservice client: invalid response")
+)
@@ -0,0 +1,24 @@
+package priceservice
+
+// CalculateRequest запрос расчёта цен услуг мойки
+// Цена считается для выбранного автомобиля пользователя user_id
+type CalculateRequest struct {
+	CompanyID  int64   `json:"company_id"`
+	UserID     *int64  `json:"user_id,omitempty"`
+	ServiceIDs []int64 `json:"service_ids"`
+}
+
+// Price рассчитанная цена услуги
+type Price struct {
+	CompanyID    int64   `json:"company_id"`
+	ServiceID    int64   `json:"service_id"`
+	Price        float64 `json:"price"`
+	Currency     string  `json:"currency"`
+	PricingType  string  `json:"pricing_type"`
+	VehicleClass *string `json:"vehicle_class,omitempty"` // nil - класс автомобиля не учитывался
+}
+
+// CalculateResponse рассчитанные цены; услуги без правила ценообразования отсутствуют
+type CalculateResponse struct {
+	Prices []Price `json:"prices"`
+}
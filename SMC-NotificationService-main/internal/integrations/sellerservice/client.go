@@ -0,0 +1,82 @@
+package sellerservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Client клиент для работы с публичным API SellerService
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient создает новый экземпляр клиента SellerService
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// ListNearbyCompanies возвращает мойки с адресом в радиусе radiusKm от точки, ближайшие первыми
+func (c *Client) ListNearbyCompanies(ctx context.Context, latitude, longitude, radiusKm float64, limit int) ([]Company, error) {
+	query := url.Values{}
+	query.Set("lat", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Set("lon", strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Set("radius_km", strconv.FormatFloat(radiusKm, 'f', -1, 64))
+	query.Set("page", "1")
+	query.Set("limit", strconv.Itoa(limit))
+
+	var response CompanyListResponse
+	if err := c.get(ctx, "/api/v1/companies?"+query.Encode(), &response); err != nil {
+		return nil, err
+	}
+	return response.Companies, nil
+}
+
+// ListServices возвращает услуги мойки
+func (c *Client) ListServices(ctx context.Context, companyID int64) ([]Service, error) {
+	var response ServiceListResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/companies/%d/services", companyID), &response); err != nil {
+		return nil, err
+	}
+	return response.Services, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusNotFound:
+		return ErrCompanyNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package sellerservice
+
+// Company мойка из SellerService
+type Company struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Addresses []Address `json:"addresses"`
+}
+
+// Address адрес мойки
+type Address struct {
+	City        string      `json:"city"`
+	Street      string      `json:"street"`
+	Building    string      `json:"building"`
+	Coordinates Coordinates `json:"coordinates"`
+}
+
+// Coordinates географические координаты
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Service услуга мойки
+type Service struct {
+	ID              int64  `json:"id"`
+	CompanyID       int64  `json:"company_id"`
+	Name            string `json:"name"`
+	AverageDuration *int   `json:"average_duration,omitempty"` // минуты
+}
+
+// CompanyListResponse список моек
+type CompanyListResponse struct {
+	Companies []Company `json:"companies"`
+}
+
+// ServiceListResponse список услуг мойки
+type ServiceListResponse struct {
+	Services []Service `json:"services"`
+}
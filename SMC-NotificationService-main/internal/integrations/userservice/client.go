@@ -0,0 +1,194 @@
+package userservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/svcauth"
+)
+
+// Client клиент для работы с UserService
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient создает новый экземпляр клиента UserService
+// Запросы к /internal эндпоинтам подписываются учётными данными сервиса
+func NewClient(baseURL string, timeout time.Duration, credentials svcauth.Credentials) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: svcauth.NewTransport(http.DefaultTransport, credentials),
+		},
+	}
+}
+
+// GetUser получает пользователя с автомобилями по tg_user_id
+func (c *Client) GetUser(ctx context.Context, tgUserID int64) (*User, error) {
+	url := fmt.Sprintf("%s/internal/users/%d", c.baseURL, tgUserID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusNotFound:
+		return nil, ErrUserNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &user, nil
+}
+
+// GetUsersBatch получает данные нескольких пользователей с выбранными автомобилями
+// Список ID разбивается на части по MaxBatchSize; ненайденные ID возвращаются во втором значении
+func (c *Client) GetUsersBatch(ctx context.Context, tgUserIDs []int64) ([]User, []int64, error) {
+	users := make([]User, 0, len(tgUserIDs))
+	missing := make([]int64, 0)
+
+	for start := 0; start < len(tgUserIDs); start += MaxBatchSize {
+		end := min(start+MaxBatchSize, len(tgUserIDs))
+
+		batch, err := c.getUsersBatch(ctx, tgUserIDs[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, batch.Users...)
+		missing = append(missing, batch.MissingIDs...)
+	}
+
+	return users, missing, nil
+}
+
+func (c *Client) getUsersBatch(ctx context.Context, tgUserIDs []int64) (*UsersBatchResponse, error) {
+	url := fmt.Sprintf("%s/internal/users/batch", c.baseURL)
+
+	body, err := json.Marshal(UsersBatchRequest{TGUserIDs: tgUserIDs})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInternal, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	default:
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(respBody))
+	}
+
+	// Парсим ответ
+	var response UsersBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &response, nil
+}
+
+// CreateCar добавляет автомобиль пользователю
+func (c *Client) CreateCar(ctx context.Context, tgUserID int64, car CreateCarRequest) (*Car, error) {
+	url := fmt.Sprintf("%s/internal/users/%d/cars", c.baseURL, tgUserID)
+
+	body, err := json.Marshal(car)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInternal, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.doCarRequest(req)
+}
+
+// SelectCar делает автомобиль пользователя выбранным
+func (c *Client) SelectCar(ctx context.Context, tgUserID, carID int64) (*Car, error) {
+	url := fmt.Sprintf("%s/internal/users/%d/cars/%d/select", c.baseURL, tgUserID, carID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	return c.doCarRequest(req)
+}
+
+// doCarRequest выполняет запрос изменения автомобиля и разбирает ответ
+func (c *Client) doCarRequest(req *http.Request) (*Car, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		// Продолжаем обработку
+	case http.StatusBadRequest:
+		return nil, ErrInvalidCar
+	case http.StatusConflict:
+		return nil, ErrCarAlreadyExists
+	case http.StatusForbidden:
+		// Чужой автомобиль неотличим от несуществующего
+		return nil, ErrCarNotFound
+	case http.StatusNotFound:
+		if req.Method == http.MethodPost {
+			return nil, ErrUserNotFound
+		}
+		return nil, ErrCarNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var car Car
+	if err := json.NewDecoder(resp.Body).Decode(&car); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &car, nil
+}
@@ -0,0 +1,23 @@
+package userservice
+
+import "errors"
+
+var (
+	// ErrUserNotFound возвращается, когда пользователь не зарегистрирован в UserService
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrCarNotFound возвращается, когда автомобиля нет или он принадлежит другому пользователю
+	ErrCarNotFound = errors.New("car not found")
+
+	// ErrCarAlreadyExists возвращается, когда у пользователя уже есть автомобиль с таким номером
+	ErrCarAlreadyExists = errors.New("car with this license plate already exists")
+
+	// ErrInvalidCar возвращается, когда UserService отклонил данные автомобиля: неверный номер или класс
+	ErrInvalidCar = errors.New("invalid car data")
+
+	// ErrInternal возвращается при внутренних ошибках клиента
+	ErrInternal = errors.New("userservice client: internal error")
+
+	// ErrInvalidResponse возвращается при некорректном ответе от сервиса
+	ErrInvalidResponse = errors.New("userservice client: invalid response")
+)
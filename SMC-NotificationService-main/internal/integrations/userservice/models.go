@@ -0,0 +1,46 @@
+package userservice
+
+import "time"
+
+// MaxBatchSize максимальное количество ID в одном запросе /internal/users/batch
+const MaxBatchSize = 100
+
+// User модель пользователя из UserService
+type User struct {
+	TGUserID        int64      `json:"tg_user_id"`
+	Name            string     `json:"name"`
+	PhoneNumber     *string    `json:"phone_number,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"` // nil - номер не подтверждён по SMS
+	TGLink          *string    `json:"tg_link,omitempty"`
+	Role            string     `json:"role"`
+	Cars            []Car      `json:"cars,omitempty"`         // заполняется в GetUser
+	SelectedCar     *Car       `json:"selected_car,omitempty"` // заполняется в GetUsersBatch
+}
+
+// Car модель автомобиля из UserService
+type Car struct {
+	ID           int64   `json:"id"`
+	Brand        string  `json:"brand"`
+	Model        string  `json:"model"`
+	LicensePlate string  `json:"license_plate"`
+	Size         *string `json:"size,omitempty"`
+	IsSelected   bool    `json:"is_selected"`
+}
+
+// CreateCarRequest данные нового автомобиля
+type CreateCarRequest struct {
+	Brand        string `json:"brand"`
+	Model        string `json:"model"`
+	LicensePlate string `json:"license_plate"`
+}
+
+// UsersBatchRequest запрос нескольких пользователей
+type UsersBatchRequest struct {
+	TGUserIDs []int64 `json:"tg_user_ids"`
+}
+
+// UsersBatchResponse найденные пользователи и ненайденные ID
+type UsersBatchResponse struct {
+	Users      []User  `json:"users"`
+	MissingIDs []int64 `json:"missing_ids"`
+}
@@ -0,0 +1,6 @@
+package service
+
+const (
+	// RoleSuperuser роль суперпользователя с полным доступом
+	RoleSuperuser = "superuser"
+)
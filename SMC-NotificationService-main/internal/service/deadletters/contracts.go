@@ -0,0 +1,14 @@
+package deadletters
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// DeadLetterRepository интерфейс репозитория dead-letter
+type DeadLetterRepository interface {
+	ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, *domain.PaginationResult, error)
+	RequeueDeadLetter(ctx context.Context, id int64) (*domain.Notification, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
+}
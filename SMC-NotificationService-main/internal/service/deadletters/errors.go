@@ -0,0 +1,17 @@
+package deadletters
+
+import "errors"
+
+var (
+	// ErrDeadLetterNotFound возвращается, когда записи dead-letter нет
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+	// ErrOnlySuperuser возвращается, когда операцию может выполнить только superuser
+	ErrOnlySuperuser = errors.New("access denied: only superuser can manage dead letters")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("invalid input data")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service: internal error")
+)
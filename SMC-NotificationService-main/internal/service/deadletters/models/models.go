@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// DeadLetterFilterRequest фильтры списка dead-letter
+type DeadLetterFilterRequest struct {
+	UserID *int64
+	Reason *string
+	Page   int
+	Limit  int
+}
+
+// DeadLetterResponse уведомление, которое не удалось доставить
+type DeadLetterResponse struct {
+	ID             int64     `json:"id"`
+	NotificationID int64     `json:"notification_id"`
+	UserID         int64     `json:"user_id"`
+	Message        string    `json:"message"`
+	Reason         string    `json:"reason"` // permanent | exhausted | lease_expired
+	Attempts       int       `json:"attempts"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DeadLetterListResponse ответ со списком dead-letter
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	Pagination  *PaginationResult    `json:"pagination"`
+}
+
+// PaginationResult результат пагинации
+type PaginationResult struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+}
+
+// RequeueResponse уведомление, возвращённое в очередь
+type RequeueResponse struct {
+	NotificationID int64  `json:"notification_id"`
+	UserID         int64  `json:"user_id"`
+	Status         string `json:"status"`
+}
+
+// FromDomainDeadLetter конвертирует domain модель в DTO
+func FromDomainDeadLetter(d *domain.DeadLetter) *DeadLetterResponse {
+	return &DeadLetterResponse{
+		ID:             d.ID,
+		NotificationID: d.NotificationID,
+		UserID:         d.UserID,
+		Message:        d.Message,
+		Reason:         string(d.Reason),
+		Attempts:       d.Attempts,
+		Error:          d.Error,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// FromDomainDeadLetterList конвертирует список с пагинацией в DTO
+func FromDomainDeadLetterList(deadLetters []domain.DeadLetter, pagination *domain.PaginationResult) *DeadLetterListResponse {
+	totalPages := 0
+	if pagination.Limit > 0 {
+		totalPages = (pagination.Total + pagination.Limit - 1) / pagination.Limit
+	}
+
+	response := make([]DeadLetterResponse, len(deadLetters))
+	for i := range deadLetters {
+		response[i] = *FromDomainDeadLetter(&deadLetters[i])
+	}
+
+	return &DeadLetterListResponse{
+		DeadLetters: response,
+		Pagination: &PaginationResult{
+			Page:       pagination.Page,
+			Limit:      pagination.Limit,
+			TotalPages: totalPages,
+			TotalItems: pagination.Total,
+		},
+	}
+}
+
+// FromDomainRequeued конвертирует возвращённое в очередь уведомление в DTO
+func FromDomainRequeued(n *domain.Notification) *RequeueResponse {
+	return &RequeueResponse{
+		NotificationID: n.ID,
+		UserID:         n.UserID,
+		Status:         string(n.Status),
+	}
+}
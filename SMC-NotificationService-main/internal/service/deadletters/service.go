@@ -0,0 +1,96 @@
+package deadletters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/service"
+	"github.com/m04kA/SMC-NotificationService/internal/service/deadletters/models"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Service просмотр и разбор уведомлений, которые не удалось доставить
+type Service struct {
+	deadLetterRepo DeadLetterRepository
+}
+
+func NewService(deadLetterRepo DeadLetterRepository) *Service {
+	return &Service{deadLetterRepo: deadLetterRepo}
+}
+
+// List возвращает dead-letter по фильтру, новые первыми
+func (s *Service) List(ctx context.Context, req *models.DeadLetterFilterRequest, userRole string) (*models.DeadLetterListResponse, error) {
+	if userRole != service.RoleSuperuser {
+		return nil, ErrOnlySuperuser
+	}
+
+	filter := domain.DeadLetterFilter{
+		UserID: req.UserID,
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+	if req.Reason != nil {
+		reason := domain.DeadLetterReason(*req.Reason)
+		if !reason.IsValid() {
+			return nil, fmt.Errorf("%w: unknown reason %q", ErrInvalidInput, *req.Reason)
+		}
+		filter.Reason = &reason
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	deadLetters, pagination, err := s.deadLetterRepo.ListDeadLetters(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainDeadLetterList(deadLetters, pagination), nil
+}
+
+// Requeue возвращает уведомление в очередь со сброшенным счётчиком попыток
+// Отправку сразу планирует вызывающий; иначе уведомление подберёт processor
+func (s *Service) Requeue(ctx context.Context, id int64, userRole string) (*models.RequeueResponse, error) {
+	if userRole != service.RoleSuperuser {
+		return nil, ErrOnlySuperuser
+	}
+
+	notification, err := s.deadLetterRepo.RequeueDeadLetter(ctx, id)
+	if err != nil {
+		if errors.Is(err, notificationRepo.ErrDeadLetterNotFound) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("%w: Requeue - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainRequeued(notification), nil
+}
+
+// Discard удаляет запись dead-letter; уведомление остаётся в статусе failed
+func (s *Service) Discard(ctx context.Context, id int64, userRole string) error {
+	if userRole != service.RoleSuperuser {
+		return ErrOnlySuperuser
+	}
+
+	if err := s.deadLetterRepo.DeleteDeadLetter(ctx, id); err != nil {
+		if errors.Is(err, notificationRepo.ErrDeadLetterNotFound) {
+			return ErrDeadLetterNotFound
+		}
+		return fmt.Errorf("%w: Discard - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+// ChannelSettingsRepository интерфейс репозитория настроек каналов
+type ChannelSettingsRepository interface {
+	Get(ctx context.Context, userID int64) (*domain.ChannelSettings, error)
+}
+
+// PreferencesRepository интерфейс репозитория настроек уведомлений
+type PreferencesRepository interface {
+	Get(ctx context.Context, userID int64) (*domain.NotificationPreferences, error)
+}
+
+// UnsubscribeLinks выпускает ссылки отписки в один клик
+type UnsubscribeLinks interface {
+	URL(userID int64, category string) string
+}
+
+// PushSubscriptionRepository интерфейс репозитория подписок Web Push
+type PushSubscriptionRepository interface {
+	ListByUserID(ctx context.Context, userID int64) ([]domain.PushSubscription, error)
+}
+
+// UserServiceClient интерфейс для получения телефона пользователя
+type UserServiceClient interface {
+	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
+}
@@ -0,0 +1,52 @@
+package delivery
+
+import (
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// Result результат доставки уведомления по цепочке каналов
+type Result struct {
+	Channel    *domain.Channel              // канал, доставивший уведомление; nil - ни один
+	Attempts   []domain.CreateDeliveryInput // попытки в порядке выполнения
+	Retryable  bool                         // хотя бы один канал вернул временную ошибку
+	RetryAfter time.Duration                // наибольшая пауза, запрошенная каналами (retry_after Telegram)
+	Throttled  *time.Time                   // канал отложил отправку ограничителем скорости до этого времени
+}
+
+// GateAction решение о доставке уведомления по настройкам пользователя
+type GateAction int
+
+const (
+	GateSend  GateAction = iota // отправить сейчас
+	GateDefer                   // отложить до конца тихих часов
+	GateDrop                    // не отправлять: пользователь отписался от категории
+)
+
+// Gate результат проверки настроек пользователя перед отправкой
+type Gate struct {
+	Action GateAction
+	Until  time.Time // конец тихих часов для GateDefer
+	Reason string    // причина для GateDrop
+}
+
+// Delivered проверяет, что уведомление доставлено хотя бы одним каналом
+func (r *Result) Delivered() bool {
+	return r.Channel != nil
+}
+
+// Reason собирает ошибки всех попыток для сохранения в уведомлении
+func (r *Result) Reason() string {
+	reasons := make([]string, 0, len(r.Attempts))
+	for _, attempt := range r.Attempts {
+		if attempt.Error != nil {
+			reasons = append(reasons, string(attempt.Channel)+": "+*attempt.Error)
+		}
+	}
+	if len(reasons) == 0 {
+		return "no delivery channels"
+	}
+	return strings.Join(reasons, "; ")
+}
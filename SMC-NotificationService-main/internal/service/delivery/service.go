@@ -0,0 +1,235 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	preferencesRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+)
+
+const (
+	reasonChannelDisabled = "channel is not configured"
+	reasonNoAddress       = "recipient has no address in this channel"
+	reasonOptedOut        = "recipient opted out of category %s"
+)
+
+// Service доставляет уведомление по цепочке каналов до первой успешной отправки
+type Service struct {
+	channels          map[domain.Channel]channels.Channel
+	settingsRepo      ChannelSettingsRepository
+	subscriptionRepo  PushSubscriptionRepository
+	preferencesRepo   PreferencesRepository
+	userServiceClient UserServiceClient
+	unsubscribe       UnsubscribeLinks // nil - ссылки отписки не добавляются
+}
+
+// NewService создает сервис доставки; каналы, не переданные в enabled, считаются отключёнными
+func NewService(
+	settingsRepo ChannelSettingsRepository,
+	subscriptionRepo PushSubscriptionRepository,
+	preferencesRepo PreferencesRepository,
+	userServiceClient UserServiceClient,
+	unsubscribe UnsubscribeLinks,
+	enabled ...channels.Channel,
+) *Service {
+	registry := make(map[domain.Channel]channels.Channel, len(enabled))
+	for _, channel := range enabled {
+		registry[channel.Name()] = channel
+	}
+
+	return &Service{
+		channels:          registry,
+		settingsRepo:      settingsRepo,
+		subscriptionRepo:  subscriptionRepo,
+		preferencesRepo:   preferencesRepo,
+		userServiceClient: userServiceClient,
+		unsubscribe:       unsubscribe,
+	}
+}
+
+// Enabled проверяет, что канал настроен
+func (s *Service) Enabled(channel domain.Channel) bool {
+	_, ok := s.channels[channel]
+	return ok
+}
+
+// Gate проверяет настройки пользователя перед отправкой
+// Транзакционные уведомления отправляются всегда; от остальных категорий можно отписаться,
+// а в тихие часы пользователя они откладываются до их окончания
+// Ошибка чтения настроек временная: отправлять, не зная об отписке, нельзя
+func (s *Service) Gate(ctx context.Context, notification *domain.Notification, now time.Time) (*Gate, error) {
+	if !notification.Category.Optional() {
+		return &Gate{Action: GateSend}, nil
+	}
+
+	preferences, err := s.preferencesRepo.Get(ctx, notification.UserID)
+	if err != nil {
+		if errors.Is(err, preferencesRepo.ErrPreferencesNotFound) {
+			return &Gate{Action: GateSend}, nil
+		}
+		return nil, fmt.Errorf("get notification preferences: %w", err)
+	}
+
+	if !preferences.Allows(notification.Category) {
+		return &Gate{Action: GateDrop, Reason: fmt.Sprintf(reasonOptedOut, notification.Category)}, nil
+	}
+	if until, quiet := preferences.QuietUntil(now); quiet {
+		return &Gate{Action: GateDefer, Until: until}, nil
+	}
+	return &Gate{Action: GateSend}, nil
+}
+
+// Dispatch пробует каналы уведомления в порядке fallback пользователя и останавливается на первом успешном
+// Результат содержит все попытки, включая пропущенные каналы, для сохранения статуса по каждому каналу,
+// и признак, имеет ли смысл повторить доставку позже
+// Канал, отложивший отправку ограничителем скорости, останавливает цепочку: сообщение ждёт своей очереди,
+// а не уходит в следующий канал, и попытка по нему не сохраняется
+func (s *Service) Dispatch(ctx context.Context, notification *domain.Notification) *Result {
+	settings, err := s.settingsRepo.Get(ctx, notification.UserID)
+	if err != nil {
+		// Пользователь не настраивал каналы или настройки недоступны - действует порядок из уведомления
+		settings = nil
+	}
+	var preferred *domain.Channel
+	if preferences, err := s.preferencesRepo.Get(ctx, notification.UserID); err == nil {
+		preferred = preferences.PreferredChannel
+	}
+
+	order := ResolveOrder(notification.Channels, settings, preferred)
+	recipient := channels.Recipient{UserID: notification.UserID}
+	if settings != nil {
+		recipient.Email = settings.Email
+	}
+	message := channels.Message{
+		NotificationID: notification.ID,
+		Text:           notification.Message,
+		ParseMode:      notification.ParseMode,
+	}
+	if s.unsubscribe != nil && notification.Category.Optional() {
+		message.UnsubscribeURL = s.unsubscribe.URL(notification.UserID, string(notification.Category))
+	}
+
+	result := &Result{Attempts: make([]domain.CreateDeliveryInput, 0, len(order))}
+	for _, name := range order {
+		attempt, err := s.try(ctx, name, &recipient, message)
+		if until, ok := channels.Throttled(err); ok {
+			result.Throttled = &until
+			break
+		}
+		attempt.NotificationID = notification.ID
+		result.Attempts = append(result.Attempts, attempt)
+
+		if err != nil && !errors.Is(err, channels.ErrPermanent) {
+			result.Retryable = true
+			if retryAfter := channels.RetryAfter(err); retryAfter > result.RetryAfter {
+				result.RetryAfter = retryAfter
+			}
+		}
+
+		if attempt.Status == domain.DeliverySent {
+			sentVia := name
+			result.Channel = &sentVia
+			break
+		}
+	}
+
+	return result
+}
+
+// ResolveOrder возвращает порядок каналов для пользователя:
+// сначала предпочитаемый канал, затем каналы из его настроек, затем остальные каналы уведомления в исходном порядке
+// Каналы, не разрешённые уведомлением, не используются
+func ResolveOrder(allowed []domain.Channel, settings *domain.ChannelSettings, preferred *domain.Channel) []domain.Channel {
+	allowedSet := make(map[domain.Channel]bool, len(allowed))
+	for _, channel := range allowed {
+		allowedSet[channel] = true
+	}
+
+	order := make([]domain.Channel, 0, len(allowed))
+	added := make(map[domain.Channel]bool, len(allowed))
+	if preferred != nil && allowedSet[*preferred] {
+		order = append(order, *preferred)
+		added[*preferred] = true
+	}
+	if settings != nil {
+		for _, channel := range settings.ChannelOrder {
+			if allowedSet[channel] && !added[channel] {
+				order = append(order, channel)
+				added[channel] = true
+			}
+		}
+	}
+	for _, channel := range allowed {
+		if !added[channel] {
+			order = append(order, channel)
+			added[channel] = true
+		}
+	}
+
+	return order
+}
+
+// try выполняет одну попытку доставки по каналу
+// Возвращает ошибку канала для неудачной попытки; пропущенные каналы ошибкой не считаются
+func (s *Service) try(ctx context.Context, name domain.Channel, recipient *channels.Recipient, message channels.Message) (domain.CreateDeliveryInput, error) {
+	attempt := domain.CreateDeliveryInput{Channel: name}
+
+	channel, ok := s.channels[name]
+	if !ok {
+		attempt.Status = domain.DeliverySkipped
+		attempt.Error = stringPtr(reasonChannelDisabled)
+		return attempt, nil
+	}
+
+	// Недоступность UserService или БД - временная ошибка
+	if err := s.loadAddress(ctx, name, recipient); err != nil {
+		attempt.Status = domain.DeliveryFailed
+		attempt.Error = stringPtr(err.Error())
+		return attempt, err
+	}
+
+	err := channel.Send(ctx, *recipient, message)
+	switch {
+	case err == nil:
+		attempt.Status = domain.DeliverySent
+	case errors.Is(err, channels.ErrNoAddress):
+		attempt.Status = domain.DeliverySkipped
+		attempt.Error = stringPtr(reasonNoAddress)
+		return attempt, nil
+	default:
+		attempt.Status = domain.DeliveryFailed
+		attempt.Error = stringPtr(err.Error())
+	}
+	return attempt, err
+}
+
+// loadAddress дозагружает адрес получателя для канала перед первой попыткой
+// Адреса запрашиваются лениво: если уведомление доставлено в Telegram, UserService не вызывается
+func (s *Service) loadAddress(ctx context.Context, name domain.Channel, recipient *channels.Recipient) error {
+	switch name {
+	case domain.ChannelSMS:
+		user, err := s.userServiceClient.GetUser(ctx, recipient.UserID)
+		if err != nil {
+			return fmt.Errorf("get phone from userservice: %w", err)
+		}
+		// SMS отправляются только на номер, подтверждённый кодом
+		if user.PhoneVerifiedAt != nil {
+			recipient.Phone = user.PhoneNumber
+		}
+	case domain.ChannelWebPush:
+		subscriptions, err := s.subscriptionRepo.ListByUserID(ctx, recipient.UserID)
+		if err != nil {
+			return fmt.Errorf("list push subscriptions: %w", err)
+		}
+		recipient.PushSubscriptions = subscriptions
+	}
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,186 @@
+package notifications
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	channelSettingsRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/channelsettings"
+	pushSubscriptionRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/pushsubscription"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/pkg/netguard"
+)
+
+const (
+	maxEmailLength = 255
+
+	// Размеры ключей подписки Web Push: несжатая точка P-256 и секрет аутентификации
+	p256dhKeyLength = 65
+	authKeyLength   = 16
+)
+
+// GetChannelSettings возвращает настройки каналов пользователя
+// Пользователь без настроек получает пустой порядок: действует порядок из уведомления
+func (s *Service) GetChannelSettings(ctx context.Context, userID int64) (*models.ChannelSettingsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, channelSettingsRepo.ErrSettingsNotFound) {
+			return &models.ChannelSettingsResponse{UserID: userID, ChannelOrder: []string{}}, nil
+		}
+		return nil, fmt.Errorf("%w: GetChannelSettings - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainChannelSettings(settings), nil
+}
+
+// UpdateChannelSettings заменяет порядок fallback и email пользователя
+func (s *Service) UpdateChannelSettings(ctx context.Context, userID int64, req *models.ChannelSettingsRequest) (*models.ChannelSettingsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+
+	order := make([]domain.Channel, 0, len(req.ChannelOrder))
+	if len(req.ChannelOrder) > 0 {
+		parsed, err := parseChannels(req.ChannelOrder)
+		if err != nil {
+			return nil, err
+		}
+		order = parsed
+	}
+
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsRepo.Upsert(ctx, domain.ChannelSettings{
+		UserID:       userID,
+		ChannelOrder: order,
+		Email:        email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: UpdateChannelSettings - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainChannelSettings(settings), nil
+}
+
+// SubscribePush сохраняет подписку браузера пользователя на Web Push
+func (s *Service) SubscribePush(ctx context.Context, userID int64, req *models.PushSubscriptionRequest) (*models.PushSubscriptionResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+	if err := validateEndpoint(req.Endpoint); err != nil {
+		return nil, err
+	}
+	if err := validateKey("keys.p256dh", req.Keys.P256dh, p256dhKeyLength); err != nil {
+		return nil, err
+	}
+	if err := validateKey("keys.auth", req.Keys.Auth, authKeyLength); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptionRepo.Upsert(ctx, domain.PushSubscription{
+		UserID:   userID,
+		Endpoint: req.Endpoint,
+		P256dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: SubscribePush - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainPushSubscription(subscription), nil
+}
+
+// UnsubscribePush удаляет подписку браузера пользователя
+func (s *Service) UnsubscribePush(ctx context.Context, userID int64, req *models.DeletePushSubscriptionRequest) error {
+	if userID <= 0 {
+		return fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+	if req.Endpoint == "" {
+		return fmt.Errorf("%w: endpoint is required", ErrInvalidInput)
+	}
+
+	if err := s.subscriptionRepo.Delete(ctx, userID, req.Endpoint); err != nil {
+		if errors.Is(err, pushSubscriptionRepo.ErrSubscriptionNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("%w: UnsubscribePush - repository error: %v", ErrInternal, err)
+	}
+	return nil
+}
+
+// notificationChannels возвращает каналы уведомления: из запроса или каналы по умолчанию
+func (s *Service) notificationChannels(requested []string) ([]domain.Channel, error) {
+	if len(requested) == 0 {
+		return s.defaultChannels, nil
+	}
+	return parseChannels(requested)
+}
+
+// parseChannels проверяет имена каналов и убирает дубликаты с сохранением порядка
+func parseChannels(names []string) ([]domain.Channel, error) {
+	channels := make([]domain.Channel, 0, len(names))
+	seen := make(map[domain.Channel]bool, len(names))
+	for _, name := range names {
+		channel := domain.Channel(strings.ToLower(strings.TrimSpace(name)))
+		if !channel.IsValid() {
+			return nil, fmt.Errorf("%w: unknown channel %q", ErrInvalidInput, name)
+		}
+		if seen[channel] {
+			continue
+		}
+		seen[channel] = true
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// normalizeEmail проверяет адрес; пустая строка и null удаляют email
+func normalizeEmail(email *string) (*string, error) {
+	if email == nil {
+		return nil, nil
+	}
+
+	value := strings.TrimSpace(*email)
+	if value == "" {
+		return nil, nil
+	}
+	if len(value) > maxEmailLength {
+		return nil, fmt.Errorf("%w: email must not exceed %d characters", ErrInvalidInput, maxEmailLength)
+	}
+
+	// Только голый адрес: имя отправителя и переводы строк в заголовок To не попадают
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		return nil, fmt.Errorf("%w: invalid email", ErrInvalidInput)
+	}
+	return &value, nil
+}
+
+// validateEndpoint проверяет адрес push-сервиса браузера
+// Push-сервисы браузеров работают только по https; внутренние адреса запрещены, иначе worker станет прокси во внутреннюю сеть
+func validateEndpoint(endpoint string) error {
+	if err := netguard.ValidateURL(endpoint); err != nil {
+		return fmt.Errorf("%w: endpoint must be a public https URL", ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateKey проверяет ключ подписки в base64url
+func validateKey(field, value string, length int) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(decoded) != length {
+		return fmt.Errorf("%w: %s must be a base64url encoded %d-byte key", ErrInvalidInput, field, length)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+// content текст уведомления вместе с разметкой и шаблоном, из которого он получен
+type content struct {
+	message   string
+	parseMode domain.ParseMode
+	template  *domain.TemplateRef
+}
+
+// resolveContent возвращает текст уведомления: переданный напрямую в message или отрендеренный из template
+// Шаблон рендерится один раз при создании: правка шаблона не меняет уже созданные уведомления
+func (s *Service) resolveContent(ctx context.Context, message string, template, locale *string, params map[string]interface{}) (*content, error) {
+	hasMessage := strings.TrimSpace(message) != ""
+	hasTemplate := template != nil && *template != ""
+
+	switch {
+	case hasMessage && hasTemplate:
+		return nil, fmt.Errorf("%w: message and template are mutually exclusive", ErrInvalidInput)
+	case !hasTemplate:
+		if locale != nil || params != nil {
+			return nil, fmt.Errorf("%w: locale and params require template", ErrInvalidInput)
+		}
+		text, err := validateMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return &content{message: text, parseMode: domain.ParseModePlain}, nil
+	}
+
+	requestedLocale := ""
+	if locale != nil {
+		requestedLocale = *locale
+	}
+
+	rendered, err := s.templateRenderer.Render(ctx, *template, requestedLocale, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, templates.ErrTemplateNotFound):
+			return nil, ErrTemplateNotFound
+		case errors.Is(err, templates.ErrInvalidInput), errors.Is(err, templates.ErrRender):
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		default:
+			return nil, fmt.Errorf("%w: render template: %v", ErrInternal, err)
+		}
+	}
+
+	return &content{
+		message:   rendered.Text,
+		parseMode: rendered.ParseMode,
+		template: &domain.TemplateRef{
+			Name:    rendered.Name,
+			Locale:  rendered.Locale,
+			Version: rendered.Version,
+		},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+// NotificationRepository интерфейс репозитория уведомлений
+type NotificationRepository interface {
+	Create(ctx context.Context, input domain.CreateNotificationInput) (*domain.Notification, error)
+	GetByID(ctx context.Context, id int64) (*domain.Notification, error)
+	ListDeliveries(ctx context.Context, notificationID int64) ([]domain.Delivery, error)
+	CreateBatch(ctx context.Context, inputs []domain.CreateNotificationInput) ([]domain.Notification, error)
+	List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, *domain.PaginationResult, error)
+	Cancel(ctx context.Context, id int64) (*domain.Notification, error)
+	CancelBySpanID(ctx context.Context, spanID string) (int64, error)
+	ListByUserID(ctx context.Context, userID int64) ([]domain.Notification, error)
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+}
+
+// SeriesRepository интерфейс репозитория серий повторяющихся уведомлений
+type SeriesRepository interface {
+	CreateSeries(ctx context.Context, input domain.CreateSeriesInput) (*domain.Series, error)
+	GetSeriesBySpanID(ctx context.Context, spanID string) (*domain.Series, error)
+	CancelSeries(ctx context.Context, spanID string) error
+	RemoveUserFromSeries(ctx context.Context, userID int64) (int64, error)
+}
+
+// ChannelSettingsRepository интерфейс репозитория настроек каналов
+type ChannelSettingsRepository interface {
+	Get(ctx context.Context, userID int64) (*domain.ChannelSettings, error)
+	Upsert(ctx context.Context, settings domain.ChannelSettings) (*domain.ChannelSettings, error)
+	Delete(ctx context.Context, userID int64) (int64, error)
+}
+
+// PreferencesRepository интерфейс репозитория настроек уведомлений
+type PreferencesRepository interface {
+	Get(ctx context.Context, userID int64) (*domain.NotificationPreferences, error)
+	Upsert(ctx context.Context, preferences domain.NotificationPreferences) (*domain.NotificationPreferences, error)
+	OptOut(ctx context.Context, userID int64, category domain.Category) (*domain.NotificationPreferences, error)
+	Delete(ctx context.Context, userID int64) (int64, error)
+}
+
+// ConversationRepository интерфейс репозитория диалогов с ботом
+type ConversationRepository interface {
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+}
+
+// CompletionEstimator оценивает время отправки пакета сообщений по лимиту скорости канала
+type CompletionEstimator interface {
+	EstimateCompletion(ctx context.Context, count int, start time.Time) (time.Time, error)
+}
+
+// UnsubscribeTokens проверяет токены отписки из ссылок в сообщениях
+type UnsubscribeTokens interface {
+	Parse(token string) (int64, string, error)
+}
+
+// PushSubscriptionRepository интерфейс репозитория подписок Web Push
+type PushSubscriptionRepository interface {
+	Upsert(ctx context.Context, subscription domain.PushSubscription) (*domain.PushSubscription, error)
+	ListByUserID(ctx context.Context, userID int64) ([]domain.PushSubscription, error)
+	Delete(ctx context.Context, userID int64, endpoint string) error
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+}
+
+// UserServiceClient интерфейс для работы с UserService
+type UserServiceClient interface {
+	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
+	GetUsersBatch(ctx context.Context, tgUserIDs []int64) ([]userservice.User, []int64, error)
+}
+
+// TemplateRenderer интерфейс реестра шаблонов уведомлений
+type TemplateRenderer interface {
+	Render(ctx context.Context, name, locale string, params map[string]interface{}) (*domain.RenderedTemplate, error)
+}
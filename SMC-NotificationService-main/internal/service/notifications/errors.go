@@ -0,0 +1,38 @@
+package notifications
+
+import "errors"
+
+var (
+	// ErrNotificationNotFound возвращается, когда уведомление не найдено
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	// ErrNotPending возвращается при отмене уже отправленного или отменённого уведомления
+	ErrNotPending = errors.New("notification is already sent or cancelled")
+
+	// ErrUserNotFound возвращается, когда получатель не зарегистрирован в UserService
+	ErrUserNotFound = errors.New("recipient not found")
+
+	// ErrNoRecipients возвращается, когда ни один получатель пакета не найден в UserService
+	ErrNoRecipients = errors.New("none of the recipients were found")
+
+	// ErrSeriesNotFound возвращается, когда серии повторяющихся уведомлений с таким span_id нет
+	ErrSeriesNotFound = errors.New("recurring notification not found")
+
+	// ErrTemplateNotFound возвращается, когда шаблона уведомления нет в реестре
+	ErrTemplateNotFound = errors.New("template not found")
+
+	// ErrSubscriptionNotFound возвращается, когда у пользователя нет подписки Web Push с таким endpoint
+	ErrSubscriptionNotFound = errors.New("push subscription not found")
+
+	// ErrInvalidUnsubscribeToken возвращается, когда токен отписки повреждён или подписан другим секретом
+	ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+	// ErrUnsubscribeDisabled возвращается, когда секрет токенов отписки не настроен
+	ErrUnsubscribeDisabled = errors.New("unsubscribe links are disabled")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("invalid input data")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service: internal error")
+)
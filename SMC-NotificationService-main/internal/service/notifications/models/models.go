@@ -0,0 +1,449 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// CreateNotificationRequest запрос на создание уведомления
+// Текст задаётся либо в message, либо шаблоном template с параметрами params
+type CreateNotificationRequest struct {
+	UserID      int64                  `json:"user_id"`
+	Message     string                 `json:"message,omitempty"`
+	Template    *string                `json:"template,omitempty"`     // имя шаблона из реестра
+	Locale      *string                `json:"locale,omitempty"`       // язык шаблона; не задано - ru
+	Params      map[string]interface{} `json:"params,omitempty"`       // переменные шаблона
+	Channels    []string               `json:"channels,omitempty"`     // порядок fallback; не задано - каналы по умолчанию
+	Category    string                 `json:"category,omitempty"`     // transactional, reminders, marketing; не задано - transactional
+	ScheduledAt *time.Time             `json:"scheduled_at,omitempty"` // не задано - отправить сразу
+}
+
+// CreateBatchNotificationRequest запрос на создание одного уведомления нескольким пользователям
+type CreateBatchNotificationRequest struct {
+	UserIDs     []int64                `json:"user_ids"`
+	Message     string                 `json:"message,omitempty"`
+	Template    *string                `json:"template,omitempty"`
+	Locale      *string                `json:"locale,omitempty"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+	Channels    []string               `json:"channels,omitempty"`
+	Category    string                 `json:"category,omitempty"`
+	ScheduledAt *time.Time             `json:"scheduled_at,omitempty"`
+}
+
+// CreateRecurringNotificationRequest запрос на создание серии повторяющихся уведомлений
+// Расписание задаётся либо cron, либо rrule; время повторений считается в часовом поясе timezone
+type CreateRecurringNotificationRequest struct {
+	UserIDs  []int64                `json:"user_ids"`
+	Message  string                 `json:"message,omitempty"`
+	Template *string                `json:"template,omitempty"`
+	Locale   *string                `json:"locale,omitempty"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Channels []string               `json:"channels,omitempty"`
+	Category string                 `json:"category,omitempty"`
+	Cron     *string                `json:"cron,omitempty"`     // "0 10 * * MON-FRI"
+	RRule    *string                `json:"rrule,omitempty"`    // "FREQ=WEEKLY;BYDAY=MO;BYHOUR=10;BYMINUTE=0"
+	Timezone string                 `json:"timezone"`           // IANA, например Europe/Moscow
+	StartAt  *time.Time             `json:"start_at,omitempty"` // не задано - сейчас; для rrule задаёт DTSTART
+	EndAt    *time.Time             `json:"end_at,omitempty"`   // не задано - без ограничения
+}
+
+// NotificationFilterRequest фильтры списка уведомлений
+type NotificationFilterRequest struct {
+	UserID *int64
+	SpanID *string
+	Status *string
+	Page   int
+	Limit  int
+}
+
+// NotificationResponse ответ с данными уведомления
+type NotificationResponse struct {
+	ID            int64              `json:"id"`
+	UserID        int64              `json:"user_id"`
+	Message       string             `json:"message"`
+	ParseMode     string             `json:"parse_mode,omitempty"`
+	Template      *TemplateRef       `json:"template,omitempty"` // шаблон, из которого получен текст
+	Channels      []string           `json:"channels"`
+	Category      string             `json:"category"`
+	SpanID        *string            `json:"span_id,omitempty"`
+	ScheduledAt   *time.Time         `json:"scheduled_at,omitempty"`
+	Status        string             `json:"status"`
+	Attempts      int                `json:"attempts"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty"` // время повтора после временной ошибки
+	Error         *string            `json:"error,omitempty"`
+	SentAt        *time.Time         `json:"sent_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	Deliveries    []DeliveryResponse `json:"deliveries,omitempty"` // только в ответе GET /notifications/{id}
+}
+
+// TemplateRef версия шаблона, из которой отрендерен текст уведомления
+type TemplateRef struct {
+	Name    string `json:"name"`
+	Locale  string `json:"locale"`
+	Version int    `json:"version"`
+}
+
+// DeliveryResponse попытка доставки по одному каналу
+type DeliveryResponse struct {
+	Channel   string    `json:"channel"`
+	Status    string    `json:"status"`
+	Error     *string   `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchNotificationResponse ответ на создание пакета уведомлений
+type BatchNotificationResponse struct {
+	SpanID         string                 `json:"span_id"`
+	Notifications  []NotificationResponse `json:"notifications"`
+	SkippedUserIDs []int64                `json:"skipped_user_ids"` // получатели, не найденные в UserService
+
+	// EstimatedCompletionAt оценка окончания отправки в Telegram с учётом лимита бота и слотов, занятых всеми экземплярами
+	EstimatedCompletionAt *time.Time `json:"estimated_completion_at,omitempty"`
+}
+
+// NotificationListResponse ответ со списком уведомлений
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Pagination    *PaginationResult      `json:"pagination"`
+}
+
+// PaginationResult результат пагинации
+type PaginationResult struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalPages int `json:"total_pages"`
+	TotalItems int `json:"total_items"`
+}
+
+// RecurringNotificationResponse серия повторяющихся уведомлений
+type RecurringNotificationResponse struct {
+	SpanID            string       `json:"span_id"` // span_id всех повторений серии
+	UserIDs           []int64      `json:"user_ids"`
+	Message           string       `json:"message"`
+	ParseMode         string       `json:"parse_mode,omitempty"`
+	Template          *TemplateRef `json:"template,omitempty"`
+	Channels          []string     `json:"channels"`
+	Category          string       `json:"category"`
+	Cron              *string      `json:"cron,omitempty"`
+	RRule             *string      `json:"rrule,omitempty"`
+	Timezone          string       `json:"timezone"`
+	StartAt           time.Time    `json:"start_at"`
+	EndAt             *time.Time   `json:"end_at,omitempty"`
+	Status            string       `json:"status"`
+	MaterializedUntil time.Time    `json:"materialized_until"` // повторения до этого момента уже созданы
+	NextOccurrences   []time.Time  `json:"next_occurrences"`   // ближайшие повторения по расписанию
+	SkippedUserIDs    []int64      `json:"skipped_user_ids,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+// CancelBatchResponse результат отмены пакета
+type CancelBatchResponse struct {
+	SpanID    string `json:"span_id"`
+	Cancelled int64  `json:"cancelled"`
+}
+
+// ChannelSettingsRequest запрос на замену настроек каналов пользователя
+type ChannelSettingsRequest struct {
+	ChannelOrder []string `json:"channel_order"` // предпочтительный порядок fallback
+	Email        *string  `json:"email"`         // null - удалить адрес
+}
+
+// ChannelSettingsResponse настройки каналов пользователя
+type ChannelSettingsResponse struct {
+	UserID       int64    `json:"user_id"`
+	ChannelOrder []string `json:"channel_order"`
+	Email        *string  `json:"email,omitempty"`
+}
+
+// NotificationSettingsRequest запрос на замену настроек уведомлений пользователя
+type NotificationSettingsRequest struct {
+	Categories       map[string]bool `json:"categories"`        // категория -> получать ли; не указанные категории включены
+	PreferredChannel *string         `json:"preferred_channel"` // null - порядок из настроек каналов
+	Timezone         string          `json:"timezone"`          // IANA; не задано - UTC
+	QuietHours       *QuietHours     `json:"quiet_hours"`       // null - без тихих часов
+}
+
+// NotificationSettingsResponse настройки уведомлений пользователя
+type NotificationSettingsResponse struct {
+	UserID           int64           `json:"user_id"`
+	Categories       map[string]bool `json:"categories"`
+	PreferredChannel *string         `json:"preferred_channel"`
+	Timezone         string          `json:"timezone"`
+	QuietHours       *QuietHours     `json:"quiet_hours"`
+}
+
+// QuietHours тихие часы в формате HH:MM по часовому поясу пользователя
+// start позже end - интервал через полночь
+type QuietHours struct {
+	Start string `json:"start"` // "22:00"
+	End   string `json:"end"`   // "08:00"
+}
+
+// UnsubscribeResponse результат отписки по ссылке из сообщения
+type UnsubscribeResponse struct {
+	UserID       int64  `json:"user_id"`
+	Category     string `json:"category"`
+	Unsubscribed bool   `json:"unsubscribed"` // false - ссылка проверена, отписка ждёт подтверждения POST
+}
+
+// PushSubscriptionRequest подписка браузера в формате PushSubscription.toJSON()
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// DeletePushSubscriptionRequest запрос на отписку браузера
+type DeletePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// PushSubscriptionResponse сохранённая подписка (ключи не возвращаются)
+type PushSubscriptionResponse struct {
+	ID        int64     `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserDataExport данные пользователя, хранящиеся в NotificationService
+type UserDataExport struct {
+	UserID            int64                         `json:"user_id"`
+	Notifications     []NotificationExport          `json:"notifications"`
+	ChannelSettings   *ChannelSettingsResponse      `json:"channel_settings,omitempty"`
+	Preferences       *NotificationSettingsResponse `json:"notification_settings,omitempty"`
+	PushSubscriptions []PushSubscriptionResponse    `json:"push_subscriptions"`
+}
+
+// NotificationExport уведомление в выгрузке персональных данных
+type NotificationExport struct {
+	ID          int64      `json:"id"`
+	Message     string     `json:"message"`
+	Status      string     `json:"status"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// UserDataErasure результат удаления данных пользователя
+type UserDataErasure struct {
+	UserID                   int64 `json:"user_id"`
+	NotificationsDeleted     int64 `json:"notifications_deleted"`
+	ChannelSettingsDeleted   bool  `json:"channel_settings_deleted"`
+	PushSubscriptionsDeleted int64 `json:"push_subscriptions_deleted"`
+	PreferencesDeleted       bool  `json:"notification_settings_deleted"`
+	ConversationsDeleted     int64 `json:"bot_conversations_deleted"`
+	SeriesUpdated            int64 `json:"series_updated"` // серии, из получателей которых исключён пользователь
+}
+
+// FromDomainNotification конвертирует domain модель в DTO
+func FromDomainNotification(n *domain.Notification) *NotificationResponse {
+	response := &NotificationResponse{
+		ID:            n.ID,
+		UserID:        n.UserID,
+		Message:       n.Message,
+		ParseMode:     string(n.ParseMode),
+		Channels:      channelNames(n.Channels),
+		Category:      string(n.Category),
+		SpanID:        n.SpanID,
+		ScheduledAt:   n.ScheduledAt,
+		Status:        string(n.Status),
+		Attempts:      n.Attempts,
+		NextAttemptAt: n.NextAttemptAt,
+		Error:         n.Error,
+		SentAt:        n.SentAt,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+		Deliveries:    FromDomainDeliveries(n.Deliveries),
+	}
+	if n.Template != nil {
+		response.Template = &TemplateRef{
+			Name:    n.Template.Name,
+			Locale:  string(n.Template.Locale),
+			Version: n.Template.Version,
+		}
+	}
+	return response
+}
+
+// FromDomainSeries конвертирует серию в DTO
+func FromDomainSeries(series *domain.Series, next []time.Time) *RecurringNotificationResponse {
+	response := &RecurringNotificationResponse{
+		SpanID:            series.SpanID,
+		UserIDs:           series.UserIDs,
+		Message:           series.Message,
+		ParseMode:         string(series.ParseMode),
+		Channels:          channelNames(series.Channels),
+		Category:          string(series.Category),
+		Timezone:          series.Timezone,
+		StartAt:           series.StartAt,
+		EndAt:             series.EndAt,
+		Status:            string(series.Status),
+		MaterializedUntil: series.MaterializedUntil,
+		NextOccurrences:   next,
+		CreatedAt:         series.CreatedAt,
+	}
+	expression := series.Expression
+	switch series.Kind {
+	case domain.ScheduleCron:
+		response.Cron = &expression
+	case domain.ScheduleRRule:
+		response.RRule = &expression
+	}
+	if series.Template != nil {
+		response.Template = &TemplateRef{
+			Name:    series.Template.Name,
+			Locale:  string(series.Template.Locale),
+			Version: series.Template.Version,
+		}
+	}
+	return response
+}
+
+// FromDomainDeliveries конвертирует попытки доставки в DTO
+func FromDomainDeliveries(deliveries []domain.Delivery) []DeliveryResponse {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	response := make([]DeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		response[i] = DeliveryResponse{
+			Channel:   string(d.Channel),
+			Status:    string(d.Status),
+			Error:     d.Error,
+			CreatedAt: d.CreatedAt,
+		}
+	}
+	return response
+}
+
+// FromDomainChannelSettings конвертирует настройки каналов в DTO
+func FromDomainChannelSettings(settings *domain.ChannelSettings) *ChannelSettingsResponse {
+	return &ChannelSettingsResponse{
+		UserID:       settings.UserID,
+		ChannelOrder: channelNames(settings.ChannelOrder),
+		Email:        settings.Email,
+	}
+}
+
+// FromDomainPreferences конвертирует настройки уведомлений в DTO
+// Все категории присутствуют в ответе: отключены только те, от которых пользователь отписался
+func FromDomainPreferences(preferences *domain.NotificationPreferences) *NotificationSettingsResponse {
+	response := &NotificationSettingsResponse{
+		UserID: preferences.UserID,
+		Categories: map[string]bool{
+			string(domain.CategoryTransactional): true,
+			string(domain.CategoryReminders):     preferences.Allows(domain.CategoryReminders),
+			string(domain.CategoryMarketing):     preferences.Allows(domain.CategoryMarketing),
+		},
+		Timezone: preferences.Timezone,
+	}
+	if preferences.PreferredChannel != nil {
+		channel := string(*preferences.PreferredChannel)
+		response.PreferredChannel = &channel
+	}
+	if preferences.QuietHours != nil {
+		response.QuietHours = &QuietHours{
+			Start: formatMinutes(preferences.QuietHours.Start),
+			End:   formatMinutes(preferences.QuietHours.End),
+		}
+	}
+	return response
+}
+
+// formatMinutes форматирует минуты от полуночи как HH:MM
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// FromDomainPushSubscription конвертирует подписку в DTO
+func FromDomainPushSubscription(s *domain.PushSubscription) *PushSubscriptionResponse {
+	return &PushSubscriptionResponse{
+		ID:        s.ID,
+		Endpoint:  s.Endpoint,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// FromDomainPushSubscriptions конвертирует список подписок в DTO
+func FromDomainPushSubscriptions(subscriptions []domain.PushSubscription) []PushSubscriptionResponse {
+	response := make([]PushSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = *FromDomainPushSubscription(&subscriptions[i])
+	}
+	return response
+}
+
+func channelNames(channels []domain.Channel) []string {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = string(channel)
+	}
+	return names
+}
+
+// FromDomainNotifications конвертирует список domain моделей в DTO
+func FromDomainNotifications(notifications []domain.Notification) []NotificationResponse {
+	response := make([]NotificationResponse, len(notifications))
+	for i := range notifications {
+		response[i] = *FromDomainNotification(&notifications[i])
+	}
+	return response
+}
+
+// FromDomainNotificationList конвертирует список с пагинацией в DTO
+func FromDomainNotificationList(notifications []domain.Notification, pagination *domain.PaginationResult) *NotificationListResponse {
+	totalPages := 0
+	if pagination.Limit > 0 {
+		totalPages = (pagination.Total + pagination.Limit - 1) / pagination.Limit
+	}
+
+	return &NotificationListResponse{
+		Notifications: FromDomainNotifications(notifications),
+		Pagination: &PaginationResult{
+			Page:       pagination.Page,
+			Limit:      pagination.Limit,
+			TotalPages: totalPages,
+			TotalItems: pagination.Total,
+		},
+	}
+}
+
+// FromDomainUserData конвертирует данные пользователя в выгрузку
+func FromDomainUserData(
+	userID int64,
+	notifications []domain.Notification,
+	settings *domain.ChannelSettings,
+	preferences *domain.NotificationPreferences,
+	subscriptions []domain.PushSubscription,
+) *UserDataExport {
+	exported := make([]NotificationExport, len(notifications))
+	for i, n := range notifications {
+		exported[i] = NotificationExport{
+			ID:          n.ID,
+			Message:     n.Message,
+			Status:      string(n.Status),
+			ScheduledAt: n.ScheduledAt,
+			SentAt:      n.SentAt,
+			CreatedAt:   n.CreatedAt,
+		}
+	}
+
+	export := &UserDataExport{
+		UserID:            userID,
+		Notifications:     exported,
+		PushSubscriptions: FromDomainPushSubscriptions(subscriptions),
+	}
+	if settings != nil {
+		export.ChannelSettings = FromDomainChannelSettings(settings)
+	}
+	if preferences != nil {
+		export.Preferences = FromDomainPreferences(preferences)
+	}
+	return export
+}
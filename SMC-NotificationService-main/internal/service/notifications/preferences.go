@@ -0,0 +1,176 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	preferencesRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+// defaultTimezone часовой пояс тихих часов, если пользователь его не указал
+const defaultTimezone = "UTC"
+
+// GetNotificationSettings возвращает настройки уведомлений пользователя
+// Пользователь без настроек получает все категории, часовой пояс UTC и отсутствие тихих часов
+func (s *Service) GetNotificationSettings(ctx context.Context, userID int64) (*models.NotificationSettingsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+
+	preferences, err := s.preferencesRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, preferencesRepo.ErrPreferencesNotFound) {
+			return models.FromDomainPreferences(&domain.NotificationPreferences{UserID: userID, Timezone: defaultTimezone}), nil
+		}
+		return nil, fmt.Errorf("%w: GetNotificationSettings - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainPreferences(preferences), nil
+}
+
+// UpdateNotificationSettings заменяет настройки уведомлений пользователя
+// От транзакционных уведомлений отписаться нельзя
+func (s *Service) UpdateNotificationSettings(ctx context.Context, userID int64, req *models.NotificationSettingsRequest) (*models.NotificationSettingsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user_id", ErrInvalidInput)
+	}
+
+	optedOut := make([]domain.Category, 0, len(req.Categories))
+	for name, enabled := range req.Categories {
+		category := domain.Category(name)
+		if !category.IsValid() {
+			return nil, fmt.Errorf("%w: unknown category %q", ErrInvalidInput, name)
+		}
+		if enabled {
+			continue
+		}
+		if !category.Optional() {
+			return nil, fmt.Errorf("%w: category %s cannot be disabled", ErrInvalidInput, name)
+		}
+		optedOut = append(optedOut, category)
+	}
+
+	var preferredChannel *domain.Channel
+	if req.PreferredChannel != nil {
+		channel := domain.Channel(strings.ToLower(strings.TrimSpace(*req.PreferredChannel)))
+		if !channel.IsValid() {
+			return nil, fmt.Errorf("%w: unknown channel %q", ErrInvalidInput, *req.PreferredChannel)
+		}
+		preferredChannel = &channel
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidInput, timezone)
+	}
+
+	quietHours, err := parseQuietHours(req.QuietHours)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.preferencesRepo.Upsert(ctx, domain.NotificationPreferences{
+		UserID:           userID,
+		OptedOut:         optedOut,
+		PreferredChannel: preferredChannel,
+		Timezone:         timezone,
+		QuietHours:       quietHours,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: UpdateNotificationSettings - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainPreferences(preferences), nil
+}
+
+// UnsubscribePreview проверяет ссылку отписки и возвращает пользователя и категорию без изменения настроек
+// GET по ссылке выполняют и превью мессенджеров, и сканеры ссылок в почте, поэтому он только показывает подтверждение
+func (s *Service) UnsubscribePreview(_ context.Context, token string) (*models.UnsubscribeResponse, error) {
+	userID, category, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UnsubscribeResponse{UserID: userID, Category: string(category)}, nil
+}
+
+// Unsubscribe отписывает пользователя от категории по токену из ссылки в сообщении
+// Повторная отписка по той же ссылке ничего не меняет
+func (s *Service) Unsubscribe(ctx context.Context, token string) (*models.UnsubscribeResponse, error) {
+	userID, category, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.preferencesRepo.OptOut(ctx, userID, category); err != nil {
+		return nil, fmt.Errorf("%w: Unsubscribe - repository error: %v", ErrInternal, err)
+	}
+
+	return &models.UnsubscribeResponse{UserID: userID, Category: string(category), Unsubscribed: true}, nil
+}
+
+// parseUnsubscribeToken проверяет подпись токена отписки; отписаться можно только от необязательной категории
+func (s *Service) parseUnsubscribeToken(token string) (int64, domain.Category, error) {
+	if s.unsubscribeTokens == nil {
+		return 0, "", ErrUnsubscribeDisabled
+	}
+
+	userID, name, err := s.unsubscribeTokens.Parse(token)
+	if err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	category := domain.Category(name)
+	if !category.Optional() {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	return userID, category, nil
+}
+
+// parseCategory проверяет категорию уведомления; не задано - transactional
+func parseCategory(name string) (domain.Category, error) {
+	if name == "" {
+		return domain.CategoryTransactional, nil
+	}
+	category := domain.Category(strings.ToLower(strings.TrimSpace(name)))
+	if !category.IsValid() {
+		return "", fmt.Errorf("%w: unknown category %q", ErrInvalidInput, name)
+	}
+	return category, nil
+}
+
+// parseQuietHours разбирает тихие часы HH:MM; null отключает тихие часы
+func parseQuietHours(quietHours *models.QuietHours) (*domain.QuietHours, error) {
+	if quietHours == nil {
+		return nil, nil
+	}
+
+	start, err := parseClock(quietHours.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w: quiet_hours.start must be HH:MM", ErrInvalidInput)
+	}
+	end, err := parseClock(quietHours.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w: quiet_hours.end must be HH:MM", ErrInvalidInput)
+	}
+	if start == end {
+		return nil, fmt.Errorf("%w: quiet_hours.start and quiet_hours.end must differ", ErrInvalidInput)
+	}
+
+	return &domain.QuietHours{Start: start, End: end}, nil
+}
+
+// parseClock переводит HH:MM в минуты от полуночи
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
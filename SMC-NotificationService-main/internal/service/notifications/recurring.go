@@ -0,0 +1,189 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/pkg/recurrence"
+)
+
+const (
+	// MinRecurringInterval минимальный промежуток между повторениями серии
+	MinRecurringInterval = time.Hour
+
+	// upcomingOccurrences количество ближайших повторений в ответе
+	upcomingOccurrences = 5
+
+	// intervalCheckOccurrences количество первых повторений, на которых проверяется MinRecurringInterval
+	intervalCheckOccurrences = 20
+)
+
+// CreateRecurring создает серию повторяющихся уведомлений по расписанию cron или RRULE
+// Повторения создаются фоновым materializer заранее, с общим span_id серии: отдельное повторение
+// отменяется как обычное уведомление, вся серия - отменой пакета по span_id
+func (s *Service) CreateRecurring(ctx context.Context, req *models.CreateRecurringNotificationRequest) (*models.RecurringNotificationResponse, error) {
+	channels, err := s.notificationChannels(req.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := parseCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := uniqueUserIDs(req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, expression, err := scheduleExpression(req.Cron, req.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" || timezone == "Local" {
+		return nil, fmt.Errorf("%w: timezone is required", ErrInvalidInput)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidInput, timezone)
+	}
+
+	now := time.Now()
+	startAt := now
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	}
+	startAt = startAt.Truncate(time.Second).In(loc)
+	if req.EndAt != nil && !req.EndAt.After(startAt) {
+		return nil, fmt.Errorf("%w: end_at must be after start_at", ErrInvalidInput)
+	}
+
+	schedule, err := recurrence.Parse(string(kind), expression, startAt, req.EndAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	next, err := validateOccurrences(schedule, now)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.resolveContent(ctx, req.Message, req.Template, req.Locale, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make([]int64, 0)
+	if _, missing, err := s.userServiceClient.GetUsersBatch(ctx, userIDs); err == nil && len(missing) > 0 {
+		userIDs, skipped = excludeUserIDs(userIDs, missing)
+		if len(userIDs) == 0 {
+			return nil, ErrNoRecipients
+		}
+	}
+
+	spanID, err := newSpanID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateRecurring - generate span_id: %v", ErrInternal, err)
+	}
+
+	series, err := s.seriesRepo.CreateSeries(ctx, domain.CreateSeriesInput{
+		SpanID:     spanID,
+		UserIDs:    userIDs,
+		Message:    content.message,
+		ParseMode:  content.parseMode,
+		Channels:   channels,
+		Template:   content.template,
+		Category:   category,
+		Kind:       kind,
+		Expression: expression,
+		Timezone:   timezone,
+		StartAt:    startAt,
+		EndAt:      req.EndAt,
+		// Повторение ровно в start_at тоже создаётся
+		MaterializedUntil: startAt.Add(-time.Second),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateRecurring - repository error: %v", ErrInternal, err)
+	}
+
+	response := models.FromDomainSeries(series, next)
+	response.SkippedUserIDs = skipped
+	return response, nil
+}
+
+// GetRecurring возвращает серию повторяющихся уведомлений и её ближайшие повторения
+func (s *Service) GetRecurring(ctx context.Context, spanID string) (*models.RecurringNotificationResponse, error) {
+	if !isUUID(spanID) {
+		return nil, fmt.Errorf("%w: span_id must be a UUID", ErrInvalidInput)
+	}
+
+	series, err := s.seriesRepo.GetSeriesBySpanID(ctx, spanID)
+	if err != nil {
+		if errors.Is(err, notificationRepo.ErrSeriesNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("%w: GetRecurring - repository error: %v", ErrInternal, err)
+	}
+
+	next := make([]time.Time, 0)
+	if series.Status == domain.SeriesActive {
+		schedule, err := seriesSchedule(series)
+		if err != nil {
+			return nil, fmt.Errorf("%w: GetRecurring - parse schedule: %v", ErrInternal, err)
+		}
+		next = recurrence.Upcoming(schedule, time.Now(), upcomingOccurrences)
+	}
+
+	return models.FromDomainSeries(series, next), nil
+}
+
+// scheduleExpression проверяет, что задан ровно один формат расписания
+func scheduleExpression(cron, rrule *string) (domain.ScheduleKind, string, error) {
+	hasCron := cron != nil && strings.TrimSpace(*cron) != ""
+	hasRRule := rrule != nil && strings.TrimSpace(*rrule) != ""
+
+	switch {
+	case hasCron && hasRRule:
+		return "", "", fmt.Errorf("%w: cron and rrule are mutually exclusive", ErrInvalidInput)
+	case hasCron:
+		return domain.ScheduleCron, strings.TrimSpace(*cron), nil
+	case hasRRule:
+		return domain.ScheduleRRule, strings.TrimSpace(*rrule), nil
+	default:
+		return "", "", fmt.Errorf("%w: cron or rrule is required", ErrInvalidInput)
+	}
+}
+
+// validateOccurrences проверяет, что у расписания есть будущие повторения и они не чаще MinRecurringInterval
+// Возвращает ближайшие повторения для ответа
+func validateOccurrences(schedule recurrence.Schedule, now time.Time) ([]time.Time, error) {
+	occurrences := recurrence.Upcoming(schedule, now, intervalCheckOccurrences)
+	if len(occurrences) == 0 {
+		return nil, fmt.Errorf("%w: schedule has no future occurrences", ErrInvalidInput)
+	}
+
+	for i := 1; i < len(occurrences); i++ {
+		if occurrences[i].Sub(occurrences[i-1]) < MinRecurringInterval {
+			return nil, fmt.Errorf("%w: occurrences must be at least %s apart", ErrInvalidInput, MinRecurringInterval)
+		}
+	}
+
+	return occurrences[:min(len(occurrences), upcomingOccurrences)], nil
+}
+
+// seriesSchedule разбирает расписание сохранённой серии
+func seriesSchedule(series *domain.Series) (recurrence.Schedule, error) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return recurrence.Parse(string(series.Kind), series.Expression, series.StartAt.In(loc), series.EndAt)
+}
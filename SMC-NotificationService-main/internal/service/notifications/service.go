@@ -0,0 +1,441 @@
+package notifications
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	channelSettingsRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/channelsettings"
+	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	preferencesRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+const (
+	// MaxMessageLength ограничение Telegram на длину текста сообщения
+	MaxMessageLength = 4096
+
+	// MaxBatchRecipients максимальное количество получателей в одном пакете
+	MaxBatchRecipients = 1000
+
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type Service struct {
+	notificationRepo  NotificationRepository
+	seriesRepo        SeriesRepository
+	settingsRepo      ChannelSettingsRepository
+	preferencesRepo   PreferencesRepository
+	subscriptionRepo  PushSubscriptionRepository
+	conversationRepo  ConversationRepository
+	userServiceClient UserServiceClient
+	templateRenderer  TemplateRenderer
+	unsubscribeTokens UnsubscribeTokens
+	completion        CompletionEstimator
+	defaultChannels   []domain.Channel
+}
+
+func NewService(
+	notificationRepo NotificationRepository,
+	seriesRepo SeriesRepository,
+	settingsRepo ChannelSettingsRepository,
+	preferencesRepo PreferencesRepository,
+	subscriptionRepo PushSubscriptionRepository,
+	conversationRepo ConversationRepository,
+	userServiceClient UserServiceClient,
+	templateRenderer TemplateRenderer,
+	unsubscribeTokens UnsubscribeTokens,
+	completion CompletionEstimator,
+	defaultChannels []domain.Channel,
+) *Service {
+	return &Service{
+		notificationRepo:  notificationRepo,
+		seriesRepo:        seriesRepo,
+		settingsRepo:      settingsRepo,
+		preferencesRepo:   preferencesRepo,
+		subscriptionRepo:  subscriptionRepo,
+		conversationRepo:  conversationRepo,
+		userServiceClient: userServiceClient,
+		templateRenderer:  templateRenderer,
+		unsubscribeTokens: unsubscribeTokens,
+		completion:        completion,
+		defaultChannels:   defaultChannels,
+	}
+}
+
+// Create создает уведомление одному пользователю
+// Текст передаётся в message или рендерится из шаблона template с параметрами params
+// Получатель проверяется в UserService; при недоступности UserService уведомление создаётся без проверки
+func (s *Service) Create(ctx context.Context, req *models.CreateNotificationRequest) (*models.NotificationResponse, error) {
+	if req.UserID <= 0 {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidInput)
+	}
+	channels, err := s.notificationChannels(req.Channels)
+	if err != nil {
+		return nil, err
+	}
+	category, err := parseCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.resolveContent(ctx, req.Message, req.Template, req.Locale, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userServiceClient.GetUser(ctx, req.UserID); errors.Is(err, userservice.ErrUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+
+	notification, err := s.notificationRepo.Create(ctx, domain.CreateNotificationInput{
+		UserID:      req.UserID,
+		Message:     content.message,
+		ParseMode:   content.parseMode,
+		Channels:    channels,
+		Template:    content.template,
+		Category:    category,
+		ScheduledAt: req.ScheduledAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: Create - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainNotification(notification), nil
+}
+
+// CreateBatch создает одно уведомление нескольким пользователям с общим span_id
+// Пользователи, не найденные в UserService, пропускаются и возвращаются в SkippedUserIDs
+// Шаблон рендерится один раз: параметры общие для всех получателей
+// Для пакетов с каналом Telegram возвращается оценка окончания отправки по лимиту скорости бота
+func (s *Service) CreateBatch(ctx context.Context, req *models.CreateBatchNotificationRequest) (*models.BatchNotificationResponse, error) {
+	channels, err := s.notificationChannels(req.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := parseCategory(req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := uniqueUserIDs(req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.resolveContent(ctx, req.Message, req.Template, req.Locale, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make([]int64, 0)
+	if _, missing, err := s.userServiceClient.GetUsersBatch(ctx, userIDs); err == nil && len(missing) > 0 {
+		userIDs, skipped = excludeUserIDs(userIDs, missing)
+		if len(userIDs) == 0 {
+			return nil, ErrNoRecipients
+		}
+	}
+
+	spanID, err := newSpanID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateBatch - generate span_id: %v", ErrInternal, err)
+	}
+
+	inputs := make([]domain.CreateNotificationInput, len(userIDs))
+	for i, userID := range userIDs {
+		inputs[i] = domain.CreateNotificationInput{
+			UserID:      userID,
+			Message:     content.message,
+			ParseMode:   content.parseMode,
+			Channels:    channels,
+			Template:    content.template,
+			Category:    category,
+			SpanID:      &spanID,
+			ScheduledAt: req.ScheduledAt,
+		}
+	}
+
+	notifications, err := s.notificationRepo.CreateBatch(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: CreateBatch - repository error: %v", ErrInternal, err)
+	}
+
+	response := &models.BatchNotificationResponse{
+		SpanID:         spanID,
+		Notifications:  models.FromDomainNotifications(notifications),
+		SkippedUserIDs: skipped,
+	}
+	if slices.Contains(channels, domain.ChannelTelegram) {
+		start := time.Now()
+		if req.ScheduledAt != nil && req.ScheduledAt.After(start) {
+			start = *req.ScheduledAt
+		}
+		// Оценка справочная: без неё пакет всё равно создан и будет отправлен
+		if estimatedAt, err := s.completion.EstimateCompletion(ctx, len(notifications), start); err == nil {
+			response.EstimatedCompletionAt = &estimatedAt
+		}
+	}
+
+	return response, nil
+}
+
+// Get возвращает уведомление вместе с попытками доставки по каналам
+func (s *Service) Get(ctx context.Context, id int64) (*models.NotificationResponse, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, notificationRepo.ErrNotificationNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - repository error: %v", ErrInternal, err)
+	}
+
+	deliveries, err := s.notificationRepo.ListDeliveries(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: Get - list deliveries: %v", ErrInternal, err)
+	}
+	notification.Deliveries = deliveries
+
+	return models.FromDomainNotification(notification), nil
+}
+
+// List возвращает уведомления по фильтру, новые первыми
+func (s *Service) List(ctx context.Context, req *models.NotificationFilterRequest) (*models.NotificationListResponse, error) {
+	filter := domain.NotificationFilter{
+		UserID: req.UserID,
+		SpanID: req.SpanID,
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+
+	if req.Status != nil {
+		status := domain.NotificationStatus(*req.Status)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, *req.Status)
+		}
+		filter.Status = &status
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	notifications, pagination, err := s.notificationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainNotificationList(notifications, pagination), nil
+}
+
+// Cancel отменяет уведомление, которое ещё не отправлено
+func (s *Service) Cancel(ctx context.Context, id int64) (*models.NotificationResponse, error) {
+	notification, err := s.notificationRepo.Cancel(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, notificationRepo.ErrNotificationNotFound):
+			return nil, ErrNotificationNotFound
+		case errors.Is(err, notificationRepo.ErrNotPending):
+			return nil, ErrNotPending
+		default:
+			return nil, fmt.Errorf("%w: Cancel - repository error: %v", ErrInternal, err)
+		}
+	}
+
+	return models.FromDomainNotification(notification), nil
+}
+
+// CancelBatch отменяет все ещё не отправленные уведомления пакета
+// Если span_id принадлежит серии повторяющихся уведомлений, серия тоже отменяется
+// Таймеры scheduler не снимаются: при срабатывании отменённое уведомление пропускается
+func (s *Service) CancelBatch(ctx context.Context, spanID string) (*models.CancelBatchResponse, error) {
+	if !isUUID(spanID) {
+		return nil, fmt.Errorf("%w: span_id must be a UUID", ErrInvalidInput)
+	}
+
+	// Серия отменяется первой: после этого materializer не создаст новых повторений,
+	// а уже созданные отменит следующий запрос
+	if err := s.seriesRepo.CancelSeries(ctx, spanID); err != nil && !errors.Is(err, notificationRepo.ErrSeriesNotFound) {
+		return nil, fmt.Errorf("%w: CancelBatch - cancel series: %v", ErrInternal, err)
+	}
+
+	cancelled, err := s.notificationRepo.CancelBySpanID(ctx, spanID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: CancelBatch - repository error: %v", ErrInternal, err)
+	}
+
+	return &models.CancelBatchResponse{
+		SpanID:    spanID,
+		Cancelled: cancelled,
+	}, nil
+}
+
+// ExportUserData возвращает уведомления, настройки каналов и уведомлений, подписки пользователя для выгрузки персональных данных
+func (s *Service) ExportUserData(ctx context.Context, userID int64) (*models.UserDataExport, error) {
+	notifications, err := s.notificationRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ExportUserData - repository error: %v", ErrInternal, err)
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, channelSettingsRepo.ErrSettingsNotFound) {
+		return nil, fmt.Errorf("%w: ExportUserData - get channel settings: %v", ErrInternal, err)
+	}
+
+	preferences, err := s.preferencesRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, preferencesRepo.ErrPreferencesNotFound) {
+		return nil, fmt.Errorf("%w: ExportUserData - get notification preferences: %v", ErrInternal, err)
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ExportUserData - list push subscriptions: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainUserData(userID, notifications, settings, preferences, subscriptions), nil
+}
+
+// EraseUserData удаляет все уведомления пользователя, включая ещё не отправленные, настройки каналов и уведомлений, подписки
+// и диалоги с ботом
+// Пользователь исключается из получателей серий до удаления уведомлений, чтобы materializer не создал новые
+func (s *Service) EraseUserData(ctx context.Context, userID int64) (*models.UserDataErasure, error) {
+	seriesUpdated, err := s.seriesRepo.RemoveUserFromSeries(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - remove user from series: %v", ErrInternal, err)
+	}
+
+	deleted, err := s.notificationRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - repository error: %v", ErrInternal, err)
+	}
+
+	settingsDeleted, err := s.settingsRepo.Delete(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - delete channel settings: %v", ErrInternal, err)
+	}
+
+	preferencesDeleted, err := s.preferencesRepo.Delete(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - delete notification preferences: %v", ErrInternal, err)
+	}
+
+	subscriptionsDeleted, err := s.subscriptionRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - delete push subscriptions: %v", ErrInternal, err)
+	}
+
+	conversationsDeleted, err := s.conversationRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - delete bot conversations: %v", ErrInternal, err)
+	}
+
+	return &models.UserDataErasure{
+		UserID:                   userID,
+		NotificationsDeleted:     deleted,
+		ChannelSettingsDeleted:   settingsDeleted > 0,
+		PushSubscriptionsDeleted: subscriptionsDeleted,
+		PreferencesDeleted:       preferencesDeleted > 0,
+		ConversationsDeleted:     conversationsDeleted,
+		SeriesUpdated:            seriesUpdated,
+	}, nil
+}
+
+// validateMessage проверяет текст сообщения и обрезает пробелы по краям
+func validateMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", fmt.Errorf("%w: message is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(message) > MaxMessageLength {
+		return "", fmt.Errorf("%w: message must not exceed %d characters", ErrInvalidInput, MaxMessageLength)
+	}
+	return message, nil
+}
+
+// uniqueUserIDs проверяет список получателей и убирает дубликаты с сохранением порядка
+func uniqueUserIDs(userIDs []int64) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("%w: user_ids is required", ErrInvalidInput)
+	}
+
+	seen := make(map[int64]bool, len(userIDs))
+	unique := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: invalid user_id %d", ErrInvalidInput, id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	if len(unique) > MaxBatchRecipients {
+		return nil, fmt.Errorf("%w: at most %d recipients allowed", ErrInvalidInput, MaxBatchRecipients)
+	}
+	return unique, nil
+}
+
+// excludeUserIDs делит получателей на оставшихся и исключённых
+func excludeUserIDs(userIDs, excluded []int64) (kept, removed []int64) {
+	excludedSet := make(map[int64]bool, len(excluded))
+	for _, id := range excluded {
+		excludedSet[id] = true
+	}
+
+	kept = make([]int64, 0, len(userIDs))
+	removed = make([]int64, 0, len(excluded))
+	for _, id := range userIDs {
+		if excludedSet[id] {
+			removed = append(removed, id)
+		} else {
+			kept = append(kept, id)
+		}
+	}
+	return kept, removed
+}
+
+// newSpanID генерирует UUID v4 для пакета уведомлений
+func newSpanID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// isUUID проверяет формат UUID (8-4-4-4-12 шестнадцатеричных символов)
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for i, r := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrBotBlocked возвращается, когда пользователь заблокировал бота или не начинал с ним диалог
+	ErrBotBlocked = errors.New("telegram: bot was blocked by the user")
+
+	// ErrChatNotFound возвращается, когда чат с пользователем не найден
+	ErrChatNotFound = errors.New("telegram: chat not found")
+
+	// ErrRateLimited возвращается, когда Telegram ограничил частоту запросов
+	ErrRateLimited = errors.New("telegram: too many requests")
+
+	// ErrBadRequest возвращается, когда Telegram отклонил сообщение: неверная разметка, слишком длинный текст
+	ErrBadRequest = errors.New("telegram: bad request")
+
+	// ErrSendMessage возвращается при остальных ошибках отправки сообщения
+	ErrSendMessage = errors.New("telegram: failed to send message")
+
+	// ErrWebhook возвращается при ошибке установки или удаления webhook
+	ErrWebhook = errors.New("telegram: webhook request failed")
+)
+
+// RateLimitError ответ 429 с паузой, которую Telegram требует выдержать перед повтором
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
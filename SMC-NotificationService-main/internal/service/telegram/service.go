@@ -0,0 +1,165 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// longPollingTimeout таймаут long polling запроса getUpdates (секунды)
+const longPollingTimeout = 60
+
+// Service обёртка над Telegram Bot API
+type Service struct {
+	bot *tgbotapi.BotAPI
+}
+
+// NewService создает новый экземпляр Telegram сервиса
+func NewService(bot *tgbotapi.BotAPI) *Service {
+	return &Service{bot: bot}
+}
+
+// SendMessage отправляет текстовое сообщение в личный чат пользователя
+// Для личных чатов chat_id совпадает с tg_user_id
+func (s *Service) SendMessage(chatID int64, text string) error {
+	if _, err := s.bot.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// URLButton кнопка-ссылка под сообщением
+type URLButton struct {
+	Text string
+	URL  string
+}
+
+// SendFormattedMessage отправляет сообщение с разметкой MarkdownV2 или HTML и кнопками-ссылками в один ряд
+// Пустой parseMode отправляет текст как есть; disablePreview отключает превью ссылок, чтобы Telegram не открывал их сам
+func (s *Service) SendFormattedMessage(chatID int64, text string, parseMode domain.ParseMode, disablePreview bool, buttons ...URLButton) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = string(parseMode)
+	msg.DisableWebPagePreview = disablePreview
+	if len(buttons) > 0 {
+		row := make([]tgbotapi.InlineKeyboardButton, len(buttons))
+		for i, button := range buttons {
+			row[i] = tgbotapi.NewInlineKeyboardButtonURL(button.Text, button.URL)
+		}
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	}
+	if _, err := s.bot.Send(msg); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// CallbackButton кнопка, нажатие на которую присылает боту callback_query с Data
+// Telegram ограничивает Data 64 байтами
+type CallbackButton struct {
+	Text string
+	Data string
+}
+
+// SendInlineKeyboard отправляет сообщение с inline клавиатурой; каждый элемент rows - отдельный ряд кнопок
+func (s *Service) SendInlineKeyboard(chatID int64, text string, rows [][]CallbackButton) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	keyboard := make([][]tgbotapi.InlineKeyboardButton, 0, len(rows))
+	for _, row := range rows {
+		buttons := make([]tgbotapi.InlineKeyboardButton, len(row))
+		for i, button := range row {
+			buttons[i] = tgbotapi.NewInlineKeyboardButtonData(button.Text, button.Data)
+		}
+		keyboard = append(keyboard, buttons)
+	}
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(keyboard...)
+	if _, err := s.bot.Send(msg); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// RequestLocation отправляет сообщение с одноразовой кнопкой отправки геопозиции
+func (s *Service) RequestLocation(chatID int64, text, buttonText string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonLocation(buttonText)))
+	keyboard.OneTimeKeyboard = true
+	keyboard.ResizeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	if _, err := s.bot.Send(msg); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// AnswerCallbackQuery подтверждает нажатие inline кнопки; без ответа клиент Telegram показывает загрузку
+// Непустой text показывается пользователю всплывающим уведомлением
+func (s *Service) AnswerCallbackQuery(callbackQueryID, text string) error {
+	if _, err := s.bot.Request(tgbotapi.NewCallback(callbackQueryID, text)); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// SetWebhook регистрирует адрес, на который Telegram будет присылать обновления
+// secretToken Telegram передаёт в заголовке X-Telegram-Bot-Api-Secret-Token каждого запроса на webhook
+func (s *Service) SetWebhook(webhookURL, secretToken string) error {
+	if _, err := url.ParseRequestURI(webhookURL); err != nil {
+		return fmt.Errorf("%w: invalid webhook url: %v", ErrWebhook, err)
+	}
+
+	// WebhookConfig библиотеки не поддерживает secret_token, поэтому параметры передаются напрямую
+	params := tgbotapi.Params{"url": webhookURL}
+	params.AddNonEmpty("secret_token", secretToken)
+	if _, err := s.bot.MakeRequest("setWebhook", params); err != nil {
+		return fmt.Errorf("%w: setWebhook: %v", ErrWebhook, err)
+	}
+	return nil
+}
+
+// DeleteWebhook удаляет webhook; без этого getUpdates возвращает ошибку
+func (s *Service) DeleteWebhook() error {
+	if _, err := s.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		return fmt.Errorf("%w: deleteWebhook: %v", ErrWebhook, err)
+	}
+	return nil
+}
+
+// GetUpdatesChan запускает long polling начиная с указанного offset
+func (s *Service) GetUpdatesChan(offset int) tgbotapi.UpdatesChannel {
+	config := tgbotapi.NewUpdate(offset)
+	config.Timeout = longPollingTimeout
+	return s.bot.GetUpdatesChan(config)
+}
+
+// StopReceivingUpdates останавливает long polling
+func (s *Service) StopReceivingUpdates() {
+	s.bot.StopReceivingUpdates()
+}
+
+// classifyError приводит ошибку Bot API к ошибкам пакета
+func classifyError(err error) error {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("%w: %v", ErrSendMessage, err)
+	}
+
+	switch {
+	case apiErr.Code == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrBotBlocked, apiErr.Message)
+	case apiErr.Code == http.StatusBadRequest && strings.Contains(strings.ToLower(apiErr.Message), "chat not found"):
+		return fmt.Errorf("%w: %s", ErrChatNotFound, apiErr.Message)
+	case apiErr.Code == http.StatusTooManyRequests:
+		return &RateLimitError{RetryAfter: time.Duration(apiErr.RetryAfter) * time.Second}
+	case apiErr.Code == http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrBadRequest, apiErr.Message)
+	default:
+		return fmt.Errorf("%w: %d %s", ErrSendMessage, apiErr.Code, apiErr.Message)
+	}
+}
@@ -0,0 +1,16 @@
+package templates
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// TemplateRepository интерфейс репозитория версий шаблонов
+type TemplateRepository interface {
+	CreateVersion(ctx context.Context, input domain.CreateTemplateInput) (*domain.Template, error)
+	GetLatest(ctx context.Context, name string, locale domain.Locale) (*domain.Template, error)
+	ListLatest(ctx context.Context) ([]domain.Template, error)
+	ListVersions(ctx context.Context, name string) ([]domain.Template, error)
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,26 @@
+package templates
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound возвращается, когда шаблона нет ни на запрошенном языке, ни на языке по умолчанию
+	ErrTemplateNotFound = errors.New("template not found")
+
+	// ErrInvalidTemplate возвращается, когда текст шаблона не разбирается text/template
+	ErrInvalidTemplate = errors.New("invalid template")
+
+	// ErrRender возвращается, когда параметры не подходят к шаблону
+	ErrRender = errors.New("failed to render template")
+
+	// ErrVersionConflict возвращается, когда версия шаблона сохранена параллельным запросом
+	ErrVersionConflict = errors.New("template was modified concurrently, retry the request")
+
+	// ErrOnlySuperuser возвращается, когда операцию может выполнить только superuser
+	ErrOnlySuperuser = errors.New("access denied: only superuser can manage templates")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("invalid input data")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service: internal error")
+)
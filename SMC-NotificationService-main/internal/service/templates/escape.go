@@ -0,0 +1,123 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	escapeFunc = "escape"
+	rawFunc    = "raw"
+)
+
+// markdownV2Special символы, которые Telegram требует экранировать в тексте MarkdownV2
+// https://core.telegram.org/bots/api#markdownv2-style
+const markdownV2Special = "\\_*[]()~`>#+-=|{}.!"
+
+// rawText значение, которое вставляется в текст без экранирования
+type rawText string
+
+// compile разбирает шаблон и добавляет экранирование ко всем выводимым значениям
+// Разметка в самом тексте шаблона не меняется, экранируются только подставляемые параметры:
+// {{.name}} с name = "A_B" в MarkdownV2 выводит "A\_B". {{raw .x}} отключает экранирование
+func compile(name, body string, mode domain.ParseMode) (*template.Template, error) {
+	t, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			escapeFunc: escaper(mode),
+			rawFunc:    func(v interface{}) rawText { return rawText(fmt.Sprint(v)) },
+		}).
+		Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tpl := range t.Templates() {
+		if tpl.Tree != nil && tpl.Tree.Root != nil {
+			escapeList(tpl.Tree.Root)
+		}
+	}
+	return t, nil
+}
+
+// escapeList добавляет вызов escape в конец каждого выводящего действия
+func escapeList(list *parse.ListNode) {
+	if list == nil {
+		return
+	}
+
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parse.ActionNode:
+			escapePipe(n.Pipe)
+		case *parse.IfNode:
+			escapeList(n.List)
+			escapeList(n.ElseList)
+		case *parse.RangeNode:
+			escapeList(n.List)
+			escapeList(n.ElseList)
+		case *parse.WithNode:
+			escapeList(n.List)
+			escapeList(n.ElseList)
+		case *parse.ListNode:
+			escapeList(n)
+		}
+	}
+}
+
+// escapePipe дописывает | escape к конвейеру; объявления переменных ничего не выводят
+func escapePipe(pipe *parse.PipeNode) {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) == 0 {
+		return
+	}
+
+	last := pipe.Cmds[len(pipe.Cmds)-1]
+	if len(last.Args) > 0 {
+		if ident, ok := last.Args[0].(*parse.IdentifierNode); ok && ident.Ident == escapeFunc {
+			return
+		}
+	}
+
+	pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      pipe.Pos,
+		Args:     []parse.Node{parse.NewIdentifier(escapeFunc).SetPos(pipe.Pos)},
+	})
+}
+
+// escaper возвращает функцию экранирования значения для режима разметки
+func escaper(mode domain.ParseMode) func(v interface{}) string {
+	return func(v interface{}) string {
+		if raw, ok := v.(rawText); ok {
+			return string(raw)
+		}
+
+		text := fmt.Sprint(v)
+		switch mode {
+		case domain.ParseModeMarkdownV2:
+			return escapeMarkdownV2(text)
+		case domain.ParseModeHTML:
+			return html.EscapeString(text)
+		default:
+			return text
+		}
+	}
+}
+
+// escapeMarkdownV2 экранирует обратным слешем специальные символы MarkdownV2
+func escapeMarkdownV2(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if strings.ContainsRune(markdownV2Special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// SaveTemplateRequest запрос на сохранение новой версии варианта шаблона
+type SaveTemplateRequest struct {
+	ParseMode string `json:"parse_mode"` // "", MarkdownV2 или HTML
+	Body      string `json:"body"`       // text/template, например "Запись на {{.time}} подтверждена"
+}
+
+// PreviewTemplateRequest запрос на рендеринг шаблона без отправки
+type PreviewTemplateRequest struct {
+	Locale    *string                `json:"locale,omitempty"` // не задано - язык по умолчанию
+	Params    map[string]interface{} `json:"params"`
+	Body      *string                `json:"body,omitempty"`       // черновик вместо сохранённой версии
+	ParseMode *string                `json:"parse_mode,omitempty"` // разметка черновика
+}
+
+// TemplateResponse версия варианта шаблона
+type TemplateResponse struct {
+	Name      string    `json:"name"`
+	Locale    string    `json:"locale"`
+	Version   int       `json:"version"`
+	ParseMode string    `json:"parse_mode"`
+	Body      string    `json:"body"`
+	CreatedBy int64     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TemplateListResponse список вариантов шаблонов
+type TemplateListResponse struct {
+	Templates []TemplateResponse `json:"templates"`
+}
+
+// PreviewResponse результат рендеринга шаблона
+type PreviewResponse struct {
+	Name      string `json:"name"`
+	Locale    string `json:"locale"`
+	Version   int    `json:"version,omitempty"` // 0 - черновик
+	ParseMode string `json:"parse_mode"`
+	Text      string `json:"text"`       // текст для Telegram
+	PlainText string `json:"plain_text"` // текст для email, SMS и Web Push
+}
+
+// FromDomainTemplate конвертирует domain модель в DTO
+func FromDomainTemplate(t *domain.Template) *TemplateResponse {
+	return &TemplateResponse{
+		Name:      t.Name,
+		Locale:    string(t.Locale),
+		Version:   t.Version,
+		ParseMode: string(t.ParseMode),
+		Body:      t.Body,
+		CreatedBy: t.CreatedBy,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// FromDomainTemplates конвертирует список domain моделей в DTO
+func FromDomainTemplates(templates []domain.Template) *TemplateListResponse {
+	response := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		response[i] = *FromDomainTemplate(&templates[i])
+	}
+	return &TemplateListResponse{Templates: response}
+}
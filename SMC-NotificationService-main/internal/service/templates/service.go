@@ -0,0 +1,315 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/m04kA/SMC-NotificationService/internal/channels"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	templateRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/template"
+	"github.com/m04kA/SMC-NotificationService/internal/service"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+const (
+	// MaxTextLength ограничение Telegram на длину текста сообщения после подстановки параметров
+	MaxTextLength = 4096
+
+	maxBodyLength = 8192
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_.-]{1,100}$`)
+
+// Service реестр шаблонов уведомлений
+type Service struct {
+	templateRepo TemplateRepository
+
+	// Версии неизменяемы, поэтому разобранный шаблон кешируется по id версии
+	mu       sync.RWMutex
+	compiled map[int64]*template.Template
+}
+
+func NewService(templateRepo TemplateRepository) *Service {
+	return &Service{
+		templateRepo: templateRepo,
+		compiled:     make(map[int64]*template.Template),
+	}
+}
+
+// List возвращает последние версии всех вариантов шаблонов
+func (s *Service) List(ctx context.Context, userRole string) (*models.TemplateListResponse, error) {
+	if userRole != service.RoleSuperuser {
+		return nil, ErrOnlySuperuser
+	}
+
+	templates, err := s.templateRepo.ListLatest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainTemplates(templates), nil
+}
+
+// ListVersions возвращает историю версий шаблона по всем языкам
+func (s *Service) ListVersions(ctx context.Context, name string, userRole string) (*models.TemplateListResponse, error) {
+	if userRole != service.RoleSuperuser {
+		return nil, ErrOnlySuperuser
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.ListVersions(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListVersions - repository error: %v", ErrInternal, err)
+	}
+	if len(templates) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+
+	return models.FromDomainTemplates(templates), nil
+}
+
+// Save сохраняет новую версию варианта шаблона; уже созданные уведомления не меняются
+func (s *Service) Save(ctx context.Context, name, locale string, userID int64, userRole string, req *models.SaveTemplateRequest) (*models.TemplateResponse, error) {
+	if userRole != service.RoleSuperuser {
+		return nil, ErrOnlySuperuser
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	loc, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := parseParseMode(req.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(req.Body) > maxBodyLength {
+		return nil, fmt.Errorf("%w: body must not exceed %d characters", ErrInvalidInput, maxBodyLength)
+	}
+	if _, err := compile(name, req.Body, mode); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	saved, err := s.templateRepo.CreateVersion(ctx, domain.CreateTemplateInput{
+		Name:      name,
+		Locale:    loc,
+		ParseMode: mode,
+		Body:      req.Body,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		if errors.Is(err, templateRepo.ErrVersionConflict) {
+			return nil, ErrVersionConflict
+		}
+		return nil, fmt.Errorf("%w: Save - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainTemplate(saved), nil
+}
+
+// Delete удаляет шаблон со всеми версиями и языками
+// Уведомления, уже созданные из шаблона, сохраняют отрендеренный текст
+func (s *Service) Delete(ctx context.Context, name string, userRole string) error {
+	if userRole != service.RoleSuperuser {
+		return ErrOnlySuperuser
+	}
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(ctx, name); err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return ErrTemplateNotFound
+		}
+		return fmt.Errorf("%w: Delete - repository error: %v", ErrInternal, err)
+	}
+	return nil
+}
+
+// Preview рендерит последнюю версию шаблона или черновик из запроса без отправки
+func (s *Service) Preview(ctx context.Context, name string, req *models.PreviewTemplateRequest) (*models.PreviewResponse, error) {
+	locale := ""
+	if req.Locale != nil {
+		locale = *req.Locale
+	}
+
+	var (
+		rendered *domain.RenderedTemplate
+		err      error
+	)
+	if req.Body != nil {
+		rendered, err = s.renderDraft(name, locale, req)
+	} else {
+		rendered, err = s.Render(ctx, name, locale, req.Params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message := channels.Message{Text: rendered.Text, ParseMode: rendered.ParseMode}
+	return &models.PreviewResponse{
+		Name:      rendered.Name,
+		Locale:    string(rendered.Locale),
+		Version:   rendered.Version,
+		ParseMode: string(rendered.ParseMode),
+		Text:      rendered.Text,
+		PlainText: message.PlainText(),
+	}, nil
+}
+
+// Render подставляет параметры в последнюю версию шаблона
+// Если варианта на запрошенном языке нет, используется язык по умолчанию
+func (s *Service) Render(ctx context.Context, name, locale string, params map[string]interface{}) (*domain.RenderedTemplate, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	loc := domain.DefaultLocale
+	if locale != "" {
+		parsed, err := parseLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		loc = parsed
+	}
+
+	latest, err := s.templateRepo.GetLatest(ctx, name, loc)
+	if errors.Is(err, templateRepo.ErrTemplateNotFound) && loc != domain.DefaultLocale {
+		latest, err = s.templateRepo.GetLatest(ctx, name, domain.DefaultLocale)
+	}
+	if err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("%w: Render - repository error: %v", ErrInternal, err)
+	}
+
+	compiled, err := s.compiledVersion(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := execute(compiled, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RenderedTemplate{
+		Name:      latest.Name,
+		Locale:    latest.Locale,
+		Version:   latest.Version,
+		ParseMode: latest.ParseMode,
+		Text:      text,
+	}, nil
+}
+
+// renderDraft рендерит несохранённый текст шаблона
+func (s *Service) renderDraft(name, locale string, req *models.PreviewTemplateRequest) (*domain.RenderedTemplate, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	loc := domain.DefaultLocale
+	if locale != "" {
+		parsed, err := parseLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		loc = parsed
+	}
+	mode := domain.ParseModePlain
+	if req.ParseMode != nil {
+		parsed, err := parseParseMode(*req.ParseMode)
+		if err != nil {
+			return nil, err
+		}
+		mode = parsed
+	}
+
+	compiled, err := compile(name, *req.Body, mode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	text, err := execute(compiled, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RenderedTemplate{Name: name, Locale: loc, ParseMode: mode, Text: text}, nil
+}
+
+// compiledVersion возвращает разобранную версию шаблона из кеша
+func (s *Service) compiledVersion(t *domain.Template) (*template.Template, error) {
+	s.mu.RLock()
+	compiled, ok := s.compiled[t.ID]
+	s.mu.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	compiled, err := compile(t.Name, t.Body, t.ParseMode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: compile %s/%s v%d: %v", ErrInternal, t.Name, t.Locale, t.Version, err)
+	}
+
+	s.mu.Lock()
+	s.compiled[t.ID] = compiled
+	s.mu.Unlock()
+	return compiled, nil
+}
+
+// execute подставляет параметры и проверяет длину результата
+func execute(t *template.Template, params map[string]interface{}) (string, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRender, err)
+	}
+
+	text := strings.TrimSpace(buf.String())
+	if text == "" {
+		return "", fmt.Errorf("%w: rendered text is empty", ErrRender)
+	}
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return "", fmt.Errorf("%w: rendered text must not exceed %d characters", ErrRender, MaxTextLength)
+	}
+	return text, nil
+}
+
+func validateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("%w: template name must match %s", ErrInvalidInput, namePattern.String())
+	}
+	return nil
+}
+
+func parseLocale(value string) (domain.Locale, error) {
+	locale := domain.Locale(strings.ToLower(strings.TrimSpace(value)))
+	if !locale.IsValid() {
+		return "", fmt.Errorf("%w: unknown locale %q (allowed: %s, %s)", ErrInvalidInput, value, domain.LocaleRU, domain.LocaleEN)
+	}
+	return locale, nil
+}
+
+func parseParseMode(value string) (domain.ParseMode, error) {
+	mode := domain.ParseMode(value)
+	if !mode.IsValid() {
+		return "", fmt.Errorf("%w: unknown parse_mode %q (allowed: MarkdownV2, HTML or empty)", ErrInvalidInput, value)
+	}
+	return mode, nil
+}
@@ -0,0 +1,153 @@
+package bot_dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+const (
+	textNotRegistered = "Зарегистрируйтесь в приложении SMC, чтобы добавлять автомобили."
+	textCars          = "Ваши автомобили. Нажмите, чтобы выбрать автомобиль для расчёта цен:"
+	textNoCars        = "У вас пока нет автомобилей."
+	textAddCar        = "Добавить автомобиль"
+	textAskBrand      = "Введите марку автомобиля, например Toyota. /cancel - отменить."
+	textAskModel      = "Введите модель автомобиля, например Camry."
+	textAskPlate      = "Введите госномер, например А123ВС77."
+	textInvalidCar    = "Номер не подходит. Введите госномер ещё раз или /cancel."
+	textCarExists     = "Автомобиль с таким номером уже добавлен. /cars - список автомобилей."
+	textCarAdded      = "Автомобиль %s добавлен. /cars - выбрать его для расчёта цен."
+	textCarSelected   = "Выбран %s"
+	textCarNotFound   = "Автомобиль не найден"
+	selectedCarMark   = "✅ "
+)
+
+// showCars отправляет автомобили пользователя кнопками выбора и кнопку добавления
+func (uc *UseCase) showCars(ctx context.Context, conversation *domain.Conversation) error {
+	user, err := uc.userService.GetUser(ctx, conversation.UserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return uc.telegram.SendMessage(conversation.ChatID, textNotRegistered)
+		}
+		return uc.fail(conversation.ChatID, fmt.Errorf("get user: %w", err))
+	}
+
+	rows := make([][]telegram.CallbackButton, 0, len(user.Cars)+1)
+	for _, car := range user.Cars {
+		label := carTitle(car)
+		if car.IsSelected {
+			label = selectedCarMark + label
+		}
+		rows = append(rows, []telegram.CallbackButton{{
+			Text: label,
+			Data: fmt.Sprintf("%s:%d", callbackCarsSelect, car.ID),
+		}})
+	}
+	rows = append(rows, []telegram.CallbackButton{{Text: textAddCar, Data: callbackCarsAdd}})
+
+	text := textCars
+	if len(user.Cars) == 0 {
+		text = textNoCars
+	}
+	return uc.telegram.SendInlineKeyboard(conversation.ChatID, text, rows)
+}
+
+// selectCar делает автомобиль выбранным; ответ показывается всплывающим уведомлением
+func (uc *UseCase) selectCar(ctx context.Context, conversation *domain.Conversation, argument string) (string, error) {
+	carID, err := strconv.ParseInt(argument, 10, 64)
+	if err != nil {
+		return textUnknown, nil
+	}
+
+	car, err := uc.userService.SelectCar(ctx, conversation.UserID, carID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrCarNotFound) || errors.Is(err, userservice.ErrUserNotFound) {
+			return textCarNotFound, nil
+		}
+		return textUnavailable, fmt.Errorf("select car: %w", err)
+	}
+
+	return fmt.Sprintf(textCarSelected, carTitle(*car)), nil
+}
+
+func (uc *UseCase) startAddCar(ctx context.Context, conversation *domain.Conversation) error {
+	conversation.Reset()
+	conversation.State = domain.ConversationCarBrand
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	return uc.telegram.SendMessage(conversation.ChatID, textAskBrand)
+}
+
+// addCarStep принимает марку, модель и номер по очереди; после номера автомобиль создаётся в UserService
+func (uc *UseCase) addCarStep(ctx context.Context, conversation *domain.Conversation, text string) error {
+	if text == "" {
+		return uc.telegram.SendMessage(conversation.ChatID, promptFor(conversation.State))
+	}
+
+	switch conversation.State {
+	case domain.ConversationCarBrand:
+		conversation.Data.CarBrand = text
+		conversation.State = domain.ConversationCarModel
+	case domain.ConversationCarModel:
+		conversation.Data.CarModel = text
+		conversation.State = domain.ConversationCarPlate
+	case domain.ConversationCarPlate:
+		return uc.createCar(ctx, conversation, text)
+	}
+
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	return uc.telegram.SendMessage(conversation.ChatID, promptFor(conversation.State))
+}
+
+func (uc *UseCase) createCar(ctx context.Context, conversation *domain.Conversation, licensePlate string) error {
+	car, err := uc.userService.CreateCar(ctx, conversation.UserID, userservice.CreateCarRequest{
+		Brand:        conversation.Data.CarBrand,
+		Model:        conversation.Data.CarModel,
+		LicensePlate: licensePlate,
+	})
+	switch {
+	case errors.Is(err, userservice.ErrInvalidCar):
+		// Остаёмся на шаге номера, чтобы пользователь исправил только его
+		return uc.telegram.SendMessage(conversation.ChatID, textInvalidCar)
+	case errors.Is(err, userservice.ErrUserNotFound):
+		return uc.finishAddCar(ctx, conversation, textNotRegistered)
+	case errors.Is(err, userservice.ErrCarAlreadyExists):
+		return uc.finishAddCar(ctx, conversation, textCarExists)
+	case err != nil:
+		return uc.fail(conversation.ChatID, fmt.Errorf("create car: %w", err))
+	}
+
+	return uc.finishAddCar(ctx, conversation, fmt.Sprintf(textCarAdded, carTitle(*car)))
+}
+
+func (uc *UseCase) finishAddCar(ctx context.Context, conversation *domain.Conversation, text string) error {
+	conversation.Reset()
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	return uc.telegram.SendMessage(conversation.ChatID, text)
+}
+
+func promptFor(state domain.ConversationState) string {
+	switch state {
+	case domain.ConversationCarModel:
+		return textAskModel
+	case domain.ConversationCarPlate:
+		return textAskPlate
+	default:
+		return textAskBrand
+	}
+}
+
+// carTitle подпись автомобиля: марка, модель и номер
+func carTitle(car userservice.Car) string {
+	return fmt.Sprintf("%s %s (%s)", car.Brand, car.Model, car.LicensePlate)
+}
@@ -0,0 +1,57 @@
+package bot_dialog
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/priceservice"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/sellerservice"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+// TelegramService интерфейс для отправки сообщений и клавиатур в Telegram
+type TelegramService interface {
+	SendMessage(chatID int64, text string) error
+	SendInlineKeyboard(chatID int64, text string, rows [][]telegram.CallbackButton) error
+	RequestLocation(chatID int64, text, buttonText string) error
+	AnswerCallbackQuery(callbackQueryID, text string) error
+}
+
+// StartHandler обработчик команды /start
+type StartHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update) error
+}
+
+// ConversationRepository интерфейс репозитория диалогов с ботом
+type ConversationRepository interface {
+	Get(ctx context.Context, chatID int64) (*domain.Conversation, error)
+	Save(ctx context.Context, conversation domain.Conversation) (*domain.Conversation, error)
+}
+
+// UserServiceClient интерфейс для работы с автомобилями пользователя в UserService
+type UserServiceClient interface {
+	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
+	CreateCar(ctx context.Context, tgUserID int64, car userservice.CreateCarRequest) (*userservice.Car, error)
+	SelectCar(ctx context.Context, tgUserID, carID int64) (*userservice.Car, error)
+}
+
+// SellerServiceClient интерфейс для поиска моек и их услуг
+type SellerServiceClient interface {
+	ListNearbyCompanies(ctx context.Context, latitude, longitude, radiusKm float64, limit int) ([]sellerservice.Company, error)
+	ListServices(ctx context.Context, companyID int64) ([]sellerservice.Service, error)
+}
+
+// PriceServiceClient интерфейс для расчёта цен
+type PriceServiceClient interface {
+	Calculate(ctx context.Context, companyID, tgUserID int64, serviceIDs []int64) ([]priceservice.Price, error)
+}
+
+// NotificationSettings интерфейс настроек уведомлений пользователя
+type NotificationSettings interface {
+	GetNotificationSettings(ctx context.Context, userID int64) (*models.NotificationSettingsResponse, error)
+	UpdateNotificationSettings(ctx context.Context, userID int64, req *models.NotificationSettingsRequest) (*models.NotificationSettingsResponse, error)
+}
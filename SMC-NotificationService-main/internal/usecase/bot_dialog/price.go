@@ -0,0 +1,103 @@
+package bot_dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/priceservice"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/sellerservice"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+const (
+	textNoWash        = "Сначала выберите мойку: /washes."
+	textWashGone      = "Мойка больше недоступна. Выберите другую: /washes."
+	textNoSelectedCar = "Сначала выберите автомобиль: /cars."
+	textNoServices    = "У мойки %s пока нет услуг."
+	textPrices        = "Цены в %s для %s:"
+	textPriceOnDemand = "цена по запросу"
+	textDuration      = ", ~%d мин"
+)
+
+// showPrices рассчитывает цены всех услуг выбранной мойки для выбранного автомобиля пользователя
+func (uc *UseCase) showPrices(ctx context.Context, conversation *domain.Conversation) error {
+	if conversation.Data.CompanyID == 0 {
+		return uc.telegram.SendMessage(conversation.ChatID, textNoWash)
+	}
+
+	user, err := uc.userService.GetUser(ctx, conversation.UserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return uc.telegram.SendMessage(conversation.ChatID, textNotRegistered)
+		}
+		return uc.fail(conversation.ChatID, fmt.Errorf("get user: %w", err))
+	}
+	car := selectedCar(user)
+	if car == nil {
+		return uc.telegram.SendMessage(conversation.ChatID, textNoSelectedCar)
+	}
+
+	services, err := uc.sellerService.ListServices(ctx, conversation.Data.CompanyID)
+	if err != nil {
+		if errors.Is(err, sellerservice.ErrCompanyNotFound) {
+			if err := uc.forgetWash(ctx, conversation); err != nil {
+				return uc.fail(conversation.ChatID, err)
+			}
+			return uc.telegram.SendMessage(conversation.ChatID, textWashGone)
+		}
+		return uc.fail(conversation.ChatID, fmt.Errorf("list services: %w", err))
+	}
+	if len(services) == 0 {
+		return uc.telegram.SendMessage(conversation.ChatID, fmt.Sprintf(textNoServices, conversation.Data.CompanyName))
+	}
+
+	serviceIDs := make([]int64, len(services))
+	for i, service := range services {
+		serviceIDs[i] = service.ID
+	}
+	prices, err := uc.priceService.Calculate(ctx, conversation.Data.CompanyID, conversation.UserID, serviceIDs)
+	if err != nil {
+		return uc.fail(conversation.ChatID, fmt.Errorf("calculate prices: %w", err))
+	}
+
+	return uc.telegram.SendMessage(conversation.ChatID, formatPrices(conversation.Data.CompanyName, *car, services, prices))
+}
+
+// formatPrices составляет прайс: услуги без правила ценообразования показываются с ценой по запросу
+func formatPrices(companyName string, car userservice.Car, services []sellerservice.Service, prices []priceservice.Price) string {
+	byService := make(map[int64]priceservice.Price, len(prices))
+	for _, price := range prices {
+		byService[price.ServiceID] = price
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, textPrices, companyName, carTitle(car))
+	for _, service := range services {
+		fmt.Fprintf(&text, "\n• %s - ", service.Name)
+		if price, ok := byService[service.ID]; ok {
+			fmt.Fprintf(&text, "%s %s", strconv.FormatFloat(price.Price, 'f', -1, 64), price.Currency)
+		} else {
+			text.WriteString(textPriceOnDemand)
+		}
+		if service.AverageDuration != nil {
+			fmt.Fprintf(&text, textDuration, *service.AverageDuration)
+		}
+	}
+	return text.String()
+}
+
+func selectedCar(user *userservice.User) *userservice.Car {
+	if user.SelectedCar != nil {
+		return user.SelectedCar
+	}
+	for i := range user.Cars {
+		if user.Cars[i].IsSelected {
+			return &user.Cars[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package bot_dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+const (
+	textSettings         = "Настройки уведомлений (часовой пояс %s). Нажмите, чтобы включить или выключить:"
+	textQuietHoursButton = "🌙 Тихие часы: %s"
+	textQuietHoursOff    = "выключены"
+	textAskQuietHours    = "Отправьте тихие часы в формате 22:00-08:00 или «выкл», чтобы их отключить. /cancel - отменить."
+	textInvalidQuiet     = "Не удалось разобрать тихие часы. Пример: 22:00-08:00 или «выкл»."
+	textQuietHoursSaved  = "Тихие часы сохранены."
+	textCategoryOn       = "Включено"
+	textCategoryOff      = "Выключено"
+	enabledMark          = "✅ "
+	disabledMark         = "❌ "
+)
+
+// quietHoursOff ответы, отключающие тихие часы
+var quietHoursOff = map[string]bool{"выкл": true, "off": true, "нет": true}
+
+// categoryTitles категории, которые можно отключить из бота, в порядке кнопок
+var categoryTitles = []struct {
+	category domain.Category
+	title    string
+}{
+	{domain.CategoryReminders, "Напоминания"},
+	{domain.CategoryMarketing, "Акции и новости"},
+}
+
+// showSettings отправляет настройки уведомлений кнопками; транзакционные уведомления не отключаются и не показываются
+func (uc *UseCase) showSettings(ctx context.Context, conversation *domain.Conversation) error {
+	settings, err := uc.settings.GetNotificationSettings(ctx, conversation.UserID)
+	if err != nil {
+		return uc.fail(conversation.ChatID, fmt.Errorf("get notification settings: %w", err))
+	}
+	return uc.sendSettings(conversation.ChatID, settings)
+}
+
+func (uc *UseCase) sendSettings(chatID int64, settings *models.NotificationSettingsResponse) error {
+	rows := make([][]telegram.CallbackButton, 0, len(categoryTitles)+1)
+	for _, item := range categoryTitles {
+		mark := disabledMark
+		if settings.Categories[string(item.category)] {
+			mark = enabledMark
+		}
+		rows = append(rows, []telegram.CallbackButton{{
+			Text: mark + item.title,
+			Data: fmt.Sprintf("%s:%s", callbackSettingsToggle, item.category),
+		}})
+	}
+
+	quietHours := textQuietHoursOff
+	if settings.QuietHours != nil {
+		quietHours = settings.QuietHours.Start + "-" + settings.QuietHours.End
+	}
+	rows = append(rows, []telegram.CallbackButton{{
+		Text: fmt.Sprintf(textQuietHoursButton, quietHours),
+		Data: callbackSettingsQuiet,
+	}})
+
+	return uc.telegram.SendInlineKeyboard(chatID, fmt.Sprintf(textSettings, settings.Timezone), rows)
+}
+
+// toggleCategory включает или выключает категорию и присылает обновлённые настройки
+func (uc *UseCase) toggleCategory(ctx context.Context, conversation *domain.Conversation, argument string) (string, error) {
+	category := domain.Category(argument)
+	if !category.Optional() {
+		return textUnknown, nil
+	}
+
+	settings, err := uc.settings.GetNotificationSettings(ctx, conversation.UserID)
+	if err != nil {
+		return textUnavailable, fmt.Errorf("get notification settings: %w", err)
+	}
+
+	req := settingsRequest(settings)
+	enabled := !settings.Categories[string(category)]
+	req.Categories[string(category)] = enabled
+
+	updated, err := uc.settings.UpdateNotificationSettings(ctx, conversation.UserID, req)
+	if err != nil {
+		return textUnavailable, fmt.Errorf("update notification settings: %w", err)
+	}
+	if err := uc.sendSettings(conversation.ChatID, updated); err != nil {
+		return "", err
+	}
+
+	if enabled {
+		return textCategoryOn, nil
+	}
+	return textCategoryOff, nil
+}
+
+func (uc *UseCase) askQuietHours(ctx context.Context, conversation *domain.Conversation) error {
+	conversation.Reset()
+	conversation.State = domain.ConversationQuietHours
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	return uc.telegram.SendMessage(conversation.ChatID, textAskQuietHours)
+}
+
+// setQuietHours принимает тихие часы HH:MM-HH:MM; при ошибке формата шаг диалога не меняется
+func (uc *UseCase) setQuietHours(ctx context.Context, conversation *domain.Conversation, text string) error {
+	var quietHours *models.QuietHours
+	if !quietHoursOff[strings.ToLower(text)] {
+		start, end, ok := strings.Cut(text, "-")
+		if !ok {
+			return uc.telegram.SendMessage(conversation.ChatID, textInvalidQuiet)
+		}
+		quietHours = &models.QuietHours{Start: strings.TrimSpace(start), End: strings.TrimSpace(end)}
+	}
+
+	settings, err := uc.settings.GetNotificationSettings(ctx, conversation.UserID)
+	if err != nil {
+		return uc.fail(conversation.ChatID, fmt.Errorf("get notification settings: %w", err))
+	}
+
+	req := settingsRequest(settings)
+	req.QuietHours = quietHours
+	updated, err := uc.settings.UpdateNotificationSettings(ctx, conversation.UserID, req)
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidInput) {
+			return uc.telegram.SendMessage(conversation.ChatID, textInvalidQuiet)
+		}
+		return uc.fail(conversation.ChatID, fmt.Errorf("update notification settings: %w", err))
+	}
+
+	conversation.Reset()
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	if err := uc.telegram.SendMessage(conversation.ChatID, textQuietHoursSaved); err != nil {
+		return err
+	}
+	return uc.sendSettings(conversation.ChatID, updated)
+}
+
+// settingsRequest копирует текущие настройки в запрос обновления: обновление заменяет настройки целиком
+func settingsRequest(settings *models.NotificationSettingsResponse) *models.NotificationSettingsRequest {
+	categories := make(map[string]bool, len(settings.Categories))
+	for name, enabled := range settings.Categories {
+		categories[name] = enabled
+	}
+	return &models.NotificationSettingsRequest{
+		Categories:       categories,
+		PreferredChannel: settings.PreferredChannel,
+		Timezone:         settings.Timezone,
+		QuietHours:       settings.QuietHours,
+	}
+}
@@ -0,0 +1,254 @@
+package bot_dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	conversationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/conversation"
+)
+
+// Команды бота
+const (
+	commandStart    = "start"
+	commandCars     = "cars"
+	commandWashes   = "washes"
+	commandPrice    = "price"
+	commandSettings = "settings"
+	commandCancel   = "cancel"
+)
+
+// Префиксы callback_data inline кнопок, вида <раздел>:<действие>[:<аргумент>]
+const (
+	callbackCarsSelect     = "cars:select"
+	callbackCarsAdd        = "cars:add"
+	callbackWashesPick     = "washes:pick"
+	callbackPrice          = "price"
+	callbackSettingsToggle = "settings:toggle"
+	callbackSettingsQuiet  = "settings:quiet"
+)
+
+const (
+	textHelp = "Команды:\n" +
+		"/cars - мои автомобили\n" +
+		"/washes - мойки рядом\n" +
+		"/price - цены выбранной мойки для выбранного автомобиля\n" +
+		"/settings - настройки уведомлений\n" +
+		"/cancel - отменить текущее действие"
+	textCancelled   = "Действие отменено."
+	textUnavailable = "Сервис временно недоступен, попробуйте позже."
+	textUnknown     = "Кнопка устарела, повторите команду."
+)
+
+// Config настройки диалогов бота
+type Config struct {
+	ConversationTTL time.Duration // бездействие, после которого незавершённый диалог сбрасывается
+	SearchRadiusKm  float64       // радиус поиска моек по геопозиции
+	SearchLimit     int           // максимум моек в ответе /washes
+}
+
+// UseCase маршрутизирует обновления Telegram по командам, нажатиям кнопок и шагу диалога
+// Шаг диалога хранится в БД, поэтому переживает рестарт и работает на нескольких экземплярах
+type UseCase struct {
+	telegram      TelegramService
+	start         StartHandler
+	conversations ConversationRepository
+	userService   UserServiceClient
+	sellerService SellerServiceClient
+	priceService  PriceServiceClient
+	settings      NotificationSettings
+	config        Config
+}
+
+// New создаёт новый экземпляр usecase
+func New(
+	telegram TelegramService,
+	start StartHandler,
+	conversations ConversationRepository,
+	userService UserServiceClient,
+	sellerService SellerServiceClient,
+	priceService PriceServiceClient,
+	settings NotificationSettings,
+	config Config,
+) *UseCase {
+	return &UseCase{
+		telegram:      telegram,
+		start:         start,
+		conversations: conversations,
+		userService:   userService,
+		sellerService: sellerService,
+		priceService:  priceService,
+		settings:      settings,
+		config:        config,
+	}
+}
+
+// HandleUpdate обрабатывает обновление Telegram; учитываются только личные чаты
+func (uc *UseCase) HandleUpdate(ctx context.Context, update tgbotapi.Update) error {
+	if update.CallbackQuery != nil {
+		return uc.handleCallback(ctx, update.CallbackQuery)
+	}
+
+	message := update.Message
+	if message == nil || message.From == nil || !message.Chat.IsPrivate() {
+		return nil
+	}
+
+	if message.IsCommand() && message.Command() == commandStart {
+		return uc.start.HandleUpdate(ctx, update)
+	}
+
+	conversation, err := uc.loadConversation(ctx, message.Chat.ID, message.From.ID)
+	if err != nil {
+		return uc.fail(message.Chat.ID, err)
+	}
+
+	switch {
+	case message.IsCommand():
+		err = uc.handleCommand(ctx, conversation, message.Command())
+	case message.Location != nil:
+		err = uc.searchWashes(ctx, conversation, message.Location.Latitude, message.Location.Longitude)
+	default:
+		err = uc.handleText(ctx, conversation, strings.TrimSpace(message.Text))
+	}
+	if err != nil {
+		return fmt.Errorf("bot_dialog: chat_id=%d: %w", message.Chat.ID, err)
+	}
+	return nil
+}
+
+func (uc *UseCase) handleCommand(ctx context.Context, conversation *domain.Conversation, command string) error {
+	// Новая команда прерывает незавершённый диалог
+	if conversation.State != domain.ConversationIdle {
+		conversation.Reset()
+		if err := uc.save(ctx, conversation); err != nil {
+			return uc.fail(conversation.ChatID, err)
+		}
+	}
+
+	switch command {
+	case commandCars:
+		return uc.showCars(ctx, conversation)
+	case commandWashes:
+		return uc.askLocation(ctx, conversation)
+	case commandPrice:
+		return uc.showPrices(ctx, conversation)
+	case commandSettings:
+		return uc.showSettings(ctx, conversation)
+	case commandCancel:
+		return uc.telegram.SendMessage(conversation.ChatID, textCancelled)
+	default:
+		return uc.telegram.SendMessage(conversation.ChatID, textHelp)
+	}
+}
+
+func (uc *UseCase) handleText(ctx context.Context, conversation *domain.Conversation, text string) error {
+	switch conversation.State {
+	case domain.ConversationCarBrand, domain.ConversationCarModel, domain.ConversationCarPlate:
+		return uc.addCarStep(ctx, conversation, text)
+	case domain.ConversationQuietHours:
+		return uc.setQuietHours(ctx, conversation, text)
+	case domain.ConversationLocation:
+		return uc.telegram.SendMessage(conversation.ChatID, textAskLocation)
+	default:
+		return uc.telegram.SendMessage(conversation.ChatID, textHelp)
+	}
+}
+
+// handleCallback обрабатывает нажатие inline кнопки; на нажатие отвечается всегда, иначе кнопка «зависает»
+func (uc *UseCase) handleCallback(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+	if query.From == nil || query.Message == nil || !query.Message.Chat.IsPrivate() {
+		return uc.telegram.AnswerCallbackQuery(query.ID, "")
+	}
+
+	conversation, err := uc.loadConversation(ctx, query.Message.Chat.ID, query.From.ID)
+	if err != nil {
+		uc.telegram.AnswerCallbackQuery(query.ID, textUnavailable)
+		return fmt.Errorf("bot_dialog: chat_id=%d: %w", query.Message.Chat.ID, err)
+	}
+
+	action, argument := query.Data, ""
+	if i := strings.LastIndex(query.Data, ":"); i > 0 && strings.Count(query.Data, ":") == 2 {
+		action, argument = query.Data[:i], query.Data[i+1:]
+	}
+
+	var answer string
+	switch action {
+	case callbackCarsSelect:
+		answer, err = uc.selectCar(ctx, conversation, argument)
+	case callbackCarsAdd:
+		err = uc.startAddCar(ctx, conversation)
+	case callbackWashesPick:
+		answer, err = uc.pickWash(ctx, conversation, argument, buttonText(query.Message, query.Data))
+	case callbackPrice:
+		err = uc.showPrices(ctx, conversation)
+	case callbackSettingsToggle:
+		answer, err = uc.toggleCategory(ctx, conversation, argument)
+	case callbackSettingsQuiet:
+		err = uc.askQuietHours(ctx, conversation)
+	default:
+		answer = textUnknown
+	}
+
+	if answerErr := uc.telegram.AnswerCallbackQuery(query.ID, answer); answerErr != nil && err == nil {
+		err = answerErr
+	}
+	if err != nil {
+		return fmt.Errorf("bot_dialog: chat_id=%d callback %q: %w", query.Message.Chat.ID, query.Data, err)
+	}
+	return nil
+}
+
+// loadConversation возвращает диалог чата; незавершённый диалог старше ConversationTTL начинается заново
+func (uc *UseCase) loadConversation(ctx context.Context, chatID, userID int64) (*domain.Conversation, error) {
+	conversation, err := uc.conversations.Get(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, conversationRepo.ErrConversationNotFound) {
+			return &domain.Conversation{ChatID: chatID, UserID: userID, State: domain.ConversationIdle}, nil
+		}
+		return nil, fmt.Errorf("load conversation: %w", err)
+	}
+
+	if conversation.State != domain.ConversationIdle && time.Since(conversation.UpdatedAt) > uc.config.ConversationTTL {
+		conversation.Reset()
+	}
+	conversation.UserID = userID
+	return conversation, nil
+}
+
+func (uc *UseCase) save(ctx context.Context, conversation *domain.Conversation) error {
+	saved, err := uc.conversations.Save(ctx, *conversation)
+	if err != nil {
+		return fmt.Errorf("save conversation: %w", err)
+	}
+	*conversation = *saved
+	return nil
+}
+
+// fail сообщает пользователю о недоступности сервиса и возвращает исходную ошибку для логирования
+func (uc *UseCase) fail(chatID int64, err error) error {
+	if sendErr := uc.telegram.SendMessage(chatID, textUnavailable); sendErr != nil {
+		return fmt.Errorf("%w (notify user: %v)", err, sendErr)
+	}
+	return err
+}
+
+// buttonText находит подпись нажатой кнопки в клавиатуре сообщения
+func buttonText(message *tgbotapi.Message, data string) string {
+	if message.ReplyMarkup == nil {
+		return ""
+	}
+	for _, row := range message.ReplyMarkup.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData != nil && *button.CallbackData == data {
+				return button.Text
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package bot_dialog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+const (
+	textAskLocation    = "Отправьте геопозицию, и я найду мойки рядом. /cancel - отменить."
+	textLocationButton = "📍 Отправить геопозицию"
+	textNoWashes       = "В радиусе %s км моек не найдено."
+	textWashes         = "Мойки рядом. Нажмите, чтобы выбрать мойку для /price:"
+	textWashPicked     = "Выбрана мойка %s"
+	textPriceButton    = "Узнать цены"
+	textWashPickedFull = "Выбрана мойка %s. Цены для выбранного автомобиля - /price."
+)
+
+func (uc *UseCase) askLocation(ctx context.Context, conversation *domain.Conversation) error {
+	conversation.State = domain.ConversationLocation
+	if err := uc.save(ctx, conversation); err != nil {
+		return uc.fail(conversation.ChatID, err)
+	}
+	return uc.telegram.RequestLocation(conversation.ChatID, textAskLocation, textLocationButton)
+}
+
+// searchWashes ищет мойки рядом с геопозицией; геопозиция принимается и без /washes
+func (uc *UseCase) searchWashes(ctx context.Context, conversation *domain.Conversation, latitude, longitude float64) error {
+	if conversation.State != domain.ConversationIdle {
+		conversation.Reset()
+		if err := uc.save(ctx, conversation); err != nil {
+			return uc.fail(conversation.ChatID, err)
+		}
+	}
+
+	companies, err := uc.sellerService.ListNearbyCompanies(ctx, latitude, longitude, uc.config.SearchRadiusKm, uc.config.SearchLimit)
+	if err != nil {
+		return uc.fail(conversation.ChatID, fmt.Errorf("list nearby companies: %w", err))
+	}
+	if len(companies) == 0 {
+		radius := strconv.FormatFloat(uc.config.SearchRadiusKm, 'f', -1, 64)
+		return uc.telegram.SendMessage(conversation.ChatID, fmt.Sprintf(textNoWashes, radius))
+	}
+
+	var text strings.Builder
+	text.WriteString(textWashes)
+	rows := make([][]telegram.CallbackButton, 0, len(companies))
+	for i, company := range companies {
+		fmt.Fprintf(&text, "\n%d. %s", i+1, company.Name)
+		if len(company.Addresses) > 0 {
+			address := company.Addresses[0]
+			fmt.Fprintf(&text, " - %s, %s %s", address.City, address.Street, address.Building)
+		}
+		rows = append(rows, []telegram.CallbackButton{{
+			Text: company.Name,
+			Data: fmt.Sprintf("%s:%d", callbackWashesPick, company.ID),
+		}})
+	}
+	return uc.telegram.SendInlineKeyboard(conversation.ChatID, text.String(), rows)
+}
+
+// pickWash запоминает выбранную мойку для /price; название берётся из подписи нажатой кнопки
+func (uc *UseCase) pickWash(ctx context.Context, conversation *domain.Conversation, argument, name string) (string, error) {
+	companyID, err := strconv.ParseInt(argument, 10, 64)
+	if err != nil {
+		return textUnknown, nil
+	}
+	if name == "" {
+		name = fmt.Sprintf("#%d", companyID)
+	}
+
+	conversation.Data.CompanyID = companyID
+	conversation.Data.CompanyName = name
+	if err := uc.save(ctx, conversation); err != nil {
+		return textUnavailable, err
+	}
+
+	rows := [][]telegram.CallbackButton{{{Text: textPriceButton, Data: callbackPrice}}}
+	if err := uc.telegram.SendInlineKeyboard(conversation.ChatID, fmt.Sprintf(textWashPickedFull, name), rows); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(textWashPicked, name), nil
+}
+
+// forgetWash сбрасывает выбранную мойку, которой больше нет в SellerService
+func (uc *UseCase) forgetWash(ctx context.Context, conversation *domain.Conversation) error {
+	conversation.Data.CompanyID = 0
+	conversation.Data.CompanyName = ""
+	return uc.save(ctx, conversation)
+}
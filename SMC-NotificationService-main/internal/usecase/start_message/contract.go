@@ -0,0 +1,17 @@
+package start_message
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+// TelegramService интерфейс для отправки сообщений в Telegram
+type TelegramService interface {
+	SendMessage(chatID int64, text string) error
+}
+
+// UserServiceClient интерфейс для работы с UserService
+type UserServiceClient interface {
+	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
+}
@@ -0,0 +1,62 @@
+package start_message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+const (
+	startCommand = "start"
+
+	greetingRegistered   = "Здравствуйте, %s! Сюда будут приходить уведомления о ваших записях на мойку."
+	greetingUnregistered = "Здравствуйте! Зарегистрируйтесь в приложении SMC, чтобы получать уведомления о записях на мойку."
+	greetingFallback     = "Здравствуйте! Сюда будут приходить уведомления о ваших записях на мойку."
+)
+
+// UseCase отвечает на команду /start приветствием
+type UseCase struct {
+	telegram    TelegramService
+	userService UserServiceClient
+}
+
+// New создаёт новый экземпляр usecase
+func New(telegram TelegramService, userService UserServiceClient) *UseCase {
+	return &UseCase{
+		telegram:    telegram,
+		userService: userService,
+	}
+}
+
+// HandleUpdate обрабатывает обновление Telegram; всё, кроме /start в личном чате, игнорируется
+func (uc *UseCase) HandleUpdate(ctx context.Context, update tgbotapi.Update) error {
+	message := update.Message
+	if message == nil || message.From == nil || !message.Chat.IsPrivate() {
+		return nil
+	}
+	if !message.IsCommand() || message.Command() != startCommand {
+		return nil
+	}
+
+	if err := uc.telegram.SendMessage(message.Chat.ID, uc.greeting(ctx, message.From.ID)); err != nil {
+		return fmt.Errorf("start_message: send greeting to tg_user_id=%d: %w", message.From.ID, err)
+	}
+	return nil
+}
+
+// greeting выбирает приветствие; при недоступности UserService отвечает без имени
+func (uc *UseCase) greeting(ctx context.Context, tgUserID int64) string {
+	user, err := uc.userService.GetUser(ctx, tgUserID)
+	switch {
+	case err == nil && user.Name != "":
+		return fmt.Sprintf(greetingRegistered, user.Name)
+	case errors.Is(err, userservice.ErrUserNotFound):
+		return greetingUnregistered
+	default:
+		return greetingFallback
+	}
+}
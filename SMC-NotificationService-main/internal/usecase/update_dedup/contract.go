@@ -0,0 +1,18 @@
+package update_dedup
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// UpdateHandler обработчик обновлений, вызываемый для каждого update_id до первой успешной обработки
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update) error
+}
+
+// ProcessedUpdates интерфейс хранилища обработанных обновлений
+type ProcessedUpdates interface {
+	Claim(ctx context.Context, updateID int) (bool, error)
+	Release(ctx context.Context, updateID int) error
+}
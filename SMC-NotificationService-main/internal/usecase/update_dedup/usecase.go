@@ -0,0 +1,56 @@
+package update_dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ErrClaimUpdate возвращается, когда не удалось отметить обновление обработанным; обновление не обработано,
+// и его безопасно доставить повторно
+var ErrClaimUpdate = errors.New("update_dedup: failed to claim update")
+
+// ErrHandleUpdate возвращается, когда обработка завершилась ошибкой и отметка снята;
+// повторная доставка обработает обновление снова
+var ErrHandleUpdate = errors.New("update_dedup: failed to handle update")
+
+// UseCase пропускает обновления, уже обработанные этим или другим экземпляром сервиса
+// Telegram повторяет обновление, если webhook не ответил вовремя, а после рестарта long polling
+// заново получает неподтверждённые обновления
+type UseCase struct {
+	next      UpdateHandler
+	processed ProcessedUpdates
+}
+
+// New создаёт новый экземпляр usecase
+func New(next UpdateHandler, processed ProcessedUpdates) *UseCase {
+	return &UseCase{
+		next:      next,
+		processed: processed,
+	}
+}
+
+// HandleUpdate отмечает обновление обработанным и передаёт его дальше; повторы игнорируются
+// Отметка ставится до обработки, чтобы параллельная доставка того же обновления его не обработала.
+// При ошибке обработки отметка снимается, и повторная доставка обработает обновление снова
+func (uc *UseCase) HandleUpdate(ctx context.Context, update tgbotapi.Update) error {
+	claimed, err := uc.processed.Claim(ctx, update.UpdateID)
+	if err != nil {
+		return fmt.Errorf("%w: update_id=%d: %v", ErrClaimUpdate, update.UpdateID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := uc.next.HandleUpdate(ctx, update); err != nil {
+		// Отметку снимаем и после отмены запроса: иначе повтор будет пропущен как обработанный
+		if releaseErr := uc.processed.Release(context.WithoutCancel(ctx), update.UpdateID); releaseErr != nil {
+			return fmt.Errorf("update_dedup: update_id=%d: %v; release claim: %v", update.UpdateID, err, releaseErr)
+		}
+		return fmt.Errorf("%w: update_id=%d: %v", ErrHandleUpdate, update.UpdateID, err)
+	}
+
+	return nil
+}
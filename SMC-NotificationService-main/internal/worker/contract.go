@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/delivery"
+)
+
+// NotificationRepository интерфейс репозитория уведомлений для фоновой отправки
+type NotificationRepository interface {
+	GetScheduledAfter(ctx context.Context, after time.Time) ([]domain.Notification, error)
+	Claim(ctx context.Context, id int64, owner string, lease time.Duration) (*domain.Notification, error)
+	ClaimDue(ctx context.Context, owner string, lease time.Duration, limit int) ([]domain.Notification, error)
+	ExtendLeases(ctx context.Context, owner string, lease time.Duration) (int64, error)
+	ReleaseLeases(ctx context.Context, owner string) (int64, error)
+	ReapExpiredLeases(ctx context.Context, limit int) (int64, error)
+	MarkSending(ctx context.Context, id int64, owner string) error
+	MarkSent(ctx context.Context, id int64, owner string, sentAt time.Time) error
+	ScheduleRetry(ctx context.Context, id int64, owner string, reason string, nextAttemptAt time.Time) error
+	Defer(ctx context.Context, id int64, owner string, until time.Time) error
+	Suppress(ctx context.Context, id int64, owner string, reason string) error
+	MoveToDeadLetter(ctx context.Context, id int64, owner string, reason domain.DeadLetterReason, errText string) error
+	CreateDelivery(ctx context.Context, input domain.CreateDeliveryInput) error
+	QueueStats(ctx context.Context) (*domain.QueueStats, error)
+}
+
+// SeriesRepository интерфейс репозитория серий повторяющихся уведомлений
+type SeriesRepository interface {
+	ListDueSeries(ctx context.Context, horizon time.Time, limit int) ([]domain.Series, error)
+	MaterializeSeries(ctx context.Context, occurrences domain.SeriesOccurrences) ([]domain.Notification, error)
+}
+
+// ProcessedUpdatesRepository интерфейс хранилища обработанных обновлений Telegram
+type ProcessedUpdatesRepository interface {
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RateLimitRepository интерфейс хранилища лимитов отправки в Telegram
+type RateLimitRepository interface {
+	DeleteExpired(ctx context.Context) (int64, error)
+	Stats(ctx context.Context) (*domain.RateLimitStats, error)
+}
+
+// RateLimitMetrics метрики заполненности лимитов отправки в Telegram
+type RateLimitMetrics interface {
+	UpdateTelegramLimiter(backlog time.Duration, chats int)
+}
+
+// QueueMetrics метрики очереди уведомлений
+type QueueMetrics interface {
+	UpdateNotificationQueue(due, scheduled, processing, expiredLeases int, lag time.Duration)
+}
+
+// Dispatcher проверяет настройки пользователя и доставляет уведомление по цепочке каналов
+type Dispatcher interface {
+	Gate(ctx context.Context, notification *domain.Notification, now time.Time) (*delivery.Gate, error)
+	Dispatch(ctx context.Context, notification *domain.Notification) *delivery.Result
+}
+
+// UpdateHandler обрабатывает входящие обновления Telegram
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
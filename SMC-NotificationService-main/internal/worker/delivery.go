@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/service/delivery"
+)
+
+// deliver доставляет захваченное уведомление по цепочке каналов и сохраняет результат
+// Сначала проверяются настройки пользователя: в тихие часы уведомление откладывается без траты попытки,
+// а при отписке от категории отменяется. Перед отправкой фиксируется её начало: если аренда уже потеряна, уведомление пропускается,
+// а после начала отправки оно не будет отправлено повторно другим экземпляром
+// Уведомление, отложенное ограничителем скорости канала, возвращается в очередь к своему слоту без траты попытки
+// После временной ошибки уведомление возвращается в pending с паузой по политике повторов,
+// после постоянной ошибки или последней попытки - переносится в dead-letter
+// Возвращает время повторной попытки, если она запланирована
+func deliver(ctx context.Context, repo NotificationRepository, dispatcher Dispatcher, policy RetryPolicy, lease *Lease, log Logger, notification *domain.Notification) *time.Time {
+	owner := lease.Owner()
+	if next, handled := applyGate(ctx, repo, dispatcher, policy, owner, log, notification); handled {
+		return next
+	}
+
+	if err := repo.MarkSending(ctx, notification.ID, owner); err != nil {
+		logOwnedError(log, err, "Failed to start notification delivery", notification.ID)
+		return nil
+	}
+
+	result := dispatcher.Dispatch(ctx, notification)
+	for _, attempt := range result.Attempts {
+		if err := repo.CreateDelivery(ctx, attempt); err != nil {
+			log.Error("Failed to save delivery attempt: id=%d, channel=%s, error=%v", notification.ID, attempt.Channel, err)
+		}
+	}
+
+	if result.Delivered() {
+		if err := repo.MarkSent(ctx, notification.ID, owner, time.Now()); err != nil {
+			logOwnedError(log, err, "Failed to mark notification as sent", notification.ID)
+			return nil
+		}
+		log.Info("Notification sent: id=%d, user_id=%d, channel=%s, attempt=%d",
+			notification.ID, notification.UserID, *result.Channel, notification.Attempts)
+		return nil
+	}
+
+	if result.Throttled != nil {
+		until := *result.Throttled
+		if err := repo.Defer(ctx, notification.ID, owner, until); err != nil {
+			logOwnedError(log, err, "Failed to defer throttled notification", notification.ID)
+			return nil
+		}
+		log.Info("Notification throttled by rate limit, deferred: id=%d, user_id=%d, until=%s",
+			notification.ID, notification.UserID, until.Format(time.RFC3339Nano))
+		return &until
+	}
+
+	reason := result.Reason()
+	if result.Retryable && !policy.Exhausted(notification.Attempts) {
+		nextAttemptAt := time.Now().Add(policy.Backoff(notification.Attempts, result.RetryAfter))
+		if err := repo.ScheduleRetry(ctx, notification.ID, owner, reason, nextAttemptAt); err != nil {
+			logOwnedError(log, err, "Failed to schedule notification retry", notification.ID)
+			return nil
+		}
+		log.Warn("Failed to deliver notification, retry scheduled: id=%d, user_id=%d, attempt=%d, next_attempt_at=%s, error=%s",
+			notification.ID, notification.UserID, notification.Attempts, nextAttemptAt.Format(time.RFC3339), reason)
+		return &nextAttemptAt
+	}
+
+	deadLetterReason := domain.DeadLetterPermanent
+	if result.Retryable {
+		deadLetterReason = domain.DeadLetterExhausted
+	}
+	if err := repo.MoveToDeadLetter(ctx, notification.ID, owner, deadLetterReason, reason); err != nil {
+		logOwnedError(log, err, "Failed to move notification to dead-letter", notification.ID)
+		return nil
+	}
+	log.Warn("Failed to deliver notification, moved to dead-letter: id=%d, user_id=%d, reason=%s, attempts=%d, error=%s",
+		notification.ID, notification.UserID, deadLetterReason, notification.Attempts, reason)
+	return nil
+}
+
+// applyGate применяет настройки пользователя к захваченному уведомлению
+// handled - уведомление отложено, отменено или не прошло проверку настроек, отправлять его сейчас не нужно
+// Если настройки не удалось проверить, уведомление повторяется по политике повторов, а после последней попытки
+// переносится в dead-letter
+func applyGate(ctx context.Context, repo NotificationRepository, dispatcher Dispatcher, policy RetryPolicy, owner string, log Logger, notification *domain.Notification) (next *time.Time, handled bool) {
+	gate, err := dispatcher.Gate(ctx, notification, time.Now())
+	if err != nil {
+		// Ошибка проверки настроек тратит попытку: иначе при постоянном сбое уведомление повторялось бы бесконечно
+		if policy.Exhausted(notification.Attempts) {
+			if err := repo.MoveToDeadLetter(ctx, notification.ID, owner, domain.DeadLetterExhausted, err.Error()); err != nil {
+				logOwnedError(log, err, "Failed to move notification to dead-letter", notification.ID)
+				return nil, true
+			}
+			log.Warn("Failed to check notification preferences, moved to dead-letter: id=%d, user_id=%d, attempts=%d, error=%v",
+				notification.ID, notification.UserID, notification.Attempts, err)
+			return nil, true
+		}
+
+		nextAttemptAt := time.Now().Add(policy.Backoff(notification.Attempts, 0))
+		if err := repo.ScheduleRetry(ctx, notification.ID, owner, err.Error(), nextAttemptAt); err != nil {
+			logOwnedError(log, err, "Failed to schedule notification retry", notification.ID)
+			return nil, true
+		}
+		log.Warn("Failed to check notification preferences, retry scheduled: id=%d, user_id=%d, next_attempt_at=%s, error=%v",
+			notification.ID, notification.UserID, nextAttemptAt.Format(time.RFC3339), err)
+		return &nextAttemptAt, true
+	}
+
+	switch gate.Action {
+	case delivery.GateDefer:
+		if err := repo.Defer(ctx, notification.ID, owner, gate.Until); err != nil {
+			logOwnedError(log, err, "Failed to defer notification", notification.ID)
+			return nil, true
+		}
+		log.Info("Notification deferred until quiet hours end: id=%d, user_id=%d, category=%s, until=%s",
+			notification.ID, notification.UserID, notification.Category, gate.Until.Format(time.RFC3339))
+		return &gate.Until, true
+	case delivery.GateDrop:
+		if err := repo.Suppress(ctx, notification.ID, owner, gate.Reason); err != nil {
+			logOwnedError(log, err, "Failed to suppress notification", notification.ID)
+			return nil, true
+		}
+		log.Info("Notification suppressed: id=%d, user_id=%d, category=%s", notification.ID, notification.UserID, notification.Category)
+		return nil, true
+	}
+	return nil, false
+}
+
+// logOwnedError логирует ошибку перехода уведомления из processing
+// Потеря аренды - не сбой: уведомление уже обработал другой экземпляр или перенёс в dead-letter
+func logOwnedError(log Logger, err error, msg string, id int64) {
+	if errors.Is(err, notificationRepo.ErrLeaseLost) {
+		log.Warn("%s, lease lost: id=%d", msg, id)
+		return
+	}
+	log.Error("%s: id=%d, error=%v", msg, id, err)
+}
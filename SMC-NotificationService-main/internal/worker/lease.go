@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Lease аренда уведомлений экземпляром сервиса
+// Heartbeat продлевает аренду всех захваченных уведомлений; если экземпляр остановился или завис,
+// аренда истекает и уведомления забирают другие экземпляры
+type Lease struct {
+	repo     NotificationRepository
+	owner    string
+	duration time.Duration
+	log      Logger
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewLease создает аренду экземпляра owner; owner должен быть уникален среди запущенных экземпляров
+func NewLease(repo NotificationRepository, owner string, duration time.Duration, log Logger) *Lease {
+	return &Lease{
+		repo:     repo,
+		owner:    owner,
+		duration: duration,
+		log:      log,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+// Owner возвращает идентификатор экземпляра
+func (l *Lease) Owner() string {
+	return l.owner
+}
+
+// Duration возвращает срок аренды
+func (l *Lease) Duration() time.Duration {
+	return l.duration
+}
+
+// Start запускает heartbeat; блокируется до вызова Stop
+// Аренда продлевается трижды за срок, чтобы пережить одну-две неудачные попытки
+func (l *Lease) Start() {
+	defer close(l.doneCh)
+
+	ticker := time.NewTicker(l.duration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.duration/3)
+			if _, err := l.repo.ExtendLeases(ctx, l.owner, l.duration); err != nil {
+				l.log.Error("Lease: failed to extend leases: owner=%s, error=%v", l.owner, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// Stop останавливает heartbeat и возвращает в очередь захваченные, но не начатые уведомления
+// Вызывается после остановки scheduler и processor
+func (l *Lease) Stop(ctx context.Context) {
+	l.stopOnce.Do(func() {
+		close(l.stopCh)
+	})
+	<-l.doneCh
+
+	released, err := l.repo.ReleaseLeases(ctx, l.owner)
+	if err != nil {
+		l.log.Error("Lease: failed to release leases: owner=%s, error=%v", l.owner, err)
+		return
+	}
+	if released > 0 {
+		l.log.Info("Lease: %d notifications returned to queue: owner=%s", released, l.owner)
+	}
+}
@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/recurrence"
+)
+
+const (
+	// seriesBatchSize серий за один проход materializer
+	seriesBatchSize = 100
+
+	// maxOccurrenceRows ограничение уведомлений, создаваемых для одной серии за проход;
+	// остальные повторения создаст следующий проход
+	maxOccurrenceRows = 1000
+
+	// missedOccurrenceGrace повторения старше этого срока, пропущенные при простое сервиса, не создаются
+	missedOccurrenceGrace = time.Hour
+)
+
+// Materializer заранее создаёт повторения серий на horizon вперёд
+// Повторения - обычные уведомления: их отправляют Scheduler и Processor, отменяют по одному или всей серией
+// через span_id. Продвижение серии проверяет прежний materialized_until, поэтому materializer
+// может работать в любом числе экземпляров сервиса
+type Materializer struct {
+	repo      SeriesRepository
+	scheduler *Scheduler
+	log       Logger
+	interval  time.Duration
+	horizon   time.Duration
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewMaterializer создает новый экземпляр materializer
+func NewMaterializer(repo SeriesRepository, scheduler *Scheduler, log Logger, interval, horizon time.Duration) *Materializer {
+	return &Materializer{
+		repo:      repo,
+		scheduler: scheduler,
+		log:       log,
+		interval:  interval,
+		horizon:   horizon,
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// Start запускает цикл создания повторений; блокируется до вызова Stop
+func (m *Materializer) Start() {
+	defer close(m.doneCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-m.stopCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.materializeDue(ctx)
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop останавливает цикл и ждёт завершения текущего прохода
+func (m *Materializer) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+	<-m.doneCh
+}
+
+func (m *Materializer) materializeDue(ctx context.Context) {
+	now := time.Now()
+	horizon := now.Add(m.horizon)
+
+	seriesList, err := m.repo.ListDueSeries(ctx, horizon, seriesBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.log.Error("Materializer: failed to list due series: %v", err)
+		}
+		return
+	}
+
+	for i := range seriesList {
+		if ctx.Err() != nil {
+			return
+		}
+		m.materialize(ctx, &seriesList[i], now, horizon)
+	}
+}
+
+// materialize создаёт повторения серии в интервале (materialized_until, horizon]
+func (m *Materializer) materialize(ctx context.Context, series *domain.Series, now, horizon time.Time) {
+	occurrences, err := nextOccurrences(series, now, horizon)
+	if err != nil {
+		// Расписание проверяется при создании серии, сюда попадает только испорченная запись
+		m.log.Error("Materializer: invalid series schedule: series_id=%d, error=%v", series.ID, err)
+		return
+	}
+
+	created, err := m.repo.MaterializeSeries(ctx, *occurrences)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.log.Error("Materializer: failed to materialize series: series_id=%d, error=%v", series.ID, err)
+		}
+		return
+	}
+
+	for _, notification := range created {
+		m.scheduler.Schedule(notification.ID, *notification.ScheduledAt)
+	}
+	if len(created) > 0 {
+		m.log.Info("Materializer: %d notifications created: series_id=%d, span_id=%s", len(created), series.ID, series.SpanID)
+	}
+	if occurrences.Finished {
+		m.log.Info("Materializer: series finished: series_id=%d, span_id=%s", series.ID, series.SpanID)
+	}
+}
+
+// nextOccurrences вычисляет повторения серии до horizon
+// Повторения, пропущенные при простое дольше missedOccurrenceGrace, не создаются
+func nextOccurrences(series *domain.Series, now, horizon time.Time) (*domain.SeriesOccurrences, error) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := recurrence.Parse(string(series.Kind), series.Expression, series.StartAt.In(loc), series.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := max(maxOccurrenceRows/max(len(series.UserIDs), 1), 1)
+	result := &domain.SeriesOccurrences{
+		SeriesID:          series.ID,
+		PrevMaterialized:  series.MaterializedUntil,
+		MaterializedUntil: horizon,
+		ScheduledAt:       make([]time.Time, 0),
+	}
+
+	after := series.MaterializedUntil
+	if missed := now.Add(-missedOccurrenceGrace); after.Before(missed) {
+		after = missed
+	}
+	for {
+		if len(result.ScheduledAt) >= limit {
+			// Остальные повторения создаст следующий проход
+			result.MaterializedUntil = after
+			break
+		}
+		next, ok := schedule.Next(after)
+		if !ok {
+			result.Finished = true
+			result.MaterializedUntil = after
+			break
+		}
+		if next.After(horizon) {
+			break
+		}
+		result.ScheduledAt = append(result.ScheduledAt, next)
+		after = next
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// PollingHandler обрабатывает обновления Telegram, полученные через long polling
+type PollingHandler struct {
+	handler UpdateHandler
+	log     Logger
+}
+
+// NewPollingHandler создает новый экземпляр обработчика long polling
+func NewPollingHandler(handler UpdateHandler, log Logger) *PollingHandler {
+	return &PollingHandler{
+		handler: handler,
+		log:     log,
+	}
+}
+
+// Start читает обновления до отмены контекста или закрытия канала
+func (h *PollingHandler) Start(ctx context.Context, updates tgbotapi.UpdatesChannel) {
+	for {
+		select {
+		case <-ctx.Done():
+			h.log.Info("Telegram long polling stopped")
+			return
+		case update, ok := <-updates:
+			if !ok {
+				h.log.Info("Telegram updates channel closed")
+				return
+			}
+			if err := h.handler.HandleUpdate(ctx, update); err != nil {
+				h.log.Error("Failed to handle Telegram update: update_id=%d, error=%v", update.UpdateID, err)
+			}
+		}
+	}
+}
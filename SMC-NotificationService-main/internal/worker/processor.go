@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Processor периодически захватывает и отправляет уведомления, время которых наступило
+// Подбирает немедленные уведомления, отложенные, чьи таймеры были потеряны при перезапуске,
+// и уведомления с истекшей арендой. Захват пачки идёт через FOR UPDATE SKIP LOCKED,
+// поэтому processor может работать в любом числе экземпляров сервиса
+type Processor struct {
+	repo       NotificationRepository
+	dispatcher Dispatcher
+	policy     RetryPolicy
+	lease      *Lease
+	metrics    QueueMetrics // nil - метрики отключены
+	log        Logger
+	interval   time.Duration
+	batchSize  int
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewProcessor создает новый экземпляр processor
+func NewProcessor(
+	repo NotificationRepository,
+	dispatcher Dispatcher,
+	policy RetryPolicy,
+	lease *Lease,
+	metrics QueueMetrics,
+	log Logger,
+	interval time.Duration,
+	batchSize int,
+) *Processor {
+	return &Processor{
+		repo:       repo,
+		dispatcher: dispatcher,
+		policy:     policy,
+		lease:      lease,
+		metrics:    metrics,
+		log:        log,
+		interval:   interval,
+		batchSize:  batchSize,
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// Start запускает цикл обработки; блокируется до вызова Stop
+func (p *Processor) Start() {
+	defer close(p.doneCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.stopCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.reapExpiredLeases(ctx)
+		// Полная пачка - очередь не разобрана, следующая забирается без ожидания
+		for p.processBatch(ctx) == p.batchSize && ctx.Err() == nil {
+		}
+		p.updateQueueMetrics(ctx)
+
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop останавливает цикл и ждёт завершения текущего прохода
+func (p *Processor) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	<-p.doneCh
+}
+
+// processBatch захватывает и отправляет одну пачку, возвращает размер пачки
+func (p *Processor) processBatch(ctx context.Context) int {
+	notifications, err := p.repo.ClaimDue(ctx, p.lease.Owner(), p.lease.Duration(), p.batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			p.log.Error("Processor: failed to claim due notifications: %v", err)
+		}
+		return 0
+	}
+	if len(notifications) == 0 {
+		return 0
+	}
+
+	p.log.Info("Processor: %d due notifications claimed", len(notifications))
+	for i := range notifications {
+		if ctx.Err() != nil {
+			// Оставшиеся уведомления пачки вернёт в очередь Lease.Stop
+			return len(notifications)
+		}
+		// Повтор подберёт следующий проход processor любого экземпляра
+		deliver(ctx, p.repo, p.dispatcher, p.policy, p.lease, p.log, &notifications[i])
+	}
+
+	return len(notifications)
+}
+
+// reapExpiredLeases переносит в dead-letter уведомления, экземпляр которых остановился во время отправки
+func (p *Processor) reapExpiredLeases(ctx context.Context) {
+	reaped, err := p.repo.ReapExpiredLeases(ctx, p.batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			p.log.Error("Processor: failed to reap expired leases: %v", err)
+		}
+		return
+	}
+	if reaped > 0 {
+		p.log.Warn("Processor: %d notifications with expired lease moved to dead-letter", reaped)
+	}
+}
+
+func (p *Processor) updateQueueMetrics(ctx context.Context) {
+	if p.metrics == nil {
+		return
+	}
+
+	stats, err := p.repo.QueueStats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			p.log.Error("Processor: failed to collect queue stats: %v", err)
+		}
+		return
+	}
+	p.metrics.UpdateNotificationQueue(stats.Due, stats.Scheduled, stats.Processing, stats.ExpiredLeases, stats.Lag)
+}
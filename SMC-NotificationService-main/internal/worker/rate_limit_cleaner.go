@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitCleanerInterval период удаления свободных бакетов чатов и обновления метрик лимитов
+const rateLimitCleanerInterval = 15 * time.Second
+
+// RateLimitCleaner удаляет свободные бакеты чатов и неиспользованные брони слотов отправки в Telegram
+// и обновляет метрики заполненности лимитов; бакеты общие для всех экземпляров, очистка на любом из них безопасна
+type RateLimitCleaner struct {
+	repo    RateLimitRepository
+	metrics RateLimitMetrics // nil - метрики выключены
+	log     Logger
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewRateLimitCleaner создает новый экземпляр очистки лимитов отправки
+func NewRateLimitCleaner(repo RateLimitRepository, metrics RateLimitMetrics, log Logger) *RateLimitCleaner {
+	return &RateLimitCleaner{
+		repo:    repo,
+		metrics: metrics,
+		log:     log,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// Start запускает цикл очистки; блокируется до вызова Stop
+func (c *RateLimitCleaner) Start() {
+	defer close(c.doneCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.stopCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(rateLimitCleanerInterval)
+	defer ticker.Stop()
+
+	for {
+		c.cleanup(ctx)
+
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop останавливает цикл и ждёт завершения текущего прохода
+func (c *RateLimitCleaner) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+	<-c.doneCh
+}
+
+func (c *RateLimitCleaner) cleanup(ctx context.Context) {
+	if _, err := c.repo.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+		c.log.Error("RateLimitCleaner: failed to delete expired rate limit buckets: %v", err)
+	}
+
+	if c.metrics == nil {
+		return
+	}
+	stats, err := c.repo.Stats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.Error("RateLimitCleaner: failed to get rate limit stats: %v", err)
+		}
+		return
+	}
+	c.metrics.UpdateTelegramLimiter(stats.Backlog, stats.Chats)
+}
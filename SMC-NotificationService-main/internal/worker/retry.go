@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy политика повторной отправки уведомлений после временных ошибок
+type RetryPolicy struct {
+	MaxAttempts int           // попыток всего, включая первую; после последней уведомление уходит в dead-letter
+	BaseDelay   time.Duration // пауза после первой неудачной попытки
+	MaxDelay    time.Duration // верхняя граница паузы
+}
+
+// Backoff возвращает паузу перед следующей попыткой: BaseDelay * 2^(attempt-1), не больше MaxDelay,
+// со случайной половиной (equal jitter), чтобы повторы одной рассылки не совпадали во времени
+// Пауза не меньше retryAfter, который запросил канал
+func (p RetryPolicy) Backoff(attempt int, retryAfter time.Duration) time.Duration {
+	delay := p.MaxDelay
+	if attempt >= 1 && attempt < 32 {
+		if exp := p.BaseDelay << (attempt - 1); exp > 0 && exp < p.MaxDelay {
+			delay = exp
+		}
+	}
+
+	delay = delay/2 + rand.N(delay/2+1)
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay
+}
+
+// Exhausted проверяет, что попыток больше не осталось
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
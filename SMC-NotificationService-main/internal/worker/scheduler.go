@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+)
+
+// Scheduler отправляет уведомления точно в scheduled_at с помощью таймеров в памяти
+// Это быстрый путь экземпляра: таймер лишь пытается захватить уведомление, поэтому одновременные таймеры
+// нескольких экземпляров не приводят к повторной отправке. Таймеры теряются при перезапуске,
+// при старте их восстанавливает LoadScheduledNotifications, а пропущенные уведомления подбирает Processor
+type Scheduler struct {
+	repo       NotificationRepository
+	dispatcher Dispatcher
+	policy     RetryPolicy
+	lease      *Lease
+	log        Logger
+
+	mu     sync.Mutex
+	timers map[int64]*time.Timer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler создает новый экземпляр планировщика
+func NewScheduler(repo NotificationRepository, dispatcher Dispatcher, policy RetryPolicy, lease *Lease, log Logger) *Scheduler {
+	return &Scheduler{
+		repo:       repo,
+		dispatcher: dispatcher,
+		policy:     policy,
+		lease:      lease,
+		log:        log,
+		timers:     make(map[int64]*time.Timer),
+	}
+}
+
+// Start подготавливает планировщик к приёму уведомлений
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+// Stop снимает все таймеры и ждёт завершения начатых отправок
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	for id, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// LoadScheduledNotifications ставит таймеры для всех ещё не наступивших уведомлений и повторов из БД
+func (s *Scheduler) LoadScheduledNotifications(ctx context.Context) error {
+	notifications, err := s.repo.GetScheduledAfter(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		s.Schedule(notification.ID, *notification.DueAt())
+	}
+
+	s.log.Info("Scheduler: %d scheduled notifications loaded", len(notifications))
+	return nil
+}
+
+// Schedule ставит таймер отправки уведомления; время в прошлом означает немедленную отправку
+func (s *Scheduler) Schedule(id int64, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx == nil || s.ctx.Err() != nil {
+		// Планировщик не запущен: уведомление отправит Processor
+		return
+	}
+
+	if timer, ok := s.timers[id]; ok {
+		timer.Stop()
+	}
+
+	s.timers[id] = time.AfterFunc(time.Until(at), func() {
+		s.fire(id)
+	})
+}
+
+// Cancel снимает таймер уведомления, если он есть
+func (s *Scheduler) Cancel(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, ok := s.timers[id]; ok {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+func (s *Scheduler) fire(id int64) {
+	s.mu.Lock()
+	delete(s.timers, id)
+	ctx := s.ctx
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	defer s.wg.Done()
+	notification, err := s.repo.Claim(ctx, id, s.lease.Owner(), s.lease.Duration())
+	if err != nil {
+		// Уведомление уже захвачено другим экземпляром, отправлено или отменено
+		if !errors.Is(err, notificationRepo.ErrNotPending) {
+			s.log.Error("Failed to claim notification: id=%d, error=%v", id, err)
+		}
+		return
+	}
+
+	if nextAttemptAt := deliver(ctx, s.repo, s.dispatcher, s.policy, s.lease, s.log, notification); nextAttemptAt != nil {
+		s.Schedule(id, *nextAttemptAt)
+	}
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// processedUpdatesRetention срок хранения обработанных update_id: Telegram хранит недоставленные обновления
+	// не дольше 24 часов, поэтому более старое обновление повторно прийти не может
+	processedUpdatesRetention = 24 * time.Hour
+
+	// updatesCleanerInterval период удаления устаревших update_id
+	updatesCleanerInterval = time.Hour
+)
+
+// UpdatesCleaner удаляет записи об обработанных обновлениях Telegram старше срока повторной доставки
+type UpdatesCleaner struct {
+	repo ProcessedUpdatesRepository
+	log  Logger
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewUpdatesCleaner создает новый экземпляр очистки обработанных обновлений
+func NewUpdatesCleaner(repo ProcessedUpdatesRepository, log Logger) *UpdatesCleaner {
+	return &UpdatesCleaner{
+		repo:   repo,
+		log:    log,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Start запускает цикл очистки; блокируется до вызова Stop
+func (c *UpdatesCleaner) Start() {
+	defer close(c.doneCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.stopCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(updatesCleanerInterval)
+	defer ticker.Stop()
+
+	for {
+		c.cleanup(ctx)
+
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop останавливает цикл и ждёт завершения текущего прохода
+func (c *UpdatesCleaner) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+	<-c.doneCh
+}
+
+func (c *UpdatesCleaner) cleanup(ctx context.Context) {
+	deleted, err := c.repo.DeleteProcessedBefore(ctx, time.Now().Add(-processedUpdatesRetention))
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.Error("UpdatesCleaner: failed to delete processed updates: %v", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		c.log.Info("UpdatesCleaner: deleted %d processed updates", deleted)
+	}
+}
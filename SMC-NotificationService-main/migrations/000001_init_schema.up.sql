@@ -0,0 +1,37 @@
+-- Уведомления пользователям в Telegram
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,                 -- tg_user_id, он же chat_id личного чата с ботом
+    message TEXT NOT NULL,
+    span_id UUID,                            -- пакет, созданный одним запросом batch
+    scheduled_at TIMESTAMPTZ,                -- NULL - отправить как можно скорее
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'sent', 'failed', 'cancelled')),
+    error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Выборка processor и загрузка отложенных уведомлений при старте
+CREATE INDEX IF NOT EXISTS idx_notifications_pending_scheduled_at
+    ON notifications(scheduled_at) WHERE status = 'pending';
+-- Список уведомлений пользователя, выгрузка и удаление персональных данных
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC);
+-- Отмена пакета
+CREATE INDEX IF NOT EXISTS idx_notifications_span_id ON notifications(span_id) WHERE span_id IS NOT NULL;
+
+-- Автоматическое обновление updated_at
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_notifications_updated_at ON notifications;
+CREATE TRIGGER update_notifications_updated_at
+    BEFORE UPDATE ON notifications
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS push_subscriptions;
+DROP TRIGGER IF EXISTS update_channel_settings_updated_at ON channel_settings;
+DROP TABLE IF EXISTS channel_settings;
+DROP TABLE IF EXISTS notification_deliveries;
+ALTER TABLE notifications DROP COLUMN IF EXISTS channels;
@@ -0,0 +1,43 @@
+-- Каналы доставки уведомления в порядке fallback
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS channels VARCHAR(20)[] NOT NULL DEFAULT '{telegram}';
+
+-- Попытки доставки по каждому каналу
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('telegram', 'email', 'sms', 'webpush')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed', 'skipped')),
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_notification_id
+    ON notification_deliveries(notification_id, id);
+
+-- Пользовательские настройки каналов
+CREATE TABLE IF NOT EXISTS channel_settings (
+    user_id BIGINT PRIMARY KEY,              -- tg_user_id
+    channel_order VARCHAR(20)[] NOT NULL DEFAULT '{}',
+    email VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DROP TRIGGER IF EXISTS update_channel_settings_updated_at ON channel_settings;
+CREATE TRIGGER update_channel_settings_updated_at
+    BEFORE UPDATE ON channel_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Подписки браузеров на Web Push
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    endpoint TEXT NOT NULL UNIQUE,           -- адрес push-сервиса браузера
+    p256dh VARCHAR(128) NOT NULL,
+    auth VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user_id ON push_subscriptions(user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_locale,
+    DROP COLUMN IF EXISTS template_name,
+    DROP COLUMN IF EXISTS parse_mode;
+DROP TABLE IF EXISTS notification_templates;
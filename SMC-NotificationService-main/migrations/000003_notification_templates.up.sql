@@ -0,0 +1,20 @@
+-- Версии шаблонов уведомлений
+-- Версии неизменяемы: редактирование добавляет строку с version + 1
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,              -- например booking.confirmed
+    locale VARCHAR(5) NOT NULL CHECK (locale IN ('ru', 'en')),
+    version INT NOT NULL CHECK (version > 0),
+    parse_mode VARCHAR(20) NOT NULL DEFAULT '' CHECK (parse_mode IN ('', 'MarkdownV2', 'HTML')),
+    body TEXT NOT NULL,                      -- Go text/template
+    created_by BIGINT NOT NULL,              -- tg_user_id суперпользователя
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (name, locale, version)
+);
+
+-- Текст уведомления может быть создан из шаблона
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS parse_mode VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS template_name VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS template_locale VARCHAR(5),
+    ADD COLUMN IF NOT EXISTS template_version INT;
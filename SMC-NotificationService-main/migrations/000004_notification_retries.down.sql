@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS notification_dead_letters;
+
+DROP INDEX IF EXISTS idx_notifications_pending_due_at;
+CREATE INDEX IF NOT EXISTS idx_notifications_pending_scheduled_at
+    ON notifications(scheduled_at) WHERE status = 'pending';
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,23 @@
+-- Повторные попытки отправки с экспоненциальной паузой
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ;   -- NULL - повтор не запланирован
+
+-- Выборка processor: scheduled_at или время повтора
+DROP INDEX IF EXISTS idx_notifications_pending_scheduled_at;
+CREATE INDEX IF NOT EXISTS idx_notifications_pending_due_at
+    ON notifications((COALESCE(next_attempt_at, scheduled_at))) WHERE status = 'pending';
+
+-- Уведомления, которые не удалось доставить: постоянная ошибка или исчерпаны попытки
+CREATE TABLE IF NOT EXISTS notification_dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT NOT NULL UNIQUE REFERENCES notifications(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('permanent', 'exhausted')),
+    attempts INT NOT NULL,
+    error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_dead_letters_created_at ON notification_dead_letters(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notification_dead_letters_user_id ON notification_dead_letters(user_id);
@@ -0,0 +1,11 @@
+DELETE FROM notification_dead_letters WHERE reason = 'lease_expired';
+ALTER TABLE notification_dead_letters DROP CONSTRAINT IF EXISTS notification_dead_letters_reason_check;
+ALTER TABLE notification_dead_letters ADD CONSTRAINT notification_dead_letters_reason_check
+    CHECK (reason IN ('permanent', 'exhausted'));
+
+DROP INDEX IF EXISTS idx_notifications_processing_locked_until;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS sending_at,
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS locked_by;
@@ -0,0 +1,19 @@
+-- Аренда уведомлений обработчиками: любое число экземпляров сервиса разбирает очередь без дублей
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS locked_by VARCHAR(255),        -- экземпляр, захвативший уведомление
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ,      -- аренда продлевается heartbeat, после истечения уведомление забирают другие
+    ADD COLUMN IF NOT EXISTS sending_at TIMESTAMPTZ;        -- начало отправки по каналам; после него повтор без подтверждения запрещён
+
+-- Поиск просроченных аренд
+CREATE INDEX IF NOT EXISTS idx_notifications_processing_locked_until
+    ON notifications(locked_until) WHERE status = 'processing';
+
+-- Уведомления, зависшие в processing до появления аренды, считаются начатыми: исход отправки неизвестен
+UPDATE notifications
+SET locked_until = NOW(), sending_at = updated_at
+WHERE status = 'processing' AND locked_until IS NULL;
+
+-- Экземпляр остановился во время отправки: доставлено ли уведомление - неизвестно
+ALTER TABLE notification_dead_letters DROP CONSTRAINT IF EXISTS notification_dead_letters_reason_check;
+ALTER TABLE notification_dead_letters ADD CONSTRAINT notification_dead_letters_reason_check
+    CHECK (reason IN ('permanent', 'exhausted', 'lease_expired'));
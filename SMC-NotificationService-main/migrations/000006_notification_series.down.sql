@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_notifications_series_occurrence;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TRIGGER IF EXISTS update_notification_series_updated_at ON notification_series;
+DROP TABLE IF EXISTS notification_series;
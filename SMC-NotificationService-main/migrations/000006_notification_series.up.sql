@@ -0,0 +1,40 @@
+-- Повторяющиеся уведомления: расписание cron или RRULE, по которому заранее создаются уведомления-повторения
+CREATE TABLE IF NOT EXISTS notification_series (
+    id BIGSERIAL PRIMARY KEY,
+    span_id UUID NOT NULL UNIQUE,            -- общий span_id всех повторений: отмена серии через отмену пакета
+    user_ids BIGINT[] NOT NULL,              -- получатели, tg_user_id
+    message TEXT NOT NULL,
+    parse_mode VARCHAR(20) NOT NULL DEFAULT '',
+    channels VARCHAR(20)[] NOT NULL DEFAULT '{telegram}',
+    template_name VARCHAR(100),
+    template_locale VARCHAR(5),
+    template_version INT,
+    schedule_kind VARCHAR(10) NOT NULL CHECK (schedule_kind IN ('cron', 'rrule')),
+    expression TEXT NOT NULL,
+    timezone VARCHAR(64) NOT NULL,           -- IANA, например Europe/Moscow
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ,                      -- NULL - без ограничения
+    materialized_until TIMESTAMPTZ NOT NULL, -- повторения до этого момента включительно уже созданы
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'finished', 'cancelled')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Выборка серий, которым нужно создать повторения
+CREATE INDEX IF NOT EXISTS idx_notification_series_active_materialized_until
+    ON notification_series(materialized_until) WHERE status = 'active';
+
+DROP TRIGGER IF EXISTS update_notification_series_updated_at ON notification_series;
+CREATE TRIGGER update_notification_series_updated_at
+    BEFORE UPDATE ON notification_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Повторение серии; NULL - разовое уведомление
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS series_id BIGINT REFERENCES notification_series(id) ON DELETE SET NULL;
+
+-- Повторение создаётся не более одного раза, даже если отменено
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_series_occurrence
+    ON notifications(series_id, user_id, scheduled_at) WHERE series_id IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS update_notification_preferences_updated_at ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
+
+ALTER TABLE notification_series
+    DROP COLUMN IF EXISTS category;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS category;
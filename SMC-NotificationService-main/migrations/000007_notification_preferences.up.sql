@@ -0,0 +1,28 @@
+-- Категория уведомления: от reminders и marketing можно отписаться, они откладываются в тихие часы
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS category VARCHAR(20) NOT NULL DEFAULT 'transactional'
+        CHECK (category IN ('transactional', 'reminders', 'marketing'));
+
+ALTER TABLE notification_series
+    ADD COLUMN IF NOT EXISTS category VARCHAR(20) NOT NULL DEFAULT 'transactional'
+        CHECK (category IN ('transactional', 'reminders', 'marketing'));
+
+-- Настройки уведомлений, которые пользователь задаёт сам
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT PRIMARY KEY,              -- tg_user_id
+    opted_out VARCHAR(20)[] NOT NULL DEFAULT '{}', -- категории, от которых пользователь отписался
+    preferred_channel VARCHAR(20)
+        CHECK (preferred_channel IN ('telegram', 'email', 'sms', 'webpush')),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 1439), -- минуты от полуночи
+    quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+DROP TRIGGER IF EXISTS update_notification_preferences_updated_at ON notification_preferences;
+CREATE TRIGGER update_notification_preferences_updated_at
+    BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_bot_conversations_updated_at ON bot_conversations;
+DROP TABLE IF EXISTS bot_conversations;
@@ -0,0 +1,18 @@
+-- Диалоги с ботом: шаг сценария и собранные данные переживают перезапуск и работают с любым экземпляром
+CREATE TABLE IF NOT EXISTS bot_conversations (
+    chat_id BIGINT PRIMARY KEY,              -- личный чат, совпадает с tg_user_id
+    user_id BIGINT NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'idle'
+        CHECK (state IN ('idle', 'car_brand', 'car_model', 'car_plate', 'location', 'quiet_hours')),
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_conversations_user_id ON bot_conversations(user_id);
+
+DROP TRIGGER IF EXISTS update_bot_conversations_updated_at ON bot_conversations;
+CREATE TRIGGER update_bot_conversations_updated_at
+    BEFORE UPDATE ON bot_conversations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
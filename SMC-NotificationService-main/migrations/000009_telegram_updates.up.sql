@@ -0,0 +1,8 @@
+-- Обработанные обновления Telegram: повторная доставка того же update_id (webhook или long polling) пропускается
+-- Telegram хранит недоставленные обновления не дольше 24 часов, более старые записи удаляет worker
+CREATE TABLE IF NOT EXISTS telegram_updates (
+    update_id BIGINT PRIMARY KEY,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_telegram_updates_processed_at ON telegram_updates(processed_at);
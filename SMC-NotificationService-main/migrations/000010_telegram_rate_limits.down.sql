@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS telegram_send_slots;
+DROP TABLE IF EXISTS telegram_rate_buckets;
@@ -0,0 +1,17 @@
+-- Лимиты отправки в Telegram общие для всех экземпляров: лимит Bot API действует на токен бота
+-- Строка bucket = 'global' - общий лимит, 'chat:<chat_id>' - лимит чата. tat (theoretical arrival time, GCRA) -
+-- время, с которого бакет снова свободен; строки чатов в прошлом удаляет worker
+CREATE TABLE IF NOT EXISTS telegram_rate_buckets (
+    bucket TEXT PRIMARY KEY,
+    tat TIMESTAMPTZ NOT NULL
+);
+
+-- Слоты, забронированные отложенными уведомлениями: при возврате из очереди уведомление, каким бы экземпляром
+-- оно ни было захвачено, отправляется в свой слот без новой брони
+CREATE TABLE IF NOT EXISTS telegram_send_slots (
+    notification_id BIGINT PRIMARY KEY REFERENCES notifications(id) ON DELETE CASCADE,
+    send_at TIMESTAMPTZ NOT NULL,
+    scope TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_telegram_send_slots_send_at ON telegram_send_slots(send_at);
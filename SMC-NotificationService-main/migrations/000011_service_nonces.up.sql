@@ -0,0 +1,9 @@
+-- Nonce подписанных межсервисных запросов (svcauth): общие для всех экземпляров сервиса,
+-- поэтому подписанный запрос нельзя повторить на другом экземпляре или после рестарта
+-- Истёкшие записи удаляет сам svcauth.Verifier не чаще раза в окно допустимого расхождения времени
+CREATE TABLE IF NOT EXISTS service_nonces (
+    nonce VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_nonces_expires_at ON service_nonces(expires_at);
@@ -0,0 +1,244 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics содержит все метрики приложения
+type Metrics struct {
+	// HTTP метрики
+	HTTPRequestsTotal   *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+	HTTPErrorsTotal     *prometheus.CounterVec
+
+	// Database метрики
+	DBQueriesTotal    *prometheus.CounterVec
+	DBQueryDuration   *prometheus.HistogramVec
+	DBErrorsTotal     *prometheus.CounterVec
+	DBConnectionsActive prometheus.Gauge
+	DBConnectionsIdle   prometheus.Gauge
+	DBConnectionsMax    prometheus.Gauge
+
+	// Метрики очереди уведомлений
+	NotificationQueueDepth *prometheus.GaugeVec
+	NotificationQueueLag   prometheus.Gauge
+
+	// Метрики ограничителя скорости Telegram
+	TelegramThrottledTotal *prometheus.CounterVec
+	TelegramThrottleWait   *prometheus.HistogramVec
+	TelegramLimiterBacklog prometheus.Gauge
+	TelegramLimiterChats   prometheus.Gauge
+}
+
+// New создаёт новый экземпляр метрик с автоматической регистрацией в Prometheus
+func New(serviceName string) *Metrics {
+	m := &Metrics{
+		// HTTP метрики
+		HTTPRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_requests_total",
+				Help: "Total number of HTTP requests",
+			},
+			[]string{"service", "method", "endpoint", "status_code"},
+		),
+
+		HTTPRequestDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_request_duration_seconds",
+				Help:    "HTTP request duration in seconds",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+			},
+			[]string{"service", "method", "endpoint", "status_code"},
+		),
+
+		HTTPErrorsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_errors_total",
+				Help: "Total number of HTTP errors",
+			},
+			[]string{"service", "method", "endpoint", "status_code", "error_type"},
+		),
+
+		// Database метрики
+		DBQueriesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "db_queries_total",
+				Help: "Total number of database queries",
+			},
+			[]string{"service", "operation", "table", "status"},
+		),
+
+		DBQueryDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "db_query_duration_seconds",
+				Help:    "Database query duration in seconds",
+				Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+			},
+			[]string{"service", "operation", "table"},
+		),
+
+		DBErrorsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "db_errors_total",
+				Help: "Total number of database errors",
+			},
+			[]string{"service", "operation", "table", "error_type"},
+		),
+
+		DBConnectionsActive: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "db_connections_active",
+				Help: "Number of active database connections",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+
+		DBConnectionsIdle: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "db_connections_idle",
+				Help: "Number of idle database connections",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+
+		DBConnectionsMax: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "db_connections_max",
+				Help: "Maximum number of database connections",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+
+		// Метрики очереди уведомлений
+		NotificationQueueDepth: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "notification_queue_depth",
+				Help: "Number of notifications in the delivery queue by state",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+			[]string{"state"},
+		),
+
+		NotificationQueueLag: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "notification_queue_lag_seconds",
+				Help: "How long the oldest due notification has been waiting for delivery",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+
+		// Метрики ограничителя скорости Telegram
+		TelegramThrottledTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "telegram_throttled_total",
+				Help: "Total number of Telegram messages delayed by the rate limiter",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+			[]string{"scope", "outcome"},
+		),
+
+		TelegramThrottleWait: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "telegram_throttle_wait_seconds",
+				Help:    "Delay imposed on Telegram messages by the rate limiter",
+				Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 180},
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+			[]string{"scope", "outcome"},
+		),
+
+		TelegramLimiterBacklog: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "telegram_limiter_backlog_seconds",
+				Help: "How far ahead the global Telegram rate limit bucket shared by all instances is booked",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+
+		TelegramLimiterChats: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "telegram_limiter_chats",
+				Help: "Number of chats that cannot receive a Telegram message right now because of the per-chat rate limit",
+				ConstLabels: prometheus.Labels{
+					"service": serviceName,
+				},
+			},
+		),
+	}
+
+	return m
+}
+
+// RecordHTTPRequest записывает метрики HTTP запроса
+func (m *Metrics) RecordHTTPRequest(service, method, endpoint, statusCode string, duration float64) {
+	m.HTTPRequestsTotal.WithLabelValues(service, method, endpoint, statusCode).Inc()
+	m.HTTPRequestDuration.WithLabelValues(service, method, endpoint, statusCode).Observe(duration)
+}
+
+// RecordHTTPError записывает метрику HTTP ошибки
+func (m *Metrics) RecordHTTPError(service, method, endpoint, statusCode, errorType string) {
+	m.HTTPErrorsTotal.WithLabelValues(service, method, endpoint, statusCode, errorType).Inc()
+}
+
+// RecordDBQuery записывает метрики database запроса
+func (m *Metrics) RecordDBQuery(service, operation, table, status string, duration float64) {
+	m.DBQueriesTotal.WithLabelValues(service, operation, table, status).Inc()
+	m.DBQueryDuration.WithLabelValues(service, operation, table).Observe(duration)
+}
+
+// RecordDBError записывает метрику database ошибки
+func (m *Metrics) RecordDBError(service, operation, table, errorType string) {
+	m.DBErrorsTotal.WithLabelValues(service, operation, table, errorType).Inc()
+}
+
+// UpdateDBConnectionStats обновляет метрики connection pool
+func (m *Metrics) UpdateDBConnectionStats(active, idle, max int) {
+	m.DBConnectionsActive.Set(float64(active))
+	m.DBConnectionsIdle.Set(float64(idle))
+	m.DBConnectionsMax.Set(float64(max))
+}
+
+// UpdateNotificationQueue обновляет метрики очереди уведомлений
+func (m *Metrics) UpdateNotificationQueue(due, scheduled, processing, expiredLeases int, lag time.Duration) {
+	m.NotificationQueueDepth.WithLabelValues("due").Set(float64(due))
+	m.NotificationQueueDepth.WithLabelValues("scheduled").Set(float64(scheduled))
+	m.NotificationQueueDepth.WithLabelValues("processing").Set(float64(processing))
+	m.NotificationQueueDepth.WithLabelValues("expired_lease").Set(float64(expiredLeases))
+	m.NotificationQueueLag.Set(lag.Seconds())
+}
+
+// RecordTelegramThrottled записывает задержку сообщения ограничителем скорости Telegram
+// scope - бакет, ограничивший отправку; deferred - сообщение возвращено в очередь вместо ожидания
+func (m *Metrics) RecordTelegramThrottled(scope string, deferred bool, wait time.Duration) {
+	outcome := "waited"
+	if deferred {
+		outcome = "deferred"
+	}
+	m.TelegramThrottledTotal.WithLabelValues(scope, outcome).Inc()
+	m.TelegramThrottleWait.WithLabelValues(scope, outcome).Observe(wait.Seconds())
+}
+
+// UpdateTelegramLimiter обновляет метрики заполненности ограничителя скорости Telegram
+func (m *Metrics) UpdateTelegramLimiter(backlog time.Duration, chats int) {
+	m.TelegramLimiterBacklog.Set(backlog.Seconds())
+	m.TelegramLimiterChats.Set(float64(chats))
+}
@@ -0,0 +1,65 @@
+// Package netguard защита исходящих запросов по адресам, которые задают пользователи
+//
+// Адрес push-сервиса приходит от клиента, поэтому сервис не должен ходить по нему во внутреннюю сеть:
+// loopback, частные, link-local и прочие неглобальные адреса запрещены. Проверка имени при сохранении
+// не защищает от DNS, который позже вернёт внутренний адрес, поэтому адрес проверяется ещё и при подключении
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress возвращается для адреса вне публичного интернета
+var ErrForbiddenAddress = errors.New("netguard: address is not public")
+
+// PublicAddr проверяет, что адрес маршрутизируется в публичном интернете
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace 100.64.0.0/10 (RFC 6598, CGNAT) - не частный по IsPrivate, но и не публичный
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// ValidateURL проверяет адрес, заданный пользователем: только https и не внутренний хост
+// Имена хостов не резолвятся - их проверяет Control при подключении
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("%w: must be an absolute https URL", ErrForbiddenAddress)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !PublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// Control запрещает подключение к непубличным адресам; подходит для net.Dialer.Control
+// Вызывается после резолва имени, поэтому закрывает и DNS, указывающий во внутреннюю сеть
+func Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !PublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays горизонт поиска следующего повторения: расписание вроде "30 февраля" не сработает никогда
+const cronSearchDays = 5 * 366
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// cronSchedule разобранное cron выражение; поля хранятся битовыми масками
+type cronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// Если ограничены и день месяца, и день недели, достаточно совпадения любого (как в Vixie cron)
+	daysRestricted     bool
+	weekdaysRestricted bool
+
+	loc *time.Location
+}
+
+// ParseCron разбирает cron выражение из 5 полей; время повторений вычисляется в loc
+func ParseCron(expr string, loc *time.Location) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: cron must have 5 fields (minute hour day month weekday), got %d", ErrInvalidExpression, len(fields))
+	}
+
+	c := &cronSchedule{loc: loc}
+	var err error
+	if c.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("%w: minute: %v", ErrInvalidExpression, err)
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("%w: hour: %v", ErrInvalidExpression, err)
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("%w: day of month: %v", ErrInvalidExpression, err)
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("%w: month: %v", ErrInvalidExpression, err)
+	}
+	if c.weekdays, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("%w: day of week: %v", ErrInvalidExpression, err)
+	}
+	// 7 - тоже воскресенье
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays = c.weekdays&^(1<<7) | 1
+	}
+	c.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	c.weekdaysRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// Next возвращает первое повторение строго после after с точностью до минуты
+func (c *cronSchedule) Next(after time.Time) (time.Time, bool) {
+	local := after.In(c.loc)
+	year, month, day := local.Date()
+
+	for i := 0; i < cronSearchDays; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, c.loc)
+		if !c.matchDate(date) {
+			continue
+		}
+
+		for hour := 0; hour < 24; hour++ {
+			if c.hours&(1<<hour) == 0 {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if c.minutes&(1<<minute) == 0 {
+					continue
+				}
+				candidate := wallClock(date.Year(), date.Month(), date.Day(), hour, minute, 0, c.loc)
+				if candidate.After(after) {
+					return candidate, true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (c *cronSchedule) matchDate(date time.Time) bool {
+	if c.months&(1<<int(date.Month())) == 0 {
+		return false
+	}
+
+	dayMatch := c.days&(1<<date.Day()) != 0
+	weekdayMatch := c.weekdays&(1<<int(date.Weekday())) != 0
+	if c.daysRestricted && c.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// parseCronField разбирает поле вида "*", "5", "1-5", "*/15", "10-20/5", "MON-FRI" и их списки через запятую
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = cronValue(from, names); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if hi, err = cronValue(to, names); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				hi = lo
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func cronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
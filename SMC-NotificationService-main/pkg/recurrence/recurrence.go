@@ -0,0 +1,99 @@
+// Package recurrence вычисляет моменты повторяющихся расписаний
+//
+// Поддерживаются:
+//   - cron: 5 полей (минута, час, день месяца, месяц, день недели), списки, диапазоны, шаги,
+//     имена месяцев и дней (JAN, MON) и макросы @hourly, @daily, @weekly, @monthly, @yearly
+//   - RRULE (RFC 5545): FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, COUNT, UNTIL, BYMONTH,
+//     BYMONTHDAY, BYDAY (в том числе 1MO, -1FR для MONTHLY и YEARLY), BYHOUR, BYMINUTE
+//
+// Время вычисляется по настенным часам часового пояса расписания: "каждый понедельник в 10:00"
+// остаётся 10:00 при переходе на летнее время
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Форматы выражения расписания для Parse
+const (
+	KindCron  = "cron"
+	KindRRule = "rrule"
+)
+
+// ErrInvalidExpression возвращается, когда выражение расписания не разбирается
+var ErrInvalidExpression = errors.New("invalid schedule expression")
+
+// Schedule расписание повторений
+type Schedule interface {
+	// Next возвращает первое повторение строго после after; false - повторений больше нет
+	Next(after time.Time) (time.Time, bool)
+}
+
+// Parse разбирает расписание формата kind и ограничивает повторения интервалом [start, end]
+// Часовой пояс расписания - часовой пояс start; end nil - без ограничения
+func Parse(kind, expression string, start time.Time, end *time.Time) (Schedule, error) {
+	var (
+		schedule Schedule
+		err      error
+	)
+	switch kind {
+	case KindCron:
+		schedule, err = ParseCron(expression, start.Location())
+	case KindRRule:
+		schedule, err = ParseRRule(expression, start)
+	default:
+		return nil, fmt.Errorf("%w: unknown schedule kind %q", ErrInvalidExpression, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &window{schedule: schedule, start: start, end: end}, nil
+}
+
+// window ограничивает повторения расписания интервалом [start, end]
+type window struct {
+	schedule Schedule
+	start    time.Time
+	end      *time.Time
+}
+
+func (w *window) Next(after time.Time) (time.Time, bool) {
+	if after.Before(w.start) {
+		// Next ищет строго после after: повторение ровно в start тоже подходит
+		after = w.start.Add(-time.Nanosecond)
+	}
+	next, ok := w.schedule.Next(after)
+	if !ok || (w.end != nil && next.After(*w.end)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// Upcoming возвращает до n ближайших повторений после after
+func Upcoming(schedule Schedule, after time.Time, n int) []time.Time {
+	occurrences := make([]time.Time, 0, n)
+	for len(occurrences) < n {
+		next, ok := schedule.Next(after)
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, next)
+		after = next
+	}
+	return occurrences
+}
+
+// wallClock возвращает момент с указанным временем на часах loc
+// Время, пропущенное при переходе на летнее время, сдвигается на момент перехода (02:30 → 03:00 + 30 минут)
+func wallClock(year int, month time.Month, day, hour, minute, second int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, second, 0, loc)
+	if t.Hour() != hour || t.Minute() != minute {
+		_, before := t.Zone()
+		_, after := t.Add(3 * time.Hour).Zone()
+		t = t.Add(time.Duration(after-before) * time.Second)
+	}
+	return t
+}
@@ -0,0 +1,441 @@
+package recurrence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxInterval = 1000
+	maxCount    = 10000
+
+	// rruleSearchPeriods горизонт поиска: BYMONTH=2;BYMONTHDAY=30 не сработает никогда
+	rruleSearchPeriods = 2000
+)
+
+type frequency int
+
+const (
+	freqDaily frequency = iota
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var frequencies = map[string]frequency{
+	"DAILY":   freqDaily,
+	"WEEKLY":  freqWeekly,
+	"MONTHLY": freqMonthly,
+	"YEARLY":  freqYearly,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// weekdayNum день недели BYDAY; n - порядковый номер в месяце (1MO, -1FR), 0 - каждый
+type weekdayNum struct {
+	weekday time.Weekday
+	n       int
+}
+
+// rrule разобранное правило RFC 5545; первое повторение - dtstart, если оно подходит под правило
+type rrule struct {
+	freq       frequency
+	interval   int
+	count      int        // 0 - без ограничения
+	until      *time.Time // включительно
+	byMonth    []int
+	byMonthDay []int
+	byDay      []weekdayNum
+	byHour     []int
+	byMinute   []int
+	dtstart    time.Time
+}
+
+// ParseRRule разбирает RRULE ("FREQ=WEEKLY;BYDAY=MO;BYHOUR=10;BYMINUTE=0", префикс "RRULE:" допускается)
+// dtstart задаёт начало серии, часовой пояс и время повторений, если BYHOUR/BYMINUTE не заданы
+func ParseRRule(rule string, dtstart time.Time) (Schedule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("%w: empty rrule", ErrInvalidExpression)
+	}
+
+	r := &rrule{interval: 1, dtstart: dtstart.Truncate(time.Second)}
+	hasFreq := false
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: invalid rrule part %q", ErrInvalidExpression, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq, known := frequencies[strings.ToUpper(value)]
+			if !known {
+				return nil, fmt.Errorf("%w: unsupported FREQ %q (DAILY, WEEKLY, MONTHLY or YEARLY)", ErrInvalidExpression, value)
+			}
+			r.freq, hasFreq = freq, true
+		case "INTERVAL":
+			r.interval, err = parseBounded(value, 1, maxInterval)
+		case "COUNT":
+			r.count, err = parseBounded(value, 1, maxCount)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value, dtstart.Location())
+			r.until = &until
+		case "BYMONTH":
+			r.byMonth, err = parseIntList(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseIntList(value, -31, 31, true)
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "BYHOUR":
+			r.byHour, err = parseIntList(value, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseIntList(value, 0, 59, false)
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("unsupported rrule part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidExpression, key, err)
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidExpression)
+	}
+	if r.count > 0 && r.until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidExpression)
+	}
+	for _, day := range r.byDay {
+		if day.n != 0 && r.freq != freqMonthly && r.freq != freqYearly {
+			return nil, fmt.Errorf("%w: BYDAY with ordinal requires FREQ=MONTHLY or YEARLY", ErrInvalidExpression)
+		}
+	}
+
+	return r, nil
+}
+
+// Next возвращает первое повторение строго после after
+func (r *rrule) Next(after time.Time) (time.Time, bool) {
+	// С COUNT повторения считаются от начала серии, без него можно сразу перейти к периоду after
+	first := 0
+	if r.count == 0 {
+		first = r.periodsBefore(after)
+	}
+
+	seen := 0
+	for k := first; k < first+rruleSearchPeriods; k++ {
+		candidates, periodStart := r.expand(k)
+		if r.until != nil && periodStart.After(*r.until) {
+			return time.Time{}, false
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Before(r.dtstart) {
+				continue
+			}
+			if r.until != nil && candidate.After(*r.until) {
+				return time.Time{}, false
+			}
+			seen++
+			if r.count > 0 && seen > r.count {
+				return time.Time{}, false
+			}
+			if candidate.After(after) {
+				return candidate, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// periodsBefore оценивает число целых периодов между dtstart и after с запасом в один период
+func (r *rrule) periodsBefore(after time.Time) int {
+	if !after.After(r.dtstart) {
+		return 0
+	}
+
+	local := after.In(r.dtstart.Location())
+	var periods int
+	switch r.freq {
+	case freqDaily:
+		periods = int(local.Sub(r.dtstart).Hours()/24) / r.interval
+	case freqWeekly:
+		periods = int(local.Sub(r.dtstart).Hours()/24/7) / r.interval
+	case freqMonthly:
+		periods = ((local.Year()-r.dtstart.Year())*12 + int(local.Month()-r.dtstart.Month())) / r.interval
+	case freqYearly:
+		periods = (local.Year() - r.dtstart.Year()) / r.interval
+	}
+
+	if periods--; periods < 0 {
+		return 0
+	}
+	return periods
+}
+
+// expand возвращает упорядоченные повторения k-го периода и начало периода
+func (r *rrule) expand(k int) ([]time.Time, time.Time) {
+	loc := r.dtstart.Location()
+	year, month, day := r.dtstart.Date()
+	step := k * r.interval
+
+	var (
+		dates       []time.Time
+		periodStart time.Time
+	)
+	switch r.freq {
+	case freqDaily:
+		periodStart = time.Date(year, month, day+step, 0, 0, 0, 0, loc)
+		dates = []time.Time{periodStart}
+	case freqWeekly:
+		monday := day - (int(r.dtstart.Weekday())+6)%7
+		periodStart = time.Date(year, month, monday+7*step, 0, 0, 0, 0, loc)
+		weekdays := []time.Weekday{r.dtstart.Weekday()}
+		if len(r.byDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, d := range r.byDay {
+				weekdays = append(weekdays, d.weekday)
+			}
+		}
+		for _, weekday := range weekdays {
+			offset := (int(weekday) + 6) % 7
+			dates = append(dates, time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day()+offset, 0, 0, 0, 0, loc))
+		}
+	case freqMonthly:
+		periodStart = time.Date(year, month+time.Month(step), 1, 0, 0, 0, 0, loc)
+		dates = r.monthDates(periodStart.Year(), periodStart.Month())
+	case freqYearly:
+		periodStart = time.Date(year+step, time.January, 1, 0, 0, 0, 0, loc)
+		months := r.byMonth
+		if len(months) == 0 {
+			months = []int{int(month)}
+		}
+		for _, m := range months {
+			dates = append(dates, r.monthDates(periodStart.Year(), time.Month(m))...)
+		}
+	}
+
+	dates = r.filter(dates)
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	hours := r.byHour
+	if len(hours) == 0 {
+		hours = []int{r.dtstart.Hour()}
+	}
+	minutes := r.byMinute
+	if len(minutes) == 0 {
+		minutes = []int{r.dtstart.Minute()}
+	}
+
+	occurrences := make([]time.Time, 0, len(dates)*len(hours)*len(minutes))
+	var last time.Time
+	for _, date := range dates {
+		if date.Equal(last) {
+			continue
+		}
+		last = date
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				occurrences = append(occurrences, wallClock(
+					date.Year(), date.Month(), date.Day(), hour, minute, r.dtstart.Second(), loc,
+				))
+			}
+		}
+	}
+
+	return occurrences, periodStart
+}
+
+// monthDates возвращает дни месяца по BYMONTHDAY и BYDAY; без них - день месяца dtstart
+func (r *rrule) monthDates(year int, month time.Month) []time.Time {
+	loc := r.dtstart.Location()
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		if r.dtstart.Day() > lastDay {
+			// 31-е число в коротком месяце пропускается, как в RFC 5545
+			return nil
+		}
+		return []time.Time{time.Date(year, month, r.dtstart.Day(), 0, 0, 0, 0, loc)}
+	}
+
+	var days []int
+	if len(r.byMonthDay) > 0 {
+		for _, d := range r.byMonthDay {
+			if d < 0 {
+				d = lastDay + 1 + d
+			}
+			if d >= 1 && d <= lastDay {
+				days = append(days, d)
+			}
+		}
+	}
+
+	if len(r.byDay) > 0 {
+		var weekdayDays []int
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, loc).Weekday()
+		for _, wd := range r.byDay {
+			first := 1 + (int(wd.weekday)-int(firstWeekday)+7)%7
+			var matches []int
+			for d := first; d <= lastDay; d += 7 {
+				matches = append(matches, d)
+			}
+			switch {
+			case wd.n == 0:
+				weekdayDays = append(weekdayDays, matches...)
+			case wd.n > 0 && wd.n <= len(matches):
+				weekdayDays = append(weekdayDays, matches[wd.n-1])
+			case wd.n < 0 && -wd.n <= len(matches):
+				weekdayDays = append(weekdayDays, matches[len(matches)+wd.n])
+			}
+		}
+
+		if len(r.byMonthDay) > 0 {
+			days = intersect(days, weekdayDays)
+		} else {
+			days = weekdayDays
+		}
+	}
+
+	dates := make([]time.Time, len(days))
+	for i, d := range days {
+		dates[i] = time.Date(year, month, d, 0, 0, 0, 0, loc)
+	}
+	return dates
+}
+
+// filter ограничивает дни периода правилами, которые для частоты не расширяют, а фильтруют
+func (r *rrule) filter(dates []time.Time) []time.Time {
+	filtered := dates[:0]
+	for _, date := range dates {
+		if len(r.byMonth) > 0 && !containsInt(r.byMonth, int(date.Month())) {
+			continue
+		}
+		if r.freq == freqDaily || r.freq == freqWeekly {
+			if len(r.byMonthDay) > 0 && !matchMonthDay(r.byMonthDay, date) {
+				continue
+			}
+			if r.freq == freqDaily && len(r.byDay) > 0 && !matchWeekday(r.byDay, date.Weekday()) {
+				continue
+			}
+		}
+		filtered = append(filtered, date)
+	}
+	return filtered
+}
+
+func matchMonthDay(byMonthDay []int, date time.Time) bool {
+	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	for _, d := range byMonthDay {
+		if d == date.Day() || lastDay+1+d == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func matchWeekday(byDay []weekdayNum, weekday time.Weekday) bool {
+	for _, d := range byDay {
+		if d.weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	var days []weekdayNum
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid weekday ordinal %q", item)
+			}
+		}
+		days = append(days, weekdayNum{weekday: weekday, n: n})
+	}
+	return days, nil
+}
+
+func parseIntList(value string, min, max int, nonZero bool) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		v, err := parseBounded(item, min, max)
+		if err != nil {
+			return nil, err
+		}
+		if nonZero && v == 0 {
+			return nil, fmt.Errorf("value must not be 0")
+		}
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	return values, nil
+}
+
+func parseBounded(value string, min, max int) (int, error) {
+	v, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range %d..%d", v, min, max)
+	}
+	return v, nil
+}
+
+// parseUntil разбирает UNTIL в UTC (20261231T235959Z), местном времени (20261231T235959) или дату (20261231, весь день)
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func intersect(a, b []int) []int {
+	var result []int
+	for _, v := range a {
+		if containsInt(b, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Package unsubscribe подписанные токены отписки в один клик
+//
+// Токен содержит пользователя и категорию рассылки и подписан HMAC-SHA256, поэтому ссылку
+// нельзя подделать для чужого пользователя. Срока действия нет: ссылка в старом письме
+// должна работать всегда, а повторная отписка ничего не меняет
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidToken возвращается, когда токен повреждён или подписан другим секретом
+var ErrInvalidToken = errors.New("unsubscribe: invalid token")
+
+// Signer выпускает и проверяет токены отписки
+type Signer struct {
+	secret  []byte
+	baseURL string
+}
+
+// NewSigner создает подписчика токенов; baseURL - адрес страницы отписки, к которому добавляется ?token=
+func NewSigner(secret, baseURL string) (*Signer, error) {
+	if secret == "" {
+		return nil, errors.New("unsubscribe: secret is required")
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, errors.New("unsubscribe: invalid base url")
+	}
+	return &Signer{secret: []byte(secret), baseURL: baseURL}, nil
+}
+
+// Token выпускает токен отписки пользователя от категории
+func (s *Signer) Token(userID int64, category string) string {
+	payload := strconv.FormatInt(userID, 10) + ":" + category
+	return encode([]byte(payload)) + "." + encode(s.sign(payload))
+}
+
+// URL возвращает ссылку отписки в один клик
+func (s *Signer) URL(userID int64, category string) string {
+	return s.baseURL + "?token=" + s.Token(userID, category)
+}
+
+// Parse проверяет подпись и возвращает пользователя и категорию
+func (s *Signer) Parse(token string) (int64, string, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, "", ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, "", ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(string(payload))) {
+		return 0, "", ErrInvalidToken
+	}
+
+	userPart, category, ok := strings.Cut(string(payload), ":")
+	if !ok || category == "" {
+		return 0, "", ErrInvalidToken
+	}
+	userID, err := strconv.ParseInt(userPart, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, "", ErrInvalidToken
+	}
+
+	return userID, category, nil
+}
+
+func (s *Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("unsubscribe:" + payload))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
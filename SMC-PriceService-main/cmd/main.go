@@ -9,24 +9,43 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // база часовых поясов для минимальных образов
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/apply_pricing_template"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/calculate_prices"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/create_pricing_rule"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/create_pricing_template"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/delete_pricing_rule"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/delete_pricing_template"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/get_daily_prices"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/get_degradation_stats"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/get_pricing_rule"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/get_pricing_template"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/get_vehicle_class_mix"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/list_pricing_rules"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/list_pricing_templates"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/simulate_pricing_rule"
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers/update_pricing_rule"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers/update_pricing_template"
 	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
 	"github.com/m04kA/SMC-PriceService/internal/config"
+	calculationLogRepo "github.com/m04kA/SMC-PriceService/internal/infra/storage/calculationlog"
 	pricingRuleRepo "github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingrule"
+	pricingTemplateRepo "github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingtemplate"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
 	"github.com/m04kA/SMC-PriceService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics"
+	calculationLogService "github.com/m04kA/SMC-PriceService/internal/service/calculationlog"
 	pricingRulesService "github.com/m04kA/SMC-PriceService/internal/service/pricingrules"
+	pricingTemplatesService "github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates"
 	"github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice"
+	"github.com/m04kA/SMC-PriceService/internal/usecase/simulateprice"
 	"github.com/m04kA/SMC-PriceService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-PriceService/pkg/jwtauth"
 	"github.com/m04kA/SMC-PriceService/pkg/logger"
 	"github.com/m04kA/SMC-PriceService/pkg/metrics"
 )
@@ -83,6 +102,8 @@ func main() {
 	var pricingRuleSvc *pricingRulesService.Service
 	var calculatePriceUC *calculateprice.UseCase
 	var pricingRuleRepository *pricingRuleRepo.Repository
+	var calculationLogRepository *calculationLogRepo.Repository
+	var pricingTemplateRepository *pricingTemplateRepo.Repository
 
 	if cfg.Metrics.Enabled {
 		wrappedDB = dbmetrics.WrapWithDefault(db, metricsCollector, cfg.Metrics.ServiceName, stopMetricsCh)
@@ -90,20 +111,62 @@ func main() {
 
 		// Инициализируем репозитории с обёрткой метрик
 		pricingRuleRepository = pricingRuleRepo.NewRepository(wrappedDB)
+		calculationLogRepository = calculationLogRepo.NewRepository(wrappedDB)
+		pricingTemplateRepository = pricingTemplateRepo.NewRepository(wrappedDB)
 
 	} else {
 		// Инициализируем репозитории без метрик
 		pricingRuleRepository = pricingRuleRepo.NewRepository(db)
+		calculationLogRepository = calculationLogRepo.NewRepository(db)
+		pricingTemplateRepository = pricingTemplateRepo.NewRepository(db)
 	}
 
 	// Инициализируем сервисы
 	pricingRuleSvc = pricingRulesService.NewService(pricingRuleRepository)
 
 	// Инициализируем UserService client
-	userServiceClient := userservice.NewClient(cfg.UserService.BaseURL, log)
+	userServiceClient := userservice.NewClient(cfg.UserService.BaseURL, cfg.InternalAuth.Credentials(), log)
+
+	// Инициализируем SellerService client
+	sellerServiceClient := sellerservice.NewClient(cfg.SellerService.BaseURL, cfg.InternalAuth.Credentials(), log)
+
+	// Шаблоны проверяют права через список менеджеров компании из SellerService
+	pricingTemplateSvc := pricingTemplatesService.NewService(pricingTemplateRepository, pricingRuleRepository, sellerServiceClient)
+
+	// Часовой пояс для условий по времени в выражениях (проверен при загрузке конфигурации)
+	pricingLocation, err := time.LoadLocation(cfg.Pricing.Timezone)
+	if err != nil {
+		log.Fatal("Failed to load pricing timezone: %v", err)
+	}
+
+	// Инициализируем журнал расчётов (асинхронная запись пачками)
+	var calculationRecorder calculateprice.CalculationRecorder = calculationLogService.NopRecorder{}
+	var recorder *calculationLogService.Recorder
+	if cfg.CalculationLog.Enabled {
+		var recorderMetrics calculationLogService.MetricsCollector
+		if cfg.Metrics.Enabled {
+			recorderMetrics = metricsCollector
+		}
+
+		recorder = calculationLogService.NewRecorder(calculationLogRepository, calculationLogService.RecorderConfig{
+			BatchSize:     cfg.CalculationLog.BatchSize,
+			FlushInterval: time.Duration(cfg.CalculationLog.FlushIntervalMs) * time.Millisecond,
+			BufferSize:    cfg.CalculationLog.BufferSize,
+		}, recorderMetrics, cfg.Metrics.ServiceName, log)
+		recorder.Start()
+		calculationRecorder = recorder
+	} else {
+		log.Info("Calculation log disabled")
+	}
 
 	// Инициализируем usecase для расчёта цен
-	calculatePriceUC = calculateprice.NewUseCase(pricingRuleRepository, userServiceClient, log)
+	calculatePriceUC = calculateprice.NewUseCase(pricingRuleRepository, userServiceClient, sellerServiceClient, calculationRecorder, pricingLocation, log)
+
+	// Инициализируем usecase для симуляции изменений правил
+	simulatePriceUC := simulateprice.NewUseCase(pricingRuleRepository, calculationLogRepository, pricingRuleSvc, sellerServiceClient, pricingLocation, log)
+
+	// Инициализируем сервис аналитики по журналу расчётов
+	analyticsSvc := analytics.NewService(calculationLogRepository, sellerServiceClient, pricingLocation)
 
 	// Инициализируем handlers
 	calculatePricesHandler := calculate_prices.NewHandler(calculatePriceUC, log)
@@ -112,6 +175,29 @@ func main() {
 	getPricingRuleHandler := get_pricing_rule.NewHandler(pricingRuleSvc, log)
 	updatePricingRuleHandler := update_pricing_rule.NewHandler(pricingRuleSvc, log)
 	deletePricingRuleHandler := delete_pricing_rule.NewHandler(pricingRuleSvc, log)
+	simulatePricingRuleHandler := simulate_pricing_rule.NewHandler(simulatePriceUC, log)
+	createPricingTemplateHandler := create_pricing_template.NewHandler(pricingTemplateSvc, log)
+	listPricingTemplatesHandler := list_pricing_templates.NewHandler(pricingTemplateSvc, log)
+	getPricingTemplateHandler := get_pricing_template.NewHandler(pricingTemplateSvc, log)
+	updatePricingTemplateHandler := update_pricing_template.NewHandler(pricingTemplateSvc, log)
+	deletePricingTemplateHandler := delete_pricing_template.NewHandler(pricingTemplateSvc, log)
+	applyPricingTemplateHandler := apply_pricing_template.NewHandler(pricingTemplateSvc, log)
+	getDailyPricesHandler := get_daily_prices.NewHandler(analyticsSvc, log)
+	getDegradationStatsHandler := get_degradation_stats.NewHandler(analyticsSvc, log)
+	getVehicleClassMixHandler := get_vehicle_class_mix.NewHandler(analyticsSvc, log)
+
+	// Инициализируем аутентификацию пользователей
+	authenticator, err := jwtauth.New(cfg.Auth.JWTAuth())
+	if err != nil {
+		log.Fatal("Failed to initialize authentication: %v", err)
+	}
+	defer authenticator.Close()
+	authMiddleware := middleware.NewAuthMiddleware(authenticator)
+	if authenticator.Mode() == jwtauth.ModeHeader {
+		log.Warn("Authentication mode is 'header': X-User-ID/X-User-Role are trusted without verification (local development only)")
+	} else {
+		log.Info("Authentication mode is 'jwt' (issuer=%s)", cfg.Auth.Issuer)
+	}
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
@@ -130,6 +216,7 @@ func main() {
 
 	// API prefix
 	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(authMiddleware.OptionalAuth)
 
 	// Public routes для расчёта цен
 	api.HandleFunc("/prices/calculate", calculatePricesHandler.Handle).Methods(http.MethodPost)
@@ -141,6 +228,28 @@ func main() {
 	api.HandleFunc("/pricing-rules/{id}", updatePricingRuleHandler.Handle).Methods(http.MethodPut)
 	api.HandleFunc("/pricing-rules/{id}", deletePricingRuleHandler.Handle).Methods(http.MethodDelete)
 
+	// Public routes для шаблонов ценообразования
+	api.HandleFunc("/pricing-templates", listPricingTemplatesHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/pricing-templates/{id}", getPricingTemplateHandler.Handle).Methods(http.MethodGet)
+
+	// Protected routes (требуют аутентификацию: Bearer токен или X-User-ID и X-User-Role в режиме header)
+	protected := r.PathPrefix("/api/v1").Subrouter()
+	protected.Use(authMiddleware.Auth)
+
+	// Protected routes для изменения шаблонов: шаблоны платформы - superuser, шаблоны компании - её менеджеры
+	protected.HandleFunc("/pricing-templates", createPricingTemplateHandler.Handle).Methods(http.MethodPost)
+	protected.HandleFunc("/pricing-templates/{id}", updatePricingTemplateHandler.Handle).Methods(http.MethodPut)
+	protected.HandleFunc("/pricing-templates/{id}", deletePricingTemplateHandler.Handle).Methods(http.MethodDelete)
+	protected.HandleFunc("/pricing-templates/{id}/apply", applyPricingTemplateHandler.Handle).Methods(http.MethodPost)
+
+	// Protected routes для симуляции правил: ответ раскрывает журнал расчётов, поэтому менеджеры компании и superuser
+	protected.HandleFunc("/pricing-rules/simulate", simulatePricingRuleHandler.Handle).Methods(http.MethodPost)
+
+	// Protected routes для аналитики по журналу расчётов: менеджеры компании и superuser
+	protected.HandleFunc("/analytics/prices/daily", getDailyPricesHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/analytics/degradation", getDegradationStatsHandler.Handle).Methods(http.MethodGet)
+	protected.HandleFunc("/analytics/vehicle-classes", getVehicleClassMixHandler.Handle).Methods(http.MethodGet)
+
 	// Создаем HTTP сервер с CORS middleware обёрнутым вокруг роутера
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	srv := &http.Server{
@@ -183,5 +292,10 @@ func main() {
 		log.Error("Server forced to shutdown: %v", err)
 	}
 
+	// Дописываем накопленные события журнала расчётов
+	if recorder != nil {
+		recorder.Stop()
+	}
+
 	log.Info("Server stopped gracefully")
 }
@@ -0,0 +1,19 @@
+package apply_pricing_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	Apply(ctx context.Context, id int64, userID int64, userRole string, req *models.ApplyPricingTemplateRequest) (*models.ApplyPricingTemplateResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
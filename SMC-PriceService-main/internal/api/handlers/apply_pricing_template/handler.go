@@ -0,0 +1,105 @@
+package apply_pricing_template
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgInvalidID          = "invalid pricing template ID"
+	msgNotFound           = "pricing template not found"
+	msgForbidden          = "access denied"
+	msgMissingUserID      = "missing user ID"
+	msgMissingUserRole    = "missing user role"
+)
+
+// Handler обработчик для применения шаблона к списку услуг
+type Handler struct {
+	service PricingTemplateService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service PricingTemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на применение шаблона ценообразования
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем пользователя из контекста аутентификации
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	// 2. Извлекаем ID из path параметров
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid pricing template ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	// 3. Парсим request body
+	var req models.ApplyPricingTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// 4. Вызываем сервис
+	result, err := h.service.Apply(r.Context(), id, userID, userRole, &req)
+	if err != nil {
+		// Обрабатываем ошибку "не найдено"
+		if errors.Is(err, pricingtemplates.ErrPricingTemplateNotFound) {
+			h.logger.Info("Pricing template not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+			return
+		}
+
+		// Обрабатываем отказ в доступе
+		if errors.Is(err, pricingtemplates.ErrAccessDenied) {
+			h.logger.Warn("Access denied: user_id=%d: %v", userID, err)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+
+		// Обрабатываем ошибки валидации
+		if errors.Is(err, pricingtemplates.ErrInvalidInput) {
+			h.logger.Warn("Invalid request: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to apply pricing template: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Pricing template %d applied to %d services (follow=%t)", id, len(result.Rules), req.Follow)
+
+	// 5. Возвращаем успешный результат
+	handlers.RespondJSON(w, http.StatusOK, result)
+}
@@ -4,19 +4,23 @@ import (
 	"net/http"
 
 	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
 	"github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice/models"
 )
 
 const (
 	msgInvalidRequestBody = "invalid request body"
 	msgInternalError      = "internal server error"
+	msgInvalidDuration    = "duration_minutes must be greater than 0"
 )
 
 // CalculatePricesRequest модель запроса для batch расчёта цен
 type CalculatePricesRequest struct {
-	CompanyID  int64   `json:"company_id"`
-	UserID     *int64  `json:"user_id,omitempty"` // опционально
-	ServiceIDs []int64 `json:"service_ids"`
+	CompanyID       int64   `json:"company_id"`
+	UserID          *int64  `json:"user_id,omitempty"` // опционально
+	ServiceIDs      []int64 `json:"service_ids"`
+	DurationMinutes *int    `json:"duration_minutes,omitempty"` // опционально, для per_minute
+	Trace           bool    `json:"trace,omitempty"`            // опционально, трассировка выражений
 }
 
 // Handler обработчик для расчёта цен
@@ -49,13 +53,25 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		tgUserID = *req.UserID
 	}
 
-	// 3. Формируем запрос для usecase
+	// 3. Проверяем длительность (если передана)
+	if req.DurationMinutes != nil && *req.DurationMinutes <= 0 {
+		h.logger.Warn("Invalid duration_minutes: %d", *req.DurationMinutes)
+		handlers.RespondBadRequest(w, msgInvalidDuration)
+		return
+	}
+
+	// 4. Формируем запрос для usecase (роль пользователя опциональна, используется в выражениях)
+	// Роль берётся только из проверенной аутентификации: заголовок X-User-Role в режиме jwt может подставить любой клиент
+	userRole, _ := middleware.GetUserRole(r.Context())
 	useCaseReq := &models.BatchCalculateRequest{
-		CompanyID:  req.CompanyID,
-		ServiceIDs: req.ServiceIDs,
+		CompanyID:       req.CompanyID,
+		ServiceIDs:      req.ServiceIDs,
+		DurationMinutes: req.DurationMinutes,
+		UserRole:        userRole,
+		Trace:           req.Trace,
 	}
 
-	// 4. Вызываем usecase
+	// 5. Вызываем usecase
 	resp, err := h.useCase.BatchCalculate(r.Context(), tgUserID, useCaseReq)
 	if err != nil {
 		h.logger.Error("Failed to calculate prices: %v", err)
@@ -63,6 +79,6 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 5. Возвращаем результат
+	// 6. Возвращаем результат
 	handlers.RespondJSON(w, http.StatusOK, resp)
 }
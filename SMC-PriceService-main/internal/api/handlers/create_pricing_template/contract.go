@@ -0,0 +1,19 @@
+package create_pricing_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	Create(ctx context.Context, userID int64, userRole string, req *models.CreatePricingTemplateRequest) (*models.PricingTemplateResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,89 @@
+package create_pricing_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+const (
+	msgInvalidRequestBody = "invalid request body"
+	msgDuplicateTemplate  = "pricing template with this name already exists"
+	msgForbidden          = "access denied"
+	msgMissingUserID      = "missing user ID"
+	msgMissingUserRole    = "missing user role"
+)
+
+// Handler обработчик для создания шаблона ценообразования
+type Handler struct {
+	service PricingTemplateService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service PricingTemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на создание шаблона ценообразования
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем пользователя из контекста аутентификации
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	// 2. Парсим request body
+	var req models.CreatePricingTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// 3. Вызываем сервис
+	template, err := h.service.Create(r.Context(), userID, userRole, &req)
+	if err != nil {
+		// Обрабатываем ошибку дубликата
+		if errors.Is(err, pricingtemplates.ErrDuplicateTemplate) {
+			h.logger.Warn("Duplicate pricing template: name=%s", req.Name)
+			handlers.RespondBadRequest(w, msgDuplicateTemplate)
+			return
+		}
+
+		// Обрабатываем отказ в доступе
+		if errors.Is(err, pricingtemplates.ErrAccessDenied) {
+			h.logger.Warn("Access denied: user_id=%d: %v", userID, err)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+
+		// Обрабатываем ошибки валидации
+		if errors.Is(err, pricingtemplates.ErrInvalidInput) {
+			h.logger.Warn("Invalid request: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to create pricing template: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 4. Возвращаем успешный результат
+	handlers.RespondJSON(w, http.StatusCreated, template)
+}
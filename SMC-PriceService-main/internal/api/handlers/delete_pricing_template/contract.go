@@ -0,0 +1,17 @@
+package delete_pricing_template
+
+import (
+	"context"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	Delete(ctx context.Context, id int64, userID int64, userRole string) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,86 @@
+package delete_pricing_template
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates"
+)
+
+const (
+	msgInvalidID       = "invalid pricing template ID"
+	msgNotFound        = "pricing template not found"
+	msgForbidden       = "access denied"
+	msgMissingUserID   = "missing user ID"
+	msgMissingUserRole = "missing user role"
+)
+
+// Handler обработчик для удаления шаблона ценообразования
+type Handler struct {
+	service PricingTemplateService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service PricingTemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на удаление шаблона ценообразования
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем пользователя из контекста аутентификации
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	// 2. Извлекаем ID из path параметров
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid pricing template ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	// 3. Вызываем сервис
+	err = h.service.Delete(r.Context(), id, userID, userRole)
+	if err != nil {
+		// Обрабатываем ошибку "не найдено"
+		if errors.Is(err, pricingtemplates.ErrPricingTemplateNotFound) {
+			h.logger.Info("Pricing template not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+			return
+		}
+
+		// Обрабатываем отказ в доступе
+		if errors.Is(err, pricingtemplates.ErrAccessDenied) {
+			h.logger.Warn("Access denied: user_id=%d: %v", userID, err)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+
+		h.logger.Error("Failed to delete pricing template: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 4. Возвращаем 204 No Content
+	w.WriteHeader(http.StatusNoContent)
+}
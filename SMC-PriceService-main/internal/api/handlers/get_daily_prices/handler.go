@@ -0,0 +1,94 @@
+package get_daily_prices
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics"
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics/models"
+)
+
+const (
+	msgInvalidCompanyID = "invalid company_id parameter"
+	msgInvalidServiceID = "invalid service_id parameter"
+	msgForbidden        = "access denied"
+	msgMissingUserID    = "missing user ID"
+	msgMissingUserRole  = "missing user role"
+)
+
+// Handler обработчик для получения статистики показанных цен по дням
+type Handler struct {
+	service AnalyticsService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service AnalyticsService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на получение статистики показанных цен по дням
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем пользователя из контекста аутентификации
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	// 2. Парсим query параметры (company_id обязателен)
+	companyID, err := handlers.ParseInt64Query(r, "company_id")
+	if err != nil || companyID == nil {
+		h.logger.Warn("Invalid company_id parameter: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidCompanyID)
+		return
+	}
+
+	serviceID, err := handlers.ParseInt64Query(r, "service_id")
+	if err != nil {
+		h.logger.Warn("Invalid service_id parameter: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidServiceID)
+		return
+	}
+
+	req := &models.AnalyticsFilterRequest{
+		CompanyID: *companyID,
+		ServiceID: serviceID,
+		From:      handlers.ParseStringQuery(r, "from"),
+		To:        handlers.ParseStringQuery(r, "to"),
+	}
+
+	// 3. Вызываем сервис
+	resp, err := h.service.GetDailyPrices(r.Context(), userID, userRole, req)
+	if err != nil {
+		if errors.Is(err, analytics.ErrInvalidInput) {
+			h.logger.Warn("Invalid analytics request: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		if errors.Is(err, analytics.ErrAccessDenied) {
+			h.logger.Warn("Access denied: user_id=%d: %v", userID, err)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+
+		h.logger.Error("Failed to get daily prices: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 4. Возвращаем результат
+	handlers.RespondJSON(w, http.StatusOK, resp)
+}
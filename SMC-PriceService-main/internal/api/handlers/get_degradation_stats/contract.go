@@ -0,0 +1,19 @@
+package get_degradation_stats
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics/models"
+)
+
+// AnalyticsService интерфейс сервиса аналитики
+type AnalyticsService interface {
+	GetDegradation(ctx context.Context, userID int64, userRole string, req *models.AnalyticsFilterRequest) (*models.DegradationResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -11,9 +11,9 @@ import (
 )
 
 const (
-	msgInvalidID       = "invalid pricing rule ID"
-	msgNotFound        = "pricing rule not found"
-	msgInternalError   = "internal server error"
+	msgInvalidID     = "invalid pricing rule ID"
+	msgNotFound      = "pricing rule not found"
+	msgInternalError = "internal server error"
 )
 
 // Handler обработчик для получения правила ценообразования
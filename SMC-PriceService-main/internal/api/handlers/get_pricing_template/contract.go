@@ -0,0 +1,19 @@
+package get_pricing_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	GetByID(ctx context.Context, id int64) (*models.PricingTemplateResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,62 @@
+package get_pricing_template
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates"
+)
+
+const (
+	msgInvalidID = "invalid pricing template ID"
+	msgNotFound  = "pricing template not found"
+)
+
+// Handler обработчик для получения шаблона ценообразования по ID
+type Handler struct {
+	service PricingTemplateService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service PricingTemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на получение шаблона ценообразования
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем ID из path параметров
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid pricing template ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	// 2. Вызываем сервис
+	template, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		// Обрабатываем ошибку "не найдено"
+		if errors.Is(err, pricingtemplates.ErrPricingTemplateNotFound) {
+			h.logger.Info("Pricing template not found: id=%d", id)
+			handlers.RespondNotFound(w, msgNotFound)
+			return
+		}
+
+		h.logger.Error("Failed to get pricing template: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 3. Возвращаем успешный результат
+	handlers.RespondJSON(w, http.StatusOK, template)
+}
@@ -0,0 +1,19 @@
+package get_vehicle_class_mix
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics/models"
+)
+
+// AnalyticsService интерфейс сервиса аналитики
+type AnalyticsService interface {
+	GetVehicleClassMix(ctx context.Context, userID int64, userRole string, req *models.AnalyticsFilterRequest) (*models.VehicleClassMixResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,19 @@
+package list_pricing_templates
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	List(ctx context.Context, req *models.PricingTemplateFilterRequest) (*models.PricingTemplateListResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,49 @@
+package list_pricing_templates
+
+import (
+	"net/http"
+
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+const (
+	msgInvalidCompanyID = "invalid company_id parameter"
+)
+
+// Handler обработчик для получения списка шаблонов ценообразования
+type Handler struct {
+	service PricingTemplateService
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(service PricingTemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на получение списка шаблонов
+// С company_id возвращаются шаблоны платформы и шаблоны этой компании
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Парсим query параметры
+	companyID, err := handlers.ParseInt64Query(r, "company_id")
+	if err != nil {
+		h.logger.Warn("Invalid company_id parameter: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidCompanyID)
+		return
+	}
+
+	// 2. Вызываем сервис
+	response, err := h.service.List(r.Context(), &models.PricingTemplateFilterRequest{CompanyID: companyID})
+	if err != nil {
+		h.logger.Error("Failed to list pricing templates: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 3. Возвращаем успешный результат
+	handlers.RespondJSON(w, http.StatusOK, response)
+}
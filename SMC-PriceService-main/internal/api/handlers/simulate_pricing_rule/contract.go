@@ -0,0 +1,19 @@
+package simulate_pricing_rule
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/usecase/simulateprice/models"
+)
+
+// SimulatePriceUseCase интерфейс для симуляции изменения правил ценообразования
+type SimulatePriceUseCase interface {
+	Simulate(ctx context.Context, userID int64, userRole string, req *models.SimulateRequest) (*models.SimulateResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
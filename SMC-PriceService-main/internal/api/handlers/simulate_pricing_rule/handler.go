@@ -0,0 +1,86 @@
+package simulate_pricing_rule
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-PriceService/internal/api/handlers"
+	"github.com/m04kA/SMC-PriceService/internal/api/middleware"
+	"github.com/m04kA/SMC-PriceService/internal/usecase/simulateprice"
+	"github.com/m04kA/SMC-PriceService/internal/usecase/simulateprice/models"
+)
+
+const (
+	msgInvalidRequestBody  = "invalid request body"
+	msgPricingRuleNotFound = "pricing rule not found"
+	msgForbidden           = "access denied"
+	msgMissingUserID       = "missing user ID"
+	msgMissingUserRole     = "missing user role"
+)
+
+// Handler обработчик для симуляции изменения правила ценообразования
+type Handler struct {
+	useCase SimulatePriceUseCase
+	logger  Logger
+}
+
+// NewHandler создаёт новый handler
+func NewHandler(useCase SimulatePriceUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Handle обрабатывает запрос на симуляцию (ничего не сохраняет)
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// 1. Извлекаем пользователя из контекста аутентификации
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserID)
+		return
+	}
+
+	userRole, ok := middleware.GetUserRole(r.Context())
+	if !ok {
+		handlers.RespondUnauthorized(w, msgMissingUserRole)
+		return
+	}
+
+	// 2. Парсим request body
+	var req models.SimulateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// 3. Вызываем usecase
+	resp, err := h.useCase.Simulate(r.Context(), userID, userRole, &req)
+	if err != nil {
+		if errors.Is(err, simulateprice.ErrInvalidInput) {
+			h.logger.Warn("Invalid simulation request: %v", err)
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		if errors.Is(err, simulateprice.ErrAccessDenied) {
+			h.logger.Warn("Access denied: user_id=%d: %v", userID, err)
+			handlers.RespondForbidden(w, msgForbidden)
+			return
+		}
+
+		if errors.Is(err, simulateprice.ErrPricingRuleNotFound) {
+			h.logger.Warn("Pricing rule not found: company_id=%d, service_id=%d", req.CompanyID, req.ServiceID)
+			handlers.RespondNotFound(w, msgPricingRuleNotFound)
+			return
+		}
+
+		h.logger.Error("Failed to simulate pricing rule: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	// 4. Возвращаем результат
+	handlers.RespondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,19 @@
+package update_pricing_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+// PricingTemplateService интерфейс для работы с шаблонами ценообразования
+type PricingTemplateService interface {
+	Update(ctx context.Context, id int64, userID int64, userRole string, req *models.UpdatePricingTemplateRequest) (*models.UpdatePricingTemplateResponse, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 )
 
 // ErrorResponse структура для ответа с ошибкой
@@ -33,6 +34,29 @@ func DecodeJSON(r *http.Request, v interface{}) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
 
+// ParseInt64Query парсит необязательный целочисленный query параметр (nil если параметр не передан)
+func ParseInt64Query(r *http.Request, name string) (*int64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// ParseStringQuery возвращает необязательный строковый query параметр (nil если параметр не передан)
+func ParseStringQuery(r *http.Request, name string) *string {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 // RespondBadRequest отправляет ошибку 400
 func RespondBadRequest(w http.ResponseWriter, message string) {
 	RespondError(w, http.StatusBadRequest, message)
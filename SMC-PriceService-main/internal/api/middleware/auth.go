@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strconv"
+
+	"github.com/m04kA/SMC-PriceService/pkg/jwtauth"
 )
 
 type contextKey string
@@ -13,34 +15,70 @@ const (
 	UserRoleKey contextKey = "user_role"
 )
 
-// Auth извлекает заголовки аутентификации и сохраняет их в контекст
-func Auth(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userIDStr := r.Header.Get("X-User-ID")
+// Authenticator извлекает идентичность пользователя из запроса (Bearer токен или заголовки X-User-*)
+type Authenticator interface {
+	Authenticate(r *http.Request) (*jwtauth.Identity, error)
+}
+
+// AuthMiddleware middleware аутентификации пользователей
+type AuthMiddleware struct {
+	authenticator Authenticator
+}
 
-		if userIDStr == "" {
-			http.Error(w, "missing X-User-ID header", http.StatusUnauthorized)
+// NewAuthMiddleware создаёт middleware аутентификации
+func NewAuthMiddleware(authenticator Authenticator) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator}
+}
+
+// Auth проверяет аутентификацию и сохраняет user ID и роль в контекст
+func (m *AuthMiddleware) Auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				http.Error(w, "missing authentication credentials", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
 			return
 		}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
-		if err != nil {
-			http.Error(w, "invalid user ID", http.StatusBadRequest)
+		if identity.Role == "" {
+			http.Error(w, "missing user role", http.StatusUnauthorized)
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserIDKey, userID)
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
+	})
+}
 
-		// X-User-Role опционален (используется только в некоторых сервисах)
-		userRole := r.Header.Get("X-User-Role")
-		if userRole != "" {
-			ctx = context.WithValue(ctx, UserRoleKey, userRole)
+// OptionalAuth сохраняет user ID и роль в контекст, если запрос аутентифицирован
+// В отличие от Auth, пропускает запросы без данных аутентификации, но отклоняет некорректные
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
+			return
 		}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
 	})
 }
 
+// withIdentity сохраняет идентичность пользователя в контекст
+func withIdentity(ctx context.Context, identity *jwtauth.Identity) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, identity.UserID)
+	if identity.Role != "" {
+		ctx = context.WithValue(ctx, UserRoleKey, identity.Role)
+	}
+	return ctx
+}
+
 // GetUserID извлекает user ID из контекста
 func GetUserID(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(UserIDKey).(int64)
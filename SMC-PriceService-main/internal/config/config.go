@@ -4,17 +4,27 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/m04kA/SMC-PriceService/pkg/jwtauth"
+	"github.com/m04kA/SMC-PriceService/pkg/svcauth"
 )
 
 // Config представляет полную конфигурацию приложения
 type Config struct {
-	Logs        LogsConfig        `toml:"logs"`
-	Server      ServerConfig      `toml:"server"`
-	Database    DatabaseConfig    `toml:"database"`
-	Metrics     MetricsConfig     `toml:"metrics"`
-	UserService UserServiceConfig `toml:"userservice"`
+	Logs           LogsConfig           `toml:"logs"`
+	Server         ServerConfig         `toml:"server"`
+	Database       DatabaseConfig       `toml:"database"`
+	Metrics        MetricsConfig        `toml:"metrics"`
+	UserService    UserServiceConfig    `toml:"userservice"`
+	SellerService  SellerServiceConfig  `toml:"sellerservice"`
+	Pricing        PricingConfig        `toml:"pricing"`
+	CalculationLog CalculationLogConfig `toml:"calculation_log"`
+	Auth           AuthConfig           `toml:"auth"`
+
+	InternalAuth InternalAuthConfig `toml:"internal_auth"`
 }
 
 // LogsConfig содержит настройки логирования
@@ -57,6 +67,68 @@ type UserServiceConfig struct {
 	BaseURL string `toml:"base_url"`
 }
 
+// SellerServiceConfig содержит настройки для интеграции с SellerService
+type SellerServiceConfig struct {
+	BaseURL string `toml:"base_url"`
+}
+
+// PricingConfig содержит настройки расчёта цен
+type PricingConfig struct {
+	Timezone string `toml:"timezone"` // часовой пояс для переменных времени в выражениях (hour, weekday)
+}
+
+// CalculationLogConfig содержит настройки журнала расчётов цен
+type CalculationLogConfig struct {
+	Enabled         bool `toml:"enabled"`
+	BatchSize       int  `toml:"batch_size"`        // максимальный размер пачки при записи
+	FlushIntervalMs int  `toml:"flush_interval_ms"` // максимальная задержка записи неполной пачки (мс)
+	BufferSize      int  `toml:"buffer_size"`       // размер буфера событий; при переполнении события отбрасываются
+}
+
+// AuthConfig содержит настройки проверки access токенов
+// По умолчанию mode = "jwt"; mode = "header" оставляет аутентификацию по X-User-ID/X-User-Role (только для локальной разработки)
+type AuthConfig struct {
+	Mode                string `toml:"mode"`                  // jwt | header
+	JWTSecret           string `toml:"jwt_secret"`            // секрет HS256
+	PublicKeyFile       string `toml:"public_key_file"`       // PEM файл с публичным ключом RS256
+	JWKSFile            string `toml:"jwks_file"`             // локальный JWKS файл
+	JWKSURL             string `toml:"jwks_url"`              // JWKS endpoint
+	JWKSRefreshInterval int    `toml:"jwks_refresh_interval"` // секунды
+	Issuer              string `toml:"issuer"`
+	Leeway              int    `toml:"leeway"` // секунды
+}
+
+// InternalAuthConfig содержит учётные данные сервиса для запросов к /internal эндпоинтам других сервисов
+// mode = "none" отправляет запросы без подписи (только для локальной разработки)
+type InternalAuthConfig struct {
+	Mode        string `toml:"mode"`         // hmac | api_key | none
+	ServiceName string `toml:"service_name"` // имя сервиса в конфигурации вызываемого сервиса
+	Secret      string `toml:"secret"`       // общий секрет (hmac) или ключ (api_key)
+}
+
+// Credentials преобразует настройки в учётные данные пакета svcauth
+func (a InternalAuthConfig) Credentials() svcauth.Credentials {
+	return svcauth.Credentials{
+		Mode:        a.Mode,
+		ServiceName: a.ServiceName,
+		Secret:      a.Secret,
+	}
+}
+
+// JWTAuth преобразует настройки в конфигурацию пакета jwtauth
+func (a AuthConfig) JWTAuth() jwtauth.Config {
+	return jwtauth.Config{
+		Mode:                a.Mode,
+		HMACSecret:          a.JWTSecret,
+		PublicKeyFile:       a.PublicKeyFile,
+		JWKSFile:            a.JWKSFile,
+		JWKSURL:             a.JWKSURL,
+		JWKSRefreshInterval: time.Duration(a.JWKSRefreshInterval) * time.Second,
+		Issuer:              a.Issuer,
+		Leeway:              time.Duration(a.Leeway) * time.Second,
+	}
+}
+
 // DSN формирует строку подключения к PostgreSQL
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
@@ -116,6 +188,25 @@ func overrideFromEnv(cfg *Config) {
 		}
 	}
 
+	// Internal auth
+	if v := os.Getenv("INTERNAL_AUTH_MODE"); v != "" {
+		cfg.InternalAuth.Mode = v
+	}
+	if v := os.Getenv("INTERNAL_AUTH_SECRET"); v != "" {
+		cfg.InternalAuth.Secret = v
+	}
+
+	// Auth
+	if v := os.Getenv("AUTH_MODE"); v != "" {
+		cfg.Auth.Mode = v
+	}
+	if v := os.Getenv("JWT_SECRET"); v != "" {
+		cfg.Auth.JWTSecret = v
+	}
+	if v := os.Getenv("JWKS_URL"); v != "" {
+		cfg.Auth.JWKSURL = v
+	}
+
 	// Logs
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		cfg.Logs.Level = v
@@ -141,6 +232,23 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("USERSERVICE_BASE_URL"); v != "" {
 		cfg.UserService.BaseURL = v
 	}
+
+	// SellerService
+	if v := os.Getenv("SELLERSERVICE_BASE_URL"); v != "" {
+		cfg.SellerService.BaseURL = v
+	}
+
+	// Pricing
+	if v := os.Getenv("PRICING_TIMEZONE"); v != "" {
+		cfg.Pricing.Timezone = v
+	}
+
+	// CalculationLog
+	if v := os.Getenv("CALCULATION_LOG_ENABLED"); v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			cfg.CalculationLog.Enabled = enabled
+		}
+	}
 }
 
 // validate проверяет корректность конфигурации
@@ -210,5 +318,71 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("userservice base_url is required")
 	}
 
+	// SellerService validation
+	if cfg.SellerService.BaseURL == "" {
+		return fmt.Errorf("sellerservice base_url is required")
+	}
+
+	// Pricing validation and defaults
+	if cfg.Pricing.Timezone == "" {
+		cfg.Pricing.Timezone = "Europe/Moscow"
+	}
+	if _, err := time.LoadLocation(cfg.Pricing.Timezone); err != nil {
+		return fmt.Errorf("invalid pricing timezone %q: %w", cfg.Pricing.Timezone, err)
+	}
+
+	// CalculationLog defaults
+	if cfg.CalculationLog.BatchSize <= 0 {
+		cfg.CalculationLog.BatchSize = 500
+	}
+	if cfg.CalculationLog.FlushIntervalMs <= 0 {
+		cfg.CalculationLog.FlushIntervalMs = 1000
+	}
+	if cfg.CalculationLog.BufferSize <= 0 {
+		cfg.CalculationLog.BufferSize = 10000
+	}
+
+	// Auth validation and defaults
+	if err := validateAuth(&cfg.Auth); err != nil {
+		return err
+	}
+
+	// Internal auth validation and defaults
+	if cfg.InternalAuth.Mode == "" {
+		cfg.InternalAuth.Mode = svcauth.ModeNone
+	}
+	if cfg.InternalAuth.ServiceName == "" {
+		cfg.InternalAuth.ServiceName = "priceservice"
+	}
+	if err := cfg.InternalAuth.Credentials().Validate(); err != nil {
+		return fmt.Errorf("internal_auth: %w", err)
+	}
+
+	return nil
+}
+
+// validateAuth проверяет настройки аутентификации и заполняет значения по умолчанию
+func validateAuth(auth *AuthConfig) error {
+	// Режим header доверяет заголовкам клиента, поэтому включается только явно
+	if auth.Mode == "" {
+		auth.Mode = jwtauth.ModeJWT
+	}
+	if auth.Mode != jwtauth.ModeJWT && auth.Mode != jwtauth.ModeHeader {
+		return fmt.Errorf("auth mode must be %s or %s", jwtauth.ModeJWT, jwtauth.ModeHeader)
+	}
+	if auth.Mode == jwtauth.ModeJWT &&
+		auth.JWTSecret == "" && auth.PublicKeyFile == "" && auth.JWKSFile == "" && auth.JWKSURL == "" {
+		return fmt.Errorf("auth mode jwt requires jwt_secret, public_key_file, jwks_file or jwks_url")
+	}
+	if auth.Issuer == "" {
+		auth.Issuer = "smc-userservice"
+	}
+	if auth.JWKSRefreshInterval == 0 {
+		auth.JWKSRefreshInterval = 300 // 5 minutes
+	}
+	if auth.Leeway == 0 {
+		auth.Leeway = 30
+	}
+
 	return nil
 }
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// DegradationReason причина деградации расчёта цены
+type DegradationReason string
+
+const (
+	DegradationUserServiceUnavailable DegradationReason = "user_service_degraded" // UserService недоступен, класс авто неизвестен
+	DegradationMultiplierNotFound     DegradationReason = "multiplier_not_found"  // нет множителя для класса авто
+	DegradationFixedPriceNotFound     DegradationReason = "fixed_price_not_found" // нет фиксированной цены для класса авто
+	DegradationDurationNotFound       DegradationReason = "duration_not_found"    // неизвестна длительность для per_minute
+	DegradationExpressionFailed       DegradationReason = "expression_failed"     // не удалось вычислить выражение
+	DegradationInvalidRule            DegradationReason = "invalid_rule"          // некорректное правило ценообразования
+)
+
+// CalculationEvent запись журнала расчётов: какая цена показана кому
+type CalculationEvent struct {
+	TgUserID          *int64             `json:"tg_user_id,omitempty"` // nil для расчёта без пользователя
+	CompanyID         int64              `json:"company_id"`
+	ServiceID         int64              `json:"service_id"`
+	PricingType       PricingType        `json:"pricing_type"`
+	VehicleClass      *string            `json:"vehicle_class,omitempty"`
+	Price             float64            `json:"price"`
+	Currency          string             `json:"currency"`
+	DegradationReason *DegradationReason `json:"degradation_reason,omitempty"` // nil если расчёт без деградации
+	LatencyMs         float64            `json:"latency_ms"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// CalculationLogFilter фильтр для аналитики по журналу расчётов
+type CalculationLogFilter struct {
+	CompanyID int64     `json:"company_id"`
+	ServiceID *int64    `json:"service_id,omitempty"`
+	From      time.Time `json:"from"`     // включительно
+	To        time.Time `json:"to"`       // не включительно
+	Timezone  string    `json:"timezone"` // часовой пояс для группировки по дням
+}
+
+// DailyPriceStats статистика показанных цен по компании и услуге за день
+type DailyPriceStats struct {
+	CompanyID    int64     `json:"company_id"`
+	ServiceID    int64     `json:"service_id"`
+	Day          time.Time `json:"day"`
+	Currency     string    `json:"currency"`
+	Calculations int64     `json:"calculations"`
+	AvgPrice     float64   `json:"avg_price"`
+	MinPrice     float64   `json:"min_price"`
+	MaxPrice     float64   `json:"max_price"`
+}
+
+// DegradationStats доля расчётов с деградацией
+type DegradationStats struct {
+	Total    int64                       `json:"total"`
+	Degraded int64                       `json:"degraded"`
+	ByReason map[DegradationReason]int64 `json:"by_reason"`
+}
+
+// VehicleClassCount количество расчётов для класса автомобиля
+type VehicleClassCount struct {
+	VehicleClass *string `json:"vehicle_class,omitempty"` // nil - класс не применялся
+	Count        int64   `json:"count"`
+}
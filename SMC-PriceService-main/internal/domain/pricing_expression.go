@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/pkg/expr"
+)
+
+// Переменные, доступные в выражениях правил с pricing_type=expression
+const (
+	ExprVarBasePrice    = "base_price"    // базовая цена правила
+	ExprVarVehicleClass = "vehicle_class" // класс автомобиля ("" если неизвестен)
+	ExprVarBrand        = "brand"         // марка автомобиля ("" если неизвестна)
+	ExprVarHasCar       = "has_car"       // известен ли автомобиль пользователя
+	ExprVarHour         = "hour"          // час расчёта (0-23)
+	ExprVarMinute       = "minute"        // минута расчёта (0-59)
+	ExprVarWeekday      = "weekday"       // день недели (1 - понедельник, 7 - воскресенье)
+	ExprVarUserRole     = "user_role"     // роль пользователя ("" если неизвестна)
+	ExprVarCartSize     = "cart_size"     // количество услуг в расчёте
+)
+
+// PricingExpressionVariables объявленные переменные выражений и их типы
+var PricingExpressionVariables = map[string]expr.Type{
+	ExprVarBasePrice:    expr.TypeNumber,
+	ExprVarVehicleClass: expr.TypeString,
+	ExprVarBrand:        expr.TypeString,
+	ExprVarHasCar:       expr.TypeBool,
+	ExprVarHour:         expr.TypeNumber,
+	ExprVarMinute:       expr.TypeNumber,
+	ExprVarWeekday:      expr.TypeNumber,
+	ExprVarUserRole:     expr.TypeString,
+	ExprVarCartSize:     expr.TypeNumber,
+}
+
+// CompilePricingExpression компилирует выражение правила ценообразования
+// Выражение должно возвращать число - итоговую цену
+func CompilePricingExpression(src string) (*expr.Program, error) {
+	return expr.Compile(src, PricingExpressionVariables, expr.TypeNumber, expr.DefaultLimits)
+}
+
+// PricingExpressionEnv контекст расчёта для вычисления выражения
+type PricingExpressionEnv struct {
+	BasePrice    float64
+	VehicleClass string
+	Brand        string
+	HasCar       bool
+	Time         time.Time // время расчёта в часовом поясе сервиса
+	UserRole     string
+	CartSize     int
+}
+
+// Vars возвращает значения переменных выражения
+func (env PricingExpressionEnv) Vars() map[string]expr.Value {
+	weekday := int(env.Time.Weekday())
+	if weekday == 0 {
+		weekday = 7 // воскресенье
+	}
+
+	return map[string]expr.Value{
+		ExprVarBasePrice:    expr.Number(env.BasePrice),
+		ExprVarVehicleClass: expr.String(env.VehicleClass),
+		ExprVarBrand:        expr.String(env.Brand),
+		ExprVarHasCar:       expr.Bool(env.HasCar),
+		ExprVarHour:         expr.Number(float64(env.Time.Hour())),
+		ExprVarMinute:       expr.Number(float64(env.Time.Minute())),
+		ExprVarWeekday:      expr.Number(float64(weekday)),
+		ExprVarUserRole:     expr.String(env.UserRole),
+		ExprVarCartSize:     expr.Number(float64(env.CartSize)),
+	}
+}
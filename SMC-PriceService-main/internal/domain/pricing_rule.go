@@ -9,6 +9,8 @@ const (
 	PricingTypeStatic                    PricingType = "static"
 	PricingTypeVehicleClassMultiplier    PricingType = "vehicle_class_pricing_multiplier"
 	PricingTypeVehicleClassFixed         PricingType = "vehicle_class_pricing_fixed"
+	PricingTypePerMinute                 PricingType = "per_minute"
+	PricingTypeExpression                PricingType = "expression"
 )
 
 // VehicleClass классы автомобилей по европейской системе
@@ -26,6 +28,29 @@ const (
 	VehicleClassS VehicleClass = "S" // спорткары
 )
 
+// VehicleClasses все поддерживаемые классы автомобилей
+var VehicleClasses = []VehicleClass{
+	VehicleClassA, VehicleClassB, VehicleClassC, VehicleClassD, VehicleClassE,
+	VehicleClassF, VehicleClassJ, VehicleClassM, VehicleClassS,
+}
+
+// IsValid проверяет, что класс автомобиля поддерживается
+func (c VehicleClass) IsValid() bool {
+	for _, class := range VehicleClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// PerMinutePricing параметры поминутной тарификации (боксы самообслуживания)
+type PerMinutePricing struct {
+	RatePerMinute      float64 `json:"rate_per_minute"`
+	MinBillableMinutes int     `json:"min_billable_minutes,omitempty"` // минимальное количество оплачиваемых минут
+	RoundingIncrement  int     `json:"rounding_increment,omitempty"`   // шаг округления вверх в минутах
+}
+
 // PricingRule доменная модель правила ценообразования
 type PricingRule struct {
 	ID                      int64                     `json:"id"`
@@ -36,6 +61,9 @@ type PricingRule struct {
 	Currency                string                    `json:"currency"`
 	VehicleClassMultipliers map[VehicleClass]float64  `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[VehicleClass]float64  `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing         `json:"per_minute,omitempty"`
+	Expression              *string                   `json:"expression,omitempty"`
+	TemplateID              *int64                    `json:"template_id,omitempty"` // шаблон, изменения которого получает правило
 	CreatedAt               time.Time                 `json:"created_at"`
 	UpdatedAt               time.Time                 `json:"updated_at"`
 }
@@ -49,6 +77,8 @@ type CreatePricingRuleInput struct {
 	Currency                string                    `json:"currency"`
 	VehicleClassMultipliers map[VehicleClass]float64  `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[VehicleClass]float64  `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing         `json:"per_minute,omitempty"`
+	Expression              *string                   `json:"expression,omitempty"`
 }
 
 // UpdatePricingRuleInput входные данные для обновления правила
@@ -58,6 +88,53 @@ type UpdatePricingRuleInput struct {
 	Currency                *string                   `json:"currency,omitempty"`
 	VehicleClassMultipliers map[VehicleClass]float64  `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[VehicleClass]float64  `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing         `json:"per_minute,omitempty"`
+	Expression              *string                   `json:"expression,omitempty"`
+	DetachTemplate          bool                      `json:"-"` // отвязать правило от шаблона
+}
+
+// ClearsPerMinute сообщает, что обновление переводит правило на другой тип и поминутные параметры нужно очистить
+func (in UpdatePricingRuleInput) ClearsPerMinute() bool {
+	return in.PricingType != nil && *in.PricingType != PricingTypePerMinute && in.PerMinute == nil
+}
+
+// ApplyUpdate возвращает копию правила с применёнными изменениями (без сохранения)
+// Семантика совпадает с обновлением в репозитории: применяются только заданные поля
+func (r *PricingRule) ApplyUpdate(input UpdatePricingRuleInput) *PricingRule {
+	updated := *r
+
+	if input.PricingType != nil {
+		updated.PricingType = *input.PricingType
+	}
+	if input.BasePrice != nil {
+		updated.BasePrice = input.BasePrice
+	}
+	if input.Currency != nil {
+		updated.Currency = *input.Currency
+	}
+	if input.VehicleClassMultipliers != nil {
+		updated.VehicleClassMultipliers = input.VehicleClassMultipliers
+	}
+	if input.VehicleClassPrices != nil {
+		updated.VehicleClassPrices = input.VehicleClassPrices
+	}
+	if input.PerMinute != nil {
+		updated.PerMinute = input.PerMinute
+	}
+	if input.ClearsPerMinute() {
+		updated.PerMinute = nil
+	}
+	if input.Expression != nil {
+		updated.Expression = input.Expression
+		if *input.Expression == "" {
+			updated.Expression = nil
+		}
+	}
+	if input.DetachTemplate {
+		updated.TemplateID = nil
+	}
+
+	return &updated
 }
 
 // PricingRuleFilter фильтры для получения правил
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// PricingTemplate шаблон ценообразования: именованный набор множителей или фиксированных цен по классам автомобилей
+// Шаблон принадлежит платформе (OwnerCompanyID == nil) или компании
+type PricingTemplate struct {
+	ID                      int64                    `json:"id"`
+	Name                    string                   `json:"name"`
+	OwnerCompanyID          *int64                   `json:"owner_company_id,omitempty"`
+	PricingType             PricingType              `json:"pricing_type"`
+	VehicleClassMultipliers map[VehicleClass]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[VehicleClass]float64 `json:"vehicle_class_prices,omitempty"`
+	CreatedAt               time.Time                `json:"created_at"`
+	UpdatedAt               time.Time                `json:"updated_at"`
+}
+
+// IsTemplatePricingType проверяет, что тип ценообразования допустим для шаблона
+func IsTemplatePricingType(pricingType PricingType) bool {
+	return pricingType == PricingTypeVehicleClassMultiplier || pricingType == PricingTypeVehicleClassFixed
+}
+
+// CanBeAppliedTo проверяет, что шаблон можно применить к услугам компании
+// Шаблон платформы доступен всем компаниям, шаблон компании - только ей
+func (t *PricingTemplate) CanBeAppliedTo(companyID int64) bool {
+	return t.OwnerCompanyID == nil || *t.OwnerCompanyID == companyID
+}
+
+// CreatePricingTemplateInput входные данные для создания шаблона
+type CreatePricingTemplateInput struct {
+	Name                    string                   `json:"name"`
+	OwnerCompanyID          *int64                   `json:"owner_company_id,omitempty"`
+	PricingType             PricingType              `json:"pricing_type"`
+	VehicleClassMultipliers map[VehicleClass]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[VehicleClass]float64 `json:"vehicle_class_prices,omitempty"`
+}
+
+// UpdatePricingTemplateInput входные данные для обновления шаблона (тип ценообразования не меняется)
+type UpdatePricingTemplateInput struct {
+	Name                    *string                  `json:"name,omitempty"`
+	VehicleClassMultipliers map[VehicleClass]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[VehicleClass]float64 `json:"vehicle_class_prices,omitempty"`
+}
+
+// PricingTemplateFilter фильтры для получения шаблонов
+type PricingTemplateFilter struct {
+	CompanyID *int64 `json:"company_id,omitempty"` // шаблоны платформы и шаблоны этой компании
+}
+
+// PricingTemplateTarget пара компания-услуга, к которой применяется шаблон
+type PricingTemplateTarget struct {
+	CompanyID int64 `json:"company_id"`
+	ServiceID int64 `json:"service_id"`
+}
+
+// ApplyPricingTemplateInput входные данные для применения шаблона к услугам
+type ApplyPricingTemplateInput struct {
+	Targets   []PricingTemplateTarget `json:"targets"`
+	BasePrice *float64                `json:"base_price,omitempty"` // nil - у существующих правил сохраняется текущая
+	Currency  *string                 `json:"currency,omitempty"`   // nil - у существующих правил сохраняется текущая
+	Follow    bool                    `json:"follow"`               // правила получают последующие изменения шаблона
+}
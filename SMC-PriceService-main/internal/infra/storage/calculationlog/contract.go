@@ -0,0 +1,6 @@
+package calculationlog
+
+import "github.com/m04kA/SMC-PriceService/pkg/dbmetrics"
+
+// Переиспользуем интерфейсы из dbmetrics
+type DBExecutor = dbmetrics.DBExecutor
@@ -0,0 +1,260 @@
+package calculationlog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/pkg/psqlbuilder"
+)
+
+// Repository репозиторий журнала расчётов цен
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория журнала расчётов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// EnsurePartition создаёт месячную секцию журнала для указанной даты (идемпотентно)
+func (r *Repository) EnsurePartition(ctx context.Context, month string) error {
+	if _, err := r.db.ExecContext(ctx, "SELECT ensure_calculation_log_partition($1)", month); err != nil {
+		return fmt.Errorf("%w: EnsurePartition - month=%s: %v", ErrExecQuery, month, err)
+	}
+	return nil
+}
+
+// InsertBatch записывает пачку событий одним запросом
+func (r *Repository) InsertBatch(ctx context.Context, events []domain.CalculationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := psqlbuilder.Insert("calculation_log").
+		Columns(
+			"tg_user_id",
+			"company_id",
+			"service_id",
+			"pricing_type",
+			"vehicle_class",
+			"price",
+			"currency",
+			"degradation_reason",
+			"latency_ms",
+			"created_at",
+		)
+
+	for _, event := range events {
+		builder = builder.Values(
+			event.TgUserID,
+			event.CompanyID,
+			event.ServiceID,
+			event.PricingType,
+			event.VehicleClass,
+			event.Price,
+			event.Currency,
+			event.DegradationReason,
+			event.LatencyMs,
+			event.CreatedAt,
+		)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: InsertBatch - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: InsertBatch - insert %d events: %v", ErrExecQuery, len(events), err)
+	}
+
+	return nil
+}
+
+// applyFilter добавляет условия фильтра к запросу
+func applyFilter(builder squirrel.SelectBuilder, filter domain.CalculationLogFilter) squirrel.SelectBuilder {
+	builder = builder.
+		Where(squirrel.Eq{"company_id": filter.CompanyID}).
+		Where(squirrel.GtOrEq{"created_at": filter.From}).
+		Where(squirrel.Lt{"created_at": filter.To})
+
+	if filter.ServiceID != nil {
+		builder = builder.Where(squirrel.Eq{"service_id": *filter.ServiceID})
+	}
+
+	return builder
+}
+
+// GetDailyPriceStats возвращает статистику показанных цен по услугам и дням
+func (r *Repository) GetDailyPriceStats(ctx context.Context, filter domain.CalculationLogFilter) ([]domain.DailyPriceStats, error) {
+	// День считаем в часовом поясе сервиса, а не UTC
+	query, args, err := applyFilter(
+		psqlbuilder.Select("company_id", "service_id").
+			Column(squirrel.Expr("(created_at AT TIME ZONE ?)::date AS day", filter.Timezone)).
+			Columns("currency", "COUNT(*)", "AVG(price)", "MIN(price)", "MAX(price)").
+			From("calculation_log"),
+		filter,
+	).
+		GroupBy("company_id", "service_id", "day", "currency").
+		OrderBy("day", "service_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDailyPriceStats - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDailyPriceStats - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.DailyPriceStats, 0)
+	for rows.Next() {
+		var stats domain.DailyPriceStats
+		if err := rows.Scan(
+			&stats.CompanyID,
+			&stats.ServiceID,
+			&stats.Day,
+			&stats.Currency,
+			&stats.Calculations,
+			&stats.AvgPrice,
+			&stats.MinPrice,
+			&stats.MaxPrice,
+		); err != nil {
+			return nil, fmt.Errorf("%w: GetDailyPriceStats - scan row: %v", ErrScanRow, err)
+		}
+		result = append(result, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: GetDailyPriceStats - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return result, nil
+}
+
+// GetDegradationStats возвращает количество расчётов с деградацией по причинам
+func (r *Repository) GetDegradationStats(ctx context.Context, filter domain.CalculationLogFilter) (*domain.DegradationStats, error) {
+	query, args, err := applyFilter(
+		psqlbuilder.Select("degradation_reason", "COUNT(*)").From("calculation_log"),
+		filter,
+	).
+		GroupBy("degradation_reason").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDegradationStats - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDegradationStats - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	stats := &domain.DegradationStats{
+		ByReason: make(map[domain.DegradationReason]int64),
+	}
+	for rows.Next() {
+		var reason sql.NullString
+		var count int64
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, fmt.Errorf("%w: GetDegradationStats - scan row: %v", ErrScanRow, err)
+		}
+
+		stats.Total += count
+		if reason.Valid {
+			stats.Degraded += count
+			stats.ByReason[domain.DegradationReason(reason.String)] = count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: GetDegradationStats - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return stats, nil
+}
+
+// GetVehicleClassMix возвращает количество расчётов по классам автомобилей
+func (r *Repository) GetVehicleClassMix(ctx context.Context, filter domain.CalculationLogFilter) ([]domain.VehicleClassCount, error) {
+	query, args, err := applyFilter(
+		psqlbuilder.Select("vehicle_class", "COUNT(*)").From("calculation_log"),
+		filter,
+	).
+		GroupBy("vehicle_class").
+		OrderBy("vehicle_class").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetVehicleClassMix - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetVehicleClassMix - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.VehicleClassCount, 0)
+	for rows.Next() {
+		var class sql.NullString
+		var item domain.VehicleClassCount
+		if err := rows.Scan(&class, &item.Count); err != nil {
+			return nil, fmt.Errorf("%w: GetVehicleClassMix - scan row: %v", ErrScanRow, err)
+		}
+		if class.Valid {
+			item.VehicleClass = &class.String
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: GetVehicleClassMix - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return result, nil
+}
+
+// GetRecentVehicleClasses возвращает распределение классов автомобилей в последних limit расчётах услуги
+// Расчёты без класса автомобиля возвращаются с ключом ""
+func (r *Repository) GetRecentVehicleClasses(ctx context.Context, companyID, serviceID int64, limit int) (map[string]int, error) {
+	recent := psqlbuilder.Select("vehicle_class").
+		From("calculation_log").
+		Where(squirrel.Eq{"company_id": companyID, "service_id": serviceID}).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit))
+
+	query, args, err := psqlbuilder.Select("COALESCE(recent.vehicle_class, '')", "COUNT(*)").
+		FromSelect(recent, "recent").
+		GroupBy("recent.vehicle_class").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetRecentVehicleClasses - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetRecentVehicleClasses - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+	for rows.Next() {
+		var class string
+		var count int
+		if err := rows.Scan(&class, &count); err != nil {
+			return nil, fmt.Errorf("%w: GetRecentVehicleClasses - scan row: %v", ErrScanRow, err)
+		}
+		result[class] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: GetRecentVehicleClasses - iterate rows: %v", ErrExecQuery, err)
+	}
+
+	return result, nil
+}
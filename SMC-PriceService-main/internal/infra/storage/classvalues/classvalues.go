@@ -0,0 +1,31 @@
+package classvalues
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+)
+
+// Marshal сериализует множители и цены по классам для JSONB колонок правил и шаблонов (пустой JSON {} если nil)
+func Marshal(multipliers, prices map[domain.VehicleClass]float64) ([]byte, []byte, error) {
+	multipliersJSON := []byte("{}")
+	if multipliers != nil {
+		data, err := json.Marshal(multipliers)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal multipliers: %w", err)
+		}
+		multipliersJSON = data
+	}
+
+	pricesJSON := []byte("{}")
+	if prices != nil {
+		data, err := json.Marshal(prices)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal prices: %w", err)
+		}
+		pricesJSON = data
+	}
+
+	return multipliersJSON, pricesJSON, nil
+}
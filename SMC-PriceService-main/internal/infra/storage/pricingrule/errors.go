@@ -20,4 +20,7 @@ var (
 
 	// ErrDuplicateRule возвращается при попытке создать дубликат правила для компании+услуги
 	ErrDuplicateRule = errors.New("repository: pricing rule already exists for this company and service")
+
+	// ErrBasePriceRequired возвращается, если после применения шаблона у правила нет base_price
+	ErrBasePriceRequired = errors.New("repository: base_price is required for a new pricing rule")
 )
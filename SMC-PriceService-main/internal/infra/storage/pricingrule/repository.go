@@ -5,14 +5,42 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/infra/storage/classvalues"
+	"github.com/m04kA/SMC-PriceService/pkg/dbmetrics"
 	"github.com/m04kA/SMC-PriceService/pkg/psqlbuilder"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
 )
 
+// pricingRuleColumns колонки таблицы pricing_rules в порядке сканирования scanPricingRule
+var pricingRuleColumns = []string{
+	"id",
+	"company_id",
+	"service_id",
+	"pricing_type",
+	"base_price",
+	"currency",
+	"vehicle_class_multipliers",
+	"vehicle_class_prices",
+	"per_minute_pricing",
+	"price_expression",
+	"template_id",
+	"created_at",
+	"updated_at",
+}
+
+// defaultCurrency валюта по умолчанию (совпадает с DEFAULT колонки currency)
+const defaultCurrency = "RUB"
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // Repository репозиторий для работы с правилами ценообразования
 type Repository struct {
 	db DBExecutor
@@ -47,6 +75,15 @@ func (r *Repository) Create(ctx context.Context, input domain.CreatePricingRuleI
 		prices = []byte("{}")
 	}
 
+	// Параметры поминутной тарификации хранятся как NULL, если не заданы
+	var perMinute []byte
+	if input.PerMinute != nil {
+		perMinute, err = json.Marshal(input.PerMinute)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Create - marshal per minute pricing: %v", ErrExecQuery, err)
+		}
+	}
+
 	query, args, err := psqlbuilder.Insert("pricing_rules").
 		Columns(
 			"company_id",
@@ -56,6 +93,8 @@ func (r *Repository) Create(ctx context.Context, input domain.CreatePricingRuleI
 			"currency",
 			"vehicle_class_multipliers",
 			"vehicle_class_prices",
+			"per_minute_pricing",
+			"price_expression",
 		).
 		Values(
 			input.CompanyID,
@@ -65,6 +104,8 @@ func (r *Repository) Create(ctx context.Context, input domain.CreatePricingRuleI
 			input.Currency,
 			multipliers,
 			prices,
+			perMinute,
+			input.Expression,
 		).
 		Suffix("RETURNING id, created_at, updated_at").
 		ToSql()
@@ -93,6 +134,8 @@ func (r *Repository) Create(ctx context.Context, input domain.CreatePricingRuleI
 		Currency:                input.Currency,
 		VehicleClassMultipliers: input.VehicleClassMultipliers,
 		VehicleClassPrices:      input.VehicleClassPrices,
+		PerMinute:               input.PerMinute,
+		Expression:              input.Expression,
 		CreatedAt:               createdAt.Time,
 		UpdatedAt:               updatedAt.Time,
 	}, nil
@@ -100,18 +143,7 @@ func (r *Repository) Create(ctx context.Context, input domain.CreatePricingRuleI
 
 // GetByID получает правило ценообразования по ID
 func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.PricingRule, error) {
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"company_id",
-		"service_id",
-		"pricing_type",
-		"base_price",
-		"currency",
-		"vehicle_class_multipliers",
-		"vehicle_class_prices",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(pricingRuleColumns...).
 		From("pricing_rules").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -120,24 +152,7 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.PricingRule
 		return nil, fmt.Errorf("%w: GetByID - build select query: %v", ErrBuildQuery, err)
 	}
 
-	var rule domain.PricingRule
-	var basePrice sql.NullFloat64
-	var multipliers, prices []byte
-	var createdAt, updatedAt sql.NullTime
-
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(
-		&rule.ID,
-		&rule.CompanyID,
-		&rule.ServiceID,
-		&rule.PricingType,
-		&basePrice,
-		&rule.Currency,
-		&multipliers,
-		&prices,
-		&createdAt,
-		&updatedAt,
-	)
-
+	rule, err := scanPricingRule(r.db.QueryRowContext(ctx, query, args...))
 	if err == sql.ErrNoRows {
 		return nil, ErrPricingRuleNotFound
 	}
@@ -145,47 +160,12 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.PricingRule
 		return nil, fmt.Errorf("%w: GetByID - scan pricing rule: %v", ErrScanRow, err)
 	}
 
-	// Десериализуем nullable поля
-	if basePrice.Valid {
-		rule.BasePrice = &basePrice.Float64
-	}
-
-	if len(multipliers) > 0 {
-		var m map[domain.VehicleClass]float64
-		if err := json.Unmarshal(multipliers, &m); err != nil {
-			return nil, fmt.Errorf("%w: GetByID - unmarshal multipliers: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassMultipliers = m
-	}
-
-	if len(prices) > 0 {
-		var p map[domain.VehicleClass]float64
-		if err := json.Unmarshal(prices, &p); err != nil {
-			return nil, fmt.Errorf("%w: GetByID - unmarshal prices: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassPrices = p
-	}
-
-	rule.CreatedAt = createdAt.Time
-	rule.UpdatedAt = updatedAt.Time
-
-	return &rule, nil
+	return rule, nil
 }
 
 // GetByCompanyAndService получает правило по company_id и service_id
 func (r *Repository) GetByCompanyAndService(ctx context.Context, companyID, serviceID int64) (*domain.PricingRule, error) {
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"company_id",
-		"service_id",
-		"pricing_type",
-		"base_price",
-		"currency",
-		"vehicle_class_multipliers",
-		"vehicle_class_prices",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(pricingRuleColumns...).
 		From("pricing_rules").
 		Where(squirrel.Eq{
 			"company_id": companyID,
@@ -197,24 +177,7 @@ func (r *Repository) GetByCompanyAndService(ctx context.Context, companyID, serv
 		return nil, fmt.Errorf("%w: GetByCompanyAndService - build select query: %v", ErrBuildQuery, err)
 	}
 
-	var rule domain.PricingRule
-	var basePrice sql.NullFloat64
-	var multipliers, prices []byte
-	var createdAt, updatedAt sql.NullTime
-
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(
-		&rule.ID,
-		&rule.CompanyID,
-		&rule.ServiceID,
-		&rule.PricingType,
-		&basePrice,
-		&rule.Currency,
-		&multipliers,
-		&prices,
-		&createdAt,
-		&updatedAt,
-	)
-
+	rule, err := scanPricingRule(r.db.QueryRowContext(ctx, query, args...))
 	if err == sql.ErrNoRows {
 		return nil, ErrPricingRuleNotFound
 	}
@@ -222,48 +185,13 @@ func (r *Repository) GetByCompanyAndService(ctx context.Context, companyID, serv
 		return nil, fmt.Errorf("%w: GetByCompanyAndService - scan pricing rule: %v", ErrScanRow, err)
 	}
 
-	// Десериализуем nullable поля
-	if basePrice.Valid {
-		rule.BasePrice = &basePrice.Float64
-	}
-
-	if len(multipliers) > 0 {
-		var m map[domain.VehicleClass]float64
-		if err := json.Unmarshal(multipliers, &m); err != nil {
-			return nil, fmt.Errorf("%w: GetByCompanyAndService - unmarshal multipliers: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassMultipliers = m
-	}
-
-	if len(prices) > 0 {
-		var p map[domain.VehicleClass]float64
-		if err := json.Unmarshal(prices, &p); err != nil {
-			return nil, fmt.Errorf("%w: GetByCompanyAndService - unmarshal prices: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassPrices = p
-	}
-
-	rule.CreatedAt = createdAt.Time
-	rule.UpdatedAt = updatedAt.Time
-
-	return &rule, nil
+	return rule, nil
 }
 
 // List получает список правил ценообразования с фильтрацией
 func (r *Repository) List(ctx context.Context, filter domain.PricingRuleFilter) ([]domain.PricingRule, error) {
 	// Базовый запрос
-	selectBuilder := psqlbuilder.Select(
-		"id",
-		"company_id",
-		"service_id",
-		"pricing_type",
-		"base_price",
-		"currency",
-		"vehicle_class_multipliers",
-		"vehicle_class_prices",
-		"created_at",
-		"updated_at",
-	).
+	selectBuilder := psqlbuilder.Select(pricingRuleColumns...).
 		From("pricing_rules").
 		OrderBy("created_at DESC")
 
@@ -289,52 +217,12 @@ func (r *Repository) List(ctx context.Context, filter domain.PricingRuleFilter)
 
 	rules := make([]domain.PricingRule, 0)
 	for rows.Next() {
-		var rule domain.PricingRule
-		var basePrice sql.NullFloat64
-		var multipliers, prices []byte
-		var createdAt, updatedAt sql.NullTime
-
-		err := rows.Scan(
-			&rule.ID,
-			&rule.CompanyID,
-			&rule.ServiceID,
-			&rule.PricingType,
-			&basePrice,
-			&rule.Currency,
-			&multipliers,
-			&prices,
-			&createdAt,
-			&updatedAt,
-		)
+		rule, err := scanPricingRule(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%w: List - scan pricing rule: %v", ErrScanRow, err)
 		}
 
-		// Десериализуем nullable поля
-		if basePrice.Valid {
-			rule.BasePrice = &basePrice.Float64
-		}
-
-		if len(multipliers) > 0 {
-			var m map[domain.VehicleClass]float64
-			if err := json.Unmarshal(multipliers, &m); err != nil {
-				return nil, fmt.Errorf("%w: List - unmarshal multipliers: %v", ErrScanRow, err)
-			}
-			rule.VehicleClassMultipliers = m
-		}
-
-		if len(prices) > 0 {
-			var p map[domain.VehicleClass]float64
-			if err := json.Unmarshal(prices, &p); err != nil {
-				return nil, fmt.Errorf("%w: List - unmarshal prices: %v", ErrScanRow, err)
-			}
-			rule.VehicleClassPrices = p
-		}
-
-		rule.CreatedAt = createdAt.Time
-		rule.UpdatedAt = updatedAt.Time
-
-		rules = append(rules, rule)
+		rules = append(rules, *rule)
 	}
 
 	return rules, nil
@@ -372,32 +260,42 @@ func (r *Repository) Update(ctx context.Context, id int64, input domain.UpdatePr
 		updateBuilder = updateBuilder.Set("vehicle_class_prices", prices)
 	}
 
+	if input.PerMinute != nil {
+		perMinute, err := json.Marshal(input.PerMinute)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Update - marshal per minute pricing: %v", ErrExecQuery, err)
+		}
+		updateBuilder = updateBuilder.Set("per_minute_pricing", perMinute)
+	}
+
+	// При смене типа с per_minute поминутные параметры сохраняем как NULL
+	if input.ClearsPerMinute() {
+		updateBuilder = updateBuilder.Set("per_minute_pricing", nil)
+	}
+
+	// Пустое выражение сохраняем как NULL
+	if input.Expression != nil {
+		if *input.Expression == "" {
+			updateBuilder = updateBuilder.Set("price_expression", nil)
+		} else {
+			updateBuilder = updateBuilder.Set("price_expression", *input.Expression)
+		}
+	}
+
+	// Ручное изменение цен по классам отвязывает правило от шаблона
+	if input.DetachTemplate {
+		updateBuilder = updateBuilder.Set("template_id", nil)
+	}
+
 	query, args, err := updateBuilder.
-		Suffix("RETURNING id, company_id, service_id, pricing_type, base_price, currency, vehicle_class_multipliers, vehicle_class_prices, created_at, updated_at").
+		Suffix("RETURNING " + strings.Join(pricingRuleColumns, ", ")).
 		ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("%w: Update - build update query: %v", ErrBuildQuery, err)
 	}
 
-	var rule domain.PricingRule
-	var basePrice sql.NullFloat64
-	var multipliers, prices []byte
-	var createdAt, updatedAt sql.NullTime
-
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(
-		&rule.ID,
-		&rule.CompanyID,
-		&rule.ServiceID,
-		&rule.PricingType,
-		&basePrice,
-		&rule.Currency,
-		&multipliers,
-		&prices,
-		&createdAt,
-		&updatedAt,
-	)
-
+	rule, err := scanPricingRule(r.db.QueryRowContext(ctx, query, args...))
 	if err == sql.ErrNoRows {
 		return nil, ErrPricingRuleNotFound
 	}
@@ -405,31 +303,7 @@ func (r *Repository) Update(ctx context.Context, id int64, input domain.UpdatePr
 		return nil, fmt.Errorf("%w: Update - scan pricing rule: %v", ErrScanRow, err)
 	}
 
-	// Десериализуем nullable поля
-	if basePrice.Valid {
-		rule.BasePrice = &basePrice.Float64
-	}
-
-	if len(multipliers) > 0 {
-		var m map[domain.VehicleClass]float64
-		if err := json.Unmarshal(multipliers, &m); err != nil {
-			return nil, fmt.Errorf("%w: Update - unmarshal multipliers: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassMultipliers = m
-	}
-
-	if len(prices) > 0 {
-		var p map[domain.VehicleClass]float64
-		if err := json.Unmarshal(prices, &p); err != nil {
-			return nil, fmt.Errorf("%w: Update - unmarshal prices: %v", ErrScanRow, err)
-		}
-		rule.VehicleClassPrices = p
-	}
-
-	rule.CreatedAt = createdAt.Time
-	rule.UpdatedAt = updatedAt.Time
-
-	return &rule, nil
+	return rule, nil
 }
 
 // Delete удаляет правило ценообразования
@@ -465,18 +339,7 @@ func (r *Repository) GetBatchByCompanyAndServices(ctx context.Context, companyID
 		return make(map[int64]*domain.PricingRule), nil
 	}
 
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"company_id",
-		"service_id",
-		"pricing_type",
-		"base_price",
-		"currency",
-		"vehicle_class_multipliers",
-		"vehicle_class_prices",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(pricingRuleColumns...).
 		From("pricing_rules").
 		Where(squirrel.Eq{
 			"company_id": companyID,
@@ -496,53 +359,171 @@ func (r *Repository) GetBatchByCompanyAndServices(ctx context.Context, companyID
 
 	result := make(map[int64]*domain.PricingRule)
 	for rows.Next() {
-		var rule domain.PricingRule
-		var basePrice sql.NullFloat64
-		var multipliers, prices []byte
-		var createdAt, updatedAt sql.NullTime
-
-		err := rows.Scan(
-			&rule.ID,
-			&rule.CompanyID,
-			&rule.ServiceID,
-			&rule.PricingType,
-			&basePrice,
-			&rule.Currency,
-			&multipliers,
-			&prices,
-			&createdAt,
-			&updatedAt,
-		)
+		rule, err := scanPricingRule(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%w: GetBatchByCompanyAndServices - scan pricing rule: %v", ErrScanRow, err)
 		}
 
-		// Десериализуем nullable поля
-		if basePrice.Valid {
-			rule.BasePrice = &basePrice.Float64
+		result[rule.ServiceID] = rule
+	}
+
+	return result, nil
+}
+
+// ApplyTemplate создаёт или обновляет правила для пар компания-услуга по шаблону в одной транзакции
+// У существующих правил сохраняются base_price и currency, если они не переданы
+// Если хотя бы одно правило остаётся без base_price, изменения откатываются
+func (r *Repository) ApplyTemplate(ctx context.Context, template *domain.PricingTemplate, input domain.ApplyPricingTemplateInput) ([]domain.PricingRule, error) {
+	multipliers, prices, err := classvalues.Marshal(template.VehicleClassMultipliers, template.VehicleClassPrices)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ApplyTemplate - %v", ErrExecQuery, err)
+	}
+
+	// Без follow правило отвязывается от шаблона, которому следовало раньше
+	var templateID *int64
+	if input.Follow {
+		templateID = &template.ID
+	}
+
+	// Для новых правил валюта по умолчанию совпадает с default колонки
+	insertCurrency := defaultCurrency
+	if input.Currency != nil {
+		insertCurrency = *input.Currency
+	}
+
+	tx, err := dbmetrics.BeginTx(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ApplyTemplate - begin transaction: %v", ErrTransaction, err)
+	}
+
+	rules := make([]domain.PricingRule, 0, len(input.Targets))
+	for _, target := range input.Targets {
+		query, args, err := psqlbuilder.Insert("pricing_rules").
+			Columns(
+				"company_id",
+				"service_id",
+				"pricing_type",
+				"base_price",
+				"currency",
+				"vehicle_class_multipliers",
+				"vehicle_class_prices",
+				"template_id",
+			).
+			Values(
+				target.CompanyID,
+				target.ServiceID,
+				template.PricingType,
+				input.BasePrice,
+				insertCurrency,
+				multipliers,
+				prices,
+				templateID,
+			).
+			Suffix(`ON CONFLICT (company_id, service_id) DO UPDATE SET
+				pricing_type = EXCLUDED.pricing_type,
+				base_price = COALESCE(EXCLUDED.base_price, pricing_rules.base_price),
+				currency = COALESCE(?, pricing_rules.currency),
+				vehicle_class_multipliers = EXCLUDED.vehicle_class_multipliers,
+				vehicle_class_prices = EXCLUDED.vehicle_class_prices,
+				per_minute_pricing = NULL,
+				price_expression = NULL,
+				template_id = EXCLUDED.template_id
+				RETURNING `+strings.Join(pricingRuleColumns, ", "), input.Currency).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%w: ApplyTemplate - build upsert query: %v", ErrBuildQuery, err)
+		}
+
+		rule, err := scanPricingRule(tx.QueryRowContext(ctx, query, args...))
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%w: ApplyTemplate - upsert rule company_id=%d, service_id=%d: %v",
+				ErrExecQuery, target.CompanyID, target.ServiceID, err)
+		}
+
+		if rule.BasePrice == nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%w: company_id=%d, service_id=%d", ErrBasePriceRequired, target.CompanyID, target.ServiceID)
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%w: ApplyTemplate - commit: %v", ErrTransaction, err)
+	}
+
+	return rules, nil
+}
+
+// scanPricingRule сканирует строку с колонками pricingRuleColumns и десериализует JSON поля
+func scanPricingRule(row rowScanner) (*domain.PricingRule, error) {
+	var rule domain.PricingRule
+	var basePrice sql.NullFloat64
+	var multipliers, prices, perMinute []byte
+	var expression sql.NullString
+	var templateID sql.NullInt64
+	var createdAt, updatedAt sql.NullTime
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.CompanyID,
+		&rule.ServiceID,
+		&rule.PricingType,
+		&basePrice,
+		&rule.Currency,
+		&multipliers,
+		&prices,
+		&perMinute,
+		&expression,
+		&templateID,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Десериализуем nullable поля
+	if basePrice.Valid {
+		rule.BasePrice = &basePrice.Float64
+	}
+
+	if len(multipliers) > 0 {
+		var m map[domain.VehicleClass]float64
+		if err := json.Unmarshal(multipliers, &m); err != nil {
+			return nil, fmt.Errorf("unmarshal multipliers: %w", err)
 		}
+		rule.VehicleClassMultipliers = m
+	}
 
-		if len(multipliers) > 0 {
-			var m map[domain.VehicleClass]float64
-			if err := json.Unmarshal(multipliers, &m); err != nil {
-				return nil, fmt.Errorf("%w: GetBatchByCompanyAndServices - unmarshal multipliers: %v", ErrScanRow, err)
-			}
-			rule.VehicleClassMultipliers = m
+	if len(prices) > 0 {
+		var p map[domain.VehicleClass]float64
+		if err := json.Unmarshal(prices, &p); err != nil {
+			return nil, fmt.Errorf("unmarshal prices: %w", err)
 		}
+		rule.VehicleClassPrices = p
+	}
 
-		if len(prices) > 0 {
-			var p map[domain.VehicleClass]float64
-			if err := json.Unmarshal(prices, &p); err != nil {
-				return nil, fmt.Errorf("%w: GetBatchByCompanyAndServices - unmarshal prices: %v", ErrScanRow, err)
-			}
-			rule.VehicleClassPrices = p
+	if len(perMinute) > 0 {
+		var pm domain.PerMinutePricing
+		if err := json.Unmarshal(perMinute, &pm); err != nil {
+			return nil, fmt.Errorf("unmarshal per minute pricing: %w", err)
 		}
+		rule.PerMinute = &pm
+	}
 
-		rule.CreatedAt = createdAt.Time
-		rule.UpdatedAt = updatedAt.Time
+	if expression.Valid {
+		rule.Expression = &expression.String
+	}
 
-		result[rule.ServiceID] = &rule
+	if templateID.Valid {
+		rule.TemplateID = &templateID.Int64
 	}
 
-	return result, nil
+	rule.CreatedAt = createdAt.Time
+	rule.UpdatedAt = updatedAt.Time
+
+	return &rule, nil
 }
@@ -0,0 +1,7 @@
+package pricingtemplate
+
+import "github.com/m04kA/SMC-PriceService/pkg/dbmetrics"
+
+// Переиспользуем интерфейсы из dbmetrics
+type DBExecutor = dbmetrics.DBExecutor
+type TxExecutor = dbmetrics.TxExecutor
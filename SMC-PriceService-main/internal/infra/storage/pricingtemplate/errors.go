@@ -0,0 +1,23 @@
+package pricingtemplate
+
+import "errors"
+
+var (
+	// ErrPricingTemplateNotFound возвращается, когда шаблон ценообразования не найден в БД
+	ErrPricingTemplateNotFound = errors.New("repository: pricing template not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки из БД
+	ErrScanRow = errors.New("repository: failed to scan row")
+
+	// ErrTransaction возвращается при ошибке работы с транзакцией
+	ErrTransaction = errors.New("repository: transaction error")
+
+	// ErrDuplicateTemplate возвращается при попытке создать шаблон с уже занятым у владельца названием
+	ErrDuplicateTemplate = errors.New("repository: pricing template with this name already exists")
+)
@@ -0,0 +1,324 @@
+package pricingtemplate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/infra/storage/classvalues"
+	"github.com/m04kA/SMC-PriceService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-PriceService/pkg/psqlbuilder"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// pricingTemplateColumns колонки таблицы pricing_templates в порядке сканирования scanPricingTemplate
+var pricingTemplateColumns = []string{
+	"id",
+	"name",
+	"owner_company_id",
+	"pricing_type",
+	"vehicle_class_multipliers",
+	"vehicle_class_prices",
+	"created_at",
+	"updated_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий для работы с шаблонами ценообразования
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория шаблонов ценообразования
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Create создает новый шаблон ценообразования
+func (r *Repository) Create(ctx context.Context, input domain.CreatePricingTemplateInput) (*domain.PricingTemplate, error) {
+	multipliers, prices, err := classvalues.Marshal(input.VehicleClassMultipliers, input.VehicleClassPrices)
+	if err != nil {
+		return nil, fmt.Errorf("%w: Create - %v", ErrExecQuery, err)
+	}
+
+	query, args, err := psqlbuilder.Insert("pricing_templates").
+		Columns(
+			"name",
+			"owner_company_id",
+			"pricing_type",
+			"vehicle_class_multipliers",
+			"vehicle_class_prices",
+		).
+		Values(
+			input.Name,
+			input.OwnerCompanyID,
+			input.PricingType,
+			multipliers,
+			prices,
+		).
+		Suffix("RETURNING " + strings.Join(pricingTemplateColumns, ", ")).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: Create - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanPricingTemplate(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		// Проверка на unique constraint violation
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrDuplicateTemplate
+		}
+		return nil, fmt.Errorf("%w: Create - insert pricing template: %v", ErrExecQuery, err)
+	}
+
+	return template, nil
+}
+
+// GetByID получает шаблон ценообразования по ID
+func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.PricingTemplate, error) {
+	query, args, err := psqlbuilder.Select(pricingTemplateColumns...).
+		From("pricing_templates").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanPricingTemplate(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrPricingTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByID - scan pricing template: %v", ErrScanRow, err)
+	}
+
+	return template, nil
+}
+
+// List получает список шаблонов ценообразования
+// С фильтром по компании возвращаются шаблоны платформы и шаблоны этой компании
+func (r *Repository) List(ctx context.Context, filter domain.PricingTemplateFilter) ([]domain.PricingTemplate, error) {
+	selectBuilder := psqlbuilder.Select(pricingTemplateColumns...).
+		From("pricing_templates").
+		OrderBy("owner_company_id NULLS FIRST", "name")
+
+	if filter.CompanyID != nil {
+		selectBuilder = selectBuilder.Where(squirrel.Or{
+			squirrel.Eq{"owner_company_id": nil},
+			squirrel.Eq{"owner_company_id": *filter.CompanyID},
+		})
+	}
+
+	query, args, err := selectBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	templates := make([]domain.PricingTemplate, 0)
+	for rows.Next() {
+		template, err := scanPricingTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: List - scan pricing template: %v", ErrScanRow, err)
+		}
+
+		templates = append(templates, *template)
+	}
+
+	return templates, nil
+}
+
+// Update обновляет шаблон ценообразования
+// При propagate=true в той же транзакции обновляются правила, следующие шаблону; возвращается их количество
+func (r *Repository) Update(ctx context.Context, id int64, input domain.UpdatePricingTemplateInput, propagate bool) (*domain.PricingTemplate, int64, error) {
+	updateBuilder := psqlbuilder.Update("pricing_templates").Where(squirrel.Eq{"id": id})
+
+	if input.Name != nil {
+		updateBuilder = updateBuilder.Set("name", *input.Name)
+	}
+
+	if input.VehicleClassMultipliers != nil {
+		multipliers, err := json.Marshal(input.VehicleClassMultipliers)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: Update - marshal multipliers: %v", ErrExecQuery, err)
+		}
+		updateBuilder = updateBuilder.Set("vehicle_class_multipliers", multipliers)
+	}
+
+	if input.VehicleClassPrices != nil {
+		prices, err := json.Marshal(input.VehicleClassPrices)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: Update - marshal prices: %v", ErrExecQuery, err)
+		}
+		updateBuilder = updateBuilder.Set("vehicle_class_prices", prices)
+	}
+
+	// updated_at меняем явно, чтобы запрос был корректным и без изменений полей
+	query, args, err := updateBuilder.
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Suffix("RETURNING " + strings.Join(pricingTemplateColumns, ", ")).
+		ToSql()
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: Update - build update query: %v", ErrBuildQuery, err)
+	}
+
+	tx, err := dbmetrics.BeginTx(ctx, r.db)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: Update - begin transaction: %v", ErrTransaction, err)
+	}
+
+	template, err := scanPricingTemplate(tx.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return nil, 0, ErrPricingTemplateNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, 0, ErrDuplicateTemplate
+		}
+		return nil, 0, fmt.Errorf("%w: Update - scan pricing template: %v", ErrScanRow, err)
+	}
+
+	var updatedRules int64
+	if propagate {
+		updatedRules, err = r.propagate(ctx, tx, template)
+		if err != nil {
+			tx.Rollback()
+			return nil, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, fmt.Errorf("%w: Update - commit: %v", ErrTransaction, err)
+	}
+
+	return template, updatedRules, nil
+}
+
+// Delete удаляет шаблон ценообразования (правила сохраняют значения и отвязываются через ON DELETE SET NULL)
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := psqlbuilder.Delete("pricing_templates").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Delete - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: Delete - execute delete: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: Delete - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPricingTemplateNotFound
+	}
+
+	return nil
+}
+
+// propagate переносит значения шаблона в правила, следующие ему
+func (r *Repository) propagate(ctx context.Context, tx TxExecutor, template *domain.PricingTemplate) (int64, error) {
+	multipliers, prices, err := classvalues.Marshal(template.VehicleClassMultipliers, template.VehicleClassPrices)
+	if err != nil {
+		return 0, fmt.Errorf("%w: propagate - %v", ErrExecQuery, err)
+	}
+
+	query, args, err := psqlbuilder.Update("pricing_rules").
+		Set("pricing_type", template.PricingType).
+		Set("vehicle_class_multipliers", multipliers).
+		Set("vehicle_class_prices", prices).
+		Where(squirrel.Eq{"template_id": template.ID}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: propagate - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: propagate - update pricing rules: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: propagate - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected, nil
+}
+
+// scanPricingTemplate сканирует строку с колонками pricingTemplateColumns и десериализует JSON поля
+func scanPricingTemplate(row rowScanner) (*domain.PricingTemplate, error) {
+	var template domain.PricingTemplate
+	var ownerCompanyID sql.NullInt64
+	var multipliers, prices []byte
+	var createdAt, updatedAt sql.NullTime
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&ownerCompanyID,
+		&template.PricingType,
+		&multipliers,
+		&prices,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerCompanyID.Valid {
+		template.OwnerCompanyID = &ownerCompanyID.Int64
+	}
+
+	// Пустой JSON {} для неиспользуемого типа оставляем nil
+	if len(multipliers) > 0 {
+		var m map[domain.VehicleClass]float64
+		if err := json.Unmarshal(multipliers, &m); err != nil {
+			return nil, fmt.Errorf("unmarshal multipliers: %w", err)
+		}
+		if len(m) > 0 {
+			template.VehicleClassMultipliers = m
+		}
+	}
+
+	if len(prices) > 0 {
+		var p map[domain.VehicleClass]float64
+		if err := json.Unmarshal(prices, &p); err != nil {
+			return nil, fmt.Errorf("unmarshal prices: %w", err)
+		}
+		if len(p) > 0 {
+			template.VehicleClassPrices = p
+		}
+	}
+
+	template.CreatedAt = createdAt.Time
+	template.UpdatedAt = updatedAt.Time
+
+	return &template, nil
+}
@@ -0,0 +1,130 @@
+package sellerservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/pkg/svcauth"
+)
+
+// Client клиент для работы с SellerService
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	log        Logger
+}
+
+// NewClient создает новый экземпляр клиента SellerService
+// Запросы подписываются учётными данными сервиса (обязательны для /internal эндпоинтов)
+func NewClient(baseURL string, credentials svcauth.Credentials, log Logger) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: svcauth.NewTransport(http.DefaultTransport, credentials),
+		},
+		log: log,
+	}
+}
+
+// GetService получает информацию об услуге компании
+func (c *Client) GetService(ctx context.Context, companyID, serviceID int64) (*Service, error) {
+	url := fmt.Sprintf("%s/internal/companies/%d/services/%d", c.baseURL, companyID, serviceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("%w: invalid company or service ID format", ErrInvalidResponse)
+	case http.StatusNotFound:
+		return nil, ErrServiceNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var service Service
+	if err := json.NewDecoder(resp.Body).Decode(&service); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &service, nil
+}
+
+// GetCompany получает компанию со списком её менеджеров
+func (c *Client) GetCompany(ctx context.Context, companyID int64) (*Company, error) {
+	url := fmt.Sprintf("%s/api/v1/companies/%d", c.baseURL, companyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("%w: invalid company ID format", ErrInvalidResponse)
+	case http.StatusNotFound:
+		return nil, ErrCompanyNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	var company Company
+	if err := json.NewDecoder(resp.Body).Decode(&company); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &company, nil
+}
+
+// GetServiceWithGracefulDegradation получает информацию об услуге с graceful degradation
+// При недоступности SellerService возвращает ErrServiceDegraded, что позволяет сервису использовать базовые цены
+func (c *Client) GetServiceWithGracefulDegradation(ctx context.Context, companyID, serviceID int64) (*Service, error) {
+	c.log.Info("Fetching service info for company_id=%d, service_id=%d", companyID, serviceID)
+
+	service, err := c.GetService(ctx, companyID, serviceID)
+	if err != nil {
+		// Если услуга не найдена - это бизнес-ошибка, пробрасываем её дальше
+		if err == ErrServiceNotFound {
+			c.log.Info("Service not found: company_id=%d, service_id=%d", companyID, serviceID)
+			return nil, err
+		}
+
+		// Для всех остальных ошибок применяем graceful degradation
+		c.log.Error("SellerService unavailable, applying graceful degradation for company_id=%d, service_id=%d: %v", companyID, serviceID, err)
+		return nil, fmt.Errorf("%w: company_id=%d, service_id=%d, error=%v", ErrServiceDegraded, companyID, serviceID, err)
+	}
+
+	c.log.Info("Successfully fetched service info for company_id=%d, service_id=%d", companyID, serviceID)
+	return service, nil
+}
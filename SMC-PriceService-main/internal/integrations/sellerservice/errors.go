@@ -0,0 +1,21 @@
+package sellerservice
+
+import "errors"
+
+var (
+	// ErrServiceNotFound возвращается, когда услуга компании не найдена
+	ErrServiceNotFound = errors.New("service not found")
+
+	// ErrCompanyNotFound возвращается, когда компания не найдена
+	ErrCompanyNotFound = errors.New("company not found")
+
+	// ErrInternal возвращается при внутренних ошибках клиента
+	ErrInternal = errors.New("sellerservice client: internal error")
+
+	// ErrInvalidResponse возвращается при некорректном ответе от сервиса
+	ErrInvalidResponse = errors.New("sellerservice client: invalid response")
+
+	// ErrServiceDegraded возвращается при применении graceful degradation
+	// Указывает, что SellerService недоступен и длительность услуги неизвестна
+	ErrServiceDegraded = errors.New("sellerservice unavailable: graceful degradation applied")
+)
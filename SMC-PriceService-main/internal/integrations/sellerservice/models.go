@@ -0,0 +1,31 @@
+package sellerservice
+
+// Service модель услуги из SellerService
+type Service struct {
+	ID              int64  `json:"id"`
+	CompanyID       int64  `json:"company_id"`
+	Name            string `json:"name"`
+	AverageDuration *int   `json:"average_duration,omitempty"` // Средняя длительность услуги в минутах
+}
+
+// Company модель компании из SellerService (только поля, нужные для проверки прав)
+type Company struct {
+	ID         int64   `json:"id"`
+	ManagerIDs []int64 `json:"manager_ids"`
+}
+
+// IsManager проверяет, является ли пользователь менеджером компании
+func (c *Company) IsManager(userID int64) bool {
+	for _, id := range c.ManagerIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorResponse модель ошибки от SellerService
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
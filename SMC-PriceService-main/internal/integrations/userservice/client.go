@@ -1,12 +1,15 @@
 package userservice
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/m04kA/SMC-PriceService/pkg/svcauth"
 )
 
 // Client клиент для работы с UserService
@@ -17,11 +20,13 @@ type Client struct {
 }
 
 // NewClient создает новый экземпляр клиента UserService
-func NewClient(baseURL string, log Logger) *Client {
+// Запросы к /internal эндпоинтам подписываются учётными данными сервиса
+func NewClient(baseURL string, credentials svcauth.Credentials, log Logger) *Client {
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: svcauth.NewTransport(http.DefaultTransport, credentials),
 		},
 		log: log,
 	}
@@ -90,3 +95,62 @@ func (c *Client) GetSelectedCarWithGracefulDegradation(ctx context.Context, tgUs
 	c.log.Info("Successfully fetched car for tg_user_id=%d, vehicle_class=%s", tgUserID, car.Size)
 	return car, nil
 }
+
+// GetUsersBatch получает данные нескольких пользователей с выбранными автомобилями
+// Список ID разбивается на части по MaxBatchSize; ненайденные ID возвращаются во втором значении
+func (c *Client) GetUsersBatch(ctx context.Context, tgUserIDs []int64) ([]User, []int64, error) {
+	users := make([]User, 0, len(tgUserIDs))
+	missing := make([]int64, 0)
+
+	for start := 0; start < len(tgUserIDs); start += MaxBatchSize {
+		end := min(start+MaxBatchSize, len(tgUserIDs))
+
+		batch, err := c.getUsersBatch(ctx, tgUserIDs[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		users = append(users, batch.Users...)
+		missing = append(missing, batch.MissingIDs...)
+	}
+
+	return users, missing, nil
+}
+
+func (c *Client) getUsersBatch(ctx context.Context, tgUserIDs []int64) (*UsersBatchResponse, error) {
+	url := fmt.Sprintf("%s/internal/users/batch", c.baseURL)
+
+	body, err := json.Marshal(UsersBatchRequest{TGUserIDs: tgUserIDs})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %v", ErrInternal, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	default:
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(respBody))
+	}
+
+	// Парсим ответ
+	var response UsersBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &response, nil
+}
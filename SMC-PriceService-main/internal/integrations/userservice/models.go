@@ -12,6 +12,30 @@ type Car struct {
 	IsSelected   bool    `json:"is_selected"`
 }
 
+// MaxBatchSize максимальное количество ID в одном запросе /internal/users/batch
+const MaxBatchSize = 100
+
+// User модель пользователя из UserService с выбранным автомобилем
+type User struct {
+	TGUserID    int64   `json:"tg_user_id"`
+	Name        string  `json:"name"`
+	PhoneNumber *string `json:"phone_number,omitempty"`
+	TGLink      *string `json:"tg_link,omitempty"`
+	Role        string  `json:"role"`
+	SelectedCar *Car    `json:"selected_car"` // nil - у пользователя нет выбранного автомобиля
+}
+
+// UsersBatchRequest запрос нескольких пользователей
+type UsersBatchRequest struct {
+	TGUserIDs []int64 `json:"tg_user_ids"`
+}
+
+// UsersBatchResponse найденные пользователи и ненайденные ID
+type UsersBatchResponse struct {
+	Users      []User  `json:"users"`
+	MissingIDs []int64 `json:"missing_ids"`
+}
+
 // ErrorResponse модель ошибки от UserService
 type ErrorResponse struct {
 	Code    int    `json:"code"`
@@ -0,0 +1,20 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+)
+
+// CalculationLogRepository интерфейс для чтения агрегатов журнала расчётов
+type CalculationLogRepository interface {
+	GetDailyPriceStats(ctx context.Context, filter domain.CalculationLogFilter) ([]domain.DailyPriceStats, error)
+	GetDegradationStats(ctx context.Context, filter domain.CalculationLogFilter) (*domain.DegradationStats, error)
+	GetVehicleClassMix(ctx context.Context, filter domain.CalculationLogFilter) ([]domain.VehicleClassCount, error)
+}
+
+// SellerServiceClient интерфейс для получения менеджеров компании (проверка прав)
+type SellerServiceClient interface {
+	GetCompany(ctx context.Context, companyID int64) (*sellerservice.Company, error)
+}
@@ -0,0 +1,14 @@
+package analytics
+
+import "errors"
+
+var (
+	// ErrInvalidInput возвращается при некорректных параметрах запроса
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrAccessDenied возвращается, когда пользователь не менеджер компании и не superuser
+	ErrAccessDenied = errors.New("access denied: user is not a manager of this company")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("internal error")
+)
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"math"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+)
+
+// dateLayout формат дат в запросах и ответах аналитики
+const dateLayout = "2006-01-02"
+
+// AnalyticsFilterRequest параметры запроса аналитики
+type AnalyticsFilterRequest struct {
+	CompanyID int64   `json:"company_id"`
+	ServiceID *int64  `json:"service_id,omitempty"`
+	From      *string `json:"from,omitempty"` // YYYY-MM-DD, включительно
+	To        *string `json:"to,omitempty"`   // YYYY-MM-DD, включительно
+}
+
+// Period период, за который построена аналитика
+type Period struct {
+	CompanyID int64  `json:"company_id"`
+	ServiceID *int64 `json:"service_id,omitempty"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// DailyPriceStats статистика показанных цен за день
+type DailyPriceStats struct {
+	ServiceID    int64   `json:"service_id"`
+	Day          string  `json:"day"`
+	Currency     string  `json:"currency"`
+	Calculations int64   `json:"calculations"`
+	AvgPrice     float64 `json:"avg_price"`
+	MinPrice     float64 `json:"min_price"`
+	MaxPrice     float64 `json:"max_price"`
+}
+
+// DailyPricesResponse цены, показанные по услугам компании по дням
+type DailyPricesResponse struct {
+	Period
+	Days []DailyPriceStats `json:"days"`
+}
+
+// DegradationResponse доля расчётов с деградацией
+type DegradationResponse struct {
+	Period
+	Total         int64            `json:"total"`
+	Degraded      int64            `json:"degraded"`
+	DegradedShare float64          `json:"degraded_share"` // от 0 до 1
+	ByReason      map[string]int64 `json:"by_reason"`
+}
+
+// VehicleClassShare количество и доля расчётов для класса автомобиля
+type VehicleClassShare struct {
+	VehicleClass *string `json:"vehicle_class,omitempty"` // отсутствует - класс не применялся
+	Count        int64   `json:"count"`
+	Share        float64 `json:"share"` // от 0 до 1
+}
+
+// VehicleClassMixResponse распределение расчётов по классам автомобилей
+type VehicleClassMixResponse struct {
+	Period
+	Total   int64               `json:"total"`
+	Classes []VehicleClassShare `json:"classes"`
+}
+
+// FromDomainDailyPriceStats преобразует domain статистику в response
+func FromDomainDailyPriceStats(stats []domain.DailyPriceStats) []DailyPriceStats {
+	result := make([]DailyPriceStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, DailyPriceStats{
+			ServiceID:    s.ServiceID,
+			Day:          s.Day.Format(dateLayout),
+			Currency:     s.Currency,
+			Calculations: s.Calculations,
+			AvgPrice:     round(s.AvgPrice, 2),
+			MinPrice:     s.MinPrice,
+			MaxPrice:     s.MaxPrice,
+		})
+	}
+	return result
+}
+
+// FromDomainDegradationStats заполняет response из domain статистики
+func FromDomainDegradationStats(period Period, stats *domain.DegradationStats) *DegradationResponse {
+	resp := &DegradationResponse{
+		Period:   period,
+		Total:    stats.Total,
+		Degraded: stats.Degraded,
+		ByReason: make(map[string]int64, len(stats.ByReason)),
+	}
+	if stats.Total > 0 {
+		resp.DegradedShare = round(float64(stats.Degraded)/float64(stats.Total), 4)
+	}
+	for reason, count := range stats.ByReason {
+		resp.ByReason[string(reason)] = count
+	}
+	return resp
+}
+
+// FromDomainVehicleClassMix заполняет response из domain распределения
+func FromDomainVehicleClassMix(period Period, counts []domain.VehicleClassCount) *VehicleClassMixResponse {
+	resp := &VehicleClassMixResponse{
+		Period:  period,
+		Classes: make([]VehicleClassShare, 0, len(counts)),
+	}
+	for _, c := range counts {
+		resp.Total += c.Count
+	}
+	for _, c := range counts {
+		share := 0.0
+		if resp.Total > 0 {
+			share = round(float64(c.Count)/float64(resp.Total), 4)
+		}
+		resp.Classes = append(resp.Classes, VehicleClassShare{
+			VehicleClass: c.VehicleClass,
+			Count:        c.Count,
+			Share:        share,
+		})
+	}
+	return resp
+}
+
+// round округляет значение до заданного количества знаков
+func round(value float64, digits int) float64 {
+	factor := math.Pow(10, float64(digits))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,162 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+	"github.com/m04kA/SMC-PriceService/internal/service"
+	"github.com/m04kA/SMC-PriceService/internal/service/analytics/models"
+)
+
+const (
+	// defaultPeriodDays период по умолчанию, если даты не переданы
+	defaultPeriodDays = 30
+	// maxPeriodDays максимальный период одного запроса
+	maxPeriodDays = 366
+
+	dateLayout = "2006-01-02"
+)
+
+// Service сервис аналитики по журналу расчётов цен
+type Service struct {
+	calculationLogRepo  CalculationLogRepository
+	sellerServiceClient SellerServiceClient
+	location            *time.Location // часовой пояс для границ дней
+}
+
+// NewService создаёт новый сервис аналитики
+func NewService(calculationLogRepo CalculationLogRepository, sellerServiceClient SellerServiceClient, location *time.Location) *Service {
+	return &Service{
+		calculationLogRepo:  calculationLogRepo,
+		sellerServiceClient: sellerServiceClient,
+		location:            location,
+	}
+}
+
+// GetDailyPrices возвращает статистику показанных цен по услугам компании по дням
+func (s *Service) GetDailyPrices(ctx context.Context, userID int64, userRole string, req *models.AnalyticsFilterRequest) (*models.DailyPricesResponse, error) {
+	filter, period, err := s.buildFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.calculationLogRepo.GetDailyPriceStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDailyPrices - repository error: %v", ErrInternal, err)
+	}
+
+	return &models.DailyPricesResponse{
+		Period: period,
+		Days:   models.FromDomainDailyPriceStats(stats),
+	}, nil
+}
+
+// GetDegradation возвращает долю расчётов с деградацией и разбивку по причинам
+func (s *Service) GetDegradation(ctx context.Context, userID int64, userRole string, req *models.AnalyticsFilterRequest) (*models.DegradationResponse, error) {
+	filter, period, err := s.buildFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.calculationLogRepo.GetDegradationStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDegradation - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainDegradationStats(period, stats), nil
+}
+
+// GetVehicleClassMix возвращает распределение расчётов по классам автомобилей
+func (s *Service) GetVehicleClassMix(ctx context.Context, userID int64, userRole string, req *models.AnalyticsFilterRequest) (*models.VehicleClassMixResponse, error) {
+	filter, period, err := s.buildFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.calculationLogRepo.GetVehicleClassMix(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetVehicleClassMix - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainVehicleClassMix(period, counts), nil
+}
+
+// checkAccess проверяет, что пользователь - superuser или менеджер компании
+func (s *Service) checkAccess(ctx context.Context, companyID int64, userID int64, userRole string) error {
+	if userRole == service.RoleSuperuser {
+		return nil
+	}
+
+	company, err := s.sellerServiceClient.GetCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, sellerservice.ErrCompanyNotFound) {
+			return fmt.Errorf("%w: company_id=%d", ErrAccessDenied, companyID)
+		}
+		return fmt.Errorf("%w: checkAccess - get company %d: %v", ErrInternal, companyID, err)
+	}
+
+	if !company.IsManager(userID) {
+		return fmt.Errorf("%w: company_id=%d", ErrAccessDenied, companyID)
+	}
+
+	return nil
+}
+
+// buildFilter проверяет параметры и строит фильтр с границами дней в часовом поясе сервиса
+// По умолчанию - последние 30 дней включая сегодня
+func (s *Service) buildFilter(req *models.AnalyticsFilterRequest) (domain.CalculationLogFilter, models.Period, error) {
+	var filter domain.CalculationLogFilter
+	var period models.Period
+
+	if req.CompanyID <= 0 {
+		return filter, period, fmt.Errorf("%w: company_id is required", ErrInvalidInput)
+	}
+
+	now := time.Now().In(s.location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.location)
+
+	to := today
+	if req.To != nil {
+		parsed, err := time.ParseInLocation(dateLayout, *req.To, s.location)
+		if err != nil {
+			return filter, period, fmt.Errorf("%w: invalid to date (expected YYYY-MM-DD): %s", ErrInvalidInput, *req.To)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultPeriodDays - 1))
+	if req.From != nil {
+		parsed, err := time.ParseInLocation(dateLayout, *req.From, s.location)
+		if err != nil {
+			return filter, period, fmt.Errorf("%w: invalid from date (expected YYYY-MM-DD): %s", ErrInvalidInput, *req.From)
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return filter, period, fmt.Errorf("%w: from must not be after to", ErrInvalidInput)
+	}
+	if to.Sub(from) > maxPeriodDays*24*time.Hour {
+		return filter, period, fmt.Errorf("%w: period must not exceed %d days", ErrInvalidInput, maxPeriodDays)
+	}
+
+	filter = domain.CalculationLogFilter{
+		CompanyID: req.CompanyID,
+		ServiceID: req.ServiceID,
+		From:      from,
+		To:        to.AddDate(0, 0, 1), // to включительно
+		Timezone:  s.location.String(),
+	}
+	period = models.Period{
+		CompanyID: req.CompanyID,
+		ServiceID: req.ServiceID,
+		From:      from.Format(dateLayout),
+		To:        to.Format(dateLayout),
+	}
+
+	return filter, period, nil
+}
@@ -0,0 +1,25 @@
+package calculationlog
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+)
+
+// EventRepository интерфейс для записи журнала расчётов
+type EventRepository interface {
+	EnsurePartition(ctx context.Context, month string) error
+	InsertBatch(ctx context.Context, events []domain.CalculationEvent) error
+}
+
+// MetricsCollector интерфейс для метрик журнала расчётов (может быть nil)
+type MetricsCollector interface {
+	RecordCalculationEvents(service, status string, count int)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,191 @@
+package calculationlog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+)
+
+// Статусы событий для метрик
+const (
+	statusWritten = "written"
+	statusDropped = "dropped"
+	statusFailed  = "failed"
+)
+
+// writeTimeout таймаут записи одной пачки в БД
+const writeTimeout = 10 * time.Second
+
+// RecorderConfig настройки асинхронной записи журнала расчётов
+type RecorderConfig struct {
+	BatchSize     int           // максимальный размер пачки
+	FlushInterval time.Duration // максимальная задержка перед записью неполной пачки
+	BufferSize    int           // размер буфера событий; при переполнении события отбрасываются
+}
+
+// Recorder асинхронно и пачками записывает события расчёта цен
+// Record никогда не блокирует расчёт цены: при переполнении буфера событие отбрасывается
+type Recorder struct {
+	repo        EventRepository
+	cfg         RecorderConfig
+	metrics     MetricsCollector
+	serviceName string
+	logger      Logger
+
+	events     chan domain.CalculationEvent
+	stop       chan struct{}
+	wg         sync.WaitGroup
+	stopOnce   sync.Once
+	partitions map[string]bool // месяцы, для которых секция уже создана
+}
+
+// NewRecorder создаёт новый Recorder (metrics может быть nil)
+func NewRecorder(repo EventRepository, cfg RecorderConfig, metrics MetricsCollector, serviceName string, logger Logger) *Recorder {
+	return &Recorder{
+		repo:        repo,
+		cfg:         cfg,
+		metrics:     metrics,
+		serviceName: serviceName,
+		logger:      logger,
+		events:      make(chan domain.CalculationEvent, cfg.BufferSize),
+		stop:        make(chan struct{}),
+		partitions:  make(map[string]bool),
+	}
+}
+
+// Start запускает фоновую запись
+func (r *Recorder) Start() {
+	// Секции на текущий и следующий месяц создаём заранее
+	now := time.Now()
+	r.ensurePartition(now)
+	r.ensurePartition(nextMonth(now))
+
+	r.wg.Add(1)
+	go r.run()
+
+	r.logger.Info("Calculation log recorder started (batch_size=%d, flush_interval=%s, buffer_size=%d)",
+		r.cfg.BatchSize, r.cfg.FlushInterval, r.cfg.BufferSize)
+}
+
+// Stop останавливает запись и дописывает оставшиеся в буфере события
+func (r *Recorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.wg.Wait()
+		r.logger.Info("Calculation log recorder stopped")
+	})
+}
+
+// Record ставит событие в очередь на запись (неблокирующий вызов)
+func (r *Recorder) Record(event domain.CalculationEvent) {
+	select {
+	case r.events <- event:
+	default:
+		r.recordMetric(statusDropped, 1)
+		r.logger.Warn("Calculation log buffer is full, event dropped: company_id=%d, service_id=%d",
+			event.CompanyID, event.ServiceID)
+	}
+}
+
+// run цикл накопления и записи пачек
+func (r *Recorder) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.CalculationEvent, 0, r.cfg.BatchSize)
+
+	for {
+		select {
+		case event := <-r.events:
+			batch = append(batch, event)
+			if len(batch) >= r.cfg.BatchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+
+		case <-r.stop:
+			// Дописываем всё, что осталось в буфере
+			for {
+				select {
+				case event := <-r.events:
+					batch = append(batch, event)
+					if len(batch) >= r.cfg.BatchSize {
+						r.flush(batch)
+						batch = batch[:0]
+					}
+				default:
+					if len(batch) > 0 {
+						r.flush(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush записывает пачку в БД
+func (r *Recorder) flush(batch []domain.CalculationEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	// Секции по умолчанию нет: событие месяца без секции не запишется, поэтому следующий месяц создаём заранее
+	for _, event := range batch {
+		r.ensurePartition(event.CreatedAt)
+		r.ensurePartition(nextMonth(event.CreatedAt))
+	}
+
+	if err := r.repo.InsertBatch(ctx, batch); err != nil {
+		r.recordMetric(statusFailed, len(batch))
+		r.logger.Error("Failed to write calculation log batch (%d events): %v", len(batch), err)
+		return
+	}
+
+	r.recordMetric(statusWritten, len(batch))
+}
+
+// ensurePartition создаёт месячную секцию, если она ещё не создавалась этим процессом
+// При ошибке секция создаётся заново при следующей записи
+func (r *Recorder) ensurePartition(t time.Time) {
+	month := t.UTC().Format("2006-01") + "-01"
+	if r.partitions[month] {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	if err := r.repo.EnsurePartition(ctx, month); err != nil {
+		r.logger.Error("Failed to create calculation log partition for %s: %v", month, err)
+		return
+	}
+	r.partitions[month] = true
+}
+
+// nextMonth возвращает начало следующего месяца в UTC (AddDate(0, 1, 0) от 31 января перескакивает февраль)
+func nextMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (r *Recorder) recordMetric(status string, count int) {
+	if r.metrics != nil {
+		r.metrics.RecordCalculationEvents(r.serviceName, status, count)
+	}
+}
+
+// NopRecorder заглушка для отключённого журнала расчётов
+type NopRecorder struct{}
+
+// Record ничего не делает
+func (NopRecorder) Record(domain.CalculationEvent) {}
@@ -15,6 +15,8 @@ type CreatePricingRuleRequest struct {
 	Currency                string                           `json:"currency"`
 	VehicleClassMultipliers map[string]float64               `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[string]float64               `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing                `json:"per_minute,omitempty"`
+	Expression              *string                          `json:"expression,omitempty"`
 }
 
 // UpdatePricingRuleRequest запрос на обновление правила ценообразования
@@ -24,6 +26,15 @@ type UpdatePricingRuleRequest struct {
 	Currency                *string                          `json:"currency,omitempty"`
 	VehicleClassMultipliers map[string]float64               `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[string]float64               `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing                `json:"per_minute,omitempty"`
+	Expression              *string                          `json:"expression,omitempty"`
+}
+
+// PerMinutePricing параметры поминутной тарификации
+type PerMinutePricing struct {
+	RatePerMinute      float64 `json:"rate_per_minute"`
+	MinBillableMinutes int     `json:"min_billable_minutes,omitempty"`
+	RoundingIncrement  int     `json:"rounding_increment,omitempty"`
 }
 
 // PricingRuleResponse ответ с правилом ценообразования
@@ -36,6 +47,9 @@ type PricingRuleResponse struct {
 	Currency                string             `json:"currency"`
 	VehicleClassMultipliers map[string]float64 `json:"vehicle_class_multipliers,omitempty"`
 	VehicleClassPrices      map[string]float64 `json:"vehicle_class_prices,omitempty"`
+	PerMinute               *PerMinutePricing  `json:"per_minute,omitempty"`
+	Expression              *string            `json:"expression,omitempty"`
+	TemplateID              *int64             `json:"template_id,omitempty"` // шаблон, изменения которого получает правило
 	CreatedAt               time.Time          `json:"created_at"`
 	UpdatedAt               time.Time          `json:"updated_at"`
 }
@@ -75,6 +89,9 @@ func (r *CreatePricingRuleRequest) ToDomainCreateInput() domain.CreatePricingRul
 		}
 	}
 
+	input.PerMinute = r.PerMinute.ToDomain()
+	input.Expression = r.Expression
+
 	return input
 }
 
@@ -104,9 +121,25 @@ func (r *UpdatePricingRuleRequest) ToDomainUpdateInput() domain.UpdatePricingRul
 		}
 	}
 
+	input.PerMinute = r.PerMinute.ToDomain()
+	input.Expression = r.Expression
+
 	return input
 }
 
+// ToDomain преобразует параметры поминутной тарификации в domain model (nil-safe)
+func (p *PerMinutePricing) ToDomain() *domain.PerMinutePricing {
+	if p == nil {
+		return nil
+	}
+
+	return &domain.PerMinutePricing{
+		RatePerMinute:      p.RatePerMinute,
+		MinBillableMinutes: p.MinBillableMinutes,
+		RoundingIncrement:  p.RoundingIncrement,
+	}
+}
+
 // ToDomainFilter преобразует request в domain filter
 func (r *PricingRuleFilterRequest) ToDomainFilter() domain.PricingRuleFilter {
 	return domain.PricingRuleFilter{
@@ -142,6 +175,17 @@ func FromDomainPricingRule(rule *domain.PricingRule) *PricingRuleResponse {
 		}
 	}
 
+	if rule.PerMinute != nil {
+		resp.PerMinute = &PerMinutePricing{
+			RatePerMinute:      rule.PerMinute.RatePerMinute,
+			MinBillableMinutes: rule.PerMinute.MinBillableMinutes,
+			RoundingIncrement:  rule.PerMinute.RoundingIncrement,
+		}
+	}
+
+	resp.Expression = rule.Expression
+	resp.TemplateID = rule.TemplateID
+
 	return resp
 }
 
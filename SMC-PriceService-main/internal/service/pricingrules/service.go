@@ -81,6 +81,13 @@ func (s *Service) Update(ctx context.Context, id int64, req *models.UpdatePricin
 	}
 
 	input := req.ToDomainUpdateInput()
+
+	// Ручное изменение типа или цен по классам отвязывает правило от шаблона
+	if currentRule.TemplateID != nil &&
+		(req.PricingType != nil || req.VehicleClassMultipliers != nil || req.VehicleClassPrices != nil) {
+		input.DetachTemplate = true
+	}
+
 	rule, err := s.pricingRuleRepo.Update(ctx, id, input)
 	if err != nil {
 		if errors.Is(err, pricingRuleRepo.ErrPricingRuleNotFound) {
@@ -104,6 +111,22 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// ValidateDraft проверяет черновик правила без сохранения (используется симулятором цен)
+func (s *Service) ValidateDraft(req *models.CreatePricingRuleRequest) error {
+	if err := s.validateCreateRequest(req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// ValidateChanges проверяет изменения существующего правила без сохранения (используется симулятором цен)
+func (s *Service) ValidateChanges(currentRule *domain.PricingRule, req *models.UpdatePricingRuleRequest) error {
+	if err := s.validateUpdateRequest(currentRule, req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return nil
+}
+
 // validateCreateRequest валидирует запрос на создание правила
 func (s *Service) validateCreateRequest(req *models.CreatePricingRuleRequest) error {
 	pricingType := domain.PricingType(req.PricingType)
@@ -123,6 +146,12 @@ func (s *Service) validateCreateRequest(req *models.CreatePricingRuleRequest) er
 		if req.VehicleClassPrices != nil {
 			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'static'")
 		}
+		if req.PerMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'static'")
+		}
+		if req.Expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'static'")
+		}
 
 	case domain.PricingTypeVehicleClassMultiplier:
 		// Для vehicle_class_pricing_multiplier требуется vehicle_class_multipliers
@@ -133,6 +162,12 @@ func (s *Service) validateCreateRequest(req *models.CreatePricingRuleRequest) er
 		if req.VehicleClassPrices != nil {
 			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
 		}
+		if req.PerMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
+		if req.Expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
 
 	case domain.PricingTypeVehicleClassFixed:
 		// Для vehicle_class_pricing_fixed требуется vehicle_class_prices
@@ -143,9 +178,44 @@ func (s *Service) validateCreateRequest(req *models.CreatePricingRuleRequest) er
 		if req.VehicleClassMultipliers != nil {
 			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'vehicle_class_pricing_fixed'")
 		}
+		if req.PerMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+		if req.Expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+
+	case domain.PricingTypePerMinute:
+		// Для per_minute требуются параметры поминутной тарификации
+		// vehicle_class_multipliers опциональны и применяются к минутной ставке
+		if req.VehicleClassPrices != nil {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'per_minute'")
+		}
+		if req.Expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'per_minute'")
+		}
+		if err := validatePerMinute(req.PerMinute.ToDomain()); err != nil {
+			return err
+		}
+
+	case domain.PricingTypeExpression:
+		// Для expression цена полностью задаётся выражением
+		// base_price доступна в выражении и используется как fallback при ошибке вычисления
+		if req.VehicleClassMultipliers != nil {
+			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'expression'")
+		}
+		if req.VehicleClassPrices != nil {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'expression'")
+		}
+		if req.PerMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'expression'")
+		}
+		if err := validateExpression(req.Expression); err != nil {
+			return err
+		}
 
 	default:
-		return fmt.Errorf("invalid pricing_type: %s (allowed: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed)", req.PricingType)
+		return fmt.Errorf("invalid pricing_type: %s (allowed: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed, per_minute, expression)", req.PricingType)
 	}
 
 	return nil
@@ -185,6 +255,23 @@ func (s *Service) validateUpdateRequest(currentRule *domain.PricingRule, req *mo
 		prices = currentRule.VehicleClassPrices
 	}
 
+	// Смена pricing_type с per_minute на другой очищает поминутные параметры
+	perMinute := currentRule.PerMinute
+	if req.PerMinute != nil {
+		perMinute = req.PerMinute.ToDomain()
+	} else if req.ToDomainUpdateInput().ClearsPerMinute() {
+		perMinute = nil
+	}
+
+	// Пустая строка очищает выражение (нужно при смене pricing_type с expression на другой)
+	expression := currentRule.Expression
+	if req.Expression != nil {
+		expression = req.Expression
+		if *req.Expression == "" {
+			expression = nil
+		}
+	}
+
 	// Валидируем итоговое состояние
 	// base_price обязателен для всех типов
 	if basePrice == nil {
@@ -199,6 +286,12 @@ func (s *Service) validateUpdateRequest(currentRule *domain.PricingRule, req *mo
 		if prices != nil && len(prices) > 0 {
 			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'static'")
 		}
+		if perMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'static'")
+		}
+		if expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'static'")
+		}
 
 	case domain.PricingTypeVehicleClassMultiplier:
 		if multipliers == nil || len(multipliers) == 0 {
@@ -207,6 +300,12 @@ func (s *Service) validateUpdateRequest(currentRule *domain.PricingRule, req *mo
 		if prices != nil && len(prices) > 0 {
 			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
 		}
+		if perMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
+		if expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
 
 	case domain.PricingTypeVehicleClassFixed:
 		if prices == nil || len(prices) == 0 {
@@ -215,6 +314,37 @@ func (s *Service) validateUpdateRequest(currentRule *domain.PricingRule, req *mo
 		if multipliers != nil && len(multipliers) > 0 {
 			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'vehicle_class_pricing_fixed'")
 		}
+		if perMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+		if expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+
+	case domain.PricingTypePerMinute:
+		if prices != nil && len(prices) > 0 {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'per_minute'")
+		}
+		if expression != nil {
+			return fmt.Errorf("expression should not be set for pricing_type 'per_minute'")
+		}
+		if err := validatePerMinute(perMinute); err != nil {
+			return err
+		}
+
+	case domain.PricingTypeExpression:
+		if multipliers != nil && len(multipliers) > 0 {
+			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'expression'")
+		}
+		if prices != nil && len(prices) > 0 {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'expression'")
+		}
+		if perMinute != nil {
+			return fmt.Errorf("per_minute should not be set for pricing_type 'expression'")
+		}
+		if err := validateExpression(expression); err != nil {
+			return err
+		}
 
 	default:
 		return fmt.Errorf("invalid pricing_type: %s", pricingType)
@@ -222,3 +352,33 @@ func (s *Service) validateUpdateRequest(currentRule *domain.PricingRule, req *mo
 
 	return nil
 }
+
+// validatePerMinute валидирует параметры поминутной тарификации
+func validatePerMinute(perMinute *domain.PerMinutePricing) error {
+	if perMinute == nil {
+		return fmt.Errorf("per_minute is required for pricing_type 'per_minute'")
+	}
+	if perMinute.RatePerMinute <= 0 {
+		return fmt.Errorf("per_minute.rate_per_minute must be greater than 0")
+	}
+	if perMinute.MinBillableMinutes < 0 {
+		return fmt.Errorf("per_minute.min_billable_minutes must not be negative")
+	}
+	if perMinute.RoundingIncrement < 0 {
+		return fmt.Errorf("per_minute.rounding_increment must not be negative")
+	}
+
+	return nil
+}
+
+// validateExpression проверяет выражение правила: синтаксис, типы и ограничения на размер
+func validateExpression(expression *string) error {
+	if expression == nil || *expression == "" {
+		return fmt.Errorf("expression is required for pricing_type 'expression'")
+	}
+	if _, err := domain.CompilePricingExpression(*expression); err != nil {
+		return fmt.Errorf("invalid expression: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package pricingtemplates
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+)
+
+// PricingTemplateRepository интерфейс репозитория шаблонов ценообразования
+type PricingTemplateRepository interface {
+	Create(ctx context.Context, input domain.CreatePricingTemplateInput) (*domain.PricingTemplate, error)
+	GetByID(ctx context.Context, id int64) (*domain.PricingTemplate, error)
+	List(ctx context.Context, filter domain.PricingTemplateFilter) ([]domain.PricingTemplate, error)
+	Update(ctx context.Context, id int64, input domain.UpdatePricingTemplateInput, propagate bool) (*domain.PricingTemplate, int64, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// PricingRuleRepository интерфейс для применения шаблона к правилам ценообразования
+type PricingRuleRepository interface {
+	ApplyTemplate(ctx context.Context, template *domain.PricingTemplate, input domain.ApplyPricingTemplateInput) ([]domain.PricingRule, error)
+}
+
+// SellerServiceClient интерфейс для получения менеджеров компании (проверка прав)
+type SellerServiceClient interface {
+	GetCompany(ctx context.Context, companyID int64) (*sellerservice.Company, error)
+}
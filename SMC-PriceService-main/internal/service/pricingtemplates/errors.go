@@ -0,0 +1,20 @@
+package pricingtemplates
+
+import "errors"
+
+var (
+	// ErrPricingTemplateNotFound возвращается, когда шаблон ценообразования не найден
+	ErrPricingTemplateNotFound = errors.New("pricing template not found")
+
+	// ErrDuplicateTemplate возвращается при попытке создать шаблон с уже занятым названием
+	ErrDuplicateTemplate = errors.New("pricing template with this name already exists")
+
+	// ErrAccessDenied возвращается, когда у пользователя нет прав на шаблон или компанию
+	ErrAccessDenied = errors.New("access denied: user is not a manager of this company")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("invalid input data")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service: internal error")
+)
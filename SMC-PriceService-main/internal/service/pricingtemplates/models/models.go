@@ -0,0 +1,180 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	pricingRuleModels "github.com/m04kA/SMC-PriceService/internal/service/pricingrules/models"
+)
+
+// CreatePricingTemplateRequest запрос на создание шаблона ценообразования
+type CreatePricingTemplateRequest struct {
+	Name                    string             `json:"name"`
+	OwnerCompanyID          *int64             `json:"owner_company_id,omitempty"` // отсутствует - шаблон платформы
+	PricingType             string             `json:"pricing_type"`
+	VehicleClassMultipliers map[string]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[string]float64 `json:"vehicle_class_prices,omitempty"`
+}
+
+// UpdatePricingTemplateRequest запрос на обновление шаблона ценообразования
+type UpdatePricingTemplateRequest struct {
+	Name                    *string            `json:"name,omitempty"`
+	VehicleClassMultipliers map[string]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[string]float64 `json:"vehicle_class_prices,omitempty"`
+	Propagate               bool               `json:"propagate"` // перенести изменения в правила, следующие шаблону
+}
+
+// PricingTemplateResponse ответ с шаблоном ценообразования
+type PricingTemplateResponse struct {
+	ID                      int64              `json:"id"`
+	Name                    string             `json:"name"`
+	OwnerCompanyID          *int64             `json:"owner_company_id,omitempty"`
+	PricingType             string             `json:"pricing_type"`
+	VehicleClassMultipliers map[string]float64 `json:"vehicle_class_multipliers,omitempty"`
+	VehicleClassPrices      map[string]float64 `json:"vehicle_class_prices,omitempty"`
+	CreatedAt               time.Time          `json:"created_at"`
+	UpdatedAt               time.Time          `json:"updated_at"`
+}
+
+// UpdatePricingTemplateResponse ответ на обновление шаблона
+type UpdatePricingTemplateResponse struct {
+	PricingTemplateResponse
+	UpdatedRules int64 `json:"updated_rules"` // количество правил, получивших изменения
+}
+
+// PricingTemplateFilterRequest запрос на фильтрацию шаблонов
+type PricingTemplateFilterRequest struct {
+	CompanyID *int64 `json:"company_id,omitempty"`
+}
+
+// PricingTemplateListResponse ответ со списком шаблонов
+type PricingTemplateListResponse struct {
+	Templates []PricingTemplateResponse `json:"templates"`
+}
+
+// TemplateTarget пара компания-услуга, к которой применяется шаблон
+type TemplateTarget struct {
+	CompanyID int64 `json:"company_id"`
+	ServiceID int64 `json:"service_id"`
+}
+
+// ApplyPricingTemplateRequest запрос на применение шаблона к списку услуг
+type ApplyPricingTemplateRequest struct {
+	Targets   []TemplateTarget `json:"targets"`
+	BasePrice *float64         `json:"base_price,omitempty"` // обязательна для услуг без правила
+	Currency  *string          `json:"currency,omitempty"`
+	Follow    bool             `json:"follow"` // правила получают последующие изменения шаблона
+}
+
+// ApplyPricingTemplateResponse ответ с созданными и обновлёнными правилами
+type ApplyPricingTemplateResponse struct {
+	TemplateID int64                                   `json:"template_id"`
+	Rules      []pricingRuleModels.PricingRuleResponse `json:"rules"`
+}
+
+// ToDomainCreateInput преобразует request в domain input
+func (r *CreatePricingTemplateRequest) ToDomainCreateInput() domain.CreatePricingTemplateInput {
+	return domain.CreatePricingTemplateInput{
+		Name:                    r.Name,
+		OwnerCompanyID:          r.OwnerCompanyID,
+		PricingType:             domain.PricingType(r.PricingType),
+		VehicleClassMultipliers: toDomainClassValues(r.VehicleClassMultipliers),
+		VehicleClassPrices:      toDomainClassValues(r.VehicleClassPrices),
+	}
+}
+
+// ToDomainUpdateInput преобразует request в domain input
+func (r *UpdatePricingTemplateRequest) ToDomainUpdateInput() domain.UpdatePricingTemplateInput {
+	return domain.UpdatePricingTemplateInput{
+		Name:                    r.Name,
+		VehicleClassMultipliers: toDomainClassValues(r.VehicleClassMultipliers),
+		VehicleClassPrices:      toDomainClassValues(r.VehicleClassPrices),
+	}
+}
+
+// ToDomainFilter преобразует request в domain filter
+func (r *PricingTemplateFilterRequest) ToDomainFilter() domain.PricingTemplateFilter {
+	return domain.PricingTemplateFilter{
+		CompanyID: r.CompanyID,
+	}
+}
+
+// ToDomainApplyInput преобразует request в domain input
+func (r *ApplyPricingTemplateRequest) ToDomainApplyInput() domain.ApplyPricingTemplateInput {
+	input := domain.ApplyPricingTemplateInput{
+		Targets:   make([]domain.PricingTemplateTarget, 0, len(r.Targets)),
+		BasePrice: r.BasePrice,
+		Currency:  r.Currency,
+		Follow:    r.Follow,
+	}
+
+	for _, target := range r.Targets {
+		input.Targets = append(input.Targets, domain.PricingTemplateTarget{
+			CompanyID: target.CompanyID,
+			ServiceID: target.ServiceID,
+		})
+	}
+
+	return input
+}
+
+// FromDomainPricingTemplate преобразует domain model в response
+func FromDomainPricingTemplate(template *domain.PricingTemplate) *PricingTemplateResponse {
+	return &PricingTemplateResponse{
+		ID:                      template.ID,
+		Name:                    template.Name,
+		OwnerCompanyID:          template.OwnerCompanyID,
+		PricingType:             string(template.PricingType),
+		VehicleClassMultipliers: fromDomainClassValues(template.VehicleClassMultipliers),
+		VehicleClassPrices:      fromDomainClassValues(template.VehicleClassPrices),
+		CreatedAt:               template.CreatedAt,
+		UpdatedAt:               template.UpdatedAt,
+	}
+}
+
+// FromDomainPricingTemplateList преобразует список domain models в response
+func FromDomainPricingTemplateList(templates []domain.PricingTemplate) *PricingTemplateListResponse {
+	resp := &PricingTemplateListResponse{
+		Templates: make([]PricingTemplateResponse, 0, len(templates)),
+	}
+
+	for i := range templates {
+		resp.Templates = append(resp.Templates, *FromDomainPricingTemplate(&templates[i]))
+	}
+
+	return resp
+}
+
+// FromDomainApplyResult преобразует правила после применения шаблона в response
+func FromDomainApplyResult(templateID int64, rules []domain.PricingRule) *ApplyPricingTemplateResponse {
+	return &ApplyPricingTemplateResponse{
+		TemplateID: templateID,
+		Rules:      pricingRuleModels.FromDomainPricingRuleList(rules).Rules,
+	}
+}
+
+// toDomainClassValues преобразует значения по классам в domain (nil-safe)
+func toDomainClassValues(values map[string]float64) map[domain.VehicleClass]float64 {
+	if values == nil {
+		return nil
+	}
+
+	result := make(map[domain.VehicleClass]float64, len(values))
+	for k, v := range values {
+		result[domain.VehicleClass(k)] = v
+	}
+	return result
+}
+
+// fromDomainClassValues преобразует значения по классам из domain (nil-safe)
+func fromDomainClassValues(values map[domain.VehicleClass]float64) map[string]float64 {
+	if values == nil {
+		return nil
+	}
+
+	result := make(map[string]float64, len(values))
+	for k, v := range values {
+		result[string(k)] = v
+	}
+	return result
+}
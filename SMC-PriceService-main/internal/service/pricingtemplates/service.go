@@ -0,0 +1,340 @@
+package pricingtemplates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	pricingRuleRepo "github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingrule"
+	pricingTemplateRepo "github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingtemplate"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+	"github.com/m04kA/SMC-PriceService/internal/service"
+	"github.com/m04kA/SMC-PriceService/internal/service/pricingtemplates/models"
+)
+
+const (
+	// maxNameLength максимальная длина названия шаблона
+	maxNameLength = 100
+	// maxApplyTargets максимальное количество услуг в одном применении шаблона
+	maxApplyTargets = 500
+)
+
+// Service сервис шаблонов ценообразования
+type Service struct {
+	pricingTemplateRepo PricingTemplateRepository
+	pricingRuleRepo     PricingRuleRepository
+	sellerServiceClient SellerServiceClient
+}
+
+// NewService создаёт новый сервис шаблонов ценообразования
+func NewService(
+	pricingTemplateRepo PricingTemplateRepository,
+	pricingRuleRepo PricingRuleRepository,
+	sellerServiceClient SellerServiceClient,
+) *Service {
+	return &Service{
+		pricingTemplateRepo: pricingTemplateRepo,
+		pricingRuleRepo:     pricingRuleRepo,
+		sellerServiceClient: sellerServiceClient,
+	}
+}
+
+// Create создает новый шаблон ценообразования
+// Шаблон платформы создаёт только superuser, шаблон компании - её менеджер
+func (s *Service) Create(ctx context.Context, userID int64, userRole string, req *models.CreatePricingTemplateRequest) (*models.PricingTemplateResponse, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err := s.checkTemplateAccess(ctx, req.OwnerCompanyID, userID, userRole); err != nil {
+		return nil, err
+	}
+
+	template, err := s.pricingTemplateRepo.Create(ctx, req.ToDomainCreateInput())
+	if err != nil {
+		if errors.Is(err, pricingTemplateRepo.ErrDuplicateTemplate) {
+			return nil, ErrDuplicateTemplate
+		}
+		return nil, fmt.Errorf("%w: Create - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainPricingTemplate(template), nil
+}
+
+// GetByID получает шаблон ценообразования по ID
+func (s *Service) GetByID(ctx context.Context, id int64) (*models.PricingTemplateResponse, error) {
+	template, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.FromDomainPricingTemplate(template), nil
+}
+
+// List получает шаблоны платформы и, если указана компания, шаблоны этой компании
+func (s *Service) List(ctx context.Context, req *models.PricingTemplateFilterRequest) (*models.PricingTemplateListResponse, error) {
+	templates, err := s.pricingTemplateRepo.List(ctx, req.ToDomainFilter())
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainPricingTemplateList(templates), nil
+}
+
+// Update обновляет шаблон ценообразования
+// При propagate=true изменения в той же транзакции переносятся в правила, следующие шаблону
+func (s *Service) Update(ctx context.Context, id int64, userID int64, userRole string, req *models.UpdatePricingTemplateRequest) (*models.UpdatePricingTemplateResponse, error) {
+	currentTemplate, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTemplateAccess(ctx, currentTemplate.OwnerCompanyID, userID, userRole); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		req.Name = &name
+	}
+	if err := s.validateUpdateRequest(currentTemplate, req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	template, updatedRules, err := s.pricingTemplateRepo.Update(ctx, id, req.ToDomainUpdateInput(), req.Propagate)
+	if err != nil {
+		if errors.Is(err, pricingTemplateRepo.ErrPricingTemplateNotFound) {
+			return nil, ErrPricingTemplateNotFound
+		}
+		if errors.Is(err, pricingTemplateRepo.ErrDuplicateTemplate) {
+			return nil, ErrDuplicateTemplate
+		}
+		return nil, fmt.Errorf("%w: Update - repository error: %v", ErrInternal, err)
+	}
+
+	return &models.UpdatePricingTemplateResponse{
+		PricingTemplateResponse: *models.FromDomainPricingTemplate(template),
+		UpdatedRules:            updatedRules,
+	}, nil
+}
+
+// Delete удаляет шаблон ценообразования; правила, следовавшие ему, сохраняют текущие значения
+func (s *Service) Delete(ctx context.Context, id int64, userID int64, userRole string) error {
+	template, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkTemplateAccess(ctx, template.OwnerCompanyID, userID, userRole); err != nil {
+		return err
+	}
+
+	if err := s.pricingTemplateRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, pricingTemplateRepo.ErrPricingTemplateNotFound) {
+			return ErrPricingTemplateNotFound
+		}
+		return fmt.Errorf("%w: Delete - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// Apply применяет шаблон к списку пар компания-услуга в одной транзакции
+// Отсутствующие правила создаются, существующие - перезаписываются значениями шаблона
+// Пользователь должен быть менеджером каждой компании из targets
+func (s *Service) Apply(ctx context.Context, id int64, userID int64, userRole string, req *models.ApplyPricingTemplateRequest) (*models.ApplyPricingTemplateResponse, error) {
+	template, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateApplyRequest(template, req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if userRole != service.RoleSuperuser {
+		checked := make(map[int64]bool, len(req.Targets))
+		for _, target := range req.Targets {
+			if checked[target.CompanyID] {
+				continue
+			}
+			if err := s.checkCompanyAccess(ctx, target.CompanyID, userID); err != nil {
+				return nil, err
+			}
+			checked[target.CompanyID] = true
+		}
+	}
+
+	rules, err := s.pricingRuleRepo.ApplyTemplate(ctx, template, req.ToDomainApplyInput())
+	if err != nil {
+		if errors.Is(err, pricingRuleRepo.ErrBasePriceRequired) {
+			return nil, fmt.Errorf("%w: base_price is required for services without pricing rule (%v)", ErrInvalidInput, err)
+		}
+		return nil, fmt.Errorf("%w: Apply - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainApplyResult(template.ID, rules), nil
+}
+
+// getTemplate получает шаблон и преобразует ошибки репозитория
+func (s *Service) getTemplate(ctx context.Context, id int64) (*domain.PricingTemplate, error) {
+	template, err := s.pricingTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pricingTemplateRepo.ErrPricingTemplateNotFound) {
+			return nil, ErrPricingTemplateNotFound
+		}
+		return nil, fmt.Errorf("%w: get pricing template: %v", ErrInternal, err)
+	}
+
+	return template, nil
+}
+
+// checkTemplateAccess проверяет право изменять шаблон с указанной компанией-владельцем
+// Шаблоны платформы (без владельца) изменяет только superuser
+func (s *Service) checkTemplateAccess(ctx context.Context, ownerCompanyID *int64, userID int64, userRole string) error {
+	if userRole == service.RoleSuperuser {
+		return nil
+	}
+	if ownerCompanyID == nil {
+		return fmt.Errorf("%w: only superuser can modify platform templates", ErrAccessDenied)
+	}
+
+	return s.checkCompanyAccess(ctx, *ownerCompanyID, userID)
+}
+
+// checkCompanyAccess проверяет, что пользователь - менеджер компании
+func (s *Service) checkCompanyAccess(ctx context.Context, companyID int64, userID int64) error {
+	company, err := s.sellerServiceClient.GetCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, sellerservice.ErrCompanyNotFound) {
+			return fmt.Errorf("%w: company %d not found", ErrInvalidInput, companyID)
+		}
+		return fmt.Errorf("%w: checkCompanyAccess - get company %d: %v", ErrInternal, companyID, err)
+	}
+
+	if !company.IsManager(userID) {
+		return fmt.Errorf("%w: company_id=%d", ErrAccessDenied, companyID)
+	}
+
+	return nil
+}
+
+// validateCreateRequest валидирует запрос на создание шаблона
+func (s *Service) validateCreateRequest(req *models.CreatePricingTemplateRequest) error {
+	if err := validateName(req.Name); err != nil {
+		return err
+	}
+	if req.OwnerCompanyID != nil && *req.OwnerCompanyID <= 0 {
+		return fmt.Errorf("owner_company_id must be greater than 0")
+	}
+
+	switch domain.PricingType(req.PricingType) {
+	case domain.PricingTypeVehicleClassMultiplier:
+		if req.VehicleClassPrices != nil {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
+		return validateClassValues("vehicle_class_multipliers", req.VehicleClassMultipliers)
+
+	case domain.PricingTypeVehicleClassFixed:
+		if req.VehicleClassMultipliers != nil {
+			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+		return validateClassValues("vehicle_class_prices", req.VehicleClassPrices)
+
+	default:
+		return fmt.Errorf("invalid pricing_type: %s (allowed: vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed)", req.PricingType)
+	}
+}
+
+// validateUpdateRequest валидирует запрос на обновление шаблона (тип ценообразования не меняется)
+func (s *Service) validateUpdateRequest(currentTemplate *domain.PricingTemplate, req *models.UpdatePricingTemplateRequest) error {
+	if req.Name != nil {
+		if err := validateName(*req.Name); err != nil {
+			return err
+		}
+	}
+
+	switch currentTemplate.PricingType {
+	case domain.PricingTypeVehicleClassMultiplier:
+		if req.VehicleClassPrices != nil {
+			return fmt.Errorf("vehicle_class_prices should not be set for pricing_type 'vehicle_class_pricing_multiplier'")
+		}
+		if req.VehicleClassMultipliers != nil {
+			return validateClassValues("vehicle_class_multipliers", req.VehicleClassMultipliers)
+		}
+
+	case domain.PricingTypeVehicleClassFixed:
+		if req.VehicleClassMultipliers != nil {
+			return fmt.Errorf("vehicle_class_multipliers should not be set for pricing_type 'vehicle_class_pricing_fixed'")
+		}
+		if req.VehicleClassPrices != nil {
+			return validateClassValues("vehicle_class_prices", req.VehicleClassPrices)
+		}
+	}
+
+	return nil
+}
+
+// validateApplyRequest валидирует запрос на применение шаблона
+func (s *Service) validateApplyRequest(template *domain.PricingTemplate, req *models.ApplyPricingTemplateRequest) error {
+	if len(req.Targets) == 0 {
+		return fmt.Errorf("targets is required")
+	}
+	if len(req.Targets) > maxApplyTargets {
+		return fmt.Errorf("too many targets: %d (max %d)", len(req.Targets), maxApplyTargets)
+	}
+	if req.BasePrice != nil && *req.BasePrice <= 0 {
+		return fmt.Errorf("base_price must be greater than 0")
+	}
+	if req.Currency != nil && len(*req.Currency) != 3 {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+
+	seen := make(map[models.TemplateTarget]bool, len(req.Targets))
+	for _, target := range req.Targets {
+		if target.CompanyID <= 0 || target.ServiceID <= 0 {
+			return fmt.Errorf("targets: company_id and service_id must be greater than 0")
+		}
+		if seen[target] {
+			return fmt.Errorf("targets: duplicate company_id=%d, service_id=%d", target.CompanyID, target.ServiceID)
+		}
+		seen[target] = true
+
+		if !template.CanBeAppliedTo(target.CompanyID) {
+			return fmt.Errorf("template %d belongs to company %d and cannot be applied to company %d",
+				template.ID, *template.OwnerCompanyID, target.CompanyID)
+		}
+	}
+
+	return nil
+}
+
+// validateName проверяет название шаблона
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len([]rune(name)) > maxNameLength {
+		return fmt.Errorf("name must not exceed %d characters", maxNameLength)
+	}
+	return nil
+}
+
+// validateClassValues проверяет множители или цены по классам автомобилей
+func validateClassValues(field string, values map[string]float64) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%s is required", field)
+	}
+	for class, value := range values {
+		if !domain.VehicleClass(class).IsValid() {
+			return fmt.Errorf("unknown vehicle class in %s: %s", field, class)
+		}
+		if value <= 0 {
+			return fmt.Errorf("%s value for class %s must be greater than 0", field, class)
+		}
+	}
+	return nil
+}
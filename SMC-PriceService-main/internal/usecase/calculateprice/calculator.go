@@ -2,6 +2,7 @@ package calculateprice
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/m04kA/SMC-PriceService/internal/domain"
 	"github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice/models"
@@ -15,19 +16,26 @@ func NewCalculator() *Calculator {
 	return &Calculator{}
 }
 
-// CalculatePrice рассчитывает цену на основе правила и информации об автомобиле
-// car может быть nil - в этом случае используется базовая цена
+// CalculatePrice рассчитывает цену на основе правила и контекста расчёта
+// calcCtx.Car может быть nil - в этом случае используется базовая цена
+// calcCtx.DurationMinutes используется только для per_minute и может быть nil
 // При ошибке возвращается базовая цена вместе с ошибкой (graceful degradation)
-func (c *Calculator) CalculatePrice(rule *models.PricingRule, car *models.Car) (*models.CalculateResponse, error) {
+func (c *Calculator) CalculatePrice(rule *models.PricingRule, calcCtx *models.CalculationContext) (*models.CalculateResponse, error) {
 	switch rule.PricingType {
 	case string(domain.PricingTypeStatic):
 		return c.calculateStaticPrice(rule), nil
 
 	case string(domain.PricingTypeVehicleClassMultiplier):
-		return c.calculateWithMultiplier(rule, car)
+		return c.calculateWithMultiplier(rule, calcCtx.Car)
 
 	case string(domain.PricingTypeVehicleClassFixed):
-		return c.calculateWithFixedPrice(rule, car)
+		return c.calculateWithFixedPrice(rule, calcCtx.Car)
+
+	case string(domain.PricingTypePerMinute):
+		return c.calculatePerMinute(rule, calcCtx.Car, calcCtx.DurationMinutes)
+
+	case string(domain.PricingTypeExpression):
+		return c.calculateExpression(rule, calcCtx)
 
 	default:
 		// Возвращаем базовую цену + ошибку
@@ -124,3 +132,116 @@ func (c *Calculator) calculateWithFixedPrice(rule *models.PricingRule, car *mode
 		VehicleClass: &vehicleClass,
 	}, nil
 }
+
+// calculatePerMinute рассчитывает цену за длительность услуги (боксы самообслуживания)
+// Итоговая цена = rate_per_minute * оплачиваемые минуты * множитель класса авто (если задан)
+func (c *Calculator) calculatePerMinute(rule *models.PricingRule, car *models.Car, durationMinutes *int) (*models.CalculateResponse, error) {
+	// Без параметров тарификации или длительности рассчитать цену нельзя - используем базовую цену
+	if rule.PerMinute == nil {
+		return c.calculateStaticPrice(rule), fmt.Errorf("%w: per_minute parameters are missing", ErrInvalidPricingRule)
+	}
+	if durationMinutes == nil || *durationMinutes <= 0 {
+		return c.calculateStaticPrice(rule), ErrDurationNotFound
+	}
+
+	duration := *durationMinutes
+	billable := billableMinutes(duration, rule.PerMinute.MinBillableMinutes, rule.PerMinute.RoundingIncrement)
+	price := rule.PerMinute.RatePerMinute * float64(billable)
+
+	resp := &models.CalculateResponse{
+		CompanyID:       rule.CompanyID,
+		ServiceID:       rule.ServiceID,
+		Currency:        rule.Currency,
+		PricingType:     rule.PricingType,
+		VehicleClass:    nil,
+		DurationMinutes: &duration,
+		BillableMinutes: &billable,
+	}
+
+	// Множители по классам опциональны: без них или без автомобиля применяется чистая минутная ставка
+	if len(rule.VehicleClassMultipliers) == 0 || car == nil {
+		resp.Price = roundPrice(price)
+		return resp, nil
+	}
+
+	vehicleClass := car.VehicleClass
+	resp.VehicleClass = &vehicleClass
+
+	multiplier, found := rule.VehicleClassMultipliers[vehicleClass]
+	if !found {
+		// Если множитель не найден - возвращаем цену без множителя + ошибку
+		resp.Price = roundPrice(price)
+		return resp, fmt.Errorf("%w: %s", ErrMultiplierNotFound, vehicleClass)
+	}
+
+	resp.Price = roundPrice(price * multiplier)
+	return resp, nil
+}
+
+// calculateExpression рассчитывает цену по выражению правила
+// При ошибке вычисления или отрицательном результате возвращается базовая цена + ошибка
+func (c *Calculator) calculateExpression(rule *models.PricingRule, calcCtx *models.CalculationContext) (*models.CalculateResponse, error) {
+	resp := c.calculateStaticPrice(rule)
+
+	if rule.Expression == nil {
+		return resp, fmt.Errorf("%w: expression is missing", ErrInvalidPricingRule)
+	}
+
+	env := domain.PricingExpressionEnv{
+		BasePrice: rule.BasePrice,
+		Time:      calcCtx.Time,
+		UserRole:  calcCtx.UserRole,
+		CartSize:  calcCtx.CartSize,
+	}
+	if calcCtx.Car != nil {
+		env.HasCar = true
+		env.VehicleClass = calcCtx.Car.VehicleClass
+		env.Brand = calcCtx.Car.Brand
+
+		if rule.Expression.Uses(domain.ExprVarVehicleClass) {
+			vehicleClass := calcCtx.Car.VehicleClass
+			resp.VehicleClass = &vehicleClass
+		}
+	}
+
+	result, trace, err := rule.Expression.Eval(env.Vars(), calcCtx.Trace)
+
+	if calcCtx.Trace {
+		resp.ExpressionTrace = make([]models.TraceStep, 0, len(trace))
+		for _, step := range trace {
+			resp.ExpressionTrace = append(resp.ExpressionTrace, models.TraceStep{
+				Expression: step.Expression,
+				Value:      step.Value,
+			})
+		}
+	}
+
+	if err != nil {
+		return resp, fmt.Errorf("%w: %v", ErrExpressionEvaluation, err)
+	}
+	if result.Num < 0 {
+		return resp, fmt.Errorf("%w: negative price %.2f", ErrExpressionEvaluation, result.Num)
+	}
+
+	resp.Price = roundPrice(result.Num)
+	return resp, nil
+}
+
+// billableMinutes возвращает количество оплачиваемых минут с учётом минимума и шага округления вверх
+func billableMinutes(duration, minMinutes, increment int) int {
+	minutes := duration
+	if minutes < minMinutes {
+		minutes = minMinutes
+	}
+
+	if increment > 1 && minutes%increment != 0 {
+		minutes = (minutes/increment + 1) * increment
+	}
+
+	return minutes
+}
+
+// roundPrice округляет цену до копеек
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
 	"github.com/m04kA/SMC-PriceService/internal/integrations/userservice"
 )
 
@@ -18,6 +19,16 @@ type UserServiceClient interface {
 	GetSelectedCarWithGracefulDegradation(ctx context.Context, tgUserID int64) (*userservice.Car, error)
 }
 
+// SellerServiceClient интерфейс для работы с SellerService
+type SellerServiceClient interface {
+	GetServiceWithGracefulDegradation(ctx context.Context, companyID, serviceID int64) (*sellerservice.Service, error)
+}
+
+// CalculationRecorder интерфейс для асинхронной записи журнала расчётов
+type CalculationRecorder interface {
+	Record(event domain.CalculationEvent)
+}
+
 // Logger интерфейс для логирования
 type Logger interface {
 	Info(format string, v ...interface{})
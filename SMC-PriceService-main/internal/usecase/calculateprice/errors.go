@@ -12,6 +12,12 @@ var (
 	// ErrFixedPriceNotFound возвращается, когда фиксированная цена для класса автомобиля не найдена
 	ErrFixedPriceNotFound = errors.New("fixed price not found for vehicle class")
 
+	// ErrDurationNotFound возвращается, когда длительность для поминутной тарификации неизвестна
+	ErrDurationNotFound = errors.New("duration not found for per minute pricing")
+
+	// ErrExpressionEvaluation возвращается, когда выражение правила не удалось вычислить
+	ErrExpressionEvaluation = errors.New("pricing expression evaluation failed")
+
 	// ErrInvalidPricingRule возвращается, когда правило ценообразования некорректно
 	ErrInvalidPricingRule = errors.New("invalid pricing rule configuration")
 
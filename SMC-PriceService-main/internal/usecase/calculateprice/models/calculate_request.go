@@ -2,12 +2,18 @@ package models
 
 // CalculateRequest запрос на расчёт цены для одной услуги
 type CalculateRequest struct {
-	CompanyID int64 `json:"company_id"`
-	ServiceID int64 `json:"service_id"`
+	CompanyID       int64  `json:"company_id"`
+	ServiceID       int64  `json:"service_id"`
+	DurationMinutes *int   `json:"duration_minutes,omitempty"` // для per_minute; если не передан - берётся средняя длительность услуги
+	UserRole        string `json:"user_role,omitempty"`        // роль пользователя для expression
+	Trace           bool   `json:"trace,omitempty"`            // вернуть трассировку вычисления выражения
 }
 
 // BatchCalculateRequest запрос на расчёт цен для нескольких услуг одной компании
 type BatchCalculateRequest struct {
-	CompanyID  int64   `json:"company_id"`
-	ServiceIDs []int64 `json:"service_ids"`
+	CompanyID       int64   `json:"company_id"`
+	ServiceIDs      []int64 `json:"service_ids"`
+	DurationMinutes *int    `json:"duration_minutes,omitempty"` // для per_minute; если не передан - берётся средняя длительность услуги
+	UserRole        string  `json:"user_role,omitempty"`        // роль пользователя для expression
+	Trace           bool    `json:"trace,omitempty"`            // вернуть трассировку вычисления выражения
 }
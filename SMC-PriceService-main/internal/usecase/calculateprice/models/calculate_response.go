@@ -8,6 +8,17 @@ type CalculateResponse struct {
 	Currency     string  `json:"currency"`
 	PricingType  string  `json:"pricing_type"`
 	VehicleClass *string `json:"vehicle_class,omitempty"` // nil если не применялся класс авто
+	// Поля поминутной тарификации (только для per_minute)
+	DurationMinutes *int `json:"duration_minutes,omitempty"` // длительность, для которой рассчитана цена
+	BillableMinutes *int `json:"billable_minutes,omitempty"` // оплачиваемые минуты с учётом минимума и округления
+	// Трассировка вычисления выражения (только для expression и только по запросу)
+	ExpressionTrace []TraceStep `json:"expression_trace,omitempty"`
+}
+
+// TraceStep шаг трассировки вычисления выражения
+type TraceStep struct {
+	Expression string `json:"expression"` // фрагмент выражения (или имя переменной)
+	Value      string `json:"value"`      // вычисленное значение
 }
 
 // BatchCalculateResponse ответ с рассчитанными ценами
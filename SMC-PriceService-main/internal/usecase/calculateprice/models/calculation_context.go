@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// CalculationContext контекст расчёта цены для калькулятора
+type CalculationContext struct {
+	Car             *Car      // nil если автомобиль неизвестен - используется базовая цена
+	DurationMinutes *int      // длительность для per_minute (может быть nil)
+	UserRole        string    // роль пользователя ("" если неизвестна)
+	CartSize        int       // количество услуг в расчёте
+	Time            time.Time // время расчёта в часовом поясе сервиса
+	Trace           bool      // вернуть трассировку вычисления выражения (для expression)
+}
@@ -3,4 +3,5 @@ package models
 // Car модель автомобиля для калькулятора
 type Car struct {
 	VehicleClass string // класс автомобиля (A, B, C, ...)
+	Brand        string // марка автомобиля
 }
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/m04kA/SMC-PriceService/pkg/expr"
+
 // PricingRule модель правила ценообразования для калькулятора
 type PricingRule struct {
 	CompanyID               int64
@@ -9,4 +11,13 @@ type PricingRule struct {
 	Currency                string
 	VehicleClassMultipliers map[string]float64 // ключ - класс авто (A, B, C, ...)
 	VehicleClassPrices      map[string]float64 // ключ - класс авто (A, B, C, ...)
+	PerMinute               *PerMinutePricing  // nil для всех типов, кроме per_minute
+	Expression              *expr.Program      // скомпилированное выражение; nil для всех типов, кроме expression
+}
+
+// PerMinutePricing параметры поминутной тарификации для калькулятора
+type PerMinutePricing struct {
+	RatePerMinute      float64
+	MinBillableMinutes int // минимальное количество оплачиваемых минут
+	RoundingIncrement  int // шаг округления вверх в минутах (0 или 1 - без округления)
 }
@@ -4,32 +4,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/m04kA/SMC-PriceService/internal/domain"
 	"github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingrule"
 	"github.com/m04kA/SMC-PriceService/internal/integrations/userservice"
 	"github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice/models"
+	"github.com/m04kA/SMC-PriceService/pkg/expr"
 )
 
 // UseCase usecase для расчёта цен
 type UseCase struct {
-	pricingRuleRepo   PricingRuleRepository
-	userServiceClient UserServiceClient
-	calculator        *Calculator
-	logger            Logger
+	pricingRuleRepo     PricingRuleRepository
+	userServiceClient   UserServiceClient
+	sellerServiceClient SellerServiceClient
+	calculator          *Calculator
+	recorder            CalculationRecorder
+	location            *time.Location // часовой пояс для переменных времени в выражениях
+	logger              Logger
 }
 
 // NewUseCase создаёт новый экземпляр usecase
 func NewUseCase(
 	pricingRuleRepo PricingRuleRepository,
 	userServiceClient UserServiceClient,
+	sellerServiceClient SellerServiceClient,
+	recorder CalculationRecorder,
+	location *time.Location,
 	logger Logger,
 ) *UseCase {
 	return &UseCase{
-		pricingRuleRepo:   pricingRuleRepo,
-		userServiceClient: userServiceClient,
-		calculator:        NewCalculator(),
-		logger:            logger,
+		pricingRuleRepo:     pricingRuleRepo,
+		userServiceClient:   userServiceClient,
+		sellerServiceClient: sellerServiceClient,
+		calculator:          NewCalculator(),
+		recorder:            recorder,
+		location:            location,
+		logger:              logger,
 	}
 }
 
@@ -39,6 +50,7 @@ func (uc *UseCase) Calculate(
 	tgUserID int64,
 	req *models.CalculateRequest,
 ) (*models.CalculateResponse, error) {
+	startedAt := time.Now()
 	uc.logger.Info("Calculating price: company_id=%d, service_id=%d, tg_user_id=%d",
 		req.CompanyID, req.ServiceID, tgUserID)
 
@@ -58,7 +70,8 @@ func (uc *UseCase) Calculate(
 
 	// 3. Получаем информацию об автомобиле (если требуется)
 	var car *models.Car
-	if uc.requiresCarInfo(domainRule.PricingType) {
+	userServiceDegraded := false
+	if uc.requiresCarInfo(rule) {
 		car, err = uc.getUserCar(ctx, tgUserID)
 		if err != nil {
 			// Критичные ошибки пробрасываем выше
@@ -70,12 +83,27 @@ func (uc *UseCase) Calculate(
 			if errors.Is(err, userservice.ErrServiceDegraded) {
 				// Деградация - продолжаем с car = nil
 				uc.logger.Error("UserService degraded, using base price for tg_user_id=%d: %v", tgUserID, err)
+				userServiceDegraded = true
 			}
 		}
 	}
 
-	// 4. Рассчитываем цену
-	price, calcErr := uc.calculator.CalculatePrice(rule, car)
+	// 4. Определяем длительность услуги (только для поминутной тарификации)
+	var duration *int
+	if domainRule.PricingType == domain.PricingTypePerMinute {
+		duration = uc.getDuration(ctx, req.CompanyID, req.ServiceID, req.DurationMinutes)
+	}
+
+	// 5. Рассчитываем цену
+	calcCtx := &models.CalculationContext{
+		Car:             car,
+		DurationMinutes: duration,
+		UserRole:        req.UserRole,
+		CartSize:        1,
+		Time:            time.Now().In(uc.location),
+		Trace:           req.Trace,
+	}
+	price, calcErr := uc.calculator.CalculatePrice(rule, calcCtx)
 	if calcErr != nil {
 		// Калькулятор вернул базовую цену + ошибку - логируем ошибку
 		uc.logger.Warn("Price calculation degraded: %v", calcErr)
@@ -84,13 +112,52 @@ func (uc *UseCase) Calculate(
 	uc.logger.Info("Price calculated: company_id=%d, service_id=%d, price=%.2f %s",
 		req.CompanyID, req.ServiceID, price.Price, price.Currency)
 
+	// 6. Записываем расчёт в журнал (асинхронно)
+	event := uc.newCalculationEvent(tgUserID, price, degradationReason(calcErr, userServiceDegraded))
+	event.LatencyMs = latencyMs(startedAt)
+	uc.recorder.Record(event)
+
 	return price, nil
 }
 
-// requiresCarInfo проверяет, требуется ли информация об автомобиле для данного типа правила
-func (uc *UseCase) requiresCarInfo(pricingType domain.PricingType) bool {
-	return pricingType == domain.PricingTypeVehicleClassMultiplier ||
-		pricingType == domain.PricingTypeVehicleClassFixed
+// requiresCarInfo проверяет, требуется ли информация об автомобиле для данного правила
+func (uc *UseCase) requiresCarInfo(rule *models.PricingRule) bool {
+	switch domain.PricingType(rule.PricingType) {
+	case domain.PricingTypeVehicleClassMultiplier, domain.PricingTypeVehicleClassFixed:
+		return true
+	case domain.PricingTypePerMinute:
+		// Для поминутной тарификации класс авто нужен только при заданных множителях
+		return len(rule.VehicleClassMultipliers) > 0
+	case domain.PricingTypeExpression:
+		// Для выражения автомобиль нужен, только если выражение его использует
+		return rule.Expression != nil && (rule.Expression.Uses(domain.ExprVarVehicleClass) ||
+			rule.Expression.Uses(domain.ExprVarBrand) ||
+			rule.Expression.Uses(domain.ExprVarHasCar))
+	default:
+		return false
+	}
+}
+
+// getDuration определяет длительность услуги в минутах для поминутной тарификации
+// Приоритет: длительность из запроса, затем средняя длительность услуги из SellerService
+// Возвращает nil, если длительность определить не удалось (калькулятор вернёт базовую цену)
+func (uc *UseCase) getDuration(ctx context.Context, companyID, serviceID int64, requested *int) *int {
+	if requested != nil {
+		return requested
+	}
+
+	service, err := uc.sellerServiceClient.GetServiceWithGracefulDegradation(ctx, companyID, serviceID)
+	if err != nil {
+		uc.logger.Warn("Failed to get average duration: company_id=%d, service_id=%d: %v", companyID, serviceID, err)
+		return nil
+	}
+
+	if service.AverageDuration == nil {
+		uc.logger.Warn("Service has no average duration: company_id=%d, service_id=%d", companyID, serviceID)
+		return nil
+	}
+
+	return service.AverageDuration
 }
 
 // getUserCar получает информацию об автомобиле пользователя
@@ -112,6 +179,7 @@ func (uc *UseCase) getUserCar(ctx context.Context, tgUserID int64) (*models.Car,
 
 	return &models.Car{
 		VehicleClass: userCar.Size,
+		Brand:        userCar.Brand,
 	}, nil
 }
 
@@ -121,6 +189,7 @@ func (uc *UseCase) BatchCalculate(
 	tgUserID int64,
 	req *models.BatchCalculateRequest,
 ) (*models.BatchCalculateResponse, error) {
+	startedAt := time.Now()
 	uc.logger.Info("Batch calculating prices: company_id=%d, services_count=%d, tg_user_id=%d",
 		req.CompanyID, len(req.ServiceIDs), tgUserID)
 
@@ -131,17 +200,20 @@ func (uc *UseCase) BatchCalculate(
 		return nil, fmt.Errorf("%w: failed to get pricing rules: %v", ErrInternal, err)
 	}
 
-	// 2. Проверяем, нужна ли информация об автомобиле
+	// 2. Конвертируем правила в модели калькулятора и проверяем, нужна ли информация об автомобиле
+	rules := make(map[int64]*models.PricingRule, len(rulesMap))
 	needsCarInfo := false
-	for _, rule := range rulesMap {
-		if uc.requiresCarInfo(rule.PricingType) {
+	for serviceID, domainRule := range rulesMap {
+		rule := uc.toPricingRuleModel(domainRule)
+		rules[serviceID] = rule
+		if uc.requiresCarInfo(rule) {
 			needsCarInfo = true
-			break
 		}
 	}
 
 	// 3. Получаем информацию об автомобиле пользователя один раз (если нужна)
 	var car *models.Car
+	userServiceDegraded := false
 	if needsCarInfo {
 		car, err = uc.getUserCar(ctx, tgUserID)
 		if err != nil {
@@ -154,42 +226,126 @@ func (uc *UseCase) BatchCalculate(
 			if errors.Is(err, userservice.ErrServiceDegraded) {
 				// Деградация - продолжаем с car = nil
 				uc.logger.Error("UserService degraded, using base prices for tg_user_id=%d: %v", tgUserID, err)
+				userServiceDegraded = true
 			}
 		}
 	}
 
 	// 4. Рассчитываем цену для каждой услуги
 	prices := make([]models.CalculateResponse, 0, len(req.ServiceIDs))
+	events := make([]domain.CalculationEvent, 0, len(req.ServiceIDs))
+	now := time.Now().In(uc.location)
 
 	for _, serviceID := range req.ServiceIDs {
-		domainRule, found := rulesMap[serviceID]
+		rule, found := rules[serviceID]
 		if !found {
 			uc.logger.Warn("Pricing rule not found for service_id=%d, skipping", serviceID)
 			continue
 		}
 
-		// Конвертируем domain model в модель калькулятора
-		rule := uc.toPricingRuleModel(domainRule)
+		// Определяем длительность услуги (только для поминутной тарификации)
+		var duration *int
+		if rule.PricingType == string(domain.PricingTypePerMinute) {
+			duration = uc.getDuration(ctx, req.CompanyID, serviceID, req.DurationMinutes)
+		}
 
 		// Рассчитываем цену
-		price, calcErr := uc.calculator.CalculatePrice(rule, car)
+		calcCtx := &models.CalculationContext{
+			Car:             car,
+			DurationMinutes: duration,
+			UserRole:        req.UserRole,
+			CartSize:        len(req.ServiceIDs),
+			Time:            now,
+			Trace:           req.Trace,
+		}
+		price, calcErr := uc.calculator.CalculatePrice(rule, calcCtx)
 		if calcErr != nil {
 			// Калькулятор вернул базовую цену + ошибку - логируем ошибку
 			uc.logger.Warn("Price calculation degraded for service_id=%d: %v", serviceID, calcErr)
 		}
 
 		prices = append(prices, *price)
+
+		// Деградация UserService важна только для правил, которым нужен автомобиль
+		reason := degradationReason(calcErr, userServiceDegraded && uc.requiresCarInfo(rule))
+		events = append(events, uc.newCalculationEvent(tgUserID, price, reason))
 	}
 
 	uc.logger.Info("Batch calculation completed: %d prices calculated", len(prices))
 
+	// 5. Записываем расчёты в журнал (асинхронно); latency общая для всего запроса
+	latency := latencyMs(startedAt)
+	for _, event := range events {
+		event.LatencyMs = latency
+		uc.recorder.Record(event)
+	}
+
 	return &models.BatchCalculateResponse{
 		Prices: prices,
 	}, nil
 }
 
+// newCalculationEvent создаёт событие журнала расчётов (latency заполняется вызывающим)
+func (uc *UseCase) newCalculationEvent(tgUserID int64, price *models.CalculateResponse, reason *domain.DegradationReason) domain.CalculationEvent {
+	event := domain.CalculationEvent{
+		CompanyID:         price.CompanyID,
+		ServiceID:         price.ServiceID,
+		PricingType:       domain.PricingType(price.PricingType),
+		VehicleClass:      price.VehicleClass,
+		Price:             price.Price,
+		Currency:          price.Currency,
+		DegradationReason: reason,
+		CreatedAt:         time.Now(),
+	}
+	if tgUserID != 0 {
+		event.TgUserID = &tgUserID
+	}
+	return event
+}
+
+// degradationReason определяет причину деградации по ошибке калькулятора и доступности UserService
+func degradationReason(calcErr error, userServiceDegraded bool) *domain.DegradationReason {
+	var reason domain.DegradationReason
+
+	switch {
+	case calcErr == nil && !userServiceDegraded:
+		return nil
+	case errors.Is(calcErr, ErrMultiplierNotFound):
+		reason = domain.DegradationMultiplierNotFound
+	case errors.Is(calcErr, ErrFixedPriceNotFound):
+		reason = domain.DegradationFixedPriceNotFound
+	case errors.Is(calcErr, ErrDurationNotFound):
+		reason = domain.DegradationDurationNotFound
+	case errors.Is(calcErr, ErrExpressionEvaluation):
+		reason = domain.DegradationExpressionFailed
+	case calcErr != nil:
+		reason = domain.DegradationInvalidRule
+	default:
+		reason = domain.DegradationUserServiceUnavailable
+	}
+
+	return &reason
+}
+
+// latencyMs возвращает время с начала обработки в миллисекундах
+func latencyMs(startedAt time.Time) float64 {
+	return float64(time.Since(startedAt).Microseconds()) / 1000
+}
+
 // toPricingRuleModel конвертирует domain.PricingRule в models.PricingRule
+// Ошибка компиляции выражения логируется: калькулятор вернёт базовую цену
 func (uc *UseCase) toPricingRuleModel(domainRule *domain.PricingRule) *models.PricingRule {
+	rule, err := NewPricingRuleModel(domainRule)
+	if err != nil {
+		uc.logger.Error("Failed to compile pricing expression: rule_id=%d: %v", domainRule.ID, err)
+	}
+	return rule
+}
+
+// NewPricingRuleModel конвертирует domain.PricingRule в модель калькулятора
+// Выражение компилируется при каждом расчёте: это дёшево, а правило уже проверено при создании
+// При ошибке компиляции возвращается модель без выражения вместе с ошибкой
+func NewPricingRuleModel(domainRule *domain.PricingRule) (*models.PricingRule, error) {
 	// Конвертируем map[domain.VehicleClass]float64 в map[string]float64
 	multipliers := make(map[string]float64, len(domainRule.VehicleClassMultipliers))
 	for class, value := range domainRule.VehicleClassMultipliers {
@@ -201,6 +357,24 @@ func (uc *UseCase) toPricingRuleModel(domainRule *domain.PricingRule) *models.Pr
 		prices[string(class)] = value
 	}
 
+	var perMinute *models.PerMinutePricing
+	if domainRule.PerMinute != nil {
+		perMinute = &models.PerMinutePricing{
+			RatePerMinute:      domainRule.PerMinute.RatePerMinute,
+			MinBillableMinutes: domainRule.PerMinute.MinBillableMinutes,
+			RoundingIncrement:  domainRule.PerMinute.RoundingIncrement,
+		}
+	}
+
+	var expression *expr.Program
+	var compileErr error
+	if domainRule.Expression != nil {
+		expression, compileErr = domain.CompilePricingExpression(*domainRule.Expression)
+		if compileErr != nil {
+			compileErr = fmt.Errorf("%w: %v", ErrInvalidPricingRule, compileErr)
+		}
+	}
+
 	return &models.PricingRule{
 		CompanyID:               domainRule.CompanyID,
 		ServiceID:               domainRule.ServiceID,
@@ -209,5 +383,7 @@ func (uc *UseCase) toPricingRuleModel(domainRule *domain.PricingRule) *models.Pr
 		Currency:                domainRule.Currency,
 		VehicleClassMultipliers: multipliers,
 		VehicleClassPrices:      prices,
-	}
+		PerMinute:               perMinute,
+		Expression:              expression,
+	}, compileErr
 }
@@ -0,0 +1,38 @@
+package simulateprice
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+	pricingRuleModels "github.com/m04kA/SMC-PriceService/internal/service/pricingrules/models"
+)
+
+// PricingRuleRepository интерфейс для получения текущего правила
+type PricingRuleRepository interface {
+	GetByCompanyAndService(ctx context.Context, companyID, serviceID int64) (*domain.PricingRule, error)
+}
+
+// CalculationLogRepository интерфейс для чтения последних расчётов услуги из журнала
+type CalculationLogRepository interface {
+	GetRecentVehicleClasses(ctx context.Context, companyID, serviceID int64, limit int) (map[string]int, error)
+}
+
+// RuleValidator интерфейс для проверки черновика или изменений правила без сохранения
+type RuleValidator interface {
+	ValidateDraft(req *pricingRuleModels.CreatePricingRuleRequest) error
+	ValidateChanges(currentRule *domain.PricingRule, req *pricingRuleModels.UpdatePricingRuleRequest) error
+}
+
+// SellerServiceClient интерфейс для проверки менеджеров компании и получения средней длительности услуги
+type SellerServiceClient interface {
+	GetCompany(ctx context.Context, companyID int64) (*sellerservice.Company, error)
+	GetServiceWithGracefulDegradation(ctx context.Context, companyID, serviceID int64) (*sellerservice.Service, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
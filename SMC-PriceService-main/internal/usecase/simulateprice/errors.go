@@ -0,0 +1,17 @@
+package simulateprice
+
+import "errors"
+
+var (
+	// ErrInvalidInput возвращается при некорректном запросе на симуляцию
+	ErrInvalidInput = errors.New("invalid simulation request")
+
+	// ErrAccessDenied возвращается, когда пользователь не менеджер компании и не superuser
+	ErrAccessDenied = errors.New("access denied: user is not a manager of this company")
+
+	// ErrPricingRuleNotFound возвращается, когда нет текущего правила для компании и услуги
+	ErrPricingRuleNotFound = errors.New("pricing rule not found for company and service")
+
+	// ErrInternal возвращается при внутренних ошибках usecase
+	ErrInternal = errors.New("simulate price usecase: internal error")
+)
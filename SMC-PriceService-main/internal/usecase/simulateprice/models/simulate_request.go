@@ -0,0 +1,35 @@
+package models
+
+import pricingRuleModels "github.com/m04kA/SMC-PriceService/internal/service/pricingrules/models"
+
+// Источники выборки для симуляции
+const (
+	SampleSourceClassDistribution  = "class_distribution"  // заданное распределение классов автомобилей
+	SampleSourceRecentCalculations = "recent_calculations" // последние расчёты услуги из журнала
+)
+
+// Ограничения выборки recent_calculations
+const (
+	DefaultRecentCalculationsLimit = 100
+	MaxRecentCalculationsLimit     = 10000
+)
+
+// SimulateRequest запрос на симуляцию изменения правила ценообразования
+// Должен быть задан ровно один из Draft (новое правило целиком) или Changes (изменения текущего правила)
+type SimulateRequest struct {
+	CompanyID int64                                       `json:"company_id"`
+	ServiceID int64                                       `json:"service_id"`
+	Draft     *pricingRuleModels.CreatePricingRuleRequest `json:"draft,omitempty"`
+	Changes   *pricingRuleModels.UpdatePricingRuleRequest `json:"changes,omitempty"`
+	Sample    Sample                                      `json:"sample"`
+}
+
+// Sample выборка, на которой сравниваются текущее и новое правило
+type Sample struct {
+	Source            string         `json:"source"`
+	ClassDistribution map[string]int `json:"class_distribution,omitempty"` // класс автомобиля -> количество расчётов
+	Limit             int            `json:"limit,omitempty"`              // для recent_calculations; по умолчанию 100
+	DurationMinutes   *int           `json:"duration_minutes,omitempty"`   // для per_minute; по умолчанию - средняя длительность услуги
+	UserRole          string         `json:"user_role,omitempty"`          // для expression
+	CartSize          int            `json:"cart_size,omitempty"`          // для expression; по умолчанию 1
+}
@@ -0,0 +1,31 @@
+package models
+
+// SimulateResponse результат симуляции: сравнение цен текущего и нового правила на выборке
+type SimulateResponse struct {
+	CompanyID            int64         `json:"company_id"`
+	ServiceID            int64         `json:"service_id"`
+	Currency             string        `json:"currency"`
+	OldPricingType       string        `json:"old_pricing_type"`
+	NewPricingType       string        `json:"new_pricing_type"`
+	SampleSource         string        `json:"sample_source"`
+	SampleSize           int           `json:"sample_size"`
+	Classes              []ClassResult `json:"classes"`
+	AverageOldPrice      float64       `json:"average_old_price"`
+	AverageNewPrice      float64       `json:"average_new_price"`
+	AverageChange        float64       `json:"average_change"`
+	AverageChangePercent *float64      `json:"average_change_percent,omitempty"` // nil если средняя текущая цена равна 0
+	OldRevenue           float64       `json:"old_revenue"`
+	NewRevenue           float64       `json:"new_revenue"`
+	RevenueDelta         float64       `json:"revenue_delta"`
+	Warnings             []string      `json:"warnings,omitempty"` // деградации расчёта и прочие замечания
+}
+
+// ClassResult сравнение цен для одного класса автомобиля
+type ClassResult struct {
+	VehicleClass  string   `json:"vehicle_class"` // пусто - расчёты без автомобиля (recent_calculations)
+	Count         int      `json:"count"`
+	OldPrice      float64  `json:"old_price"`
+	NewPrice      float64  `json:"new_price"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent,omitempty"` // nil если текущая цена равна 0
+}
@@ -0,0 +1,331 @@
+package simulateprice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m04kA/SMC-PriceService/internal/domain"
+	"github.com/m04kA/SMC-PriceService/internal/infra/storage/pricingrule"
+	"github.com/m04kA/SMC-PriceService/internal/integrations/sellerservice"
+	"github.com/m04kA/SMC-PriceService/internal/service"
+	"github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice"
+	calcModels "github.com/m04kA/SMC-PriceService/internal/usecase/calculateprice/models"
+	"github.com/m04kA/SMC-PriceService/internal/usecase/simulateprice/models"
+)
+
+// UseCase usecase для симуляции изменения правил ценообразования ("что если")
+// Ничего не сохраняет: текущее правило только читается, новое существует лишь в памяти
+type UseCase struct {
+	pricingRuleRepo     PricingRuleRepository
+	calculationLogRepo  CalculationLogRepository
+	ruleValidator       RuleValidator
+	sellerServiceClient SellerServiceClient
+	calculator          *calculateprice.Calculator
+	location            *time.Location
+	logger              Logger
+}
+
+// NewUseCase создаёт новый экземпляр usecase
+func NewUseCase(
+	pricingRuleRepo PricingRuleRepository,
+	calculationLogRepo CalculationLogRepository,
+	ruleValidator RuleValidator,
+	sellerServiceClient SellerServiceClient,
+	location *time.Location,
+	logger Logger,
+) *UseCase {
+	return &UseCase{
+		pricingRuleRepo:     pricingRuleRepo,
+		calculationLogRepo:  calculationLogRepo,
+		ruleValidator:       ruleValidator,
+		sellerServiceClient: sellerServiceClient,
+		calculator:          calculateprice.NewCalculator(),
+		location:            location,
+		logger:              logger,
+	}
+}
+
+// samplePoint элемент выборки: класс автомобиля и количество расчётов с ним
+// Пустой класс - расчёты без автомобиля
+type samplePoint struct {
+	vehicleClass string
+	count        int
+}
+
+// Simulate сравнивает цены текущего и нового правила на выборке
+// Доступно менеджерам компании и superuser: ответ раскрывает распределение классов из журнала расчётов
+func (uc *UseCase) Simulate(ctx context.Context, userID int64, userRole string, req *models.SimulateRequest) (*models.SimulateResponse, error) {
+	uc.logger.Info("Simulating pricing rule: company_id=%d, service_id=%d, sample_source=%s",
+		req.CompanyID, req.ServiceID, req.Sample.Source)
+
+	// 1. Проверяем запрос и строим выборку
+	if req.CompanyID <= 0 || req.ServiceID <= 0 {
+		return nil, fmt.Errorf("%w: company_id and service_id are required", ErrInvalidInput)
+	}
+	if (req.Draft == nil) == (req.Changes == nil) {
+		return nil, fmt.Errorf("%w: exactly one of draft or changes must be set", ErrInvalidInput)
+	}
+	if err := uc.checkAccess(ctx, req.CompanyID, userID, userRole); err != nil {
+		return nil, err
+	}
+
+	points, err := uc.buildSample(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Получаем текущее правило
+	currentRule, err := uc.pricingRuleRepo.GetByCompanyAndService(ctx, req.CompanyID, req.ServiceID)
+	if err != nil {
+		if errors.Is(err, pricingrule.ErrPricingRuleNotFound) {
+			return nil, ErrPricingRuleNotFound
+		}
+		return nil, fmt.Errorf("%w: failed to get pricing rule: %v", ErrInternal, err)
+	}
+
+	// 3. Строим новое правило (в памяти) и проверяем его так же, как при сохранении
+	newRule, err := uc.buildNewRule(req, currentRule)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Конвертируем правила в модели калькулятора
+	oldModel, err := calculateprice.NewPricingRuleModel(currentRule)
+	if err != nil {
+		uc.logger.Warn("Current pricing rule is invalid: rule_id=%d: %v", currentRule.ID, err)
+	}
+	newModel, err := calculateprice.NewPricingRuleModel(newRule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	// 5. Определяем длительность (только если одно из правил поминутное)
+	var duration *int
+	if currentRule.PricingType == domain.PricingTypePerMinute || newRule.PricingType == domain.PricingTypePerMinute {
+		duration = uc.getDuration(ctx, req.CompanyID, req.ServiceID, req.Sample.DurationMinutes)
+	}
+
+	cartSize := req.Sample.CartSize
+	if cartSize <= 0 {
+		cartSize = 1
+	}
+
+	resp := &models.SimulateResponse{
+		CompanyID:      req.CompanyID,
+		ServiceID:      req.ServiceID,
+		Currency:       newRule.Currency,
+		OldPricingType: string(currentRule.PricingType),
+		NewPricingType: string(newRule.PricingType),
+		SampleSource:   req.Sample.Source,
+		Classes:        make([]models.ClassResult, 0, len(points)),
+	}
+	if currentRule.Currency != newRule.Currency {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("currency changes from %s to %s, revenue delta is not comparable",
+			currentRule.Currency, newRule.Currency))
+	}
+
+	// 6. Рассчитываем цены для каждого элемента выборки
+	now := time.Now().In(uc.location)
+	for _, point := range points {
+		var car *calcModels.Car
+		if point.vehicleClass != "" {
+			car = &calcModels.Car{VehicleClass: point.vehicleClass}
+		}
+		calcCtx := &calcModels.CalculationContext{
+			Car:             car,
+			DurationMinutes: duration,
+			UserRole:        req.Sample.UserRole,
+			CartSize:        cartSize,
+			Time:            now,
+		}
+
+		oldPrice, oldErr := uc.calculator.CalculatePrice(oldModel, calcCtx)
+		if oldErr != nil {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("current rule, class %s: %v", point.vehicleClass, oldErr))
+		}
+		newPrice, newErr := uc.calculator.CalculatePrice(newModel, calcCtx)
+		if newErr != nil {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("new rule, class %s: %v", point.vehicleClass, newErr))
+		}
+
+		resp.Classes = append(resp.Classes, models.ClassResult{
+			VehicleClass:  point.vehicleClass,
+			Count:         point.count,
+			OldPrice:      oldPrice.Price,
+			NewPrice:      newPrice.Price,
+			Change:        roundPrice(newPrice.Price - oldPrice.Price),
+			ChangePercent: changePercent(oldPrice.Price, newPrice.Price),
+		})
+
+		resp.SampleSize += point.count
+		resp.OldRevenue += oldPrice.Price * float64(point.count)
+		resp.NewRevenue += newPrice.Price * float64(point.count)
+	}
+
+	// 7. Агрегируем результаты (средние взвешены по количеству расчётов)
+	if resp.SampleSize > 0 {
+		resp.AverageOldPrice = roundPrice(resp.OldRevenue / float64(resp.SampleSize))
+		resp.AverageNewPrice = roundPrice(resp.NewRevenue / float64(resp.SampleSize))
+		resp.AverageChange = roundPrice(resp.AverageNewPrice - resp.AverageOldPrice)
+		resp.AverageChangePercent = changePercent(resp.AverageOldPrice, resp.AverageNewPrice)
+	}
+	resp.OldRevenue = roundPrice(resp.OldRevenue)
+	resp.NewRevenue = roundPrice(resp.NewRevenue)
+	resp.RevenueDelta = roundPrice(resp.NewRevenue - resp.OldRevenue)
+
+	uc.logger.Info("Simulation completed: company_id=%d, service_id=%d, sample_size=%d, revenue_delta=%.2f",
+		req.CompanyID, req.ServiceID, resp.SampleSize, resp.RevenueDelta)
+
+	return resp, nil
+}
+
+// buildSample строит выборку из запроса
+func (uc *UseCase) buildSample(ctx context.Context, req *models.SimulateRequest) ([]samplePoint, error) {
+	sample := &req.Sample
+	if sample.DurationMinutes != nil && *sample.DurationMinutes <= 0 {
+		return nil, fmt.Errorf("%w: sample.duration_minutes must be greater than 0", ErrInvalidInput)
+	}
+
+	switch sample.Source {
+	case models.SampleSourceClassDistribution:
+		if len(sample.ClassDistribution) == 0 {
+			return nil, fmt.Errorf("%w: sample.class_distribution is required for source '%s'", ErrInvalidInput, sample.Source)
+		}
+		for class, count := range sample.ClassDistribution {
+			if !domain.VehicleClass(class).IsValid() {
+				return nil, fmt.Errorf("%w: unknown vehicle class in sample.class_distribution: %s", ErrInvalidInput, class)
+			}
+			if count <= 0 {
+				return nil, fmt.Errorf("%w: sample.class_distribution count for class %s must be greater than 0", ErrInvalidInput, class)
+			}
+		}
+
+		// Порядок классов фиксирован, чтобы ответ был стабильным
+		points := make([]samplePoint, 0, len(sample.ClassDistribution))
+		for _, class := range domain.VehicleClasses {
+			if count, ok := sample.ClassDistribution[string(class)]; ok {
+				points = append(points, samplePoint{vehicleClass: string(class), count: count})
+			}
+		}
+		return points, nil
+
+	case models.SampleSourceRecentCalculations:
+		limit := sample.Limit
+		if limit == 0 {
+			limit = models.DefaultRecentCalculationsLimit
+		}
+		if limit < 0 || limit > models.MaxRecentCalculationsLimit {
+			return nil, fmt.Errorf("%w: sample.limit must be between 1 and %d", ErrInvalidInput, models.MaxRecentCalculationsLimit)
+		}
+
+		classes, err := uc.calculationLogRepo.GetRecentVehicleClasses(ctx, req.CompanyID, req.ServiceID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get recent calculations: %v", ErrInternal, err)
+		}
+		if len(classes) == 0 {
+			return nil, fmt.Errorf("%w: no recent calculations for company_id=%d, service_id=%d", ErrInvalidInput,
+				req.CompanyID, req.ServiceID)
+		}
+
+		// Порядок классов фиксирован; расчёты без автомобиля - последними
+		points := make([]samplePoint, 0, len(classes))
+		for _, class := range domain.VehicleClasses {
+			if count, ok := classes[string(class)]; ok {
+				points = append(points, samplePoint{vehicleClass: string(class), count: count})
+			}
+		}
+		if count, ok := classes[""]; ok {
+			points = append(points, samplePoint{count: count})
+		}
+		return points, nil
+
+	default:
+		return nil, fmt.Errorf("%w: invalid sample.source: %s (allowed: %s, %s)", ErrInvalidInput, sample.Source,
+			models.SampleSourceClassDistribution, models.SampleSourceRecentCalculations)
+	}
+}
+
+// buildNewRule строит новое правило из черновика или изменений текущего правила
+func (uc *UseCase) buildNewRule(req *models.SimulateRequest, currentRule *domain.PricingRule) (*domain.PricingRule, error) {
+	if req.Changes != nil {
+		if err := uc.ruleValidator.ValidateChanges(currentRule, req.Changes); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return currentRule.ApplyUpdate(req.Changes.ToDomainUpdateInput()), nil
+	}
+
+	// Черновик всегда относится к компании и услуге из запроса
+	draft := *req.Draft
+	draft.CompanyID = req.CompanyID
+	draft.ServiceID = req.ServiceID
+	if err := uc.ruleValidator.ValidateDraft(&draft); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	input := draft.ToDomainCreateInput()
+	return &domain.PricingRule{
+		CompanyID:               input.CompanyID,
+		ServiceID:               input.ServiceID,
+		PricingType:             input.PricingType,
+		BasePrice:               input.BasePrice,
+		Currency:                input.Currency,
+		VehicleClassMultipliers: input.VehicleClassMultipliers,
+		VehicleClassPrices:      input.VehicleClassPrices,
+		PerMinute:               input.PerMinute,
+		Expression:              input.Expression,
+	}, nil
+}
+
+// getDuration определяет длительность услуги для поминутной тарификации
+// Приоритет: длительность из выборки, затем средняя длительность услуги из SellerService
+func (uc *UseCase) getDuration(ctx context.Context, companyID, serviceID int64, requested *int) *int {
+	if requested != nil {
+		return requested
+	}
+
+	service, err := uc.sellerServiceClient.GetServiceWithGracefulDegradation(ctx, companyID, serviceID)
+	if err != nil {
+		uc.logger.Warn("Failed to get average duration: company_id=%d, service_id=%d: %v", companyID, serviceID, err)
+		return nil
+	}
+
+	return service.AverageDuration
+}
+
+// changePercent возвращает изменение цены в процентах (nil если исходная цена равна 0)
+func changePercent(oldPrice, newPrice float64) *float64 {
+	if oldPrice == 0 {
+		return nil
+	}
+	percent := roundPrice((newPrice - oldPrice) / oldPrice * 100)
+	return &percent
+}
+
+// roundPrice округляет значение до сотых
+func roundPrice(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// checkAccess проверяет, что пользователь - superuser или менеджер компании
+func (uc *UseCase) checkAccess(ctx context.Context, companyID int64, userID int64, userRole string) error {
+	if userRole == service.RoleSuperuser {
+		return nil
+	}
+
+	company, err := uc.sellerServiceClient.GetCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, sellerservice.ErrCompanyNotFound) {
+			return fmt.Errorf("%w: company_id=%d", ErrAccessDenied, companyID)
+		}
+		return fmt.Errorf("%w: checkAccess - get company %d: %v", ErrInternal, companyID, err)
+	}
+
+	if !company.IsManager(userID) {
+		return fmt.Errorf("%w: company_id=%d", ErrAccessDenied, companyID)
+	}
+
+	return nil
+}
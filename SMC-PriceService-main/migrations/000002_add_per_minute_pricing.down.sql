@@ -0,0 +1,4 @@
+-- Удаление параметров поминутной тарификации
+ALTER TABLE pricing_rules DROP COLUMN IF EXISTS per_minute_pricing;
+
+COMMENT ON COLUMN pricing_rules.pricing_type IS 'Тип ценообразования: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed';
@@ -0,0 +1,5 @@
+-- Параметры поминутной тарификации (pricing_type = 'per_minute')
+ALTER TABLE pricing_rules ADD COLUMN per_minute_pricing JSONB;
+
+COMMENT ON COLUMN pricing_rules.pricing_type IS 'Тип ценообразования: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed, per_minute';
+COMMENT ON COLUMN pricing_rules.per_minute_pricing IS 'JSON с параметрами поминутной тарификации: rate_per_minute, min_billable_minutes, rounding_increment';
@@ -0,0 +1,4 @@
+-- Удаление выражений для условной цены
+ALTER TABLE pricing_rules DROP COLUMN IF EXISTS price_expression;
+
+COMMENT ON COLUMN pricing_rules.pricing_type IS 'Тип ценообразования: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed, per_minute';
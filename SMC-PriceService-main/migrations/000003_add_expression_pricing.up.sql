@@ -0,0 +1,5 @@
+-- Выражение для правил с условной ценой (pricing_type = 'expression')
+ALTER TABLE pricing_rules ADD COLUMN price_expression TEXT;
+
+COMMENT ON COLUMN pricing_rules.pricing_type IS 'Тип ценообразования: static, vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed, per_minute, expression';
+COMMENT ON COLUMN pricing_rules.price_expression IS 'Выражение для расчёта цены (переменные: base_price, vehicle_class, brand, has_car, hour, minute, weekday, user_role, cart_size)';
@@ -0,0 +1,3 @@
+-- Удаление журнала расчётов (секции удаляются вместе с родительской таблицей)
+DROP FUNCTION IF EXISTS ensure_calculation_log_partition(DATE);
+DROP TABLE IF EXISTS calculation_log;
@@ -0,0 +1,54 @@
+-- Журнал расчётов цен (какие цены показаны кому), секционирован по месяцам
+CREATE TABLE IF NOT EXISTS calculation_log (
+    id BIGSERIAL,
+    tg_user_id BIGINT,
+    company_id BIGINT NOT NULL,
+    service_id BIGINT NOT NULL,
+    pricing_type VARCHAR(50) NOT NULL,
+    vehicle_class VARCHAR(1),
+    price DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    degradation_reason VARCHAR(50),
+    latency_ms DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Ключ секционирования обязан входить в первичный ключ
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+-- Секция по умолчанию: записи не теряются, даже если месячная секция ещё не создана
+CREATE TABLE IF NOT EXISTS calculation_log_default PARTITION OF calculation_log DEFAULT;
+
+-- Функция для создания месячной секции (вызывается сервисом перед записью), границы секций в UTC
+CREATE OR REPLACE FUNCTION ensure_calculation_log_partition(month_start DATE)
+RETURNS VOID AS $$
+DECLARE
+    from_date DATE := date_trunc('month', month_start)::DATE;
+    to_date DATE := (date_trunc('month', month_start) + INTERVAL '1 month')::DATE;
+    partition_name TEXT := 'calculation_log_' || to_char(from_date, 'YYYYMM');
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF calculation_log FOR VALUES FROM (%L) TO (%L)',
+        partition_name, from_date::TEXT || ' 00:00:00+00', to_date::TEXT || ' 00:00:00+00'
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- Индекс для агрегатов по компании/услуге за период
+CREATE INDEX idx_calculation_log_company_service_created ON calculation_log(company_id, service_id, created_at);
+
+-- Индекс для выборки последних расчётов услуги (симулятор цен)
+CREATE INDEX idx_calculation_log_service_created ON calculation_log(service_id, created_at DESC);
+
+-- Комментарии к таблице и колонкам
+COMMENT ON TABLE calculation_log IS 'Журнал расчётов цен, секционирован по месяцам (created_at)';
+COMMENT ON COLUMN calculation_log.tg_user_id IS 'Telegram ID пользователя (NULL для расчёта без пользователя)';
+COMMENT ON COLUMN calculation_log.company_id IS 'ID компании';
+COMMENT ON COLUMN calculation_log.service_id IS 'ID услуги';
+COMMENT ON COLUMN calculation_log.pricing_type IS 'Тип ценообразования правила на момент расчёта';
+COMMENT ON COLUMN calculation_log.vehicle_class IS 'Класс автомобиля, применённый при расчёте (NULL если не применялся)';
+COMMENT ON COLUMN calculation_log.price IS 'Показанная цена';
+COMMENT ON COLUMN calculation_log.currency IS 'Валюта';
+COMMENT ON COLUMN calculation_log.degradation_reason IS 'Причина деградации расчёта (NULL если расчёт без деградации)';
+COMMENT ON COLUMN calculation_log.latency_ms IS 'Длительность обработки запроса на расчёт в миллисекундах';
+COMMENT ON COLUMN calculation_log.created_at IS 'Время расчёта';
@@ -0,0 +1,7 @@
+-- Удаление связи правил с шаблонами
+DROP INDEX IF EXISTS idx_pricing_rules_template_id;
+ALTER TABLE pricing_rules DROP COLUMN IF EXISTS template_id;
+
+-- Удаление шаблонов ценообразования
+DROP TRIGGER IF EXISTS update_pricing_templates_updated_at ON pricing_templates;
+DROP TABLE IF EXISTS pricing_templates;
@@ -0,0 +1,42 @@
+-- Шаблоны ценообразования: именованный набор множителей или фиксированных цен по классам автомобилей
+CREATE TABLE IF NOT EXISTS pricing_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_company_id BIGINT,
+    pricing_type VARCHAR(50) NOT NULL,
+    vehicle_class_multipliers JSONB NOT NULL DEFAULT '{}',
+    vehicle_class_prices JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT pricing_templates_pricing_type_check
+        CHECK (pricing_type IN ('vehicle_class_pricing_multiplier', 'vehicle_class_pricing_fixed'))
+);
+
+-- Уникальность названия в пределах владельца (платформенные шаблоны - owner_company_id IS NULL)
+CREATE UNIQUE INDEX idx_pricing_templates_owner_name ON pricing_templates(COALESCE(owner_company_id, 0), name);
+
+-- Индекс для выборки шаблонов компании
+CREATE INDEX idx_pricing_templates_owner_company_id ON pricing_templates(owner_company_id);
+
+-- Триггер для автоматического обновления updated_at
+CREATE TRIGGER update_pricing_templates_updated_at
+    BEFORE UPDATE ON pricing_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Связь правила с шаблоном, изменения которого оно получает
+-- При удалении шаблона правила сохраняют текущие значения и отвязываются
+ALTER TABLE pricing_rules
+    ADD COLUMN template_id BIGINT REFERENCES pricing_templates(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_pricing_rules_template_id ON pricing_rules(template_id) WHERE template_id IS NOT NULL;
+
+-- Комментарии к таблице и колонкам
+COMMENT ON TABLE pricing_templates IS 'Шаблоны ценообразования, общие для платформы или компании';
+COMMENT ON COLUMN pricing_templates.name IS 'Название шаблона (уникально в пределах владельца)';
+COMMENT ON COLUMN pricing_templates.owner_company_id IS 'ID компании-владельца (NULL - шаблон платформы, доступен всем компаниям)';
+COMMENT ON COLUMN pricing_templates.pricing_type IS 'Тип ценообразования: vehicle_class_pricing_multiplier, vehicle_class_pricing_fixed';
+COMMENT ON COLUMN pricing_templates.vehicle_class_multipliers IS 'JSON с множителями для классов автомобилей';
+COMMENT ON COLUMN pricing_templates.vehicle_class_prices IS 'JSON с фиксированными ценами для классов автомобилей';
+COMMENT ON COLUMN pricing_rules.template_id IS 'ID шаблона, изменения которого получает правило (NULL - правило не следует шаблону)';
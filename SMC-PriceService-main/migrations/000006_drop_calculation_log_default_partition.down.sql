@@ -0,0 +1,2 @@
+-- Возврат секции по умолчанию журнала расчётов
+CREATE TABLE IF NOT EXISTS calculation_log_default PARTITION OF calculation_log DEFAULT;
@@ -0,0 +1,32 @@
+-- Удаление секции по умолчанию журнала расчётов
+-- Пока в секции по умолчанию есть строки месяца M, создать месячную секцию M нельзя
+-- (ensure_calculation_log_partition падает на проверке секции по умолчанию).
+-- Секции создаются заранее сервисом (текущий и следующий месяц), поэтому секция по умолчанию не нужна:
+-- отсоединяем её, переносим строки в месячные секции и удаляем
+DO $$
+DECLARE
+    month_start DATE;
+BEGIN
+    IF to_regclass('calculation_log_default') IS NULL THEN
+        RETURN;
+    END IF;
+
+    ALTER TABLE calculation_log DETACH PARTITION calculation_log_default;
+
+    FOR month_start IN
+        SELECT DISTINCT date_trunc('month', created_at AT TIME ZONE 'UTC')::DATE FROM calculation_log_default
+    LOOP
+        PERFORM ensure_calculation_log_partition(month_start);
+    END LOOP;
+
+    INSERT INTO calculation_log (
+        id, tg_user_id, company_id, service_id, pricing_type, vehicle_class,
+        price, currency, degradation_reason, latency_ms, created_at
+    )
+    SELECT
+        id, tg_user_id, company_id, service_id, pricing_type, vehicle_class,
+        price, currency, degradation_reason, latency_ms, created_at
+    FROM calculation_log_default;
+
+    DROP TABLE calculation_log_default;
+END $$;
@@ -3,8 +3,12 @@ package dbmetrics
 import (
 	"context"
 	"database/sql"
+	"errors"
 )
 
+// ErrTxNotSupported возвращается, когда исполнитель запросов не умеет начинать транзакции
+var ErrTxNotSupported = errors.New("db type does not support transactions")
+
 // DBExecutor интерфейс для выполнения SQL запросов
 type DBExecutor interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
@@ -31,3 +35,27 @@ func (w *SqlTxWrapper) Commit() error {
 func (w *SqlTxWrapper) Rollback() error {
 	return w.Tx.Rollback()
 }
+
+// TxBeginner интерфейс для начала транзакций (реализует *DB)
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (TxExecutor, error)
+}
+
+// BeginTx начинает транзакцию (поддерживает *sql.DB и *DB)
+func BeginTx(ctx context.Context, db DBExecutor) (TxExecutor, error) {
+	// *DB собирает метрики и для запросов внутри транзакции
+	if txBeginner, ok := db.(TxBeginner); ok {
+		return txBeginner.BeginTx(ctx, nil)
+	}
+
+	// Fallback для обычного *sql.DB
+	if sqlDB, ok := db.(*sql.DB); ok {
+		tx, err := sqlDB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &SqlTxWrapper{Tx: tx}, nil
+	}
+
+	return nil, ErrTxNotSupported
+}
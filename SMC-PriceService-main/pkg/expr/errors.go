@@ -0,0 +1,17 @@
+package expr
+
+import "errors"
+
+var (
+	// ErrSyntax возвращается при синтаксической ошибке в выражении
+	ErrSyntax = errors.New("expression syntax error")
+
+	// ErrType возвращается при несовпадении типов или неизвестной переменной/функции
+	ErrType = errors.New("expression type error")
+
+	// ErrLimitExceeded возвращается при превышении ограничений на размер или вычисление выражения
+	ErrLimitExceeded = errors.New("expression limit exceeded")
+
+	// ErrEvaluation возвращается при ошибке во время вычисления (деление на ноль, некорректный результат)
+	ErrEvaluation = errors.New("expression evaluation error")
+)
@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// evaluator состояние одного вычисления выражения
+type evaluator struct {
+	prog      *Program
+	vars      map[string]Value
+	steps     int
+	trace     []TraceStep
+	withTrace bool
+	traced    map[string]bool // переменные, уже попавшие в трассировку
+}
+
+// Eval вычисляет выражение для переданных значений переменных
+// При withTrace=true возвращает трассировку: значения использованных переменных,
+// результаты условий и вызовов функций, итоговый результат
+func (p *Program) Eval(vars map[string]Value, withTrace bool) (Value, []TraceStep, error) {
+	e := &evaluator{
+		prog:      p,
+		vars:      vars,
+		withTrace: withTrace,
+		traced:    make(map[string]bool),
+	}
+
+	result, err := e.eval(p.root)
+	if err != nil {
+		return Value{}, e.trace, err
+	}
+
+	if result.Type == TypeNumber && (math.IsNaN(result.Num) || math.IsInf(result.Num, 0)) {
+		return Value{}, e.trace, fmt.Errorf("%w: result is not a finite number", ErrEvaluation)
+	}
+
+	e.record("result", result)
+	return result, e.trace, nil
+}
+
+func (e *evaluator) record(expression string, value Value) {
+	if e.withTrace {
+		e.trace = append(e.trace, TraceStep{Expression: expression, Value: value.String()})
+	}
+}
+
+func (e *evaluator) eval(n *node) (Value, error) {
+	e.steps++
+	if e.steps > e.prog.limits.MaxSteps {
+		return Value{}, fmt.Errorf("%w: evaluation took more than %d steps", ErrLimitExceeded, e.prog.limits.MaxSteps)
+	}
+
+	switch n.kind {
+	case nodeLiteral:
+		return n.value, nil
+
+	case nodeIdent:
+		value, ok := e.vars[n.name]
+		if !ok || value.Type != n.typ {
+			return Value{}, fmt.Errorf("%w: variable %q is not set", ErrEvaluation, n.name)
+		}
+		if !e.traced[n.name] {
+			e.traced[n.name] = true
+			e.record(n.name, value)
+		}
+		return value, nil
+
+	case nodeList:
+		items := make([]Value, 0, len(n.args))
+		for _, arg := range n.args {
+			item, err := e.eval(arg)
+			if err != nil {
+				return Value{}, err
+			}
+			items = append(items, item)
+		}
+		return Value{Type: TypeList, List: items}, nil
+
+	case nodeUnary:
+		operand, err := e.eval(n.args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		if n.op == "!" {
+			return Bool(!operand.Bool), nil
+		}
+		return Number(-operand.Num), nil
+
+	case nodeBinary:
+		return e.evalBinary(n)
+
+	case nodeTernary:
+		cond, err := e.eval(n.args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		e.record(e.prog.text(n.args[0]), cond)
+		if cond.Bool {
+			return e.eval(n.args[1])
+		}
+		return e.eval(n.args[2])
+
+	case nodeCall:
+		args := make([]float64, 0, len(n.args))
+		for _, arg := range n.args {
+			value, err := e.eval(arg)
+			if err != nil {
+				return Value{}, err
+			}
+			args = append(args, value.Num)
+		}
+		result := Number(functions[n.name].call(args))
+		e.record(e.prog.text(n), result)
+		return result, nil
+	}
+
+	return Value{}, fmt.Errorf("%w: unknown node at position %d", ErrEvaluation, n.start)
+}
+
+func (e *evaluator) evalBinary(n *node) (Value, error) {
+	left, err := e.eval(n.args[0])
+	if err != nil {
+		return Value{}, err
+	}
+
+	// Логические операторы вычисляются по короткой схеме
+	switch n.op {
+	case "&&":
+		if !left.Bool {
+			return Bool(false), nil
+		}
+		return e.eval(n.args[1])
+	case "||":
+		if left.Bool {
+			return Bool(true), nil
+		}
+		return e.eval(n.args[1])
+	}
+
+	right, err := e.eval(n.args[1])
+	if err != nil {
+		return Value{}, err
+	}
+
+	switch n.op {
+	case "+":
+		return Number(left.Num + right.Num), nil
+	case "-":
+		return Number(left.Num - right.Num), nil
+	case "*":
+		return Number(left.Num * right.Num), nil
+	case "/":
+		if right.Num == 0 {
+			return Value{}, fmt.Errorf("%w: division by zero at position %d", ErrEvaluation, n.start)
+		}
+		return Number(left.Num / right.Num), nil
+	case "%":
+		if right.Num == 0 {
+			return Value{}, fmt.Errorf("%w: division by zero at position %d", ErrEvaluation, n.start)
+		}
+		return Number(math.Mod(left.Num, right.Num)), nil
+	case "<":
+		return Bool(left.Num < right.Num), nil
+	case "<=":
+		return Bool(left.Num <= right.Num), nil
+	case ">":
+		return Bool(left.Num > right.Num), nil
+	case ">=":
+		return Bool(left.Num >= right.Num), nil
+	case "==":
+		return Bool(left.equal(right)), nil
+	case "!=":
+		return Bool(!left.equal(right)), nil
+	case "in":
+		for _, item := range right.List {
+			if left.equal(item) {
+				return Bool(true), nil
+			}
+		}
+		return Bool(false), nil
+	}
+
+	return Value{}, fmt.Errorf("%w: unknown operator %q at position %d", ErrEvaluation, n.op, n.start)
+}
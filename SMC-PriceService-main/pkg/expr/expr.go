@@ -0,0 +1,264 @@
+// Package expr реализует небольшой песочный язык выражений.
+//
+// Выражение не имеет доступа ни к чему, кроме объявленных переменных и встроенных функций,
+// не содержит циклов и присваиваний, проходит проверку типов при компиляции и вычисляется
+// с ограничением на количество шагов.
+//
+// Поддерживается:
+//   - литералы: 1500, 0.9, "J", 'bmw', true, false, списки ["J", "M"]
+//   - арифметика: + - * / %
+//   - сравнения: == != < <= > >=, проверка вхождения: x in [..]
+//   - логика: && || !
+//   - условный оператор: cond ? a : b
+//   - функции: min(a, b, ...), max(a, b, ...), round(x), floor(x), ceil(x), abs(x)
+//
+// Строки сравниваются без учёта регистра.
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// Limits ограничения на размер и вычисление выражения
+type Limits struct {
+	MaxLength int // максимальная длина исходного текста в байтах
+	MaxNodes  int // максимальное количество узлов синтаксического дерева
+	MaxDepth  int // максимальная глубина вложенности
+	MaxSteps  int // максимальное количество шагов вычисления
+}
+
+// DefaultLimits ограничения по умолчанию
+var DefaultLimits = Limits{
+	MaxLength: 2000,
+	MaxNodes:  256,
+	MaxDepth:  32,
+	MaxSteps:  1000,
+}
+
+// Program скомпилированное и проверенное выражение
+type Program struct {
+	src    string
+	root   *node
+	vars   map[string]Type
+	used   map[string]bool
+	limits Limits
+}
+
+// TraceStep шаг трассировки вычисления: фрагмент выражения и его значение
+type TraceStep struct {
+	Expression string `json:"expression"`
+	Value      string `json:"value"`
+}
+
+// Compile разбирает выражение и проверяет типы
+// vars - объявленные переменные и их типы; resultType - ожидаемый тип результата
+func Compile(src string, vars map[string]Type, resultType Type, limits Limits) (*Program, error) {
+	if len(src) > limits.MaxLength {
+		return nil, fmt.Errorf("%w: expression is longer than %d bytes", ErrLimitExceeded, limits.MaxLength)
+	}
+
+	root, err := parse(src, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	prog := &Program{
+		src:    src,
+		root:   root,
+		vars:   vars,
+		used:   make(map[string]bool),
+		limits: limits,
+	}
+
+	if err := prog.check(root); err != nil {
+		return nil, err
+	}
+
+	if root.typ != resultType {
+		return nil, fmt.Errorf("%w: expression must return %s, got %s", ErrType, resultType, root.typ)
+	}
+
+	return prog, nil
+}
+
+// Source возвращает исходный текст выражения
+func (p *Program) Source() string {
+	return p.src
+}
+
+// Uses проверяет, используется ли переменная в выражении
+func (p *Program) Uses(name string) bool {
+	return p.used[name]
+}
+
+// text возвращает фрагмент исходного текста, соответствующий узлу
+func (p *Program) text(n *node) string {
+	return p.src[n.start:n.end]
+}
+
+// check выполняет проверку типов и заполняет типы узлов
+func (p *Program) check(n *node) error {
+	for _, arg := range n.args {
+		if err := p.check(arg); err != nil {
+			return err
+		}
+	}
+
+	switch n.kind {
+	case nodeLiteral:
+		n.typ = n.value.Type
+
+	case nodeIdent:
+		typ, ok := p.vars[n.name]
+		if !ok {
+			return fmt.Errorf("%w: unknown variable %q at position %d", ErrType, n.name, n.start)
+		}
+		p.used[n.name] = true
+		n.typ = typ
+
+	case nodeList:
+		if len(n.args) == 0 {
+			return fmt.Errorf("%w: empty list at position %d", ErrType, n.start)
+		}
+		elem := n.args[0].typ
+		if elem != TypeNumber && elem != TypeString {
+			return fmt.Errorf("%w: list may contain only numbers or strings at position %d", ErrType, n.start)
+		}
+		for _, item := range n.args {
+			if item.typ != elem {
+				return fmt.Errorf("%w: list items must have the same type at position %d", ErrType, item.start)
+			}
+		}
+		n.typ = TypeList
+		n.elem = elem
+
+	case nodeUnary:
+		operand := n.args[0]
+		switch n.op {
+		case "!":
+			if operand.typ != TypeBool {
+				return p.operatorTypeError(n, operand.typ)
+			}
+			n.typ = TypeBool
+		case "-":
+			if operand.typ != TypeNumber {
+				return p.operatorTypeError(n, operand.typ)
+			}
+			n.typ = TypeNumber
+		}
+
+	case nodeBinary:
+		return p.checkBinary(n)
+
+	case nodeTernary:
+		cond, then, otherwise := n.args[0], n.args[1], n.args[2]
+		if cond.typ != TypeBool {
+			return fmt.Errorf("%w: condition must be bool, got %s at position %d", ErrType, cond.typ, cond.start)
+		}
+		if then.typ != otherwise.typ || then.typ == TypeList {
+			return fmt.Errorf("%w: branches of '?:' must have the same type at position %d", ErrType, n.start)
+		}
+		n.typ = then.typ
+
+	case nodeCall:
+		fn, ok := functions[n.name]
+		if !ok {
+			return fmt.Errorf("%w: unknown function %q at position %d", ErrType, n.name, n.start)
+		}
+		if len(n.args) < fn.minArgs || (fn.maxArgs > 0 && len(n.args) > fn.maxArgs) {
+			return fmt.Errorf("%w: wrong number of arguments for %s at position %d", ErrType, n.name, n.start)
+		}
+		for _, arg := range n.args {
+			if arg.typ != TypeNumber {
+				return fmt.Errorf("%w: %s expects numbers, got %s at position %d", ErrType, n.name, arg.typ, arg.start)
+			}
+		}
+		n.typ = TypeNumber
+	}
+
+	return nil
+}
+
+// checkBinary проверяет типы операндов бинарного оператора
+func (p *Program) checkBinary(n *node) error {
+	left, right := n.args[0], n.args[1]
+
+	switch n.op {
+	case "+", "-", "*", "/", "%":
+		if left.typ != TypeNumber {
+			return p.operatorTypeError(n, left.typ)
+		}
+		if right.typ != TypeNumber {
+			return p.operatorTypeError(n, right.typ)
+		}
+		n.typ = TypeNumber
+
+	case "<", "<=", ">", ">=":
+		if left.typ != TypeNumber {
+			return p.operatorTypeError(n, left.typ)
+		}
+		if right.typ != TypeNumber {
+			return p.operatorTypeError(n, right.typ)
+		}
+		n.typ = TypeBool
+
+	case "==", "!=":
+		if left.typ != right.typ || left.typ == TypeList {
+			return fmt.Errorf("%w: cannot compare %s and %s at position %d", ErrType, left.typ, right.typ, n.start)
+		}
+		n.typ = TypeBool
+
+	case "&&", "||":
+		if left.typ != TypeBool {
+			return p.operatorTypeError(n, left.typ)
+		}
+		if right.typ != TypeBool {
+			return p.operatorTypeError(n, right.typ)
+		}
+		n.typ = TypeBool
+
+	case "in":
+		if right.typ != TypeList {
+			return fmt.Errorf("%w: right side of 'in' must be a list at position %d", ErrType, right.start)
+		}
+		if left.typ != right.elem {
+			return fmt.Errorf("%w: cannot check %s in list of %s at position %d", ErrType, left.typ, right.elem, n.start)
+		}
+		n.typ = TypeBool
+	}
+
+	return nil
+}
+
+func (p *Program) operatorTypeError(n *node, got Type) error {
+	return fmt.Errorf("%w: operator %q does not support %s at position %d", ErrType, n.op, got, n.start)
+}
+
+// function встроенная функция над числами
+type function struct {
+	minArgs int
+	maxArgs int // 0 - без ограничения
+	call    func(args []float64) float64
+}
+
+var functions = map[string]function{
+	"min": {minArgs: 1, call: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result
+	}},
+	"max": {minArgs: 1, call: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result
+	}},
+	"round": {minArgs: 1, maxArgs: 1, call: func(args []float64) float64 { return math.Round(args[0]) }},
+	"floor": {minArgs: 1, maxArgs: 1, call: func(args []float64) float64 { return math.Floor(args[0]) }},
+	"ceil":  {minArgs: 1, maxArgs: 1, call: func(args []float64) float64 { return math.Ceil(args[0]) }},
+	"abs":   {minArgs: 1, maxArgs: 1, call: func(args []float64) float64 { return math.Abs(args[0]) }},
+}
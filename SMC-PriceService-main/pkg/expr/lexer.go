@@ -0,0 +1,123 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind тип лексемы
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+// token лексема с позицией в исходном тексте
+type token struct {
+	kind  tokenKind
+	text  string  // оператор, идентификатор или значение строки
+	num   float64 // значение числа
+	start int
+	end   int
+}
+
+// operators операторы, отсортированные так, что двухсимвольные проверяются первыми
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")", "[", "]", ",",
+}
+
+// tokenize разбивает выражение на лексемы
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+
+	pos := 0
+	for pos < len(src) {
+		ch := rune(src[pos])
+
+		// Пропускаем пробельные символы
+		if unicode.IsSpace(ch) {
+			pos++
+			continue
+		}
+
+		start := pos
+
+		switch {
+		case isDigit(ch):
+			for pos < len(src) && (isDigit(rune(src[pos])) || src[pos] == '.') {
+				pos++
+			}
+			num, err := strconv.ParseFloat(src[start:pos], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrSyntax, src[start:pos], start)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, num: num, text: src[start:pos], start: start, end: pos})
+
+		case isIdentStart(ch):
+			for pos < len(src) && isIdentPart(rune(src[pos])) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:pos], start: start, end: pos})
+
+		case ch == '"' || ch == '\'':
+			quote := src[pos]
+			pos++
+			var sb strings.Builder
+			closed := false
+			for pos < len(src) {
+				if src[pos] == '\\' && pos+1 < len(src) {
+					sb.WriteByte(src[pos+1])
+					pos += 2
+					continue
+				}
+				if src[pos] == quote {
+					pos++
+					closed = true
+					break
+				}
+				sb.WriteByte(src[pos])
+				pos++
+			}
+			if !closed {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrSyntax, start)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), start: start, end: pos})
+
+		default:
+			matched := ""
+			for _, op := range operators {
+				if strings.HasPrefix(src[pos:], op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrSyntax, ch, pos)
+			}
+			pos += len(matched)
+			tokens = append(tokens, token{kind: tokenOperator, text: matched, start: start, end: pos})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, start: len(src), end: len(src)})
+	return tokens, nil
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isIdentStart(ch rune) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentPart(ch rune) bool {
+	return isIdentStart(ch) || isDigit(ch)
+}
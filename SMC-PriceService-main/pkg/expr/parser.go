@@ -0,0 +1,313 @@
+package expr
+
+import "fmt"
+
+// nodeKind тип узла синтаксического дерева
+type nodeKind int
+
+const (
+	nodeLiteral nodeKind = iota
+	nodeIdent
+	nodeList
+	nodeUnary
+	nodeBinary
+	nodeTernary
+	nodeCall
+)
+
+// node узел синтаксического дерева
+type node struct {
+	kind  nodeKind
+	op    string  // оператор (unary/binary)
+	name  string  // имя переменной или функции
+	value Value   // значение литерала
+	args  []*node // операнды, аргументы функции или элементы списка
+	start int     // позиция начала в исходном тексте
+	end   int     // позиция конца в исходном тексте
+	typ   Type    // тип результата (заполняется при проверке типов)
+	elem  Type    // тип элементов (только для списков)
+}
+
+// parser рекурсивный нисходящий парсер с ограничением глубины и количества узлов
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	nodes  int
+	limits Limits
+}
+
+// parse строит синтаксическое дерево выражения
+func parse(src string, limits Limits) (*node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, limits: limits}
+	root, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.text, tok.start)
+	}
+
+	return root, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isOperator проверяет, является ли текущая лексема одним из операторов
+func (p *parser) isOperator(ops ...string) bool {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) (token, error) {
+	tok := p.next()
+	if tok.kind != tokenOperator || tok.text != op {
+		return tok, fmt.Errorf("%w: expected %q at position %d", ErrSyntax, op, tok.start)
+	}
+	return tok, nil
+}
+
+// newNode создаёт узел с учётом ограничения на количество узлов
+func (p *parser) newNode(n *node) (*node, error) {
+	p.nodes++
+	if p.nodes > p.limits.MaxNodes {
+		return nil, fmt.Errorf("%w: expression has more than %d nodes", ErrLimitExceeded, p.limits.MaxNodes)
+	}
+	return n, nil
+}
+
+// enter увеличивает глубину вложенности и проверяет ограничение
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > p.limits.MaxDepth {
+		return fmt.Errorf("%w: expression nesting is deeper than %d", ErrLimitExceeded, p.limits.MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// parseTernary: cond ? a : b
+func (p *parser) parseTernary() (*node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isOperator("?") {
+		return cond, nil
+	}
+	p.next()
+
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.newNode(&node{kind: nodeTernary, args: []*node{cond, then, otherwise}, start: cond.start, end: otherwise.end})
+}
+
+// binaryLevels бинарные операторы по возрастанию приоритета
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// matchBinary возвращает оператор текущего уровня приоритета, если он следующий в потоке
+func (p *parser) matchBinary(level int) (string, bool) {
+	tok := p.peek()
+	for _, op := range binaryLevels[level] {
+		if op == "in" {
+			if tok.kind == tokenIdent && tok.text == "in" {
+				return op, true
+			}
+			continue
+		}
+		if tok.kind == tokenOperator && tok.text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// parseBinary разбирает левоассоциативные бинарные операторы начиная с уровня level
+func (p *parser) parseBinary(level int) (*node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.matchBinary(level)
+		if !ok {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = p.newNode(&node{kind: nodeBinary, op: op, args: []*node{left, right}, start: left.start, end: right.end})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseUnary: !x, -x
+func (p *parser) parseUnary() (*node, error) {
+	if !p.isOperator("!", "-") {
+		return p.parsePrimary()
+	}
+
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	tok := p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.newNode(&node{kind: nodeUnary, op: tok.text, args: []*node{operand}, start: tok.start, end: operand.end})
+}
+
+// parsePrimary: литералы, переменные, вызовы функций, списки и скобки
+func (p *parser) parsePrimary() (*node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		return p.newNode(&node{kind: nodeLiteral, value: Number(tok.num), start: tok.start, end: tok.end})
+
+	case tokenString:
+		return p.newNode(&node{kind: nodeLiteral, value: String(tok.text), start: tok.start, end: tok.end})
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return p.newNode(&node{kind: nodeLiteral, value: Bool(true), start: tok.start, end: tok.end})
+		case "false":
+			return p.newNode(&node{kind: nodeLiteral, value: Bool(false), start: tok.start, end: tok.end})
+		case "in":
+			return nil, fmt.Errorf("%w: unexpected 'in' at position %d", ErrSyntax, tok.start)
+		}
+
+		if !p.isOperator("(") {
+			return p.newNode(&node{kind: nodeIdent, name: tok.text, start: tok.start, end: tok.end})
+		}
+		p.next()
+
+		args, end, err := p.parseItems(")")
+		if err != nil {
+			return nil, err
+		}
+		return p.newNode(&node{kind: nodeCall, name: tok.text, args: args, start: tok.start, end: end})
+
+	case tokenOperator:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			closing, err := p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+			inner.start, inner.end = tok.start, closing.end
+			return inner, nil
+
+		case "[":
+			items, end, err := p.parseItems("]")
+			if err != nil {
+				return nil, err
+			}
+			return p.newNode(&node{kind: nodeList, args: items, start: tok.start, end: end})
+		}
+	}
+
+	if tok.kind == tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+	return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.text, tok.start)
+}
+
+// parseItems разбирает список выражений через запятую до закрывающего оператора
+func (p *parser) parseItems(closing string) ([]*node, int, error) {
+	var items []*node
+
+	if p.isOperator(closing) {
+		tok := p.next()
+		return items, tok.end, nil
+	}
+
+	for {
+		item, err := p.parseTernary()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+
+		if p.isOperator(",") {
+			p.next()
+			continue
+		}
+
+		tok, err := p.expect(closing)
+		if err != nil {
+			return nil, 0, err
+		}
+		return items, tok.end, nil
+	}
+}
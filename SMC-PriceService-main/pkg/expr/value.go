@@ -0,0 +1,92 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Type тип значения выражения
+type Type int
+
+const (
+	TypeInvalid Type = iota
+	TypeNumber
+	TypeString
+	TypeBool
+	TypeList
+)
+
+// String возвращает название типа для сообщений об ошибках
+func (t Type) String() string {
+	switch t {
+	case TypeNumber:
+		return "number"
+	case TypeString:
+		return "string"
+	case TypeBool:
+		return "bool"
+	case TypeList:
+		return "list"
+	default:
+		return "invalid"
+	}
+}
+
+// Value значение, вычисленное выражением (или переданное как переменная)
+type Value struct {
+	Type Type
+	Num  float64
+	Str  string
+	Bool bool
+	List []Value
+}
+
+// Number создаёт числовое значение
+func Number(n float64) Value {
+	return Value{Type: TypeNumber, Num: n}
+}
+
+// String создаёт строковое значение
+func String(s string) Value {
+	return Value{Type: TypeString, Str: s}
+}
+
+// Bool создаёт логическое значение
+func Bool(b bool) Value {
+	return Value{Type: TypeBool, Bool: b}
+}
+
+// String возвращает текстовое представление значения (используется в трассировке)
+func (v Value) String() string {
+	switch v.Type {
+	case TypeNumber:
+		return strconv.FormatFloat(v.Num, 'f', -1, 64)
+	case TypeString:
+		return strconv.Quote(v.Str)
+	case TypeBool:
+		return strconv.FormatBool(v.Bool)
+	case TypeList:
+		items := make([]string, 0, len(v.List))
+		for _, item := range v.List {
+			items = append(items, item.String())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return "<invalid>"
+	}
+}
+
+// equal сравнивает два значения одного типа
+// Строки сравниваются без учёта регистра (марки и роли могут приходить в разном написании)
+func (v Value) equal(other Value) bool {
+	switch v.Type {
+	case TypeNumber:
+		return v.Num == other.Num
+	case TypeString:
+		return strings.EqualFold(v.Str, other.Str)
+	case TypeBool:
+		return v.Bool == other.Bool
+	default:
+		return false
+	}
+}
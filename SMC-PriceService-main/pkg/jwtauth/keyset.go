@@ -0,0 +1,288 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// fetchTimeout таймаут загрузки JWKS
+	fetchTimeout = 10 * time.Second
+	// minRefreshInterval минимальный интервал внеплановой загрузки JWKS при неизвестном kid
+	minRefreshInterval = 30 * time.Second
+	// maxJWKSSize максимальный размер JWKS документа
+	maxJWKSSize = 1 << 20
+)
+
+// jwk ключ в формате JWK (RFC 7517); поддерживаются kty oct (HS256) и RSA (RS256)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	K   string `json:"k,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// KeySet набор ключей проверки подписи
+// Статические ключи (секрет HS256, PEM RS256) используются для токенов без kid,
+// ключи из JWKS выбираются по kid и периодически перечитываются (ротация ключей)
+type KeySet struct {
+	cfg    Config
+	static interface{}
+	client *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	lastRefresh time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewKeySet загружает ключи из источников конфигурации и запускает их периодическое обновление
+func NewKeySet(cfg Config) (*KeySet, error) {
+	ks := &KeySet{
+		cfg:    cfg,
+		client: &http.Client{Timeout: fetchTimeout},
+		keys:   make(map[string]interface{}),
+		stop:   make(chan struct{}),
+	}
+
+	switch {
+	case cfg.HMACSecret != "" && cfg.PublicKeyFile != "":
+		return nil, errors.New("jwtauth: hmac secret and public key file are mutually exclusive")
+	case cfg.HMACSecret != "":
+		ks.static = []byte(cfg.HMACSecret)
+	case cfg.PublicKeyFile != "":
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: read public key file: %w", err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: parse public key file: %w", err)
+		}
+		ks.static = publicKey
+	}
+
+	if cfg.JWKSFile != "" && cfg.JWKSURL != "" {
+		return nil, errors.New("jwtauth: jwks file and jwks url are mutually exclusive")
+	}
+
+	if ks.hasJWKS() {
+		if err := ks.refresh(); err != nil {
+			return nil, err
+		}
+		if cfg.JWKSRefreshInterval > 0 {
+			go ks.run(cfg.JWKSRefreshInterval)
+		}
+	}
+
+	if ks.static == nil && len(ks.keys) == 0 {
+		return nil, errors.New("jwtauth: no verification keys configured")
+	}
+
+	return ks, nil
+}
+
+// Get возвращает ключ по kid
+// Для токенов без kid используется статический ключ, а если его нет - единственный ключ JWKS
+func (ks *KeySet) Get(kid string) (interface{}, error) {
+	if kid == "" {
+		if ks.static != nil {
+			return ks.static, nil
+		}
+
+		ks.mu.RLock()
+		defer ks.mu.RUnlock()
+		if len(ks.keys) == 1 {
+			for _, key := range ks.keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: token has no kid", ErrUnknownKey)
+	}
+
+	ks.mu.RLock()
+	key, ok := ks.keys[kid]
+	canRefresh := ks.hasJWKS() && time.Since(ks.lastRefresh) >= minRefreshInterval
+	ks.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// Неизвестный kid - возможно, ключи уже ротированы: перечитываем JWKS (не чаще minRefreshInterval)
+	if canRefresh {
+		if err := ks.refresh(); err == nil {
+			ks.mu.RLock()
+			key, ok = ks.keys[kid]
+			ks.mu.RUnlock()
+			if ok {
+				return key, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+}
+
+// Close останавливает периодическое обновление ключей
+func (ks *KeySet) Close() {
+	ks.stopOnce.Do(func() {
+		close(ks.stop)
+	})
+}
+
+func (ks *KeySet) hasJWKS() bool {
+	return ks.cfg.JWKSFile != "" || ks.cfg.JWKSURL != ""
+}
+
+// run периодически перечитывает JWKS; при ошибке остаются прежние ключи
+func (ks *KeySet) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = ks.refresh()
+		case <-ks.stop:
+			return
+		}
+	}
+}
+
+// refresh загружает JWKS и целиком заменяет набор ключей
+func (ks *KeySet) refresh() error {
+	data, err := ks.load()
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.lastRefresh = time.Now()
+
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	ks.keys = keys
+
+	return nil
+}
+
+func (ks *KeySet) load() ([]byte, error) {
+	if ks.cfg.JWKSFile != "" {
+		data, err := os.ReadFile(ks.cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: read jwks file: %w", err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: create jwks request: %w", err)
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwtauth: fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: read jwks response: %w", err)
+	}
+
+	return data, nil
+}
+
+// parseJWKS разбирает JWKS документ; ключи с use отличным от sig пропускаются
+func parseJWKS(data []byte) (map[string]interface{}, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwtauth: decode jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		parsed, err := key.parse()
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth: jwk %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = parsed
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwtauth: jwks contains no signing keys")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) parse() (interface{}, error) {
+	switch k.Kty {
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return nil, errors.New("invalid symmetric key")
+		}
+		return secret, nil
+
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.New("invalid RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exponent.Int64()),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,106 @@
+package jwtauth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenTypeAccess значение claim token_type у access токенов
+const tokenTypeAccess = "access"
+
+var (
+	ErrInvalidToken = errors.New("jwtauth: invalid token")
+	ErrUnknownKey   = errors.New("jwtauth: unknown signing key")
+)
+
+// Claims claims access токена SMC
+type Claims struct {
+	TGUserID  int64  `json:"tg_user_id"`
+	Role      string `json:"role,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verifier проверяет подпись и claims access токенов
+type Verifier struct {
+	keys   *KeySet
+	issuer string
+	leeway time.Duration
+}
+
+// NewVerifier создаёт Verifier и загружает ключи из источников конфигурации
+func NewVerifier(cfg Config) (*Verifier, error) {
+	keys, err := NewKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		keys:   keys,
+		issuer: cfg.Issuer,
+		leeway: cfg.Leeway,
+	}, nil
+}
+
+// Verify проверяет токен и возвращает идентичность пользователя
+func (v *Verifier) Verify(value string) (*Identity, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(value, claims, v.keyFunc, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	// Refresh токены не дают доступа к API
+	if claims.TokenType != "" && claims.TokenType != tokenTypeAccess {
+		return nil, fmt.Errorf("%w: unexpected token type %q", ErrInvalidToken, claims.TokenType)
+	}
+	if claims.TGUserID <= 0 {
+		return nil, fmt.Errorf("%w: missing tg_user_id", ErrInvalidToken)
+	}
+
+	return &Identity{
+		UserID: claims.TGUserID,
+		Role:   claims.Role,
+	}, nil
+}
+
+// Close останавливает фоновое обновление ключей
+func (v *Verifier) Close() {
+	v.keys.Close()
+}
+
+// keyFunc выбирает ключ по kid и проверяет, что он подходит к алгоритму токена
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := v.keys.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if secret, ok := key.([]byte); ok {
+			return secret, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if publicKey, ok := key.(*rsa.PublicKey); ok {
+			return publicKey, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: key %q does not match algorithm %s", ErrUnknownKey, kid, token.Method.Alg())
+}
@@ -19,6 +19,9 @@ type Metrics struct {
 	DBConnectionsActive prometheus.Gauge
 	DBConnectionsIdle   prometheus.Gauge
 	DBConnectionsMax    prometheus.Gauge
+
+	// Метрики журнала расчётов
+	CalculationEventsTotal *prometheus.CounterVec
 }
 
 // New создаёт новый экземпляр метрик с автоматической регистрацией в Prometheus
@@ -105,6 +108,15 @@ func New(serviceName string) *Metrics {
 				},
 			},
 		),
+
+		// Метрики журнала расчётов
+		CalculationEventsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calculation_log_events_total",
+				Help: "Total number of calculation log events by status (written, dropped, failed)",
+			},
+			[]string{"service", "status"},
+		),
 	}
 
 	return m
@@ -138,3 +150,8 @@ func (m *Metrics) UpdateDBConnectionStats(active, idle, max int) {
 	m.DBConnectionsIdle.Set(float64(idle))
 	m.DBConnectionsMax.Set(float64(max))
 }
+
+// RecordCalculationEvents записывает метрику событий журнала расчётов
+func (m *Metrics) RecordCalculationEvents(service, status string, count int) {
+	m.CalculationEventsTotal.WithLabelValues(service, status).Add(float64(count))
+}
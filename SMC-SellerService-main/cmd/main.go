@@ -18,8 +18,11 @@ import (
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/create_service"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/delete_company"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/delete_service"
+	"github.com/m04kA/SMC-SellerService/internal/api/handlers/erase_user_data"
+	"github.com/m04kA/SMC-SellerService/internal/api/handlers/export_user_data"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/get_company"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/get_service"
+	"github.com/m04kA/SMC-SellerService/internal/api/handlers/get_service_info"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/list_companies"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/list_services"
 	"github.com/m04kA/SMC-SellerService/internal/api/handlers/update_company"
@@ -28,13 +31,16 @@ import (
 	"github.com/m04kA/SMC-SellerService/internal/config"
 	companyRepo "github.com/m04kA/SMC-SellerService/internal/infra/storage/company"
 	serviceRepo "github.com/m04kA/SMC-SellerService/internal/infra/storage/service"
+	serviceNonceRepo "github.com/m04kA/SMC-SellerService/internal/infra/storage/servicenonce"
 	"github.com/m04kA/SMC-SellerService/internal/integrations/priceservice"
 	"github.com/m04kA/SMC-SellerService/internal/integrations/userservice"
 	companiesService "github.com/m04kA/SMC-SellerService/internal/service/companies"
 	servicesService "github.com/m04kA/SMC-SellerService/internal/service/services"
 	"github.com/m04kA/SMC-SellerService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-SellerService/pkg/jwtauth"
 	"github.com/m04kA/SMC-SellerService/pkg/logger"
 	"github.com/m04kA/SMC-SellerService/pkg/metrics"
+	"github.com/m04kA/SMC-SellerService/pkg/svcauth"
 )
 
 func main() {
@@ -90,12 +96,13 @@ func main() {
 	log.Info("PriceService client initialized (base_url=%s)", cfg.PriceService.BaseURL)
 
 	// Инициализируем UserService клиент
-	userClient := userservice.NewClient(cfg.UserService.BaseURL, log)
+	userClient := userservice.NewClient(cfg.UserService.BaseURL, cfg.InternalAuth.Credentials(), log)
 	log.Info("UserService client initialized (base_url=%s)", cfg.UserService.BaseURL)
 
 	// Инициализируем репозитории и сервисы (с метриками или без)
 	var companySvc *companiesService.Service
 	var serviceSvc *servicesService.Service
+	var serviceNonceRepository *serviceNonceRepo.Repository
 
 	if cfg.Metrics.Enabled {
 		wrappedDB = dbmetrics.WrapWithDefault(db, metricsCollector, cfg.Metrics.ServiceName, stopMetricsCh)
@@ -104,6 +111,7 @@ func main() {
 		// Инициализируем репозитории с обёрткой метрик
 		companyRepository := companyRepo.NewRepository(wrappedDB)
 		serviceRepository := serviceRepo.NewRepository(wrappedDB)
+		serviceNonceRepository = serviceNonceRepo.NewRepository(wrappedDB)
 
 		companySvc = companiesService.NewService(companyRepository, userClient)
 		serviceSvc = servicesService.NewService(serviceRepository, companyRepository, priceClient)
@@ -111,6 +119,7 @@ func main() {
 		// Инициализируем репозитории без метрик
 		companyRepository := companyRepo.NewRepository(db)
 		serviceRepository := serviceRepo.NewRepository(db)
+		serviceNonceRepository = serviceNonceRepo.NewRepository(db)
 
 		companySvc = companiesService.NewService(companyRepository, userClient)
 		serviceSvc = servicesService.NewService(serviceRepository, companyRepository, priceClient)
@@ -129,6 +138,37 @@ func main() {
 	listServicesHandler := list_services.NewHandler(serviceSvc, log)
 	updateServiceHandler := update_service.NewHandler(serviceSvc, log)
 	deleteServiceHandler := delete_service.NewHandler(serviceSvc, log)
+	getServiceInfoHandler := get_service_info.NewHandler(serviceSvc, log)
+
+	// Инициализируем handlers для данных пользователя (выгрузка и удаление по запросу UserService)
+	exportUserDataHandler := export_user_data.NewHandler(companySvc, log)
+	eraseUserDataHandler := erase_user_data.NewHandler(companySvc, log)
+
+	// Инициализируем аутентификацию пользователей
+	authenticator, err := jwtauth.New(cfg.Auth.JWTAuth())
+	if err != nil {
+		log.Fatal("Failed to initialize authentication: %v", err)
+	}
+	defer authenticator.Close()
+	authMiddleware := middleware.NewAuthMiddleware(authenticator)
+	if authenticator.Mode() == jwtauth.ModeHeader {
+		log.Warn("Authentication mode is 'header': X-User-ID/X-User-Role are trusted without verification (local development only)")
+	} else {
+		log.Info("Authentication mode is 'jwt' (issuer=%s)", cfg.Auth.Issuer)
+	}
+
+	// Инициализируем проверку межсервисных запросов к /internal; nonce хранятся в БД, общей для всех экземпляров
+	verifierConfig := cfg.InternalAuth.Verifier()
+	verifierConfig.Nonces = serviceNonceRepository
+	serviceVerifier, err := svcauth.NewVerifier(verifierConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize internal authentication: %v", err)
+	}
+	if serviceVerifier.Mode() == svcauth.ModeNone {
+		log.Warn("Internal authentication mode is 'none': /internal routes are not protected (local development only)")
+	} else {
+		log.Info("Internal authentication mode is '%s' (%d services)", serviceVerifier.Mode(), len(cfg.InternalAuth.Keys))
+	}
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
@@ -138,7 +178,7 @@ func main() {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-User-ID, X-User-Role")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-User-ID, X-User-Role")
 			
 			if r.Method == http.MethodOptions {
 				w.WriteHeader(http.StatusOK)
@@ -161,12 +201,19 @@ func main() {
 		log.Info("Prometheus metrics endpoint exposed at %s", cfg.Metrics.Path)
 	}
 
+	// Internal routes для межсервисного взаимодействия (требуют учётные данные сервиса)
+	internal := r.PathPrefix("/internal").Subrouter()
+	internal.Use(serviceVerifier.Middleware)
+	internal.HandleFunc("/companies/{company_id}/services/{service_id}", getServiceInfoHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users/{tg_user_id:[0-9]+}/export", exportUserDataHandler.Handle).Methods(http.MethodGet)
+	internal.HandleFunc("/users/{tg_user_id:[0-9]+}", eraseUserDataHandler.Handle).Methods(http.MethodDelete)
+
 	// API prefix
 	api := r.PathPrefix("/api/v1").Subrouter()
 
 	// Public routes (с опциональной аутентификацией для получения дополнительных данных)
 	public := api.PathPrefix("").Subrouter()
-	public.Use(middleware.OptionalAuth)
+	public.Use(authMiddleware.OptionalAuth)
 
 	// Public routes для компаний
 	public.HandleFunc("/companies", listCompaniesHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
@@ -176,9 +223,9 @@ func main() {
 	public.HandleFunc("/companies/{company_id}/services", listServicesHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
 	public.HandleFunc("/companies/{company_id}/services/{service_id}", getServiceHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
 
-	// Protected routes (требуют X-User-ID и X-User-Role)
+	// Protected routes (требуют аутентификацию: Bearer токен или X-User-ID и X-User-Role в режиме header)
 	protected := api.PathPrefix("").Subrouter()
-	protected.Use(middleware.Auth)
+	protected.Use(authMiddleware.Auth)
 
 	// Protected routes для компаний
 	protected.HandleFunc("/companies", createCompanyHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
@@ -0,0 +1,17 @@
+package erase_user_data
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-SellerService/internal/service/companies/models"
+)
+
+type CompanyService interface {
+	EraseUserData(ctx context.Context, userID int64) (*models.UserDataErasure, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
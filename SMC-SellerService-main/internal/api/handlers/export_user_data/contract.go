@@ -0,0 +1,17 @@
+package export_user_data
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-SellerService/internal/service/companies/models"
+)
+
+type CompanyService interface {
+	ExportUserData(ctx context.Context, userID int64) (*models.UserDataExport, error)
+}
+
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,66 @@
+package get_service_info
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-SellerService/internal/api/handlers"
+	"github.com/m04kA/SMC-SellerService/internal/service/services"
+)
+
+const (
+	msgInvalidCompanyID = "invalid company ID"
+	msgInvalidServiceID = "invalid service ID"
+	msgNotFound         = "service not found"
+)
+
+type Handler struct {
+	service ServiceService
+	logger  Logger
+}
+
+func NewHandler(service ServiceService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle GET /internal/companies/{company_id}/services/{service_id}
+// В отличие от публичного endpoint не обращается к PriceService за ценами
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	companyIDStr := vars["company_id"]
+	serviceIDStr := vars["service_id"]
+
+	companyID, err := strconv.ParseInt(companyIDStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("GET /internal/companies/{company_id}/services/{service_id} - Invalid company ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidCompanyID)
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(serviceIDStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("GET /internal/companies/{company_id}/services/{service_id} - Invalid service ID: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidServiceID)
+		return
+	}
+
+	service, err := h.service.GetInfoByID(r.Context(), companyID, serviceID)
+	if err != nil {
+		if errors.Is(err, services.ErrServiceNotFound) {
+			h.logger.Warn("GET /internal/companies/{company_id}/services/{service_id} - Service not found: company_id=%d, service_id=%d", companyID, serviceID)
+			handlers.RespondNotFound(w, msgNotFound)
+			return
+		}
+		h.logger.Error("GET /internal/companies/{company_id}/services/{service_id} - Failed to get service: company_id=%d, service_id=%d, error=%v", companyID, serviceID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("GET /internal/companies/{company_id}/services/{service_id} - Service retrieved successfully: company_id=%d, service_id=%d", companyID, serviceID)
+	handlers.RespondJSON(w, http.StatusOK, service)
+}
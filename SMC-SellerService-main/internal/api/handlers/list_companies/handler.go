@@ -10,8 +10,14 @@ import (
 )
 
 const (
-	msgInvalidPageParam  = "invalid page parameter"
-	msgInvalidLimitParam = "invalid limit parameter"
+	msgInvalidPageParam     = "invalid page parameter"
+	msgInvalidLimitParam    = "invalid limit parameter"
+	msgInvalidLocationParam = "lat and lon must be passed together as valid coordinates"
+	msgInvalidRadiusParam   = "invalid radius_km parameter"
+
+	// defaultRadiusKm радиус поиска рядом с точкой, если radius_km не передан
+	defaultRadiusKm = 10
+	maxRadiusKm     = 100
 )
 
 type Handler struct {
@@ -47,6 +53,29 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		req.City = &city
 	}
 
+	// Парсим точку поиска (опционально): компании рядом, ближайшие первыми
+	if latStr, lonStr := query.Get("lat"), query.Get("lon"); latStr != "" || lonStr != "" {
+		lat, latErr := strconv.ParseFloat(latStr, 64)
+		lon, lonErr := strconv.ParseFloat(lonStr, 64)
+		if latErr != nil || lonErr != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			h.logger.Warn("GET /companies - Invalid location: lat=%q, lon=%q", latStr, lonStr)
+			handlers.RespondBadRequest(w, msgInvalidLocationParam)
+			return
+		}
+
+		radius := float64(defaultRadiusKm)
+		if radiusStr := query.Get("radius_km"); radiusStr != "" {
+			parsed, err := strconv.ParseFloat(radiusStr, 64)
+			if err != nil || parsed <= 0 || parsed > maxRadiusKm {
+				h.logger.Warn("GET /companies - Invalid radius_km parameter: %q", radiusStr)
+				handlers.RespondBadRequest(w, msgInvalidRadiusParam)
+				return
+			}
+			radius = parsed
+		}
+		req.Near = &models.NearFilter{Latitude: lat, Longitude: lon, RadiusKm: radius}
+	}
+
 	// Парсим пагинацию (опционально)
 	if pageStr := query.Get("page"); pageStr != "" {
 		page, err := strconv.Atoi(pageStr)
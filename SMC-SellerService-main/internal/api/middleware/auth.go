@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strconv"
+
+	"github.com/m04kA/SMC-SellerService/pkg/jwtauth"
 )
 
 type contextKey string
@@ -13,30 +15,70 @@ const (
 	UserRoleKey contextKey = "user_role"
 )
 
-// Auth извлекает заголовки аутентификации и сохраняет их в контекст
-func Auth(next http.Handler) http.Handler {
+// Authenticator извлекает идентичность пользователя из запроса (Bearer токен или заголовки X-User-*)
+type Authenticator interface {
+	Authenticate(r *http.Request) (*jwtauth.Identity, error)
+}
+
+// AuthMiddleware middleware аутентификации пользователей
+type AuthMiddleware struct {
+	authenticator Authenticator
+}
+
+// NewAuthMiddleware создаёт middleware аутентификации
+func NewAuthMiddleware(authenticator Authenticator) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator}
+}
+
+// Auth проверяет аутентификацию и сохраняет user ID и роль в контекст
+func (m *AuthMiddleware) Auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userIDStr := r.Header.Get("X-User-ID")
-		userRole := r.Header.Get("X-User-Role")
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				http.Error(w, "missing authentication credentials", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
+			return
+		}
 
-		if userIDStr == "" || userRole == "" {
-			http.Error(w, "missing authentication headers", http.StatusUnauthorized)
+		if identity.Role == "" {
+			http.Error(w, "missing user role", http.StatusUnauthorized)
 			return
 		}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
+	})
+}
+
+// OptionalAuth сохраняет user ID и роль в контекст, если запрос аутентифицирован
+// В отличие от Auth, пропускает запросы без данных аутентификации, но отклоняет некорректные
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
 		if err != nil {
-			http.Error(w, "invalid user ID", http.StatusBadRequest)
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "invalid authentication credentials", http.StatusUnauthorized)
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserIDKey, userID)
-		ctx = context.WithValue(ctx, UserRoleKey, userRole)
-
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
 	})
 }
 
+// withIdentity сохраняет идентичность пользователя в контекст
+func withIdentity(ctx context.Context, identity *jwtauth.Identity) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, identity.UserID)
+	if identity.Role != "" {
+		ctx = context.WithValue(ctx, UserRoleKey, identity.Role)
+	}
+	return ctx
+}
+
 // GetUserID извлекает user ID из контекста
 func GetUserID(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(UserIDKey).(int64)
@@ -48,25 +90,3 @@ func GetUserRole(ctx context.Context) (string, bool) {
 	userRole, ok := ctx.Value(UserRoleKey).(string)
 	return userRole, ok
 }
-
-// OptionalAuth извлекает опциональные заголовки аутентификации и сохраняет их в контекст
-// В отличие от Auth, не требует обязательного наличия заголовков
-func OptionalAuth(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		// Пытаемся получить X-User-ID (опционально)
-		if userIDStr := r.Header.Get("X-User-ID"); userIDStr != "" {
-			if userID, err := strconv.ParseInt(userIDStr, 10, 64); err == nil && userID > 0 {
-				ctx = context.WithValue(ctx, UserIDKey, userID)
-			}
-		}
-
-		// Пытаемся получить X-User-Role (опционально)
-		if userRole := r.Header.Get("X-User-Role"); userRole != "" {
-			ctx = context.WithValue(ctx, UserRoleKey, userRole)
-		}
-
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/m04kA/SMC-SellerService/pkg/jwtauth"
+	"github.com/m04kA/SMC-SellerService/pkg/svcauth"
 )
 
 // Config представляет полную конфигурацию приложения
@@ -16,6 +21,9 @@ type Config struct {
 	Metrics      MetricsConfig      `toml:"metrics"`
 	PriceService PriceServiceConfig `toml:"priceservice"`
 	UserService  UserServiceConfig  `toml:"userservice"`
+	Auth         AuthConfig         `toml:"auth"`
+
+	InternalAuth InternalAuthConfig `toml:"internal_auth"`
 }
 
 // LogsConfig содержит настройки логирования
@@ -63,6 +71,62 @@ type UserServiceConfig struct {
 	BaseURL string `toml:"base_url"`
 }
 
+// AuthConfig содержит настройки проверки access токенов
+// По умолчанию mode = "jwt"; mode = "header" оставляет аутентификацию по X-User-ID/X-User-Role (только для локальной разработки)
+type AuthConfig struct {
+	Mode                string `toml:"mode"`                  // jwt | header
+	JWTSecret           string `toml:"jwt_secret"`            // секрет HS256
+	PublicKeyFile       string `toml:"public_key_file"`       // PEM файл с публичным ключом RS256
+	JWKSFile            string `toml:"jwks_file"`             // локальный JWKS файл
+	JWKSURL             string `toml:"jwks_url"`              // JWKS endpoint
+	JWKSRefreshInterval int    `toml:"jwks_refresh_interval"` // секунды
+	Issuer              string `toml:"issuer"`
+	Leeway              int    `toml:"leeway"` // секунды
+}
+
+// InternalAuthConfig содержит учётные данные сервиса для запросов к /internal эндпоинтам других сервисов
+// и ключи сервисов, которым разрешено вызывать /internal эндпоинты SellerService
+// mode = "none" отправляет запросы без подписи и не проверяет входящие (только для локальной разработки)
+type InternalAuthConfig struct {
+	Mode         string            `toml:"mode"`           // hmac | api_key | none
+	ServiceName  string            `toml:"service_name"`   // имя сервиса в конфигурации вызываемого сервиса
+	Secret       string            `toml:"secret"`         // общий секрет (hmac) или ключ (api_key)
+	MaxClockSkew int               `toml:"max_clock_skew"` // секунды, допустимое расхождение времени подписи
+	Keys         map[string]string `toml:"keys"`           // имя вызывающего сервиса -> секрет (hmac) или ключ (api_key)
+}
+
+// Credentials преобразует настройки в учётные данные пакета svcauth
+func (a InternalAuthConfig) Credentials() svcauth.Credentials {
+	return svcauth.Credentials{
+		Mode:        a.Mode,
+		ServiceName: a.ServiceName,
+		Secret:      a.Secret,
+	}
+}
+
+// Verifier преобразует настройки в конфигурацию проверки входящих запросов пакета svcauth
+func (a InternalAuthConfig) Verifier() svcauth.VerifierConfig {
+	return svcauth.VerifierConfig{
+		Mode:         a.Mode,
+		Keys:         a.Keys,
+		MaxClockSkew: time.Duration(a.MaxClockSkew) * time.Second,
+	}
+}
+
+// JWTAuth преобразует настройки в конфигурацию пакета jwtauth
+func (a AuthConfig) JWTAuth() jwtauth.Config {
+	return jwtauth.Config{
+		Mode:                a.Mode,
+		HMACSecret:          a.JWTSecret,
+		PublicKeyFile:       a.PublicKeyFile,
+		JWKSFile:            a.JWKSFile,
+		JWKSURL:             a.JWKSURL,
+		JWKSRefreshInterval: time.Duration(a.JWKSRefreshInterval) * time.Second,
+		Issuer:              a.Issuer,
+		Leeway:              time.Duration(a.Leeway) * time.Second,
+	}
+}
+
 // DSN формирует строку подключения к PostgreSQL
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
@@ -122,6 +186,36 @@ func overrideFromEnv(cfg *Config) {
 		}
 	}
 
+	// Internal auth
+	if v := os.Getenv("INTERNAL_AUTH_MODE"); v != "" {
+		cfg.InternalAuth.Mode = v
+	}
+	if v := os.Getenv("INTERNAL_AUTH_SECRET"); v != "" {
+		cfg.InternalAuth.Secret = v
+	}
+	// Формат: userservice=secret1,notificationservice=secret2
+	if v := os.Getenv("INTERNAL_AUTH_KEYS"); v != "" {
+		keys := make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			name, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && name != "" {
+				keys[name] = key
+			}
+		}
+		cfg.InternalAuth.Keys = keys
+	}
+
+	// Auth
+	if v := os.Getenv("AUTH_MODE"); v != "" {
+		cfg.Auth.Mode = v
+	}
+	if v := os.Getenv("JWT_SECRET"); v != "" {
+		cfg.Auth.JWTSecret = v
+	}
+	if v := os.Getenv("JWKS_URL"); v != "" {
+		cfg.Auth.JWKSURL = v
+	}
+
 	// Logs
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		cfg.Logs.Level = v
@@ -226,5 +320,50 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("userservice base_url is required")
 	}
 
+	// Auth validation and defaults
+	if err := validateAuth(&cfg.Auth); err != nil {
+		return err
+	}
+
+	// Internal auth validation and defaults
+	if cfg.InternalAuth.Mode == "" {
+		cfg.InternalAuth.Mode = svcauth.ModeNone
+	}
+	if cfg.InternalAuth.ServiceName == "" {
+		cfg.InternalAuth.ServiceName = "sellerservice"
+	}
+	if cfg.InternalAuth.MaxClockSkew == 0 {
+		cfg.InternalAuth.MaxClockSkew = 60
+	}
+	if err := cfg.InternalAuth.Credentials().Validate(); err != nil {
+		return fmt.Errorf("internal_auth: %w", err)
+	}
+
+	return nil
+}
+
+// validateAuth проверяет настройки аутентификации и заполняет значения по умолчанию
+func validateAuth(auth *AuthConfig) error {
+	// Режим header доверяет заголовкам клиента, поэтому включается только явно
+	if auth.Mode == "" {
+		auth.Mode = jwtauth.ModeJWT
+	}
+	if auth.Mode != jwtauth.ModeJWT && auth.Mode != jwtauth.ModeHeader {
+		return fmt.Errorf("auth mode must be %s or %s", jwtauth.ModeJWT, jwtauth.ModeHeader)
+	}
+	if auth.Mode == jwtauth.ModeJWT &&
+		auth.JWTSecret == "" && auth.PublicKeyFile == "" && auth.JWKSFile == "" && auth.JWKSURL == "" {
+		return fmt.Errorf("auth mode jwt requires jwt_secret, public_key_file, jwks_file or jwks_url")
+	}
+	if auth.Issuer == "" {
+		auth.Issuer = "smc-userservice"
+	}
+	if auth.JWKSRefreshInterval == 0 {
+		auth.JWKSRefreshInterval = 300 // 5 minutes
+	}
+	if auth.Leeway == 0 {
+		auth.Leeway = 30
+	}
+
 	return nil
 }
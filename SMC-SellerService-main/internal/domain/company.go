@@ -48,10 +48,18 @@ type UpdateCompanyInput struct {
 	ManagerIDs   []int64
 }
 
+// GeoFilter поиск компаний рядом с точкой
+type GeoFilter struct {
+	Coordinates Coordinates
+	RadiusKm    float64
+}
+
 // CompanyFilter фильтры для поиска компаний
 type CompanyFilter struct {
-	Tags  []string
-	City  *string
-	Page  *int // Опционально: если nil, пагинация не применяется
-	Limit *int // Опционально: если nil, пагинация не применяется
+	Tags      []string
+	City      *string
+	ManagerID *int64     // Опционально: только компании, где пользователь входит в manager_ids
+	Near      *GeoFilter // Опционально: только компании с адресом в радиусе, ближайшие первыми
+	Page      *int       // Опционально: если nil, пагинация не применяется
+	Limit     *int       // Опционально: если nil, пагинация не применяется
 }
@@ -143,12 +143,15 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Company, er
 }
 
 
+// distanceKmExpr расстояние по формуле гаверсинусов от адреса до точки (широта, широта, долгота) в километрах
+const distanceKmExpr = "2 * 6371 * ASIN(SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + " +
+	"COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)))"
+
 // List получает список компаний с фильтрацией
 func (r *Repository) List(ctx context.Context, filter domain.CompanyFilter) ([]domain.Company, *domain.PaginationResult, error) {
 	// Базовый запрос
 	selectBuilder := psqlbuilder.Select("id", "name", "logo", "description", "tags", "manager_ids", "created_at", "updated_at").
-		From("companies").
-		OrderBy("created_at DESC")
+		From("companies")
 
 	// Применяем фильтры
 	if len(filter.Tags) > 0 {
@@ -160,6 +163,19 @@ func (r *Repository) List(ctx context.Context, filter domain.CompanyFilter) ([]d
 		selectBuilder = selectBuilder.Where("id IN (SELECT company_id FROM addresses WHERE city = ?)", *filter.City)
 	}
 
+	if filter.ManagerID != nil {
+		selectBuilder = selectBuilder.Where("? = ANY(manager_ids)", *filter.ManagerID)
+	}
+
+	if filter.Near != nil {
+		// Ближайшие первыми: расстояние до ближайшего адреса компании
+		lat, lon := filter.Near.Coordinates.Latitude, filter.Near.Coordinates.Longitude
+		selectBuilder = selectBuilder.
+			Where("id IN (SELECT company_id FROM addresses WHERE "+distanceKmExpr+" <= ?)", lat, lat, lon, filter.Near.RadiusKm).
+			OrderByClause("(SELECT MIN("+distanceKmExpr+") FROM addresses WHERE company_id = companies.id)", lat, lat, lon)
+	}
+	selectBuilder = selectBuilder.OrderBy("created_at DESC")
+
 	// Применяем пагинацию только если Page и Limit заданы
 	var pagination *domain.PaginationResult
 	if filter.Page != nil && filter.Limit != nil {
@@ -375,6 +391,31 @@ func (r *Repository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// RemoveManager удаляет пользователя из manager_ids всех компаний
+// Возвращает количество затронутых компаний
+func (r *Repository) RemoveManager(ctx context.Context, userID int64) (int64, error) {
+	query, args, err := psqlbuilder.Update("companies").
+		Set("manager_ids", squirrel.Expr("array_remove(manager_ids, ?)", userID)).
+		Where("? = ANY(manager_ids)", userID).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveManager - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveManager - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: RemoveManager - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected, nil
+}
+
 // IsManager проверяет, является ли пользователь менеджером компании
 func (r *Repository) IsManager(ctx context.Context, companyID int64, userID int64) (bool, error) {
 	query, args, err := psqlbuilder.Select("manager_ids").
@@ -0,0 +1,8 @@
+package servicenonce
+
+import (
+	"github.com/m04kA/SMC-SellerService/pkg/dbmetrics"
+)
+
+// Переиспользуем интерфейс из dbmetrics (поддерживает *sql.DB и *dbmetrics.DB)
+type DBExecutor = dbmetrics.DBExecutor
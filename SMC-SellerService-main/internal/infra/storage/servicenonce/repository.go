@@ -0,0 +1,61 @@
+package servicenonce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/m04kA/SMC-SellerService/pkg/psqlbuilder"
+)
+
+// Repository хранилище nonce межсервисных запросов, общее для всех экземпляров сервиса
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория nonce
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// UseNonce запоминает nonce до expiresAt; false - nonce уже использован
+func (r *Repository) UseNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	query, args, err := psqlbuilder.Insert("service_nonces").
+		Columns("nonce", "expires_at").
+		Values(nonce, expiresAt).
+		Suffix("ON CONFLICT (nonce) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: UseNonce - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: UseNonce - insert nonce: %v", ErrExecQuery, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: UseNonce - rows affected: %v", ErrExecQuery, err)
+	}
+
+	return inserted == 1, nil
+}
+
+// DeleteExpiredNonces удаляет nonce, срок хранения которых истёк до before
+func (r *Repository) DeleteExpiredNonces(ctx context.Context, before time.Time) error {
+	query, args, err := psqlbuilder.Delete("service_nonces").
+		Where(squirrel.Lt{"expires_at": before}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: DeleteExpiredNonces - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: DeleteExpiredNonces - delete nonces: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ type CompanyRepository interface {
 	Update(ctx context.Context, id int64, input domain.UpdateCompanyInput) (*domain.Company, error)
 	Delete(ctx context.Context, id int64) error
 	IsManager(ctx context.Context, companyID int64, userID int64) (bool, error)
+	RemoveManager(ctx context.Context, userID int64) (int64, error)
 }
 
 // UserServiceClient интерфейс для работы с UserService
@@ -119,10 +119,38 @@ type PaginationResult struct {
 
 // CompanyFilterRequest фильтр для списка компаний
 type CompanyFilterRequest struct {
-	Tags  []string `json:"tags,omitempty"`
-	City  *string  `json:"city,omitempty"`
-	Page  *int     `json:"page,omitempty"`
-	Limit *int     `json:"limit,omitempty"`
+	Tags  []string    `json:"tags,omitempty"`
+	City  *string     `json:"city,omitempty"`
+	Near  *NearFilter `json:"near,omitempty"`
+	Page  *int        `json:"page,omitempty"`
+	Limit *int        `json:"limit,omitempty"`
+}
+
+// NearFilter поиск компаний рядом с точкой
+type NearFilter struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+// UserDataExport данные пользователя, хранящиеся в SellerService
+type UserDataExport struct {
+	UserID           int64                  `json:"user_id"`
+	ManagedCompanies []ManagedCompanyExport `json:"managed_companies"`
+}
+
+// ManagedCompanyExport компания, в которой пользователь является менеджером
+// Список других менеджеров не выгружается: это чужие персональные данные
+type ManagedCompanyExport struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserDataErasure результат удаления данных пользователя
+type UserDataErasure struct {
+	UserID            int64 `json:"user_id"`
+	CompaniesAffected int64 `json:"companies_affected"`
 }
 
 // ToDomainCreateInput конвертирует DTO в domain модель
@@ -204,12 +232,19 @@ func (r *UpdateCompanyRequest) ToDomainUpdateInput() domain.UpdateCompanyInput {
 
 // ToDomainFilter конвертирует DTO в domain модель
 func (r *CompanyFilterRequest) ToDomainFilter() domain.CompanyFilter {
-	return domain.CompanyFilter{
+	filter := domain.CompanyFilter{
 		Tags:  r.Tags,
 		City:  r.City,
 		Page:  r.Page,
 		Limit: r.Limit,
 	}
+	if r.Near != nil {
+		filter.Near = &domain.GeoFilter{
+			Coordinates: domain.Coordinates{Latitude: r.Near.Latitude, Longitude: r.Near.Longitude},
+			RadiusKm:    r.Near.RadiusKm,
+		}
+	}
+	return filter
 }
 
 // FromDomainCompany конвертирует domain модель в DTO
@@ -304,3 +339,20 @@ func timeStringToStringPtr(ts *domain.TimeString) *string {
 	s := string(*ts)
 	return &s
 }
+
+// FromDomainUserData конвертирует список компаний пользователя в DTO выгрузки
+func FromDomainUserData(userID int64, companies []domain.Company) *UserDataExport {
+	managed := make([]ManagedCompanyExport, len(companies))
+	for i, c := range companies {
+		managed[i] = ManagedCompanyExport{
+			ID:        c.ID,
+			Name:      c.Name,
+			CreatedAt: c.CreatedAt,
+		}
+	}
+
+	return &UserDataExport{
+		UserID:           userID,
+		ManagedCompanies: managed,
+	}
+}
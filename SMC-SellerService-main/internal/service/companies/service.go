@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/m04kA/SMC-SellerService/internal/domain"
 	"github.com/m04kA/SMC-SellerService/internal/service"
 	"github.com/m04kA/SMC-SellerService/internal/service/companies/models"
 	companyRepo "github.com/m04kA/SMC-SellerService/internal/infra/storage/company"
@@ -160,6 +161,31 @@ func (s *Service) Delete(ctx context.Context, id int64, userID int64, userRole s
 	return nil
 }
 
+// ExportUserData возвращает данные пользователя, хранящиеся в SellerService
+// Используется UserService при формировании выгрузки персональных данных
+func (s *Service) ExportUserData(ctx context.Context, userID int64) (*models.UserDataExport, error) {
+	companies, _, err := s.companyRepo.List(ctx, domain.CompanyFilter{ManagerID: &userID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: ExportUserData - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainUserData(userID, companies), nil
+}
+
+// EraseUserData удаляет пользователя из списков менеджеров всех компаний
+// Сами компании сохраняются: они принадлежат бизнесу, а не пользователю
+func (s *Service) EraseUserData(ctx context.Context, userID int64) (*models.UserDataErasure, error) {
+	affected, err := s.companyRepo.RemoveManager(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: EraseUserData - repository error: %v", ErrInternal, err)
+	}
+
+	return &models.UserDataErasure{
+		UserID:            userID,
+		CompaniesAffected: affected,
+	}, nil
+}
+
 // checkAccess проверяет права доступа пользователя к компании
 func (s *Service) checkAccess(ctx context.Context, companyID int64, userID int64, userRole string) error {
 	// Superuser имеет полный доступ
@@ -67,6 +67,20 @@ func (s *Service) GetByID(ctx context.Context, companyID int64, serviceID int64,
 	return servicePtrs[0], nil
 }
 
+// GetInfoByID получает услугу по ID без обогащения ценами
+// Используется для межсервисного взаимодействия (PriceService запрашивает длительность услуги)
+func (s *Service) GetInfoByID(ctx context.Context, companyID int64, serviceID int64) (*models.ServiceResponse, error) {
+	service, err := s.serviceRepo.GetByID(ctx, companyID, serviceID)
+	if err != nil {
+		if errors.Is(err, serviceRepo.ErrServiceNotFound) {
+			return nil, ErrServiceNotFound
+		}
+		return nil, fmt.Errorf("%w: GetInfoByID - repository error: %v", ErrInternal, err)
+	}
+
+	return models.FromDomainService(service), nil
+}
+
 // ListByCompany получает список услуг компании с опциональным обогащением ценами
 func (s *Service) ListByCompany(ctx context.Context, companyID int64, userID *int64) (*models.ServiceListResponse, error) {
 	services, err := s.serviceRepo.ListByCompany(ctx, companyID)
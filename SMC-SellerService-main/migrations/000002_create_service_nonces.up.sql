@@ -0,0 +1,9 @@
+-- Nonce подписанных межсервисных запросов (svcauth): общие для всех экземпляров сервиса,
+-- поэтому подписанный запрос нельзя повторить на другом экземпляре или после рестарта
+CREATE TABLE service_nonces (
+    nonce VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Индекс для удаления истёкших nonce
+CREATE INDEX idx_service_nonces_expires_at ON service_nonces(expires_at);
@@ -0,0 +1,138 @@
+// Package jwtauth проверяет access токены SMC и извлекает из них идентичность пользователя
+//
+// Пакет одинаковый во всех сервисах SMC. Поддерживаются два режима:
+//   - jwt: токен из заголовка Authorization: Bearer <token> (HS256/RS256, ротация ключей через JWKS)
+//   - header: заголовки X-User-ID и X-User-Role без проверки (только для локальной разработки)
+package jwtauth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Режимы аутентификации
+const (
+	ModeJWT    = "jwt"
+	ModeHeader = "header"
+)
+
+// Заголовки аутентификации
+const (
+	HeaderAuthorization = "Authorization"
+	HeaderUserID        = "X-User-ID"
+	HeaderUserRole      = "X-User-Role"
+
+	bearerPrefix = "Bearer "
+)
+
+var (
+	// ErrNoCredentials запрос не содержит данных аутентификации
+	ErrNoCredentials = errors.New("jwtauth: missing credentials")
+	// ErrInvalidCredentials данные аутентификации некорректны
+	ErrInvalidCredentials = errors.New("jwtauth: invalid credentials")
+)
+
+// Identity идентичность пользователя из токена или заголовков
+type Identity struct {
+	UserID int64
+	Role   string // может быть пустой в режиме header
+}
+
+// Config настройки аутентификации
+type Config struct {
+	Mode string // jwt | header
+
+	// Источники ключей проверки подписи (нужен хотя бы один в режиме jwt)
+	HMACSecret    string // общий секрет HS256
+	PublicKeyFile string // PEM файл с публичным ключом RS256
+	JWKSFile      string // локальный JWKS файл
+	JWKSURL       string // JWKS endpoint
+
+	// JWKSRefreshInterval период перечитывания JWKS (0 - не перечитывать)
+	JWKSRefreshInterval time.Duration
+	// Issuer ожидаемый iss (пустой - не проверяется)
+	Issuer string
+	// Leeway допустимое расхождение часов при проверке exp/nbf/iat
+	Leeway time.Duration
+}
+
+// Authenticator извлекает идентичность из HTTP запроса в выбранном режиме
+type Authenticator struct {
+	mode     string
+	verifier *Verifier
+}
+
+// New создаёт Authenticator; в режиме jwt загружает ключи
+func New(cfg Config) (*Authenticator, error) {
+	switch cfg.Mode {
+	case ModeHeader:
+		return &Authenticator{mode: ModeHeader}, nil
+
+	case ModeJWT:
+		verifier, err := NewVerifier(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &Authenticator{mode: ModeJWT, verifier: verifier}, nil
+
+	default:
+		return nil, fmt.Errorf("jwtauth: unknown mode %q (allowed: %s, %s)", cfg.Mode, ModeJWT, ModeHeader)
+	}
+}
+
+// Mode возвращает режим аутентификации
+func (a *Authenticator) Mode() string {
+	return a.mode
+}
+
+// Authenticate извлекает идентичность из запроса
+// Возвращает ErrNoCredentials, если данных аутентификации нет, и ErrInvalidCredentials, если они некорректны
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if a.mode == ModeHeader {
+		return fromHeaders(r)
+	}
+
+	header := r.Header.Get(HeaderAuthorization)
+	if header == "" {
+		return nil, ErrNoCredentials
+	}
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return nil, fmt.Errorf("%w: expected Bearer token", ErrInvalidCredentials)
+	}
+
+	identity, err := a.verifier.Verify(strings.TrimSpace(header[len(bearerPrefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	return identity, nil
+}
+
+// Close останавливает фоновое обновление ключей
+func (a *Authenticator) Close() {
+	if a.verifier != nil {
+		a.verifier.Close()
+	}
+}
+
+// fromHeaders читает X-User-ID и X-User-Role (режим локальной разработки)
+func fromHeaders(r *http.Request) (*Identity, error) {
+	userIDStr := r.Header.Get(HeaderUserID)
+	if userIDStr == "" {
+		return nil, ErrNoCredentials
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid user ID", ErrInvalidCredentials)
+	}
+
+	return &Identity{
+		UserID: userID,
+		Role:   r.Header.Get(HeaderUserRole),
+	}, nil
+}
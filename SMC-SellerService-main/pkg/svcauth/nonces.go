@@ -0,0 +1,52 @@
+package svcauth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NonceStore хранилище использованных nonce
+// Чтобы подписанный запрос нельзя было повторить на другом экземпляре сервиса или после рестарта,
+// хранилище должно быть общим для всех экземпляров (например, таблица в БД сервиса)
+type NonceStore interface {
+	// UseNonce запоминает nonce до expiresAt; false - nonce уже использован
+	UseNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+	// DeleteExpiredNonces удаляет nonce, срок хранения которых истёк до before
+	DeleteExpiredNonces(ctx context.Context, before time.Time) error
+}
+
+// memoryNonceStore хранит nonce в памяти процесса (один экземпляр сервиса, локальная разработка)
+type memoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+func newMemoryNonceStore() *memoryNonceStore {
+	return &memoryNonceStore{nonces: make(map[string]time.Time)}
+}
+
+func (s *memoryNonceStore) UseNonce(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, seen := s.nonces[nonce]; seen {
+		return false, nil
+	}
+	s.nonces[nonce] = expiresAt
+
+	return true, nil
+}
+
+func (s *memoryNonceStore) DeleteExpiredNonces(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for nonce, expiresAt := range s.nonces {
+		if expiresAt.Before(before) {
+			delete(s.nonces, nonce)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package svcauth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport http.RoundTripper, который подписывает каждый исходящий запрос
+type Transport struct {
+	base        http.RoundTripper
+	credentials Credentials
+}
+
+// NewTransport оборачивает base (nil - http.DefaultTransport) подписью запросов
+func NewTransport(base http.RoundTripper, credentials Credentials) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:        base,
+		credentials: credentials,
+	}
+}
+
+// RoundTrip подписывает копию запроса и передаёт её базовому транспорту
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.credentials.Mode == ModeNone {
+		return t.base.RoundTrip(req)
+	}
+
+	signed := req.Clone(req.Context())
+	if err := Sign(signed, t.credentials, time.Now()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return t.base.RoundTrip(signed)
+}
+
+// Sign добавляет в запрос заголовки межсервисной аутентификации
+// В режиме hmac тело запроса читается для подписи и восстанавливается
+func Sign(req *http.Request, credentials Credentials, now time.Time) error {
+	switch credentials.Mode {
+	case ModeNone:
+		return nil
+
+	case ModeAPIKey:
+		req.Header.Set(HeaderServiceName, credentials.ServiceName)
+		req.Header.Set(HeaderAPIKey, credentials.Secret)
+		return nil
+
+	case ModeHMAC:
+		body, err := readBody(req)
+		if err != nil {
+			return err
+		}
+
+		nonce, err := newNonce()
+		if err != nil {
+			return err
+		}
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+
+		req.Header.Set(HeaderServiceName, credentials.ServiceName)
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderNonce, nonce)
+		req.Header.Set(HeaderSignature, signature(credentials.Secret, req, credentials.ServiceName, timestamp, nonce, body))
+		return nil
+
+	default:
+		return validateMode(credentials.Mode)
+	}
+}
+
+// signature вычисляет подпись канонического представления запроса
+func signature(secret string, req *http.Request, serviceName, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s\n%s\n%s",
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		serviceName,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// readBody читает тело запроса и восстанавливает его для дальнейшей отправки или обработки
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("svcauth: read request body: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
+
+func newNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("svcauth: generate nonce: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
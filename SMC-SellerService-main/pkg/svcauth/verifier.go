@@ -0,0 +1,203 @@
+package svcauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxClockSkew допустимое расхождение времени подписи и сервера
+	defaultMaxClockSkew = time.Minute
+	// maxBodySize максимальный размер тела подписанного запроса
+	maxBodySize = 10 << 20
+)
+
+type contextKey string
+
+const serviceNameKey contextKey = "service_name"
+
+// VerifierConfig настройки проверки межсервисных запросов
+type VerifierConfig struct {
+	Mode         string            // hmac | api_key | none
+	Keys         map[string]string // имя сервиса -> секрет (hmac) или ключ (api_key)
+	MaxClockSkew time.Duration     // 0 - одна минута
+	Nonces       NonceStore        // nil - nonce в памяти процесса
+}
+
+// Verifier проверяет учётные данные входящих межсервисных запросов
+// Использованные nonce хранятся в Nonces в пределах окна MaxClockSkew. Хранилище в памяти процесса
+// не защищает от повтора на другом экземпляре сервиса или после рестарта, поэтому при нескольких
+// экземплярах нужно общее хранилище
+type Verifier struct {
+	mode         string
+	keys         map[string]string
+	maxClockSkew time.Duration
+	nonces       NonceStore
+
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// NewVerifier создаёт Verifier
+func NewVerifier(cfg VerifierConfig) (*Verifier, error) {
+	if err := validateMode(cfg.Mode); err != nil {
+		return nil, err
+	}
+	if cfg.Mode != ModeNone && len(cfg.Keys) == 0 {
+		return nil, errors.New("svcauth: at least one service key is required")
+	}
+	for name, key := range cfg.Keys {
+		if key == "" {
+			return nil, fmt.Errorf("svcauth: empty key for service %q", name)
+		}
+	}
+
+	maxClockSkew := cfg.MaxClockSkew
+	if maxClockSkew <= 0 {
+		maxClockSkew = defaultMaxClockSkew
+	}
+
+	nonces := cfg.Nonces
+	if nonces == nil {
+		nonces = newMemoryNonceStore()
+	}
+
+	return &Verifier{
+		mode:         cfg.Mode,
+		keys:         cfg.Keys,
+		maxClockSkew: maxClockSkew,
+		nonces:       nonces,
+	}, nil
+}
+
+// Mode возвращает режим проверки
+func (v *Verifier) Mode() string {
+	return v.mode
+}
+
+// Verify проверяет запрос и возвращает имя вызывающего сервиса
+func (v *Verifier) Verify(r *http.Request, now time.Time) (string, error) {
+	if v.mode == ModeNone {
+		return r.Header.Get(HeaderServiceName), nil
+	}
+
+	serviceName := r.Header.Get(HeaderServiceName)
+	if serviceName == "" {
+		return "", ErrMissingCredentials
+	}
+
+	key, ok := v.keys[serviceName]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownService, serviceName)
+	}
+
+	if v.mode == ModeAPIKey {
+		provided := r.Header.Get(HeaderAPIKey)
+		if provided == "" {
+			return "", ErrMissingCredentials
+		}
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) != 1 {
+			return "", ErrInvalidCredentials
+		}
+		return serviceName, nil
+	}
+
+	timestamp := r.Header.Get(HeaderTimestamp)
+	nonce := r.Header.Get(HeaderNonce)
+	provided := r.Header.Get(HeaderSignature)
+	if timestamp == "" || nonce == "" || provided == "" {
+		return "", ErrMissingCredentials
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid timestamp", ErrInvalidCredentials)
+	}
+	signedAt := time.Unix(unix, 0)
+	if signedAt.Before(now.Add(-v.maxClockSkew)) || signedAt.After(now.Add(v.maxClockSkew)) {
+		return "", ErrExpiredTimestamp
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, maxBodySize)
+	body, err := readBody(r)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	expected := signature(key, r, serviceName, timestamp, nonce, body)
+	if !hmac.Equal([]byte(provided), []byte(expected)) {
+		return "", ErrInvalidCredentials
+	}
+
+	// Nonce запоминаем только после проверки подписи, чтобы его нельзя было "занять" чужим запросом
+	used, err := v.useNonce(r.Context(), serviceName+":"+nonce, now)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNonceStore, err)
+	}
+	if !used {
+		return "", ErrReplayedNonce
+	}
+
+	return serviceName, nil
+}
+
+// Middleware отклоняет запросы без корректных учётных данных сервиса (401)
+// и сохраняет имя вызывающего сервиса в контекст. Недоступность хранилища nonce - 503
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serviceName, err := v.Verify(r, time.Now())
+		if err != nil {
+			if errors.Is(err, ErrNonceStore) {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := r.Context()
+		if serviceName != "" {
+			ctx = context.WithValue(ctx, serviceNameKey, serviceName)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetServiceName извлекает имя вызывающего сервиса из контекста
+func GetServiceName(ctx context.Context) (string, bool) {
+	serviceName, ok := ctx.Value(serviceNameKey).(string)
+	return serviceName, ok
+}
+
+// useNonce запоминает nonce; возвращает false, если он уже использовался в пределах окна
+// Подпись принимается в окне ±maxClockSkew от времени сервера, поэтому nonce достаточно хранить 2*maxClockSkew
+func (v *Verifier) useNonce(ctx context.Context, nonce string, now time.Time) (bool, error) {
+	v.purgeNonces(ctx, now)
+	return v.nonces.UseNonce(ctx, nonce, now.Add(2*v.maxClockSkew))
+}
+
+// purgeNonces удаляет истёкшие nonce не чаще раза в maxClockSkew
+// Ошибка удаления не мешает проверке запроса: истёкшие nonce будут удалены при следующей попытке
+func (v *Verifier) purgeNonces(ctx context.Context, now time.Time) {
+	v.mu.Lock()
+	if now.Sub(v.lastPurged) <= v.maxClockSkew {
+		v.mu.Unlock()
+		return
+	}
+	v.lastPurged = now
+	v.mu.Unlock()
+
+	if err := v.nonces.DeleteExpiredNonces(ctx, now); err != nil {
+		v.mu.Lock()
+		v.lastPurged = time.Time{}
+		v.mu.Unlock()
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,21 +16,50 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/m04kA/SMC-UserService/internal/config"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/auth_telegram"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/classify_car"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/confirm_phone"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_car"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_user_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/create_user"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/delete_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/delete_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/export_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/find_cars_by_plate"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_role_history"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_selected_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_superusers"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_user_by_id"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/get_users_batch"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/grant_role"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/list_users"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/refresh_token"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/revoke_role"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/restore_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/revoke_token"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/search_users"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/select_car"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/select_user_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/update_car"
 	"github.com/m04kA/SMC-UserService/internal/handlers/api/update_current_user"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api/verify_phone"
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	"github.com/m04kA/SMC-UserService/internal/infra/carclass"
+	"github.com/m04kA/SMC-UserService/internal/infra/sms"
 	carrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/car"
+	phoneverificationrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/phoneverification"
+	refreshtokenrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/refreshtoken"
+	servicenoncerepo "github.com/m04kA/SMC-UserService/internal/infra/storage/servicenonce"
 	userrepo "github.com/m04kA/SMC-UserService/internal/infra/storage/user"
+	"github.com/m04kA/SMC-UserService/internal/integrations/userdata"
+	authservice "github.com/m04kA/SMC-UserService/internal/service/auth"
+	phoneservice "github.com/m04kA/SMC-UserService/internal/service/phone"
+	privacyservice "github.com/m04kA/SMC-UserService/internal/service/privacy"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/authtoken"
+	"github.com/m04kA/SMC-UserService/pkg/jwtauth"
+	"github.com/m04kA/SMC-UserService/pkg/svcauth"
 	"github.com/m04kA/SMC-UserService/pkg/logger"
 )
 
@@ -69,22 +99,156 @@ func main() {
 	// Инициализируем репозитории
 	userRepo := userrepo.NewRepository(db)
 	carRepo := carrepo.NewRepository(db)
+	phoneVerificationRepo := phoneverificationrepo.NewRepository(db)
+	refreshTokenRepo := refreshtokenrepo.NewRepository(db)
+	serviceNonceRepo := servicenoncerepo.NewRepository(db)
+
+	// Загружаем справочник классов автомобилей
+	carClasses, err := carclass.Load(cfg.CarClasses.File)
+	if err != nil {
+		log.Fatal("Failed to load car class dictionary: %v", err)
+	}
+	log.Info("Car class dictionary loaded (entries=%d, auto_fill=%t)", carClasses.Len(), cfg.CarClasses.AutoFill)
 
 	// Инициализируем сервис
-	service := userservice.NewUserService(userRepo, carRepo)
+	service := userservice.NewUserService(userRepo, carRepo, carClasses, cfg.CarClasses.AutoFill)
+
+	// Подтверждение номера телефона (сейчас поддерживается только отправка в лог)
+	codeSecret := []byte(cfg.PhoneVerification.CodeSecret)
+	if len(codeSecret) == 0 {
+		codeSecret = make([]byte, 32)
+		if _, err := rand.Read(codeSecret); err != nil {
+			log.Fatal("Failed to generate phone code secret: %v", err)
+		}
+		log.Warn("Phone verification code_secret is empty: using random key, pending codes are lost on restart")
+	}
+	log.Warn("SMS sender is '%s': verification codes are written to the log (local development only)", cfg.PhoneVerification.Sender)
+	phoneService := phoneservice.NewPhoneService(
+		userRepo,
+		phoneVerificationRepo,
+		sms.NewLogSender(log),
+		phoneservice.Config{
+			CodeLength:        cfg.PhoneVerification.CodeLength,
+			CodeTTL:           time.Duration(cfg.PhoneVerification.CodeTTL) * time.Second,
+			MaxAttempts:       cfg.PhoneVerification.MaxAttempts,
+			ResendInterval:    time.Duration(cfg.PhoneVerification.ResendInterval) * time.Second,
+			SendWindow:        time.Duration(cfg.PhoneVerification.SendWindow) * time.Second,
+			MaxSendsPerWindow: cfg.PhoneVerification.MaxSendsPerWindow,
+			CodeSecret:        codeSecret,
+		},
+	)
+
+	// Выгрузка данных и удаление аккаунта: данные в других сервисах запрашиваются через /internal/users
+	var dataSources []privacyservice.DataSource
+	requestTimeout := time.Duration(cfg.AccountDeletion.RequestTimeout) * time.Second
+	if cfg.AccountDeletion.SellerServiceURL != "" {
+		dataSources = append(dataSources, userdata.NewClient("sellerservice", cfg.AccountDeletion.SellerServiceURL, requestTimeout, cfg.InternalAuth.Credentials(), log))
+	}
+	if cfg.AccountDeletion.NotificationServiceURL != "" {
+		dataSources = append(dataSources, userdata.NewClient("notificationservice", cfg.AccountDeletion.NotificationServiceURL, requestTimeout, cfg.InternalAuth.Credentials(), log))
+	}
+	if len(dataSources) == 0 {
+		log.Warn("No external services configured for account deletion: data in SellerService and NotificationService is not exported or erased")
+	}
+	privacyService := privacyservice.NewPrivacyService(
+		userRepo,
+		carRepo,
+		phoneVerificationRepo,
+		refreshTokenRepo,
+		dataSources,
+		privacyservice.Config{
+			GracePeriod:    time.Duration(cfg.AccountDeletion.GracePeriod) * 24 * time.Hour,
+			PurgeBatchSize: cfg.AccountDeletion.PurgeBatchSize,
+		},
+	)
+
+	// Задача анонимизации аккаунтов работает до завершения сервиса
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go privacyservice.NewPurgeJob(privacyService, time.Duration(cfg.AccountDeletion.PurgeInterval)*time.Second, log).Run(purgeCtx)
+	log.Info("Account purge job started (grace_period=%dd, interval=%ds, services=%d)",
+		cfg.AccountDeletion.GracePeriod, cfg.AccountDeletion.PurgeInterval, len(dataSources))
 
 	// Инициализируем handlers
 	createUserHandler := create_user.NewHandler(service, log)
 	getCurrentUserHandler := get_current_user.NewHandler(service, log)
 	updateCurrentUserHandler := update_current_user.NewHandler(service, log)
-	deleteCurrentUserHandler := delete_current_user.NewHandler(service, log)
+	deleteCurrentUserHandler := delete_current_user.NewHandler(privacyService, log)
+	restoreCurrentUserHandler := restore_current_user.NewHandler(privacyService, log)
+	exportCurrentUserHandler := export_current_user.NewHandler(privacyService, log)
 	createCarHandler := create_car.NewHandler(service, log)
 	updateCarHandler := update_car.NewHandler(service, log)
 	deleteCarHandler := delete_car.NewHandler(service, log)
 	getSelectedCarHandler := get_selected_car.NewHandler(service, log)
 	selectCarHandler := select_car.NewHandler(service, log)
+	createUserCarHandler := create_user_car.NewHandler(service, log)
+	selectUserCarHandler := select_user_car.NewHandler(service, log)
+	classifyCarHandler := classify_car.NewHandler(service, log)
 	getUserByIDHandler := get_user_by_id.NewHandler(service, log)
+	getUsersBatchHandler := get_users_batch.NewHandler(service, log)
 	getSuperUsersHandler := get_superusers.NewHandler(service, log)
+	listUsersHandler := list_users.NewHandler(service, log)
+	searchUsersHandler := search_users.NewHandler(service, log)
+	grantRoleHandler := grant_role.NewHandler(service, log)
+	revokeRoleHandler := revoke_role.NewHandler(service, log)
+	getRoleHistoryHandler := get_role_history.NewHandler(service, log)
+	findCarsByPlateHandler := find_cars_by_plate.NewHandler(service, log)
+	verifyPhoneHandler := verify_phone.NewHandler(phoneService, log)
+	confirmPhoneHandler := confirm_phone.NewHandler(phoneService, log)
+
+	// Вход через Telegram Mini App (только если задан токен бота)
+	var (
+		authTelegramHandler *auth_telegram.Handler
+		refreshTokenHandler *refresh_token.Handler
+		revokeTokenHandler  *revoke_token.Handler
+	)
+	if cfg.Auth.Enabled() {
+		issuer := authtoken.NewIssuer(
+			cfg.Auth.JWTSecret,
+			cfg.Auth.Issuer,
+			time.Duration(cfg.Auth.AccessTokenTTL)*time.Second,
+			time.Duration(cfg.Auth.RefreshTokenTTL)*time.Second,
+		)
+		authService := authservice.NewAuthService(
+			userRepo,
+			refreshTokenRepo,
+			issuer,
+			cfg.Auth.BotToken,
+			time.Duration(cfg.Auth.InitDataMaxAge)*time.Second,
+		)
+
+		authTelegramHandler = auth_telegram.NewHandler(authService, log)
+		refreshTokenHandler = refresh_token.NewHandler(authService, log)
+		revokeTokenHandler = revoke_token.NewHandler(authService, log)
+	} else {
+		log.Warn("Telegram bot token is not set, /auth endpoints are disabled")
+	}
+
+	// Инициализируем проверку access токенов
+	authenticator, err := jwtauth.New(cfg.Auth.JWTAuth())
+	if err != nil {
+		log.Fatal("Failed to initialize authentication: %v", err)
+	}
+	defer authenticator.Close()
+	authMiddleware := middleware.NewAuthMiddleware(authenticator)
+	if authenticator.Mode() == jwtauth.ModeHeader {
+		log.Warn("Authentication mode is 'header': X-User-ID/X-User-Role are trusted without verification (local development only)")
+	} else {
+		log.Info("Authentication mode is 'jwt' (issuer=%s)", cfg.Auth.Issuer)
+	}
+
+	// Инициализируем проверку межсервисных запросов; nonce хранятся в БД, общей для всех экземпляров
+	verifierConfig := cfg.InternalAuth.Verifier()
+	verifierConfig.Nonces = serviceNonceRepo
+	serviceVerifier, err := svcauth.NewVerifier(verifierConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize internal authentication: %v", err)
+	}
+	if serviceVerifier.Mode() == svcauth.ModeNone {
+		log.Warn("Internal authentication mode is 'none': /internal routes are not protected (local development only)")
+	} else {
+		log.Info("Internal authentication mode is '%s' (%d services)", serviceVerifier.Mode(), len(cfg.InternalAuth.Keys))
+	}
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
@@ -94,7 +258,7 @@ func main() {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-User-ID, X-User-Role")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-User-ID, X-User-Role")
 			
 			if r.Method == http.MethodOptions {
 				w.WriteHeader(http.StatusOK)
@@ -113,24 +277,59 @@ func main() {
 
 	// Public routes
 	r.HandleFunc("/users", createUserHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	if cfg.Auth.Enabled() {
+		r.HandleFunc("/auth/telegram", authTelegramHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+		r.HandleFunc("/auth/refresh", refreshTokenHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+		r.HandleFunc("/auth/revoke", revokeTokenHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	}
+
+	// Internal routes (для межсервисного взаимодействия, требуют учётные данные сервиса)
+	// При заданном internal_http_port обслуживаются отдельным listener'ом и недоступны на публичном порту
+	internalRouter := r
+	if cfg.Server.InternalHTTPPort != 0 {
+		internalRouter = mux.NewRouter()
+		internalRouter.Use(middleware.Metrics)
+	}
 
-	// Internal routes (для межсервисного взаимодействия)
-	r.HandleFunc("/internal/users/superusers", getSuperUsersHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
-	r.HandleFunc("/internal/users/{tg_user_id}", getUserByIDHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
-	r.HandleFunc("/internal/users/{tg_user_id}/cars/selected", getSelectedCarHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	internal := internalRouter.PathPrefix("/internal").Subrouter()
+	internal.Use(serviceVerifier.Middleware)
 
-	// Protected routes (требуют заголовок X-User-ID)
+	internal.HandleFunc("/users/superusers", getSuperUsersHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	internal.HandleFunc("/users/batch", getUsersBatchHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	internal.HandleFunc("/users/{tg_user_id}", getUserByIDHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	internal.HandleFunc("/users/{tg_user_id}/cars/selected", getSelectedCarHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	internal.HandleFunc("/users/{tg_user_id}/cars", createUserCarHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	internal.HandleFunc("/users/{tg_user_id}/cars/{car_id}/select", selectUserCarHandler.Handle).Methods(http.MethodPut, http.MethodOptions)
+
+	// Protected routes (требуют Bearer токен или заголовок X-User-ID в режиме header)
 	protected := r.PathPrefix("").Subrouter()
-	protected.Use(middleware.UserIDAuth)
+	protected.Use(authMiddleware.UserIDAuth)
 
-	protected.HandleFunc("/users/me", getCurrentUserHandler.Handle).Methods(http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodOptions)
+	protected.HandleFunc("/users/me", getCurrentUserHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
 	protected.HandleFunc("/users/me", updateCurrentUserHandler.Handle).Methods(http.MethodPut, http.MethodOptions)
 	protected.HandleFunc("/users/me", deleteCurrentUserHandler.Handle).Methods(http.MethodDelete, http.MethodOptions)
+	protected.HandleFunc("/users/me/restore", restoreCurrentUserHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	protected.HandleFunc("/users/me/export", exportCurrentUserHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+
+	protected.HandleFunc("/users/me/phone/verify", verifyPhoneHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	protected.HandleFunc("/users/me/phone/confirm", confirmPhoneHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
 
 	protected.HandleFunc("/users/me/cars", createCarHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
 	protected.HandleFunc("/users/me/cars/{car_id}", updateCarHandler.Handle).Methods(http.MethodPatch, http.MethodOptions)
 	protected.HandleFunc("/users/me/cars/{car_id}", deleteCarHandler.Handle).Methods(http.MethodDelete, http.MethodOptions)
 	protected.HandleFunc("/users/me/cars/{car_id}/select", selectCarHandler.Handle).Methods(http.MethodPut, http.MethodOptions)
+	protected.HandleFunc("/cars/classify", classifyCarHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+
+	// Управление ролями и поиск автомобилей (только для суперпользователя)
+	admin := protected.PathPrefix("").Subrouter()
+	admin.Use(middleware.RequireSuperUser)
+
+	admin.HandleFunc("/users", listUsersHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	admin.HandleFunc("/admin/users", searchUsersHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	admin.HandleFunc("/users/{tg_user_id:[0-9]+}/role", grantRoleHandler.Handle).Methods(http.MethodPost, http.MethodOptions)
+	admin.HandleFunc("/users/{tg_user_id:[0-9]+}/role", revokeRoleHandler.Handle).Methods(http.MethodDelete, http.MethodOptions)
+	admin.HandleFunc("/users/{tg_user_id:[0-9]+}/role/history", getRoleHistoryHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
+	admin.HandleFunc("/cars", findCarsByPlateHandler.Handle).Methods(http.MethodGet, http.MethodOptions)
 
 	// Создаем HTTP сервер
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
@@ -142,6 +341,18 @@ func main() {
 		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
 	}
 
+	// Отдельный HTTP сервер для internal routes (если задан порт)
+	var internalSrv *http.Server
+	if cfg.Server.InternalHTTPPort != 0 {
+		internalSrv = &http.Server{
+			Addr:         fmt.Sprintf(":%d", cfg.Server.InternalHTTPPort),
+			Handler:      internalRouter,
+			ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+			IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
+		}
+	}
+
 	// Graceful shutdown
 	go func() {
 		log.Info("Starting server on %s", addr)
@@ -150,12 +361,22 @@ func main() {
 		}
 	}()
 
+	if internalSrv != nil {
+		go func() {
+			log.Info("Starting internal server on %s", internalSrv.Addr)
+			if err := internalSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal("Internal server failed to start: %v", err)
+			}
+		}()
+	}
+
 	// Ожидаем сигнал завершения
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopPurge()
 
 	shutdownCtx, cancel := context.WithTimeout(
 		context.Background(),
@@ -166,6 +387,11 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Error("Server forced to shutdown: %v", err)
 	}
+	if internalSrv != nil {
+		if err := internalSrv.Shutdown(shutdownCtx); err != nil {
+			log.Error("Internal server forced to shutdown: %v", err)
+		}
+	}
 
 	log.Info("Server stopped gracefully")
 }
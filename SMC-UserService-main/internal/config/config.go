@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/m04kA/SMC-UserService/pkg/jwtauth"
+	"github.com/m04kA/SMC-UserService/pkg/svcauth"
 )
 
 // Config представляет полную конфигурацию приложения
@@ -13,6 +18,15 @@ type Config struct {
 	Logs     LogsConfig     `toml:"logs"`
 	Server   ServerConfig   `toml:"server"`
 	Database DatabaseConfig `toml:"database"`
+	Auth     AuthConfig     `toml:"auth"`
+
+	CarClasses CarClassesConfig `toml:"car_classes"`
+
+	PhoneVerification PhoneVerificationConfig `toml:"phone_verification"`
+
+	AccountDeletion AccountDeletionConfig `toml:"account_deletion"`
+
+	InternalAuth InternalAuthConfig `toml:"internal_auth"`
 }
 
 // LogsConfig содержит настройки логирования
@@ -23,11 +37,12 @@ type LogsConfig struct {
 
 // ServerConfig содержит настройки HTTP сервера
 type ServerConfig struct {
-	HTTPPort        int `toml:"http_port"`
-	ReadTimeout     int `toml:"read_timeout"`
-	WriteTimeout    int `toml:"write_timeout"`
-	IdleTimeout     int `toml:"idle_timeout"`
-	ShutdownTimeout int `toml:"shutdown_timeout"`
+	HTTPPort         int `toml:"http_port"`
+	InternalHTTPPort int `toml:"internal_http_port"` // отдельный порт для /internal (0 - общий с HTTPPort)
+	ReadTimeout      int `toml:"read_timeout"`
+	WriteTimeout     int `toml:"write_timeout"`
+	IdleTimeout      int `toml:"idle_timeout"`
+	ShutdownTimeout  int `toml:"shutdown_timeout"`
 }
 
 // DatabaseConfig содержит настройки подключения к PostgreSQL
@@ -43,6 +58,110 @@ type DatabaseConfig struct {
 	ConnMaxLifetime int    `toml:"conn_max_lifetime"`
 }
 
+// AuthConfig содержит настройки входа через Telegram Mini App, выпуска и проверки JWT
+// Если bot_token не задан, эндпоинты /auth/* не регистрируются
+// По умолчанию mode = "jwt"; mode = "header" оставляет аутентификацию по X-User-ID/X-User-Role (только для локальной разработки)
+type AuthConfig struct {
+	BotToken        string `toml:"bot_token"`
+	JWTSecret       string `toml:"jwt_secret"`
+	Issuer          string `toml:"issuer"`
+	AccessTokenTTL  int    `toml:"access_token_ttl"`  // секунды
+	RefreshTokenTTL int    `toml:"refresh_token_ttl"` // секунды
+	InitDataMaxAge  int    `toml:"init_data_max_age"` // секунды, максимальный возраст auth_date
+
+	Mode                string `toml:"mode"`                  // jwt | header
+	PublicKeyFile       string `toml:"public_key_file"`       // PEM файл с публичным ключом RS256
+	JWKSFile            string `toml:"jwks_file"`             // локальный JWKS файл
+	JWKSURL             string `toml:"jwks_url"`              // JWKS endpoint
+	JWKSRefreshInterval int    `toml:"jwks_refresh_interval"` // секунды
+	Leeway              int    `toml:"leeway"`                // секунды
+}
+
+// Enabled проверяет, включён ли вход через Telegram
+func (a AuthConfig) Enabled() bool {
+	return a.BotToken != ""
+}
+
+// CarClassesConfig содержит настройки справочника классов автомобилей (марка/модель -> класс)
+type CarClassesConfig struct {
+	File     string `toml:"file"`      // CSV файл brand,model,class (пусто - встроенный справочник)
+	AutoFill bool   `toml:"auto_fill"` // заполнять класс по справочнику, если пользователь его не указал
+}
+
+// SMSSenderLog отправитель, который пишет SMS в лог (только для локальной разработки)
+const SMSSenderLog = "log"
+
+// PhoneVerificationConfig содержит настройки подтверждения номера телефона кодом из SMS
+type PhoneVerificationConfig struct {
+	Sender            string `toml:"sender"`               // способ отправки SMS: log
+	CodeSecret        string `toml:"code_secret"`          // ключ HMAC для хранения кодов
+	CodeLength        int    `toml:"code_length"`          // количество цифр в коде
+	CodeTTL           int    `toml:"code_ttl"`             // секунды, время действия кода
+	MaxAttempts       int    `toml:"max_attempts"`         // неверных попыток на один код
+	ResendInterval    int    `toml:"resend_interval"`      // секунды, минимальный интервал между кодами на номер
+	SendWindow        int    `toml:"send_window"`          // секунды, окно ограничения количества кодов на номер
+	MaxSendsPerWindow int    `toml:"max_sends_per_window"` // максимум кодов на номер за окно
+}
+
+// AccountDeletionConfig содержит настройки выгрузки данных и удаления аккаунта
+// Пустой URL сервиса означает, что его данные не выгружаются и не удаляются
+type AccountDeletionConfig struct {
+	GracePeriod            int    `toml:"grace_period"`             // дни между запросом удаления и анонимизацией
+	PurgeInterval          int    `toml:"purge_interval"`           // секунды, период запуска задачи анонимизации
+	PurgeBatchSize         int    `toml:"purge_batch_size"`         // максимум аккаунтов за один запуск
+	RequestTimeout         int    `toml:"request_timeout"`          // секунды, таймаут запросов к другим сервисам
+	SellerServiceURL       string `toml:"seller_service_url"`       // базовый URL SellerService
+	NotificationServiceURL string `toml:"notification_service_url"` // базовый URL NotificationService
+}
+
+// HasDataSources проверяет, настроен ли хотя бы один сервис с данными пользователя
+func (a AccountDeletionConfig) HasDataSources() bool {
+	return a.SellerServiceURL != "" || a.NotificationServiceURL != ""
+}
+
+// InternalAuthConfig содержит настройки аутентификации межсервисных запросов к /internal
+// и учётные данные UserService для запросов к /internal эндпоинтам других сервисов
+// mode = "none" отключает проверку и подпись (только для локальной разработки)
+type InternalAuthConfig struct {
+	Mode         string            `toml:"mode"`           // hmac | api_key | none
+	MaxClockSkew int               `toml:"max_clock_skew"` // секунды, допустимое расхождение времени подписи
+	Keys         map[string]string `toml:"keys"`           // имя сервиса -> секрет (hmac) или ключ (api_key)
+	ServiceName  string            `toml:"service_name"`   // имя UserService в конфигурации вызываемых сервисов
+	Secret       string            `toml:"secret"`         // секрет (hmac) или ключ (api_key) для исходящих запросов
+}
+
+// Credentials преобразует настройки в учётные данные пакета svcauth для исходящих запросов
+func (a InternalAuthConfig) Credentials() svcauth.Credentials {
+	return svcauth.Credentials{
+		Mode:        a.Mode,
+		ServiceName: a.ServiceName,
+		Secret:      a.Secret,
+	}
+}
+
+// Verifier преобразует настройки в конфигурацию пакета svcauth
+func (a InternalAuthConfig) Verifier() svcauth.VerifierConfig {
+	return svcauth.VerifierConfig{
+		Mode:         a.Mode,
+		Keys:         a.Keys,
+		MaxClockSkew: time.Duration(a.MaxClockSkew) * time.Second,
+	}
+}
+
+// JWTAuth преобразует настройки проверки токенов в конфигурацию пакета jwtauth
+func (a AuthConfig) JWTAuth() jwtauth.Config {
+	return jwtauth.Config{
+		Mode:                a.Mode,
+		HMACSecret:          a.JWTSecret,
+		PublicKeyFile:       a.PublicKeyFile,
+		JWKSFile:            a.JWKSFile,
+		JWKSURL:             a.JWKSURL,
+		JWKSRefreshInterval: time.Duration(a.JWKSRefreshInterval) * time.Second,
+		Issuer:              a.Issuer,
+		Leeway:              time.Duration(a.Leeway) * time.Second,
+	}
+}
+
 // DSN формирует строку подключения к PostgreSQL
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
@@ -101,6 +220,65 @@ func overrideFromEnv(cfg *Config) {
 			cfg.Server.HTTPPort = port
 		}
 	}
+	if v := os.Getenv("INTERNAL_HTTP_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Server.InternalHTTPPort = port
+		}
+	}
+
+	// Internal auth
+	if v := os.Getenv("INTERNAL_AUTH_MODE"); v != "" {
+		cfg.InternalAuth.Mode = v
+	}
+	if v := os.Getenv("INTERNAL_AUTH_SECRET"); v != "" {
+		cfg.InternalAuth.Secret = v
+	}
+	// Формат: sellerservice=secret1,priceservice=secret2
+	if v := os.Getenv("INTERNAL_AUTH_KEYS"); v != "" {
+		keys := make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			name, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && name != "" {
+				keys[name] = key
+			}
+		}
+		cfg.InternalAuth.Keys = keys
+	}
+
+	// Account deletion
+	if v := os.Getenv("SELLER_SERVICE_URL"); v != "" {
+		cfg.AccountDeletion.SellerServiceURL = v
+	}
+	if v := os.Getenv("NOTIFICATION_SERVICE_URL"); v != "" {
+		cfg.AccountDeletion.NotificationServiceURL = v
+	}
+
+	// Auth
+	if v := os.Getenv("TELEGRAM_BOT_TOKEN"); v != "" {
+		cfg.Auth.BotToken = v
+	}
+	if v := os.Getenv("JWT_SECRET"); v != "" {
+		cfg.Auth.JWTSecret = v
+	}
+	if v := os.Getenv("AUTH_MODE"); v != "" {
+		cfg.Auth.Mode = v
+	}
+	if v := os.Getenv("JWKS_URL"); v != "" {
+		cfg.Auth.JWKSURL = v
+	}
+
+	// Phone verification
+	if v := os.Getenv("PHONE_CODE_SECRET"); v != "" {
+		cfg.PhoneVerification.CodeSecret = v
+	}
+	if v := os.Getenv("SMS_SENDER"); v != "" {
+		cfg.PhoneVerification.Sender = v
+	}
+
+	// Car classes
+	if v := os.Getenv("CAR_CLASSES_FILE"); v != "" {
+		cfg.CarClasses.File = v
+	}
 
 	// Logs
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
@@ -131,6 +309,12 @@ func validate(cfg *Config) error {
 	if cfg.Server.HTTPPort <= 0 || cfg.Server.HTTPPort > 65535 {
 		return fmt.Errorf("HTTP port must be between 1 and 65535")
 	}
+	if cfg.Server.InternalHTTPPort < 0 || cfg.Server.InternalHTTPPort > 65535 {
+		return fmt.Errorf("internal HTTP port must be between 1 and 65535 (or 0 to share HTTP port)")
+	}
+	if cfg.Server.InternalHTTPPort == cfg.Server.HTTPPort {
+		return fmt.Errorf("internal HTTP port must differ from HTTP port")
+	}
 
 	// Logs validation
 	if cfg.Logs.Level == "" {
@@ -165,5 +349,102 @@ func validate(cfg *Config) error {
 		cfg.Database.ConnMaxLifetime = 300 // 5 minutes
 	}
 
+	// Auth validation
+	if cfg.Auth.Enabled() && cfg.Auth.JWTSecret == "" {
+		return fmt.Errorf("auth jwt_secret is required when bot_token is set")
+	}
+	if cfg.Auth.Issuer == "" {
+		cfg.Auth.Issuer = "smc-userservice"
+	}
+	if cfg.Auth.AccessTokenTTL == 0 {
+		cfg.Auth.AccessTokenTTL = 900 // 15 minutes
+	}
+	if cfg.Auth.RefreshTokenTTL == 0 {
+		cfg.Auth.RefreshTokenTTL = 2592000 // 30 days
+	}
+	if cfg.Auth.InitDataMaxAge == 0 {
+		cfg.Auth.InitDataMaxAge = 86400 // 24 hours
+	}
+	// Режим header доверяет заголовкам клиента, поэтому включается только явно
+	if cfg.Auth.Mode == "" {
+		cfg.Auth.Mode = jwtauth.ModeJWT
+	}
+	if cfg.Auth.Mode != jwtauth.ModeJWT && cfg.Auth.Mode != jwtauth.ModeHeader {
+		return fmt.Errorf("auth mode must be %s or %s", jwtauth.ModeJWT, jwtauth.ModeHeader)
+	}
+	if cfg.Auth.Mode == jwtauth.ModeJWT &&
+		cfg.Auth.JWTSecret == "" && cfg.Auth.PublicKeyFile == "" && cfg.Auth.JWKSFile == "" && cfg.Auth.JWKSURL == "" {
+		return fmt.Errorf("auth mode jwt requires jwt_secret, public_key_file, jwks_file or jwks_url")
+	}
+	if cfg.Auth.JWKSRefreshInterval == 0 {
+		cfg.Auth.JWKSRefreshInterval = 300 // 5 minutes
+	}
+	if cfg.Auth.Leeway == 0 {
+		cfg.Auth.Leeway = 30
+	}
+
+	// Internal auth validation
+	if cfg.InternalAuth.Mode == "" {
+		cfg.InternalAuth.Mode = svcauth.ModeNone
+	}
+	if cfg.InternalAuth.MaxClockSkew == 0 {
+		cfg.InternalAuth.MaxClockSkew = 60
+	}
+	if cfg.InternalAuth.ServiceName == "" {
+		cfg.InternalAuth.ServiceName = "userservice"
+	}
+	// Учётные данные нужны только для запросов к другим сервисам
+	if cfg.AccountDeletion.HasDataSources() {
+		if err := cfg.InternalAuth.Credentials().Validate(); err != nil {
+			return fmt.Errorf("internal_auth: %w", err)
+		}
+	}
+
+	// Account deletion validation
+	if cfg.AccountDeletion.GracePeriod == 0 {
+		cfg.AccountDeletion.GracePeriod = 30
+	}
+	if cfg.AccountDeletion.GracePeriod < 0 {
+		return fmt.Errorf("account_deletion grace_period must not be negative")
+	}
+	if cfg.AccountDeletion.PurgeInterval == 0 {
+		cfg.AccountDeletion.PurgeInterval = 3600 // 1 hour
+	}
+	if cfg.AccountDeletion.PurgeBatchSize == 0 {
+		cfg.AccountDeletion.PurgeBatchSize = 100
+	}
+	if cfg.AccountDeletion.RequestTimeout == 0 {
+		cfg.AccountDeletion.RequestTimeout = 10
+	}
+
+	// Phone verification validation
+	if cfg.PhoneVerification.Sender == "" {
+		cfg.PhoneVerification.Sender = SMSSenderLog
+	}
+	if cfg.PhoneVerification.Sender != SMSSenderLog {
+		return fmt.Errorf("phone verification sender must be %s", SMSSenderLog)
+	}
+	if cfg.PhoneVerification.CodeLength == 0 {
+		cfg.PhoneVerification.CodeLength = 6
+	}
+	if cfg.PhoneVerification.CodeLength < 4 || cfg.PhoneVerification.CodeLength > 10 {
+		return fmt.Errorf("phone verification code_length must be between 4 and 10")
+	}
+	if cfg.PhoneVerification.CodeTTL == 0 {
+		cfg.PhoneVerification.CodeTTL = 300 // 5 minutes
+	}
+	if cfg.PhoneVerification.MaxAttempts == 0 {
+		cfg.PhoneVerification.MaxAttempts = 5
+	}
+	if cfg.PhoneVerification.ResendInterval == 0 {
+		cfg.PhoneVerification.ResendInterval = 60
+	}
+	if cfg.PhoneVerification.SendWindow == 0 {
+		cfg.PhoneVerification.SendWindow = 3600 // 1 hour
+	}
+	if cfg.PhoneVerification.MaxSendsPerWindow == 0 {
+		cfg.PhoneVerification.MaxSendsPerWindow = 5
+	}
+
 	return nil
 }
@@ -9,4 +9,7 @@ type Car struct {
 	Color        *string `json:"color,omitempty" db:"color"`
 	Size         *string `json:"size,omitempty" db:"size"`
 	IsSelected   bool    `json:"is_selected" db:"is_selected"`
+	// LicensePlateNormalized номер в каноническом виде (см. NormalizeLicensePlate)
+	// NULL только у старых записей, дублирующих другой автомобиль пользователя
+	LicensePlateNormalized *string `json:"license_plate_normalized,omitempty" db:"license_plate_normalized"`
 }
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// PlateFormat формат государственного регистрационного знака
+type PlateFormat string
+
+const (
+	PlateFormatRUPrivate PlateFormat = "ru_private" // А123ВС77 - легковые, грузовые, автобусы (ГОСТ Р 50577, тип 1)
+	PlateFormatRUTaxi    PlateFormat = "ru_taxi"    // АВ12377 - такси и маршрутные ТС (тип 1А)
+	PlateFormatRUTrailer PlateFormat = "ru_trailer" // АВ123477 - прицепы (тип 2)
+	PlateFormatRUMoto    PlateFormat = "ru_moto"    // 1234АВ77 - мотоциклы (тип 4)
+	PlateFormatRUTransit PlateFormat = "ru_transit" // АВ123С77 - транзитные (тип 5)
+	PlateFormatRUPolice  PlateFormat = "ru_police"  // А123477 - полиция (тип 6)
+	PlateFormatGeneric   PlateFormat = "generic"    // номера других стран: 2-12 латинских букв и цифр
+)
+
+// plateLetters буквы, допустимые в российских номерах (совпадают по начертанию с латинскими)
+const plateLetters = "ABEKMHOPCTYX"
+
+// plateHomoglyphs кириллические буквы российских номеров и их латинские двойники
+var plateHomoglyphs = strings.NewReplacer(
+	"А", "A", "В", "B", "Е", "E", "К", "K", "М", "M", "Н", "H",
+	"О", "O", "Р", "P", "С", "C", "Т", "T", "У", "Y", "Х", "X",
+)
+
+// plateSeparators символы, которые пользователи ставят между частями номера
+var plateSeparators = strings.NewReplacer(" ", "", "-", "", "_", "", ".", "", "|", "")
+
+var ruPlateFormats = []struct {
+	format  PlateFormat
+	pattern *regexp.Regexp
+}{
+	{PlateFormatRUPrivate, regexp.MustCompile(`^[` + plateLetters + `]\d{3}[` + plateLetters + `]{2}\d{2,3}$`)},
+	{PlateFormatRUTaxi, regexp.MustCompile(`^[` + plateLetters + `]{2}\d{3}\d{2,3}$`)},
+	{PlateFormatRUTrailer, regexp.MustCompile(`^[` + plateLetters + `]{2}\d{4}\d{2,3}$`)},
+	{PlateFormatRUMoto, regexp.MustCompile(`^\d{4}[` + plateLetters + `]{2}\d{2,3}$`)},
+	{PlateFormatRUTransit, regexp.MustCompile(`^[` + plateLetters + `]{2}\d{3}[` + plateLetters + `]\d{2,3}$`)},
+	{PlateFormatRUPolice, regexp.MustCompile(`^[` + plateLetters + `]\d{4}\d{2,3}$`)},
+}
+
+var genericPlatePattern = regexp.MustCompile(`^[A-Z0-9]{2,12}$`)
+
+var platePrefixPattern = regexp.MustCompile(`^[A-Z0-9]{1,12}$`)
+
+// NormalizeLicensePlate приводит номер к каноническому виду для поиска и проверки дубликатов:
+// верхний регистр, без пробелов и разделителей, кириллические буквы заменены латинскими двойниками
+// Номер с кириллицей должен соответствовать одному из российских форматов,
+// латинский номер, не подходящий под российский формат, проверяется по общему шаблону
+func NormalizeLicensePlate(plate string) (string, PlateFormat, bool) {
+	normalized := stripPlateSeparators(plate)
+	hasCyrillic := strings.IndexFunc(normalized, func(r rune) bool {
+		return unicode.Is(unicode.Cyrillic, r)
+	}) >= 0
+	normalized = plateHomoglyphs.Replace(normalized)
+
+	for _, f := range ruPlateFormats {
+		if f.pattern.MatchString(normalized) {
+			return normalized, f.format, true
+		}
+	}
+
+	// Кириллица допустима только в российских номерах
+	if hasCyrillic {
+		return "", "", false
+	}
+	if genericPlatePattern.MatchString(normalized) {
+		return normalized, PlateFormatGeneric, true
+	}
+
+	return "", "", false
+}
+
+// NormalizeLicensePlatePrefix приводит начало номера к каноническому виду для поиска по префиксу
+// Формат не проверяется: префикс может быть любой частью номера от первого символа
+func NormalizeLicensePlatePrefix(prefix string) (string, bool) {
+	normalized := plateHomoglyphs.Replace(stripPlateSeparators(prefix))
+	if !platePrefixPattern.MatchString(normalized) {
+		return "", false
+	}
+	return normalized, true
+}
+
+func stripPlateSeparators(plate string) string {
+	normalized := plateSeparators.Replace(strings.ToUpper(strings.TrimSpace(plate)))
+	return strings.Join(strings.Fields(normalized), "")
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// PhoneVerification запрос на подтверждение номера телефона одноразовым кодом
+// Код не хранится, только его HMAC
+type PhoneVerification struct {
+	ID          int64      `db:"id"`
+	TGUserID    int64      `db:"tg_user_id"`
+	PhoneNumber string     `db:"phone_number"`
+	CodeHash    string     `db:"code_hash"`
+	Attempts    int        `db:"attempts"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// IsExpired проверяет, что срок действия кода истёк
+func (v *PhoneVerification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// PhoneSendStats статистика отправки кодов на номер за окно ограничения
+type PhoneSendStats struct {
+	Count      int        `db:"count"`
+	LastSentAt *time.Time `db:"last_sent_at"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// RefreshToken выданный refresh токен (сам токен не хранится, только его jti)
+type RefreshToken struct {
+	JTI        string     `db:"jti"`
+	TGUserID   int64      `db:"tg_user_id"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	ReplacedBy *string    `db:"replaced_by"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// IsActive проверяет, что токен не отозван и не истёк
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// RoleChange запись журнала изменений ролей
+type RoleChange struct {
+	ID        int64     `db:"id"`
+	TGUserID  int64     `db:"tg_user_id"`
+	ChangedBy *int64    `db:"changed_by"` // nil - системное изменение
+	FromRole  Role      `db:"from_role"`
+	ToRole    Role      `db:"to_role"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// UserFilter фильтр списка пользователей
+type UserFilter struct {
+	Role   *Role
+	Limit  int
+	Offset int
+}
@@ -3,11 +3,20 @@ package domain
 import "time"
 
 type User struct {
-	TGUserID    int64     `json:"tg_user_id" db:"tg_user_id"`
-	Name        string    `json:"name" db:"name" validate:"required"`
-	PhoneNumber *string   `json:"phone_number" db:"phone_number" validate:"omitempty,e164"`
-	TGLink      *string   `json:"tg_link" db:"tg_link"`
-	RoleID      int       `json:"role_id" db:"role_id"`
-	Role        Role      `json:"role" db:"role_name"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	TGUserID    int64   `json:"tg_user_id" db:"tg_user_id"`
+	Name        string  `json:"name" db:"name" validate:"required"`
+	PhoneNumber *string `json:"phone_number" db:"phone_number" validate:"omitempty,e164"`
+	// PhoneVerifiedAt время подтверждения номера кодом из SMS (nil - номер не подтверждён)
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at" db:"phone_verified_at"`
+	TGLink          *string    `json:"tg_link" db:"tg_link"`
+	RoleID          int        `json:"role_id" db:"role_id"`
+	Role            Role       `json:"role" db:"role_name"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	// DeletionRequestedAt/DeletionScheduledAt заполнены, пока запрошено удаление аккаунта
+	// После DeletionScheduledAt аккаунт анонимизируется задачей очистки
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty" db:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"`
 }
+
+// AnonymizedUserName имя, которое получает пользователь после анонимизации
+const AnonymizedUserName = "Deleted user"
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// UserSortField поле сортировки каталога пользователей
+type UserSortField string
+
+const (
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortName      UserSortField = "name"
+)
+
+// IsValid проверяет, что сортировка по полю поддерживается
+func (f UserSortField) IsValid() bool {
+	switch f {
+	case UserSortCreatedAt, UserSortName:
+		return true
+	}
+	return false
+}
+
+// UserSearchFilter фильтр каталога пользователей с keyset пагинацией
+type UserSearchFilter struct {
+	Name        *string // подстрока имени без учёта регистра
+	PhonePrefix *string // начало номера в формате E.164 ("+7999")
+	PlatePrefix *string // начало номера автомобиля в каноническом виде (см. NormalizeLicensePlatePrefix)
+	Role        *Role
+	CreatedFrom *time.Time // включительно
+	CreatedTo   *time.Time // не включительно
+	Sort        UserSortField
+	Desc        bool
+	After       *UserCursor // nil - первая страница
+	Limit       int
+}
+
+// UserCursor позиция в каталоге: значение поля сортировки и tg_user_id последней записи страницы
+type UserCursor struct {
+	CreatedAt time.Time
+	Name      string
+	TGUserID  int64
+}
+
+// UserSearchResult пользователь каталога с количеством автомобилей
+type UserSearchResult struct {
+	User
+	CarsCount int `db:"cars_count"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "strings"
+
+// VehicleClass класс автомобиля (европейская классификация), совпадает с классами PriceService
+type VehicleClass string
+
+const (
+	VehicleClassA VehicleClass = "A" // мини-автомобили
+	VehicleClassB VehicleClass = "B" // малые
+	VehicleClassC VehicleClass = "C" // средние (гольф-класс)
+	VehicleClassD VehicleClass = "D" // большие средние
+	VehicleClassE VehicleClass = "E" // бизнес-класс
+	VehicleClassF VehicleClass = "F" // люксовые
+	VehicleClassJ VehicleClass = "J" // внедорожники
+	VehicleClassM VehicleClass = "M" // минивэны
+	VehicleClassS VehicleClass = "S" // спорткары
+)
+
+// VehicleClasses все поддерживаемые классы автомобилей
+var VehicleClasses = []VehicleClass{
+	VehicleClassA, VehicleClassB, VehicleClassC, VehicleClassD, VehicleClassE,
+	VehicleClassF, VehicleClassJ, VehicleClassM, VehicleClassS,
+}
+
+// cyrillicClassLetters кириллические буквы, которые пользователи вводят вместо латинских
+var cyrillicClassLetters = strings.NewReplacer(
+	"А", "A", "В", "B", "С", "C", "Е", "E", "М", "M",
+)
+
+// IsValid проверяет, что класс автомобиля поддерживается
+func (c VehicleClass) IsValid() bool {
+	for _, class := range VehicleClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseVehicleClass приводит введённое значение к классу автомобиля
+// Допускает пробелы по краям, нижний регистр и кириллические буквы-двойники ("с" -> "C")
+func ParseVehicleClass(value string) (VehicleClass, bool) {
+	normalized := cyrillicClassLetters.Replace(strings.ToUpper(strings.TrimSpace(value)))
+	class := VehicleClass(normalized)
+	if !class.IsValid() {
+		return "", false
+	}
+	return class, true
+}
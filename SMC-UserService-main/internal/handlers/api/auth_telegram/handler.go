@@ -0,0 +1,58 @@
+package auth_telegram
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	authservice "github.com/m04kA/SMC-UserService/internal/service/auth"
+	"github.com/m04kA/SMC-UserService/internal/service/auth/models"
+)
+
+type Handler struct {
+	service *authservice.Service
+	log     Logger
+}
+
+func NewHandler(service *authservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /auth/telegram
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var input models.TelegramLoginInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /auth/telegram - Invalid request body: %v", err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if input.InitData == "" {
+		h.log.Warn("POST /auth/telegram - Missing init_data")
+		api.RespondBadRequest(w, "init_data is required")
+		return
+	}
+
+	result, err := h.service.LoginTelegram(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, authservice.ErrInitDataExpired) {
+			h.log.Warn("POST /auth/telegram - Init data expired")
+			api.RespondUnauthorized(w, "Telegram init data expired")
+			return
+		}
+		if errors.Is(err, authservice.ErrInvalidInitData) {
+			h.log.Warn("POST /auth/telegram - Invalid init data: %v", err)
+			api.RespondUnauthorized(w, "Invalid Telegram init data")
+			return
+		}
+		h.log.Error("POST /auth/telegram - Failed to login: error=%v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /auth/telegram - User logged in: tg_user_id=%d, new_user=%t", result.User.TGUserID, result.IsNewUser)
+	api.RespondJSON(w, http.StatusOK, result)
+}
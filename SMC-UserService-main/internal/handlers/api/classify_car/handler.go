@@ -0,0 +1,40 @@
+package classify_car
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /cars/classify?brand=&model=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	brand := strings.TrimSpace(r.URL.Query().Get("brand"))
+	model := strings.TrimSpace(r.URL.Query().Get("model"))
+	if brand == "" {
+		h.log.Warn("GET /cars/classify - Brand missing")
+		api.RespondBadRequest(w, "Brand is required")
+		return
+	}
+
+	result := h.service.ClassifyCar(brand, model)
+	if result.Size == nil {
+		h.log.Info("GET /cars/classify - Class not found: brand=%s, model=%s", brand, model)
+	} else {
+		h.log.Info("GET /cars/classify - Class suggested: brand=%s, model=%s, size=%s", brand, model, *result.Size)
+	}
+	api.RespondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,69 @@
+package confirm_phone
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	phoneservice "github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/internal/service/phone/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *phoneservice.Service
+	log     Logger
+}
+
+func NewHandler(service *phoneservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/phone/confirm
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/phone/confirm - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var input models.ConfirmVerificationInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /users/me/phone/confirm - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.service.ConfirmVerification(r.Context(), userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, userservice.ErrUserNotFound):
+			h.log.Warn("POST /users/me/phone/confirm - User not found: user_id=%d", userID)
+			api.RespondUserNotFound(w)
+		case errors.Is(err, phoneservice.ErrVerificationNotFound):
+			h.log.Warn("POST /users/me/phone/confirm - No pending verification: user_id=%d", userID)
+			api.RespondError(w, http.StatusNotFound, "Verification not found, request a new code")
+		case errors.Is(err, phoneservice.ErrCodeExpired):
+			h.log.Warn("POST /users/me/phone/confirm - Code expired: user_id=%d", userID)
+			api.RespondError(w, http.StatusGone, "Verification code expired, request a new code")
+		case errors.Is(err, phoneservice.ErrTooManyAttempts):
+			h.log.Warn("POST /users/me/phone/confirm - Too many attempts: user_id=%d", userID)
+			api.RespondError(w, http.StatusTooManyRequests, "Too many invalid attempts, request a new code")
+		case errors.Is(err, phoneservice.ErrInvalidCode):
+			h.log.Warn("POST /users/me/phone/confirm - Invalid code: user_id=%d, error=%v", userID, err)
+			api.RespondBadRequest(w, err.Error())
+		default:
+			h.log.Error("POST /users/me/phone/confirm - Failed to confirm verification: user_id=%d, error=%v", userID, err)
+			api.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/phone/confirm - Phone verified: user_id=%d", userID)
+	api.RespondJSON(w, http.StatusOK, user)
+}
@@ -45,6 +45,21 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			api.RespondUserNotFound(w)
 			return
 		}
+		if errors.Is(err, userservice.ErrInvalidPlate) {
+			h.log.Warn("POST /users/me/cars - Invalid license plate: user_id=%d", userID)
+			api.RespondBadRequest(w, "Invalid license plate")
+			return
+		}
+		if errors.Is(err, userservice.ErrCarAlreadyExists) {
+			h.log.Warn("POST /users/me/cars - Duplicate license plate: user_id=%d", userID)
+			api.RespondCarAlreadyExists(w)
+			return
+		}
+		if errors.Is(err, userservice.ErrInvalidCarSize) {
+			h.log.Warn("POST /users/me/cars - Invalid car size: user_id=%d", userID)
+			api.RespondBadRequest(w, err.Error())
+			return
+		}
 		h.log.Error("POST /users/me/cars - Failed to create car: user_id=%d, error=%v", userID, err)
 		api.RespondInternalError(w)
 		return
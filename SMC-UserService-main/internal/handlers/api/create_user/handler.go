@@ -37,6 +37,16 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			api.RespondUserAlreadyExists(w)
 			return
 		}
+		if errors.Is(err, userservice.ErrInvalidRole) {
+			h.log.Warn("POST /users - Invalid role: tg_user_id=%d, role=%s", input.TGUserID, input.Role)
+			api.RespondBadRequest(w, "Invalid role")
+			return
+		}
+		if errors.Is(err, userservice.ErrRoleNotAllowed) {
+			h.log.Warn("POST /users - Role not allowed for self-registration: tg_user_id=%d, role=%s", input.TGUserID, input.Role)
+			api.RespondError(w, http.StatusForbidden, "Self-registration is allowed only with client role")
+			return
+		}
 		h.log.Error("POST /users - Failed to create user: tg_user_id=%d, error=%v", input.TGUserID, err)
 		api.RespondInternalError(w)
 		return
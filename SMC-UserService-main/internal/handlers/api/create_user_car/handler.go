@@ -0,0 +1,73 @@
+package create_user_car
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /internal/users/{tg_user_id}/cars
+// Добавление автомобиля от имени пользователя (Telegram-бот NotificationService)
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userIDStr := mux.Vars(r)["tg_user_id"]
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("POST /internal/users/{tg_user_id}/cars - Invalid user_id format: %s", userIDStr)
+		api.RespondBadRequest(w, "Invalid user_id format")
+		return
+	}
+
+	var input models.CreateCarInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /internal/users/{tg_user_id}/cars - Invalid request body: user_id=%d, error=%v", userID, err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	car, err := h.service.CreateCar(r.Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			h.log.Warn("POST /internal/users/{tg_user_id}/cars - User not found: user_id=%d", userID)
+			api.RespondUserNotFound(w)
+			return
+		}
+		if errors.Is(err, userservice.ErrInvalidPlate) {
+			h.log.Warn("POST /internal/users/{tg_user_id}/cars - Invalid license plate: user_id=%d", userID)
+			api.RespondBadRequest(w, "Invalid license plate")
+			return
+		}
+		if errors.Is(err, userservice.ErrCarAlreadyExists) {
+			h.log.Warn("POST /internal/users/{tg_user_id}/cars - Duplicate license plate: user_id=%d", userID)
+			api.RespondCarAlreadyExists(w)
+			return
+		}
+		if errors.Is(err, userservice.ErrInvalidCarSize) {
+			h.log.Warn("POST /internal/users/{tg_user_id}/cars - Invalid car size: user_id=%d", userID)
+			api.RespondBadRequest(w, err.Error())
+			return
+		}
+		h.log.Error("POST /internal/users/{tg_user_id}/cars - Failed to create car: user_id=%d, error=%v", userID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /internal/users/{tg_user_id}/cars - Car created successfully: user_id=%d, car_id=%d", userID, car.ID)
+	api.RespondJSON(w, http.StatusCreated, car)
+}
@@ -3,18 +3,20 @@ package delete_current_user
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/m04kA/SMC-UserService/internal/handlers/api"
 	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	privacyservice "github.com/m04kA/SMC-UserService/internal/service/privacy"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 )
 
 type Handler struct {
-	service *userservice.Service
+	service *privacyservice.Service
 	log     Logger
 }
 
-func NewHandler(service *userservice.Service, log Logger) *Handler {
+func NewHandler(service *privacyservice.Service, log Logger) *Handler {
 	return &Handler{
 		service: service,
 		log:     log,
@@ -22,6 +24,7 @@ func NewHandler(service *userservice.Service, log Logger) *Handler {
 }
 
 // Handle DELETE /users/me
+// Аккаунт не удаляется сразу: он анонимизируется после периода ожидания, до этого удаление можно отменить
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserIDFromContext(r.Context())
 	if err != nil {
@@ -30,18 +33,22 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.DeleteUser(r.Context(), userID)
+	deletion, err := h.service.RequestDeletion(r.Context(), userID)
 	if err != nil {
-		if errors.Is(err, userservice.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, userservice.ErrUserNotFound):
 			h.log.Warn("DELETE /users/me - User not found: user_id=%d", userID)
 			api.RespondUserNotFound(w)
-			return
+		case errors.Is(err, privacyservice.ErrDeletionAlreadyScheduled), errors.Is(err, userservice.ErrLastSuperUser):
+			h.log.Warn("DELETE /users/me - Deletion rejected: user_id=%d, reason=%v", userID, err)
+			api.RespondError(w, http.StatusConflict, err.Error())
+		default:
+			h.log.Error("DELETE /users/me - Failed to schedule deletion: user_id=%d, error=%v", userID, err)
+			api.RespondInternalError(w)
 		}
-		h.log.Error("DELETE /users/me - Failed to delete user: user_id=%d, error=%v", userID, err)
-		api.RespondInternalError(w)
 		return
 	}
 
-	h.log.Info("DELETE /users/me - User deleted successfully: user_id=%d", userID)
-	w.WriteHeader(http.StatusNoContent)
+	h.log.Info("DELETE /users/me - Deletion scheduled: user_id=%d, scheduled_at=%s", userID, deletion.DeletionScheduledAt.Format(time.RFC3339))
+	api.RespondJSON(w, http.StatusAccepted, deletion)
 }
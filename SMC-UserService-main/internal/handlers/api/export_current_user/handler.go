@@ -0,0 +1,51 @@
+package export_current_user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	privacyservice "github.com/m04kA/SMC-UserService/internal/service/privacy"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *privacyservice.Service
+	log     Logger
+}
+
+func NewHandler(service *privacyservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/me/export
+// Возвращает JSON архив персональных данных пользователя из всех сервисов
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("GET /users/me/export - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	export, err := h.service.ExportUserData(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			h.log.Warn("GET /users/me/export - User not found: user_id=%d", userID)
+			api.RespondUserNotFound(w)
+			return
+		}
+		h.log.Error("GET /users/me/export - Failed to export user data: user_id=%d, error=%v", userID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /users/me/export - User data exported: user_id=%d, services=%d", userID, len(export.Services))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="smc-user-%d-export.json"`, userID))
+	api.RespondJSON(w, http.StatusOK, export)
+}
@@ -0,0 +1,46 @@
+package find_cars_by_plate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /cars?license_plate=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	plate := r.URL.Query().Get("license_plate")
+	if plate == "" {
+		h.log.Warn("GET /cars - License plate missing")
+		api.RespondBadRequest(w, "License plate is required")
+		return
+	}
+
+	result, err := h.service.FindCarsByPlate(r.Context(), plate)
+	if err != nil {
+		if errors.Is(err, userservice.ErrInvalidPlate) {
+			h.log.Warn("GET /cars - Invalid license plate: %s", plate)
+			api.RespondBadRequest(w, "Invalid license plate")
+			return
+		}
+		h.log.Error("GET /cars - Failed to find cars: license_plate=%s, error=%v", plate, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /cars - Cars found: license_plate=%s, count=%d", result.LicensePlateNormalized, len(result.Cars))
+	api.RespondJSON(w, http.StatusOK, result)
+}
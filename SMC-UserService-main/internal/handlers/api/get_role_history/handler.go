@@ -0,0 +1,49 @@
+package get_role_history
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users/{tg_user_id}/role/history
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	tgUserIDStr := mux.Vars(r)["tg_user_id"]
+	tgUserID, err := strconv.ParseInt(tgUserIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("GET /users/{tg_user_id}/role/history - Invalid user ID format: tg_user_id_str=%s", tgUserIDStr)
+		api.RespondBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	history, err := h.service.GetRoleHistory(r.Context(), tgUserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			h.log.Warn("GET /users/{tg_user_id}/role/history - User not found: tg_user_id=%d", tgUserID)
+			api.RespondUserNotFound(w)
+			return
+		}
+		h.log.Error("GET /users/{tg_user_id}/role/history - Failed to get role history: tg_user_id=%d, error=%v", tgUserID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /users/{tg_user_id}/role/history - Role history retrieved: tg_user_id=%d, count=%d", tgUserID, len(history.Changes))
+	api.RespondJSON(w, http.StatusOK, history)
+}
@@ -0,0 +1,48 @@
+package get_users_batch
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type Handler struct {
+	service *user.Service
+	log     Logger
+}
+
+func NewHandler(service *user.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /internal/users/batch
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var input models.UserBatchInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /internal/users/batch - invalid request body: %v", err)
+		api.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	batch, err := h.service.GetUsersBatch(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidBatch) {
+			h.log.Warn("POST /internal/users/batch - invalid request: %v", err)
+			api.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		h.log.Error("POST /internal/users/batch - failed to get users: %v", err)
+		api.RespondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.log.Info("POST /internal/users/batch - success: requested=%d, found=%d, missing=%d", len(input.TGUserIDs), len(batch.Users), len(batch.MissingIDs))
+	api.RespondJSON(w, http.StatusOK, batch)
+}
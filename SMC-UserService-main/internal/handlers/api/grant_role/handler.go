@@ -0,0 +1,76 @@
+package grant_role
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/{tg_user_id}/role
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	changedBy, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/{tg_user_id}/role - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	tgUserIDStr := mux.Vars(r)["tg_user_id"]
+	tgUserID, err := strconv.ParseInt(tgUserIDStr, 10, 64)
+	if err != nil {
+		h.log.Warn("POST /users/{tg_user_id}/role - Invalid user ID format: tg_user_id_str=%s", tgUserIDStr)
+		api.RespondBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var input models.GrantRoleInputDTO
+	if err = api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /users/{tg_user_id}/role - Invalid request body: tg_user_id=%d, error=%v", tgUserID, err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	change, err := h.service.GrantRole(r.Context(), tgUserID, changedBy, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, userservice.ErrUserNotFound):
+			h.log.Warn("POST /users/{tg_user_id}/role - User not found: tg_user_id=%d", tgUserID)
+			api.RespondUserNotFound(w)
+		case errors.Is(err, userservice.ErrInvalidRole),
+			errors.Is(err, userservice.ErrReasonRequired),
+			errors.Is(err, userservice.ErrSelfRoleChange):
+			h.log.Warn("POST /users/{tg_user_id}/role - Invalid request: tg_user_id=%d, changed_by=%d, error=%v", tgUserID, changedBy, err)
+			api.RespondBadRequest(w, err.Error())
+		case errors.Is(err, userservice.ErrRoleUnchanged),
+			errors.Is(err, userservice.ErrLastSuperUser):
+			h.log.Warn("POST /users/{tg_user_id}/role - Conflict: tg_user_id=%d, changed_by=%d, error=%v", tgUserID, changedBy, err)
+			api.RespondError(w, http.StatusConflict, err.Error())
+		default:
+			h.log.Error("POST /users/{tg_user_id}/role - Failed to grant role: tg_user_id=%d, changed_by=%d, error=%v", tgUserID, changedBy, err)
+			api.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/{tg_user_id}/role - Role granted: tg_user_id=%d, changed_by=%d, from=%s, to=%s",
+		tgUserID, changedBy, change.FromRole, change.ToRole)
+	api.RespondJSON(w, http.StatusOK, change)
+}
@@ -45,6 +45,10 @@ func RespondCarNotFound(w http.ResponseWriter) {
 	RespondError(w, http.StatusNotFound, "Car not found")
 }
 
+func RespondCarAlreadyExists(w http.ResponseWriter) {
+	RespondError(w, http.StatusConflict, "Car with this license plate already exists")
+}
+
 func RespondCarAccessDenied(w http.ResponseWriter) {
 	RespondError(w, http.StatusForbidden, "Access denied to this car")
 }
@@ -0,0 +1,74 @@
+package list_users
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /users?role=&limit=&offset=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var role *domain.Role
+	if value := query.Get("role"); value != "" {
+		parsed := domain.Role(value)
+		role = &parsed
+	}
+
+	limit, err := parseIntParam(query.Get("limit"))
+	if err != nil {
+		h.log.Warn("GET /users - Invalid limit: %s", query.Get("limit"))
+		api.RespondBadRequest(w, "Invalid limit")
+		return
+	}
+	offset, err := parseIntParam(query.Get("offset"))
+	if err != nil {
+		h.log.Warn("GET /users - Invalid offset: %s", query.Get("offset"))
+		api.RespondBadRequest(w, "Invalid offset")
+		return
+	}
+
+	users, err := h.service.ListUsers(r.Context(), role, limit, offset)
+	if err != nil {
+		if errors.Is(err, userservice.ErrInvalidRole) {
+			h.log.Warn("GET /users - Invalid role filter: %s", query.Get("role"))
+			api.RespondBadRequest(w, "Invalid role")
+			return
+		}
+		h.log.Error("GET /users - Failed to list users: error=%v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("GET /users - Users listed: count=%d, limit=%d, offset=%d", len(users.Users), users.Limit, users.Offset)
+	api.RespondJSON(w, http.StatusOK, users)
+}
+
+// parseIntParam разбирает неотрицательный числовой параметр (пустая строка - 0)
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, errors.New("invalid integer parameter")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,53 @@
+package refresh_token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	authservice "github.com/m04kA/SMC-UserService/internal/service/auth"
+	"github.com/m04kA/SMC-UserService/internal/service/auth/models"
+)
+
+type Handler struct {
+	service *authservice.Service
+	log     Logger
+}
+
+func NewHandler(service *authservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /auth/refresh
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var input models.RefreshTokenInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /auth/refresh - Invalid request body: %v", err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if input.RefreshToken == "" {
+		h.log.Warn("POST /auth/refresh - Missing refresh_token")
+		api.RespondBadRequest(w, "refresh_token is required")
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, authservice.ErrInvalidRefreshToken) {
+			h.log.Warn("POST /auth/refresh - Invalid refresh token")
+			api.RespondUnauthorized(w, "Invalid or revoked refresh token")
+			return
+		}
+		h.log.Error("POST /auth/refresh - Failed to refresh tokens: error=%v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /auth/refresh - Tokens refreshed successfully")
+	api.RespondJSON(w, http.StatusOK, tokens)
+}
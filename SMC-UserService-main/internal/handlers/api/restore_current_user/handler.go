@@ -0,0 +1,53 @@
+package restore_current_user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	privacyservice "github.com/m04kA/SMC-UserService/internal/service/privacy"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *privacyservice.Service
+	log     Logger
+}
+
+func NewHandler(service *privacyservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/restore
+// Отменяет запрошенное удаление аккаунта, пока не истёк период ожидания
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/restore - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	err = h.service.CancelDeletion(r.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, userservice.ErrUserNotFound):
+			h.log.Warn("POST /users/me/restore - User not found: user_id=%d", userID)
+			api.RespondUserNotFound(w)
+		case errors.Is(err, privacyservice.ErrDeletionNotScheduled):
+			h.log.Warn("POST /users/me/restore - Deletion is not scheduled: user_id=%d", userID)
+			api.RespondError(w, http.StatusConflict, err.Error())
+		default:
+			h.log.Error("POST /users/me/restore - Failed to cancel deletion: user_id=%d, error=%v", userID, err)
+			api.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/restore - Deletion cancelled: user_id=%d", userID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+package revoke_token
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	authservice "github.com/m04kA/SMC-UserService/internal/service/auth"
+	"github.com/m04kA/SMC-UserService/internal/service/auth/models"
+)
+
+type Handler struct {
+	service *authservice.Service
+	log     Logger
+}
+
+func NewHandler(service *authservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /auth/revoke
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var input models.RevokeTokenInputDTO
+	if err := api.DecodeJSON(r, &input); err != nil {
+		h.log.Warn("POST /auth/revoke - Invalid request body: %v", err)
+		api.RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if input.RefreshToken == "" {
+		h.log.Warn("POST /auth/revoke - Missing refresh_token")
+		api.RespondBadRequest(w, "refresh_token is required")
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), input); err != nil {
+		if errors.Is(err, authservice.ErrInvalidRefreshToken) {
+			h.log.Warn("POST /auth/revoke - Invalid refresh token")
+			api.RespondUnauthorized(w, "Invalid refresh token")
+			return
+		}
+		h.log.Error("POST /auth/revoke - Failed to revoke token: error=%v", err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("POST /auth/revoke - Token revoked successfully: all_sessions=%t", input.AllSessions)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package search_users
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+const dateLayout = "2006-01-02"
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle GET /admin/users?name=&phone=&plate=&role=&created_from=&created_to=&sort=&order=&cursor=&limit=
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := models.UserDirectoryQueryDTO{
+		Name:   optionalParam(query.Get("name")),
+		Phone:  optionalParam(query.Get("phone")),
+		Plate:  optionalParam(query.Get("plate")),
+		Sort:   query.Get("sort"),
+		Order:  query.Get("order"),
+		Cursor: query.Get("cursor"),
+	}
+
+	if value := query.Get("role"); value != "" {
+		role := domain.Role(value)
+		input.Role = &role
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			h.log.Warn("GET /admin/users - Invalid limit: %s", value)
+			api.RespondBadRequest(w, "Invalid limit")
+			return
+		}
+		input.Limit = limit
+	}
+
+	createdFrom, err := parseTimeParam(query.Get("created_from"), false)
+	if err != nil {
+		h.log.Warn("GET /admin/users - Invalid created_from: %s", query.Get("created_from"))
+		api.RespondBadRequest(w, "Invalid created_from")
+		return
+	}
+	input.CreatedFrom = createdFrom
+
+	createdTo, err := parseTimeParam(query.Get("created_to"), true)
+	if err != nil {
+		h.log.Warn("GET /admin/users - Invalid created_to: %s", query.Get("created_to"))
+		api.RespondBadRequest(w, "Invalid created_to")
+		return
+	}
+	input.CreatedTo = createdTo
+
+	users, err := h.service.SearchUsers(r.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, userservice.ErrInvalidRole):
+			h.log.Warn("GET /admin/users - Invalid role filter: %s", query.Get("role"))
+			api.RespondBadRequest(w, "Invalid role")
+		case errors.Is(err, userservice.ErrInvalidPlate):
+			h.log.Warn("GET /admin/users - Invalid plate prefix: %s", query.Get("plate"))
+			api.RespondBadRequest(w, "Invalid plate")
+		case errors.Is(err, userservice.ErrInvalidSearch):
+			h.log.Warn("GET /admin/users - Invalid search query: %v", err)
+			api.RespondBadRequest(w, err.Error())
+		default:
+			h.log.Error("GET /admin/users - Failed to search users: error=%v", err)
+			api.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.log.Info("GET /admin/users - Users found: count=%d, limit=%d, has_next=%t", len(users.Users), users.Limit, users.NextCursor != nil)
+	api.RespondJSON(w, http.StatusOK, users)
+}
+
+func optionalParam(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// parseTimeParam принимает RFC3339 или дату YYYY-MM-DD
+// Для верхней границы дата включается целиком: 2024-05-01 означает "до 2024-05-02 00:00"
+func parseTimeParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return &parsed, nil
+}
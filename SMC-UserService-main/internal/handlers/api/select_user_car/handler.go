@@ -0,0 +1,63 @@
+package select_user_car
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *userservice.Service
+	log     Logger
+}
+
+func NewHandler(service *userservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle PUT /internal/users/{tg_user_id}/cars/{car_id}/select
+// Выбор автомобиля от имени пользователя: выбрать можно только свой автомобиль
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := strconv.ParseInt(vars["tg_user_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Invalid user_id format: %s", vars["tg_user_id"])
+		api.RespondBadRequest(w, "Invalid user_id format")
+		return
+	}
+	carID, err := strconv.ParseInt(vars["car_id"], 10, 64)
+	if err != nil {
+		h.log.Warn("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Invalid car_id: user_id=%d, car_id=%s", userID, vars["car_id"])
+		api.RespondBadRequest(w, "Invalid car_id")
+		return
+	}
+
+	// Права владельца, а не роль пользователя: сервис действует только от его имени
+	car, err := h.service.SetSelectedCar(r.Context(), userID, carID, domain.RoleClient)
+	if err != nil {
+		if errors.Is(err, userservice.ErrCarNotFound) {
+			h.log.Warn("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Car not found: user_id=%d, car_id=%d", userID, carID)
+			api.RespondError(w, http.StatusNotFound, "Car not found")
+			return
+		}
+		if errors.Is(err, userservice.ErrCarAccessDenied) {
+			h.log.Warn("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Access denied: user_id=%d, car_id=%d", userID, carID)
+			api.RespondCarAccessDenied(w)
+			return
+		}
+		h.log.Error("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Failed to select car: user_id=%d, car_id=%d, error=%v", userID, carID, err)
+		api.RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("PUT /internal/users/{tg_user_id}/cars/{car_id}/select - Car selected: user_id=%d, car_id=%d", userID, carID)
+	api.RespondJSON(w, http.StatusOK, car)
+}
@@ -69,6 +69,21 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			api.RespondCarNotFound(w)
 			return
 		}
+		if errors.Is(err, userservice.ErrInvalidPlate) {
+			h.log.Warn("PATCH /users/me/cars/{car_id} - Invalid license plate: user_id=%d, car_id=%d", userID, carID)
+			api.RespondBadRequest(w, "Invalid license plate")
+			return
+		}
+		if errors.Is(err, userservice.ErrCarAlreadyExists) {
+			h.log.Warn("PATCH /users/me/cars/{car_id} - Duplicate license plate: user_id=%d, car_id=%d", userID, carID)
+			api.RespondCarAlreadyExists(w)
+			return
+		}
+		if errors.Is(err, userservice.ErrInvalidCarSize) {
+			h.log.Warn("PATCH /users/me/cars/{car_id} - Invalid car size: user_id=%d, car_id=%d", userID, carID)
+			api.RespondBadRequest(w, err.Error())
+			return
+		}
 		if errors.Is(err, userservice.ErrCarAccessDenied) {
 			h.log.Warn("PATCH /users/me/cars/{car_id} - Access denied: user_id=%d, car_id=%d, role=%s", userID, carID, role)
 			api.RespondCarAccessDenied(w)
@@ -0,0 +1,73 @@
+package verify_phone
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-UserService/internal/handlers/api"
+	"github.com/m04kA/SMC-UserService/internal/handlers/middleware"
+	phoneservice "github.com/m04kA/SMC-UserService/internal/service/phone"
+	"github.com/m04kA/SMC-UserService/internal/service/phone/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+type Handler struct {
+	service *phoneservice.Service
+	log     Logger
+}
+
+func NewHandler(service *phoneservice.Service, log Logger) *Handler {
+	return &Handler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Handle POST /users/me/phone/verify
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		h.log.Warn("POST /users/me/phone/verify - Unauthorized access attempt")
+		api.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// Тело запроса необязательно: без него подтверждается номер из профиля
+	var input models.StartVerificationInputDTO
+	if r.ContentLength != 0 {
+		if err := api.DecodeJSON(r, &input); err != nil {
+			h.log.Warn("POST /users/me/phone/verify - Invalid request body: user_id=%d, error=%v", userID, err)
+			api.RespondBadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	verification, err := h.service.StartVerification(r.Context(), userID, input)
+	if err != nil {
+		var rateLimitErr *phoneservice.RateLimitError
+		switch {
+		case errors.Is(err, userservice.ErrUserNotFound):
+			h.log.Warn("POST /users/me/phone/verify - User not found: user_id=%d", userID)
+			api.RespondUserNotFound(w)
+		case errors.Is(err, phoneservice.ErrPhoneRequired), errors.Is(err, phoneservice.ErrInvalidPhone):
+			h.log.Warn("POST /users/me/phone/verify - Invalid phone: user_id=%d, error=%v", userID, err)
+			api.RespondBadRequest(w, err.Error())
+		case errors.Is(err, phoneservice.ErrPhoneAlreadyVerified):
+			h.log.Warn("POST /users/me/phone/verify - Phone already verified: user_id=%d", userID)
+			api.RespondError(w, http.StatusConflict, err.Error())
+		case errors.As(err, &rateLimitErr):
+			h.log.Warn("POST /users/me/phone/verify - Rate limited: user_id=%d, error=%v", userID, err)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+			api.RespondError(w, http.StatusTooManyRequests, rateLimitErr.Err.Error())
+		default:
+			h.log.Error("POST /users/me/phone/verify - Failed to start verification: user_id=%d, error=%v", userID, err)
+			api.RespondInternalError(w)
+		}
+		return
+	}
+
+	h.log.Info("POST /users/me/phone/verify - Verification code sent: user_id=%d", userID)
+	api.RespondJSON(w, http.StatusAccepted, verification)
+}
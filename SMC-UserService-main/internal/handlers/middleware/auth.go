@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/pkg/jwtauth"
 )
 
 type contextKey string
@@ -17,54 +17,89 @@ const (
 )
 
 var (
-	ErrMissingUserID = errors.New("missing X-User-ID header")
-	ErrInvalidUserID = errors.New("invalid user ID format")
-	ErrMissingRole   = errors.New("missing X-User-Role header")
-	ErrInvalidRole   = errors.New("invalid role")
+	ErrMissingCredentials = errors.New("missing authentication credentials")
+	ErrInvalidCredentials = errors.New("invalid authentication credentials")
+	ErrMissingUserID      = errors.New("missing X-User-ID header")
+	ErrInvalidUserID      = errors.New("invalid user ID format")
+	ErrMissingRole        = errors.New("missing X-User-Role header")
+	ErrInvalidRole        = errors.New("invalid role")
 )
 
-// UserIDAuth извлекает user ID и role из заголовков X-User-ID и X-User-Role
-func UserIDAuth(next http.Handler) http.Handler {
+// Authenticator извлекает идентичность пользователя из запроса (Bearer токен или заголовки X-User-*)
+type Authenticator interface {
+	Authenticate(r *http.Request) (*jwtauth.Identity, error)
+}
+
+// AuthMiddleware middleware аутентификации пользователей
+type AuthMiddleware struct {
+	authenticator Authenticator
+}
+
+// NewAuthMiddleware создаёт middleware аутентификации
+func NewAuthMiddleware(authenticator Authenticator) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator}
+}
+
+// UserIDAuth проверяет аутентификацию и сохраняет user ID и role (если есть) в контекст
+func (m *AuthMiddleware) UserIDAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userIDStr := r.Header.Get("X-User-ID")
-		if userIDStr == "" {
-			http.Error(w, ErrMissingUserID.Error(), http.StatusUnauthorized)
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				http.Error(w, ErrMissingCredentials.Error(), http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, ErrInvalidCredentials.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		ctx, err := withIdentity(r.Context(), identity)
 		if err != nil {
-			http.Error(w, ErrInvalidUserID.Error(), http.StatusBadRequest)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		ctx := r.Context()
-		roleStr := r.Header.Get("X-User-Role")
-		if roleStr != "" {
-			role := domain.Role(roleStr)
-			if !role.IsValid() {
-				http.Error(w, ErrInvalidRole.Error(), http.StatusBadRequest)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// OptionalAuth сохраняет user ID и role в контекст, если запрос аутентифицирован
+// В отличие от UserIDAuth, пропускает запросы без данных аутентификации, но отклоняет некорректные
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			if errors.Is(err, jwtauth.ErrNoCredentials) {
+				next.ServeHTTP(w, r)
 				return
 			}
-			ctx = context.WithValue(ctx, RoleKey, role)
+			http.Error(w, ErrInvalidCredentials.Error(), http.StatusUnauthorized)
+			return
 		}
 
-		// if roleStr == "" {
-		// 	http.Error(w, ErrMissingRole.Error(), http.StatusUnauthorized)
-		// 	return
-		// }
-
-		// role := domain.Role(roleStr)
-		// if !role.IsValid() {
-		// 	http.Error(w, ErrInvalidRole.Error(), http.StatusBadRequest)
-		// 	return
-		// }
+		ctx, err := withIdentity(r.Context(), identity)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		ctx = context.WithValue(ctx, UserIDKey, userID)
-		// ctx = context.WithValue(ctx, RoleKey, role)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// withIdentity сохраняет идентичность пользователя в контекст
+func withIdentity(ctx context.Context, identity *jwtauth.Identity) (context.Context, error) {
+	if identity.Role != "" {
+		role := domain.Role(identity.Role)
+		if !role.IsValid() {
+			return nil, ErrInvalidRole
+		}
+		ctx = context.WithValue(ctx, RoleKey, role)
+	}
+
+	return context.WithValue(ctx, UserIDKey, identity.UserID), nil
+}
+
 // GetUserIDFromContext извлекает user ID из контекста
 func GetUserIDFromContext(ctx context.Context) (int64, error) {
 	userID, ok := ctx.Value(UserIDKey).(int64)
@@ -0,0 +1,130 @@
+package carclass
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+// anyModel значение колонки model, задающее класс по умолчанию для всех моделей марки
+const anyModel = "*"
+
+//go:embed car_classes.csv
+var defaultDictionary []byte
+
+var (
+	ErrReadDictionary    = errors.New("failed to read car class dictionary")
+	ErrInvalidDictionary = errors.New("invalid car class dictionary")
+)
+
+// Dictionary справочник "марка/модель -> класс автомобиля"
+type Dictionary struct {
+	models map[string]domain.VehicleClass // ключ: марка + "|" + модель
+	brands map[string]domain.VehicleClass // класс по умолчанию для марки (model = "*")
+}
+
+// Load загружает справочник из CSV файла (brand,model,class)
+// Если путь пустой, используется справочник, встроенный в бинарник
+func Load(path string) (*Dictionary, error) {
+	if path == "" {
+		return Parse(strings.NewReader(string(defaultDictionary)))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReadDictionary, err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse разбирает CSV справочник. Первая строка - заголовок brand,model,class
+// Строки, начинающиеся с #, считаются комментариями
+func Parse(r io.Reader) (*Dictionary, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	dict := &Dictionary{
+		models: make(map[string]domain.VehicleClass),
+		brands: make(map[string]domain.VehicleClass),
+	}
+
+	header := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrReadDictionary, err)
+		}
+		if header {
+			header = false
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		brand := normalize(record[0])
+		model := strings.TrimSpace(record[1])
+		class, ok := domain.ParseVehicleClass(record[2])
+		if brand == "" || model == "" {
+			return nil, fmt.Errorf("%w: line %d: brand and model are required", ErrInvalidDictionary, line)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: unknown vehicle class %q", ErrInvalidDictionary, line, record[2])
+		}
+
+		if model == anyModel {
+			dict.brands[brand] = class
+			continue
+		}
+		dict.models[key(brand, normalize(model))] = class
+	}
+
+	return dict, nil
+}
+
+// Classify определяет класс автомобиля по марке и модели
+// Сначала ищется модель (с отбрасыванием уточнений в конце: "Camry 2.5 Hybrid" -> "Camry"),
+// затем класс по умолчанию для марки
+func (d *Dictionary) Classify(brand, model string) (domain.VehicleClass, bool) {
+	normalizedBrand := normalize(brand)
+	if normalizedBrand == "" {
+		return "", false
+	}
+
+	words := strings.Fields(normalize(model))
+	for n := len(words); n > 0; n-- {
+		if class, ok := d.models[key(normalizedBrand, strings.Join(words[:n], " "))]; ok {
+			return class, true
+		}
+	}
+
+	class, ok := d.brands[normalizedBrand]
+	return class, ok
+}
+
+// Len возвращает количество записей справочника
+func (d *Dictionary) Len() int {
+	return len(d.models) + len(d.brands)
+}
+
+// normalize приводит марку или модель к ключу справочника: нижний регистр, дефисы как пробелы
+func normalize(value string) string {
+	value = strings.ToLower(value)
+	value = strings.NewReplacer("-", " ", "_", " ").Replace(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func key(brand, model string) string {
+	return brand + "|" + model
+}
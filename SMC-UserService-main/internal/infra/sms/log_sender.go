@@ -0,0 +1,23 @@
+package sms
+
+import "context"
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+}
+
+// LogSender пишет SMS в лог вместо отправки (только для локальной разработки)
+type LogSender struct {
+	log Logger
+}
+
+func NewLogSender(log Logger) *LogSender {
+	return &LogSender{log: log}
+}
+
+// Send записывает сообщение в лог
+func (s *LogSender) Send(_ context.Context, phoneNumber, message string) error {
+	s.log.Info("SMS (log sender) to %s: %s", phoneNumber, message)
+	return nil
+}
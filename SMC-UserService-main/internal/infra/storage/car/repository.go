@@ -8,6 +8,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/m04kA/SMC-UserService/internal/domain"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
@@ -21,6 +22,13 @@ var (
 	ErrBuildQuery = errors.New("failed to build SQL query")
 )
 
+// uniqueLicensePlateConstraint уникальный индекс номера в пределах пользователя (миграция 010)
+const uniqueLicensePlateConstraint = "uq_cars_user_license_plate"
+
+var carColumns = []string{
+	"id", "user_id", "brand", "model", "license_plate", "license_plate_normalized", "color", "size", "is_selected",
+}
+
 type Repository struct {
 	db *sqlx.DB
 }
@@ -34,8 +42,8 @@ func NewRepository(executor *sqlx.DB) *Repository {
 // Create создает новый автомобиль и возвращает его с присвоенным ID
 func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car, error) {
 	query, args, err := psqlbuilder.Insert("cars").
-		Columns("user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
-		Values(car.UserID, car.Brand, car.Model, car.LicensePlate, car.Color, car.Size, car.IsSelected).
+		Columns("user_id", "brand", "model", "license_plate", "license_plate_normalized", "color", "size", "is_selected").
+		Values(car.UserID, car.Brand, car.Model, car.LicensePlate, car.LicensePlateNormalized, car.Color, car.Size, car.IsSelected).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -45,6 +53,9 @@ func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car,
 	var carID int64
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(&carID)
 	if err != nil {
+		if isLicensePlateConflict(err) {
+			return nil, userservice.ErrCarAlreadyExists
+		}
 		return nil, fmt.Errorf("%w: %v", ErrCreateCar, err)
 	}
 
@@ -54,7 +65,7 @@ func (r *Repository) Create(ctx context.Context, car *domain.Car) (*domain.Car,
 
 // GetByID получает автомобиль по ID
 func (r *Repository) GetByID(ctx context.Context, carID int64) (*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
 		Where(squirrel.Eq{"id": carID}).
 		ToSql()
@@ -76,7 +87,7 @@ func (r *Repository) GetByID(ctx context.Context, carID int64) (*domain.Car, err
 
 // GetByUserID получает все автомобили пользователя
 func (r *Repository) GetByUserID(ctx context.Context, userID int64) ([]*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
 		Where(squirrel.Eq{"user_id": userID}).
 		ToSql()
@@ -103,6 +114,7 @@ func (r *Repository) Update(ctx context.Context, car *domain.Car) error {
 		Set("brand", car.Brand).
 		Set("model", car.Model).
 		Set("license_plate", car.LicensePlate).
+		Set("license_plate_normalized", car.LicensePlateNormalized).
 		Set("color", car.Color).
 		Set("size", car.Size).
 		Set("is_selected", car.IsSelected).
@@ -114,6 +126,9 @@ func (r *Repository) Update(ctx context.Context, car *domain.Car) error {
 
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
+		if isLicensePlateConflict(err) {
+			return userservice.ErrCarAlreadyExists
+		}
 		return fmt.Errorf("%w: %v", ErrUpdateCar, err)
 	}
 
@@ -157,7 +172,7 @@ func (r *Repository) Delete(ctx context.Context, carID int64) error {
 
 // GetSelectedByUserID получает выбранный автомобиль пользователя
 func (r *Repository) GetSelectedByUserID(ctx context.Context, userID int64) (*domain.Car, error) {
-	query, args, err := psqlbuilder.Select("id", "user_id", "brand", "model", "license_plate", "color", "size", "is_selected").
+	query, args, err := psqlbuilder.Select(carColumns...).
 		From("cars").
 		Where(squirrel.Eq{"user_id": userID, "is_selected": true}).
 		ToSql()
@@ -177,6 +192,25 @@ func (r *Repository) GetSelectedByUserID(ctx context.Context, userID int64) (*do
 	return &car, nil
 }
 
+// GetSelectedByUserIDs получает выбранные автомобили нескольких пользователей
+func (r *Repository) GetSelectedByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error) {
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(squirrel.Eq{"user_id": userIDs, "is_selected": true}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	cars := make([]*domain.Car, 0, len(userIDs))
+	err = r.db.SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	return cars, nil
+}
+
 // UnselectAllByUserID снимает выбор со всех автомобилей пользователя
 func (r *Repository) UnselectAllByUserID(ctx context.Context, userID int64) error {
 	query, args, err := psqlbuilder.Update("cars").
@@ -194,3 +228,29 @@ func (r *Repository) UnselectAllByUserID(ctx context.Context, userID int64) erro
 
 	return nil
 }
+
+// GetByLicensePlate ищет автомобили всех пользователей по нормализованному номеру
+func (r *Repository) GetByLicensePlate(ctx context.Context, normalizedPlate string) ([]*domain.Car, error) {
+	query, args, err := psqlbuilder.Select(carColumns...).
+		From("cars").
+		Where(squirrel.Eq{"license_plate_normalized": normalizedPlate}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	cars := make([]*domain.Car, 0)
+	err = r.db.SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetCar, err)
+	}
+
+	return cars, nil
+}
+
+// isLicensePlateConflict проверяет, что запись нарушила уникальность номера в пределах пользователя
+func isLicensePlateConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == uniqueLicensePlateConstraint
+}
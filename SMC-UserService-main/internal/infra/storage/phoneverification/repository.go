@@ -0,0 +1,235 @@
+package phoneverification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	phoneservice "github.com/m04kA/SMC-UserService/internal/service/phone"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateVerification  = errors.New("failed to create phone verification in database")
+	ErrGetVerification     = errors.New("failed to get phone verification from database")
+	ErrUpdateVerification  = errors.New("failed to update phone verification in database")
+	ErrConfirmVerification = errors.New("failed to confirm phone verification in database")
+	ErrBuildQuery          = errors.New("failed to build SQL query")
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Create сохраняет новый код и завершает срок действия предыдущих неподтверждённых кодов пользователя
+func (r *Repository) Create(ctx context.Context, verification *domain.PhoneVerification) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: begin transaction: %v", ErrCreateVerification, err)
+	}
+	defer tx.Rollback()
+
+	expireQuery, expireArgs, err := psqlbuilder.Update("phone_verifications").
+		Set("expires_at", verification.CreatedAt).
+		Where(squirrel.Eq{"tg_user_id": verification.TGUserID, "confirmed_at": nil}).
+		Where(squirrel.Gt{"expires_at": verification.CreatedAt}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, expireQuery, expireArgs...); err != nil {
+		return fmt.Errorf("%w: expire previous codes: %v", ErrCreateVerification, err)
+	}
+
+	insertQuery, insertArgs, err := psqlbuilder.Insert("phone_verifications").
+		Columns("tg_user_id", "phone_number", "code_hash", "attempts", "expires_at", "created_at").
+		Values(
+			verification.TGUserID,
+			verification.PhoneNumber,
+			verification.CodeHash,
+			verification.Attempts,
+			verification.ExpiresAt,
+			verification.CreatedAt,
+		).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if err := tx.GetContext(ctx, &verification.ID, insertQuery, insertArgs...); err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateVerification, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: commit: %v", ErrCreateVerification, err)
+	}
+
+	return nil
+}
+
+// GetLatestPending возвращает последний неподтверждённый код пользователя (в том числе истёкший)
+func (r *Repository) GetLatestPending(ctx context.Context, tgUserID int64) (*domain.PhoneVerification, error) {
+	query, args, err := psqlbuilder.Select(
+		"id", "tg_user_id", "phone_number", "code_hash", "attempts", "expires_at", "confirmed_at", "created_at",
+	).
+		From("phone_verifications").
+		Where(squirrel.Eq{"tg_user_id": tgUserID, "confirmed_at": nil}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var verification domain.PhoneVerification
+	err = r.db.GetContext(ctx, &verification, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, phoneservice.ErrVerificationNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetVerification, err)
+	}
+
+	return &verification, nil
+}
+
+// ListByUserID возвращает все коды пользователя (новые первыми), используется для выгрузки данных
+func (r *Repository) ListByUserID(ctx context.Context, tgUserID int64) ([]*domain.PhoneVerification, error) {
+	query, args, err := psqlbuilder.Select(
+		"id", "tg_user_id", "phone_number", "code_hash", "attempts", "expires_at", "confirmed_at", "created_at",
+	).
+		From("phone_verifications").
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	verifications := make([]*domain.PhoneVerification, 0)
+	if err := r.db.SelectContext(ctx, &verifications, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetVerification, err)
+	}
+
+	return verifications, nil
+}
+
+// GetSendStats возвращает количество кодов, отправленных на номер с момента since, и время последней отправки
+func (r *Repository) GetSendStats(ctx context.Context, phoneNumber string, since time.Time) (*domain.PhoneSendStats, error) {
+	query, args, err := psqlbuilder.Select("COUNT(*) AS count", "MAX(created_at) AS last_sent_at").
+		From("phone_verifications").
+		Where(squirrel.Eq{"phone_number": phoneNumber}).
+		Where(squirrel.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var stats domain.PhoneSendStats
+	if err := r.db.GetContext(ctx, &stats, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetVerification, err)
+	}
+
+	return &stats, nil
+}
+
+// IncrementAttempts расходует попытку ввода кода и возвращает новое значение счётчика
+// Проверка лимита, срока действия и увеличение выполняются одним UPDATE, поэтому параллельные запросы
+// не превысят maxAttempts. Возвращает ErrVerificationNotFound, если код истёк, использован или попытки исчерпаны
+func (r *Repository) IncrementAttempts(ctx context.Context, id int64, maxAttempts int) (int, error) {
+	query, args, err := psqlbuilder.Update("phone_verifications").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Where(squirrel.Eq{"id": id, "confirmed_at": nil}).
+		Where(squirrel.Lt{"attempts": maxAttempts}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		Suffix("RETURNING attempts").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var attempts int
+	if err := r.db.GetContext(ctx, &attempts, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, phoneservice.ErrVerificationNotFound
+		}
+		return 0, fmt.Errorf("%w: %v", ErrUpdateVerification, err)
+	}
+
+	return attempts, nil
+}
+
+// Confirm в одной транзакции помечает код использованным и сохраняет подтверждённый номер пользователя
+// Попытка подтверждения уже учтена IncrementAttempts, поэтому допускается attempts <= maxAttempts
+func (r *Repository) Confirm(ctx context.Context, verification *domain.PhoneVerification, maxAttempts int, confirmedAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: begin transaction: %v", ErrConfirmVerification, err)
+	}
+	defer tx.Rollback()
+
+	// Условие confirmed_at IS NULL защищает от повторного использования кода параллельным запросом,
+	// attempts и expires_at - от подтверждения кода, который истёк или исчерпал попытки после чтения
+	confirmQuery, confirmArgs, err := psqlbuilder.Update("phone_verifications").
+		Set("confirmed_at", confirmedAt).
+		Where(squirrel.Eq{"id": verification.ID, "confirmed_at": nil}).
+		Where(squirrel.LtOrEq{"attempts": maxAttempts}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := tx.ExecContext(ctx, confirmQuery, confirmArgs...)
+	if err != nil {
+		return fmt.Errorf("%w: confirm code: %v", ErrConfirmVerification, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrConfirmVerification, err)
+	}
+	if rowsAffected == 0 {
+		return phoneservice.ErrVerificationNotFound
+	}
+
+	userQuery, userArgs, err := psqlbuilder.Update("users").
+		Set("phone_number", verification.PhoneNumber).
+		Set("phone_verified_at", confirmedAt).
+		Where(squirrel.Eq{"tg_user_id": verification.TGUserID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err = tx.ExecContext(ctx, userQuery, userArgs...)
+	if err != nil {
+		return fmt.Errorf("%w: update user: %v", ErrConfirmVerification, err)
+	}
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrConfirmVerification, err)
+	}
+	if rowsAffected == 0 {
+		return userservice.ErrUserNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: commit: %v", ErrConfirmVerification, err)
+	}
+
+	return nil
+}
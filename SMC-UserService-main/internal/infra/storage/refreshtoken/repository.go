@@ -0,0 +1,163 @@
+package refreshtoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	authservice "github.com/m04kA/SMC-UserService/internal/service/auth"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrCreateToken = errors.New("failed to create refresh token in database")
+	ErrGetToken    = errors.New("failed to get refresh token from database")
+	ErrRevokeToken = errors.New("failed to revoke refresh token in database")
+	ErrRotateToken = errors.New("failed to rotate refresh token in database")
+	ErrBuildQuery  = errors.New("failed to build SQL query")
+)
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// Create сохраняет выданный refresh токен
+func (r *Repository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.insert(ctx, r.db, token)
+}
+
+// GetByJTI находит refresh токен по jti
+func (r *Repository) GetByJTI(ctx context.Context, jti string) (*domain.RefreshToken, error) {
+	query, args, err := psqlbuilder.Select("jti", "tg_user_id", "expires_at", "revoked_at", "replaced_by", "created_at").
+		From("refresh_tokens").
+		Where(squirrel.Eq{"jti": jti}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var token domain.RefreshToken
+	err = r.db.GetContext(ctx, &token, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, authservice.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGetToken, err)
+	}
+
+	return &token, nil
+}
+
+// Rotate в одной транзакции отзывает активный токен oldJTI и сохраняет новый
+func (r *Repository) Rotate(ctx context.Context, oldJTI string, newToken *domain.RefreshToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: begin transaction: %v", ErrRotateToken, err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := psqlbuilder.Update("refresh_tokens").
+		Set("revoked_at", newToken.CreatedAt).
+		Set("replaced_by", newToken.JTI).
+		Where(squirrel.Eq{"jti": oldJTI, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRotateToken, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrRotateToken, err)
+	}
+
+	if rowsAffected == 0 {
+		return authservice.ErrRefreshTokenNotFound
+	}
+
+	if err := r.insert(ctx, tx, newToken); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: commit: %v", ErrRotateToken, err)
+	}
+
+	return nil
+}
+
+// ListByUserID возвращает все refresh токены пользователя (новые первыми), используется для выгрузки данных
+func (r *Repository) ListByUserID(ctx context.Context, tgUserID int64) ([]*domain.RefreshToken, error) {
+	query, args, err := psqlbuilder.Select("jti", "tg_user_id", "expires_at", "revoked_at", "replaced_by", "created_at").
+		From("refresh_tokens").
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	tokens := make([]*domain.RefreshToken, 0)
+	if err := r.db.SelectContext(ctx, &tokens, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetToken, err)
+	}
+
+	return tokens, nil
+}
+
+// Revoke отзывает refresh токен (повторный отзыв не является ошибкой)
+func (r *Repository) Revoke(ctx context.Context, jti string) error {
+	return r.revoke(ctx, squirrel.Eq{"jti": jti, "revoked_at": nil})
+}
+
+// RevokeAllByUserID отзывает все активные refresh токены пользователя
+func (r *Repository) RevokeAllByUserID(ctx context.Context, tgUserID int64) error {
+	return r.revoke(ctx, squirrel.Eq{"tg_user_id": tgUserID, "revoked_at": nil})
+}
+
+func (r *Repository) revoke(ctx context.Context, where squirrel.Eq) error {
+	query, args, err := psqlbuilder.Update("refresh_tokens").
+		Set("revoked_at", time.Now()).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrRevokeToken, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) insert(ctx context.Context, executor sqlx.ExecerContext, token *domain.RefreshToken) error {
+	query, args, err := psqlbuilder.Insert("refresh_tokens").
+		Columns("jti", "tg_user_id", "expires_at", "created_at").
+		Values(token.JTI, token.TGUserID, token.ExpiresAt, token.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrCreateToken, err)
+	}
+
+	return nil
+}
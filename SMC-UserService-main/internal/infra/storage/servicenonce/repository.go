@@ -0,0 +1,69 @@
+package servicenonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
+)
+
+var (
+	ErrUseNonce     = errors.New("failed to save service nonce in database")
+	ErrDeleteNonces = errors.New("failed to delete expired service nonces from database")
+	ErrBuildQuery   = errors.New("failed to build SQL query")
+)
+
+// Repository хранилище nonce межсервисных запросов, общее для всех экземпляров сервиса
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(executor *sqlx.DB) *Repository {
+	return &Repository{
+		db: executor,
+	}
+}
+
+// UseNonce запоминает nonce до expiresAt; false - nonce уже использован
+func (r *Repository) UseNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	query, args, err := psqlbuilder.Insert("service_nonces").
+		Columns("nonce", "expires_at").
+		Values(nonce, expiresAt).
+		Suffix("ON CONFLICT (nonce) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUseNonce, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUseNonce, err)
+	}
+
+	return inserted == 1, nil
+}
+
+// DeleteExpiredNonces удаляет nonce, срок хранения которых истёк до before
+func (r *Repository) DeleteExpiredNonces(ctx context.Context, before time.Time) error {
+	query, args, err := psqlbuilder.Delete("service_nonces").
+		Where(squirrel.Lt{"expires_at": before}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeleteNonces, err)
+	}
+
+	return nil
+}
@@ -5,23 +5,49 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	"github.com/m04kA/SMC-UserService/internal/domain"
+	privacyservice "github.com/m04kA/SMC-UserService/internal/service/privacy"
 	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
 	"github.com/m04kA/SMC-UserService/pkg/psqlbuilder"
 )
 
 var (
-	ErrCreateUser      = errors.New("failed to create user in database")
-	ErrGetUser         = errors.New("failed to get user from database")
-	ErrUpdateUser      = errors.New("failed to update user in database")
-	ErrDeleteUser      = errors.New("failed to delete user from database")
-	ErrGetSuperUsers   = errors.New("failed to get super users from database")
-	ErrBuildQuery      = errors.New("failed to build SQL query")
+	ErrCreateUser       = errors.New("failed to create user in database")
+	ErrGetUser          = errors.New("failed to get user from database")
+	ErrUpdateUser       = errors.New("failed to update user in database")
+	ErrScheduleDeletion = errors.New("failed to schedule user deletion in database")
+	ErrCancelDeletion   = errors.New("failed to cancel user deletion in database")
+	ErrAnonymizeUser    = errors.New("failed to anonymize user in database")
+	ErrGetSuperUsers    = errors.New("failed to get super users from database")
+	ErrListUsers        = errors.New("failed to list users from database")
+	ErrSearchUsers      = errors.New("failed to search users in database")
+	ErrChangeRole       = errors.New("failed to change user role in database")
+	ErrGetRoleChanges   = errors.New("failed to get role changes from database")
+	ErrBuildQuery       = errors.New("failed to build SQL query")
 )
 
+// userColumns колонки пользователя для выборки (таблица users u, роли roles r)
+var userColumns = []string{
+	"u.tg_user_id",
+	"u.name",
+	"u.phone_number",
+	"u.phone_verified_at",
+	"u.tg_link",
+	"u.role_id",
+	"r.name as role_name",
+	"u.created_at",
+	"u.deletion_requested_at",
+	"u.deletion_scheduled_at",
+}
+
+// anonymizedRoleChangeReason причина понижения роли в журнале role_changes при анонимизации
+const anonymizedRoleChangeReason = "account anonymized"
+
 type Repository struct {
 	db *sqlx.DB
 }
@@ -33,37 +59,51 @@ func NewRepository(executor *sqlx.DB) *Repository {
 }
 
 // Create сохраняет нового пользователя в базу данных
+// Строка анонимизированного пользователя с тем же tg_user_id переиспользуется (повторная регистрация)
 func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	query, args, err := psqlbuilder.Insert("users").
 		Columns("tg_user_id", "name", "phone_number", "tg_link", "role_id", "created_at").
 		Values(user.TGUserID, user.Name, user.PhoneNumber, user.TGLink, user.RoleID, user.CreatedAt).
+		Suffix(`ON CONFLICT (tg_user_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			phone_number = EXCLUDED.phone_number,
+			phone_verified_at = NULL,
+			tg_link = EXCLUDED.tg_link,
+			role_id = EXCLUDED.role_id,
+			created_at = EXCLUDED.created_at,
+			deletion_requested_at = NULL,
+			deletion_scheduled_at = NULL,
+			anonymized_at = NULL
+		WHERE users.anonymized_at IS NOT NULL`).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrCreateUser, err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrCreateUser, err)
+	}
+
+	// Конфликт с активным пользователем: условие WHERE не выполнено, строка не изменена
+	if rowsAffected == 0 {
+		return userservice.ErrUserAlreadyExists
+	}
+
 	return nil
 }
 
 // GetByTGID находит пользователя по Telegram ID
 func (r *Repository) GetByTGID(ctx context.Context, tgID int64) (*domain.User, error) {
-	query, args, err := psqlbuilder.Select(
-		"u.tg_user_id",
-		"u.name",
-		"u.phone_number",
-		"u.tg_link",
-		"u.role_id",
-		"r.name as role_name",
-		"u.created_at",
-	).
+	query, args, err := psqlbuilder.Select(userColumns...).
 		From("users u").
 		LeftJoin("roles r ON u.role_id = r.id").
-		Where(squirrel.Eq{"u.tg_user_id": tgID}).
+		Where(squirrel.Eq{"u.tg_user_id": tgID, "u.anonymized_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
@@ -81,13 +121,35 @@ func (r *Repository) GetByTGID(ctx context.Context, tgID int64) (*domain.User, e
 	return &user, nil
 }
 
+// GetByTGIDs получает пользователей по списку ID; отсутствующие ID пропускаются
+func (r *Repository) GetByTGIDs(ctx context.Context, tgIDs []int64) ([]*domain.User, error) {
+	query, args, err := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.tg_user_id": tgIDs, "u.anonymized_at": nil}).
+		OrderBy("u.tg_user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	users := make([]*domain.User, 0, len(tgIDs))
+	err = r.db.SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
+	}
+
+	return users, nil
+}
+
 // Update обновляет данные пользователя
 func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	query, args, err := psqlbuilder.Update("users").
 		Set("name", user.Name).
 		Set("phone_number", user.PhoneNumber).
+		Set("phone_verified_at", user.PhoneVerifiedAt).
 		Set("tg_link", user.TGLink).
-		Where(squirrel.Eq{"tg_user_id": user.TGUserID}).
+		Where(squirrel.Eq{"tg_user_id": user.TGUserID, "anonymized_at": nil}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
@@ -110,10 +172,261 @@ func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-// Delete удаляет пользователя
-func (r *Repository) Delete(ctx context.Context, tgID int64) error {
-	query, args, err := psqlbuilder.Delete("users").
-		Where(squirrel.Eq{"tg_user_id": tgID}).
+// GetSuperUsers возвращает список tg_user_id всех суперпользователей
+func (r *Repository) GetSuperUsers(ctx context.Context) ([]int64, error) {
+	query, args, err := psqlbuilder.Select("u.tg_user_id").
+		From("users u").
+		Where(squirrel.Eq{"u.role_id": domain.RoleIDSuperUser, "u.anonymized_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var userIDs []int64
+	err = r.db.SelectContext(ctx, &userIDs, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetSuperUsers, err)
+	}
+
+	return userIDs, nil
+}
+
+// List возвращает пользователей по фильтру (сортировка по дате регистрации)
+func (r *Repository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	builder := psqlbuilder.Select(userColumns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.anonymized_at": nil}).
+		OrderBy("u.created_at DESC", "u.tg_user_id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset))
+
+	if filter.Role != nil {
+		builder = builder.Where(squirrel.Eq{"r.name": string(*filter.Role)})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	users := make([]*domain.User, 0)
+	err = r.db.SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrListUsers, err)
+	}
+
+	return users, nil
+}
+
+// Search возвращает страницу каталога пользователей с количеством автомобилей
+// Пагинация по ключу: записи после filter.After в порядке (поле сортировки, tg_user_id)
+func (r *Repository) Search(ctx context.Context, filter domain.UserSearchFilter) ([]*domain.UserSearchResult, error) {
+	columns := append([]string{}, userColumns...)
+	columns = append(columns, "(SELECT COUNT(*) FROM cars c WHERE c.user_id = u.tg_user_id) AS cars_count")
+
+	builder := psqlbuilder.Select(columns...).
+		From("users u").
+		LeftJoin("roles r ON u.role_id = r.id").
+		Where(squirrel.Eq{"u.anonymized_at": nil})
+
+	if filter.Name != nil {
+		builder = builder.Where("u.name ILIKE ?", "%"+escapeLike(*filter.Name)+"%")
+	}
+	if filter.PhonePrefix != nil {
+		builder = builder.Where("u.phone_number LIKE ?", escapeLike(*filter.PhonePrefix)+"%")
+	}
+	if filter.PlatePrefix != nil {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM cars c WHERE c.user_id = u.tg_user_id AND c.license_plate_normalized LIKE ?)",
+			escapeLike(*filter.PlatePrefix)+"%",
+		)
+	}
+	if filter.Role != nil {
+		builder = builder.Where(squirrel.Eq{"r.name": string(*filter.Role)})
+	}
+	if filter.CreatedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"u.created_at": *filter.CreatedFrom})
+	}
+	if filter.CreatedTo != nil {
+		builder = builder.Where(squirrel.Lt{"u.created_at": *filter.CreatedTo})
+	}
+
+	sortColumn := "u.created_at"
+	if filter.Sort == domain.UserSortName {
+		sortColumn = "u.name"
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		var sortValue interface{} = filter.After.CreatedAt
+		if filter.Sort == domain.UserSortName {
+			sortValue = filter.After.Name
+		}
+		builder = builder.Where(
+			fmt.Sprintf("(%s, u.tg_user_id) %s (?, ?)", sortColumn, comparison),
+			sortValue, filter.After.TGUserID,
+		)
+	}
+
+	query, args, err := builder.
+		OrderBy(sortColumn+" "+direction, "u.tg_user_id "+direction).
+		Limit(uint64(filter.Limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	results := make([]*domain.UserSearchResult, 0)
+	if err := r.db.SelectContext(ctx, &results, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSearchUsers, err)
+	}
+
+	return results, nil
+}
+
+// ChangeRole в одной транзакции меняет роль пользователя и добавляет запись в журнал role_changes
+func (r *Repository) ChangeRole(ctx context.Context, tgUserID int64, toRoleID int, changedBy int64, reason string) (*domain.RoleChange, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: begin transaction: %v", ErrChangeRole, err)
+	}
+	defer tx.Rollback()
+
+	// Сначала блокируем всех суперпользователей, затем пользователя - единый порядок блокировок
+	// исключает взаимоблокировку при одновременном понижении двух суперпользователей
+	superUserIDs, err := lockSuperUsers(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	userQuery, userArgs, err := psqlbuilder.Select("role_id").
+		From("users").
+		Where(squirrel.Eq{"tg_user_id": tgUserID, "anonymized_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var fromRoleID int
+	if err := tx.GetContext(ctx, &fromRoleID, userQuery, userArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, userservice.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: lock user: %v", ErrChangeRole, err)
+	}
+
+	if fromRoleID == toRoleID {
+		return nil, userservice.ErrRoleUnchanged
+	}
+	if fromRoleID == domain.RoleIDSuperUser && len(superUserIDs) <= 1 {
+		return nil, userservice.ErrLastSuperUser
+	}
+
+	updateQuery, updateArgs, err := psqlbuilder.Update("users").
+		Set("role_id", toRoleID).
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+		return nil, fmt.Errorf("%w: update user: %v", ErrChangeRole, err)
+	}
+
+	changeID, err := insertRoleChange(ctx, tx, tgUserID, &changedBy, fromRoleID, toRoleID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChangeRole, err)
+	}
+
+	changes, err := r.selectRoleChanges(ctx, tx, squirrel.Eq{"rc.id": changeID})
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: role change %d not found after insert", ErrChangeRole, changeID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%w: commit: %v", ErrChangeRole, err)
+	}
+
+	return changes[0], nil
+}
+
+// GetRoleChanges возвращает журнал изменений роли пользователя (новые записи первыми)
+func (r *Repository) GetRoleChanges(ctx context.Context, tgUserID int64) ([]*domain.RoleChange, error) {
+	return r.selectRoleChanges(ctx, r.db, squirrel.Eq{"rc.tg_user_id": tgUserID})
+}
+
+func (r *Repository) selectRoleChanges(ctx context.Context, executor sqlx.QueryerContext, where squirrel.Eq) ([]*domain.RoleChange, error) {
+	query, args, err := psqlbuilder.Select(
+		"rc.id",
+		"rc.tg_user_id",
+		"rc.changed_by",
+		"fr.name as from_role",
+		"tr.name as to_role",
+		"rc.reason",
+		"rc.created_at",
+	).
+		From("role_changes rc").
+		Join("roles fr ON rc.from_role_id = fr.id").
+		Join("roles tr ON rc.to_role_id = tr.id").
+		Where(where).
+		OrderBy("rc.created_at DESC", "rc.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	changes := make([]*domain.RoleChange, 0)
+	if err := sqlx.SelectContext(ctx, executor, &changes, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetRoleChanges, err)
+	}
+
+	return changes, nil
+}
+
+// ScheduleDeletion сохраняет запрос на удаление аккаунта и время анонимизации
+func (r *Repository) ScheduleDeletion(ctx context.Context, tgUserID int64, requestedAt, scheduledAt time.Time) error {
+	query, args, err := psqlbuilder.Update("users").
+		Set("deletion_requested_at", requestedAt).
+		Set("deletion_scheduled_at", scheduledAt).
+		Where(squirrel.Eq{"tg_user_id": tgUserID, "anonymized_at": nil, "deletion_scheduled_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScheduleDeletion, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrScheduleDeletion, err)
+	}
+
+	if rowsAffected == 0 {
+		return privacyservice.ErrDeletionAlreadyScheduled
+	}
+
+	return nil
+}
+
+// CancelDeletion отменяет запрос на удаление аккаунта
+func (r *Repository) CancelDeletion(ctx context.Context, tgUserID int64) error {
+	query, args, err := psqlbuilder.Update("users").
+		Set("deletion_requested_at", nil).
+		Set("deletion_scheduled_at", nil).
+		Where(squirrel.Eq{"tg_user_id": tgUserID, "anonymized_at": nil}).
+		Where(squirrel.NotEq{"deletion_scheduled_at": nil}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
@@ -121,36 +434,170 @@ func (r *Repository) Delete(ctx context.Context, tgID int64) error {
 
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrDeleteUser, err)
+		return fmt.Errorf("%w: %v", ErrCancelDeletion, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%w: failed to get rows affected: %v", ErrDeleteUser, err)
+		return fmt.Errorf("%w: failed to get rows affected: %v", ErrCancelDeletion, err)
 	}
 
 	if rowsAffected == 0 {
-		return userservice.ErrUserNotFound
+		return privacyservice.ErrDeletionNotScheduled
 	}
 
 	return nil
 }
 
-// GetSuperUsers возвращает список tg_user_id всех суперпользователей
-func (r *Repository) GetSuperUsers(ctx context.Context) ([]int64, error) {
-	query, args, err := psqlbuilder.Select("u.tg_user_id").
-		From("users u").
-		Where(squirrel.Eq{"u.role_id": domain.RoleIDSuperUser}).
+// ListDueForAnonymization возвращает пользователей, у которых истёк срок ожидания удаления
+func (r *Repository) ListDueForAnonymization(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	query, args, err := psqlbuilder.Select("tg_user_id").
+		From("users").
+		Where(squirrel.Eq{"anonymized_at": nil}).
+		Where(squirrel.LtOrEq{"deletion_scheduled_at": now}).
+		OrderBy("deletion_scheduled_at", "tg_user_id").
+		Limit(uint64(limit)).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
 	}
 
-	var userIDs []int64
-	err = r.db.SelectContext(ctx, &userIDs, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrGetSuperUsers, err)
+	userIDs := make([]int64, 0)
+	if err := r.db.SelectContext(ctx, &userIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGetUser, err)
 	}
 
 	return userIDs, nil
 }
+
+// Anonymize стирает персональные данные пользователя, сохраняя строку users и журнал role_changes
+func (r *Repository) Anonymize(ctx context.Context, tgUserID int64, anonymizedAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: begin transaction: %v", ErrAnonymizeUser, err)
+	}
+	defer tx.Rollback()
+
+	// Порядок блокировок как в ChangeRole: сначала суперпользователи, затем пользователь
+	superUserIDs, err := lockSuperUsers(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAnonymizeUser, err)
+	}
+
+	userQuery, userArgs, err := psqlbuilder.Select("role_id").
+		From("users").
+		Where(squirrel.Eq{"tg_user_id": tgUserID, "anonymized_at": nil}).
+		Where(squirrel.LtOrEq{"deletion_scheduled_at": anonymizedAt}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var roleID int
+	if err := tx.GetContext(ctx, &roleID, userQuery, userArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return privacyservice.ErrDeletionNotScheduled
+		}
+		return fmt.Errorf("%w: lock user: %v", ErrAnonymizeUser, err)
+	}
+
+	if roleID == domain.RoleIDSuperUser && len(superUserIDs) <= 1 {
+		return userservice.ErrLastSuperUser
+	}
+
+	deletes := []struct {
+		table  string
+		column string
+	}{
+		{table: "cars", column: "user_id"},
+		{table: "phone_verifications", column: "tg_user_id"},
+		{table: "refresh_tokens", column: "tg_user_id"},
+	}
+	for _, d := range deletes {
+		query, args, err := psqlbuilder.Delete(d.table).
+			Where(squirrel.Eq{d.column: tgUserID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("%w: delete %s: %v", ErrAnonymizeUser, d.table, err)
+		}
+	}
+
+	if roleID != domain.RoleIDClient {
+		if _, err := insertRoleChange(ctx, tx, tgUserID, nil, roleID, domain.RoleIDClient, anonymizedRoleChangeReason); err != nil {
+			return fmt.Errorf("%w: %v", ErrAnonymizeUser, err)
+		}
+	}
+
+	updateQuery, updateArgs, err := psqlbuilder.Update("users").
+		Set("name", domain.AnonymizedUserName).
+		Set("phone_number", nil).
+		Set("phone_verified_at", nil).
+		Set("tg_link", nil).
+		Set("role_id", domain.RoleIDClient).
+		Set("anonymized_at", anonymizedAt).
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+		return fmt.Errorf("%w: update user: %v", ErrAnonymizeUser, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: commit: %v", ErrAnonymizeUser, err)
+	}
+
+	return nil
+}
+
+// lockSuperUsers блокирует строки активных суперпользователей в порядке tg_user_id
+func lockSuperUsers(ctx context.Context, tx *sqlx.Tx) ([]int64, error) {
+	query, args, err := psqlbuilder.Select("tg_user_id").
+		From("users").
+		Where(squirrel.Eq{"role_id": domain.RoleIDSuperUser, "anonymized_at": nil}).
+		OrderBy("tg_user_id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var superUserIDs []int64
+	if err := tx.SelectContext(ctx, &superUserIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("lock superusers: %v", err)
+	}
+
+	return superUserIDs, nil
+}
+
+// insertRoleChange добавляет запись в журнал role_changes (changedBy nil - системное изменение)
+func insertRoleChange(ctx context.Context, tx *sqlx.Tx, tgUserID int64, changedBy *int64, fromRoleID, toRoleID int, reason string) (int64, error) {
+	query, args, err := psqlbuilder.Insert("role_changes").
+		Columns("tg_user_id", "changed_by", "from_role_id", "to_role_id", "reason").
+		Values(tgUserID, changedBy, fromRoleID, toRoleID, reason).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrBuildQuery, err)
+	}
+
+	var changeID int64
+	if err := tx.GetContext(ctx, &changeID, query, args...); err != nil {
+		return 0, fmt.Errorf("insert role change: %v", err)
+	}
+
+	return changeID, nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE (экранирующий символ по умолчанию - обратная косая черта)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,95 @@
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/pkg/svcauth"
+)
+
+// Client клиент для выгрузки и удаления данных пользователя в другом сервисе
+// Все сервисы реализуют одинаковый контракт:
+// GET /internal/users/{tg_user_id}/export и DELETE /internal/users/{tg_user_id}
+type Client struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+	log        Logger
+}
+
+// NewClient создает клиент сервиса name
+// Запросы к /internal эндпоинтам подписываются учётными данными UserService
+func NewClient(name, baseURL string, timeout time.Duration, credentials svcauth.Credentials, log Logger) *Client {
+	return &Client{
+		name:    name,
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: svcauth.NewTransport(http.DefaultTransport, credentials),
+		},
+		log: log,
+	}
+}
+
+// Name возвращает имя сервиса (ключ раздела в выгрузке)
+func (c *Client) Name() string {
+	return c.name
+}
+
+// ExportUserData вызывает GET /internal/users/{tg_user_id}/export и возвращает ответ без разбора
+func (c *Client) ExportUserData(ctx context.Context, tgUserID int64) (json.RawMessage, error) {
+	url := fmt.Sprintf("%s/internal/users/%d/export", c.baseURL, tgUserID)
+
+	body, err := c.do(ctx, http.MethodGet, url)
+	if err != nil {
+		c.log.Error("%s unavailable, user data export is incomplete: user_id=%d, error=%v", c.name, tgUserID, err)
+		return nil, err
+	}
+
+	if !json.Valid(body) {
+		c.log.Error("%s returned invalid JSON for user data export: user_id=%d", c.name, tgUserID)
+		return nil, fmt.Errorf("%w: invalid JSON", ErrInvalidResponse)
+	}
+
+	return json.RawMessage(body), nil
+}
+
+// EraseUserData вызывает DELETE /internal/users/{tg_user_id}
+func (c *Client) EraseUserData(ctx context.Context, tgUserID int64) error {
+	url := fmt.Sprintf("%s/internal/users/%d", c.baseURL, tgUserID)
+
+	if _, err := c.do(ctx, http.MethodDelete, url); err != nil {
+		return err
+	}
+
+	c.log.Info("User data erased in %s: user_id=%d", c.name, tgUserID)
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, url string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read response: %v", ErrInvalidResponse, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,11 @@
+package userdata
+
+import "errors"
+
+var (
+	// ErrInternal возвращается при внутренних ошибках клиента
+	ErrInternal = errors.New("userdata client: internal error")
+
+	// ErrInvalidResponse возвращается при некорректном ответе от сервиса
+	ErrInvalidResponse = errors.New("userdata client: invalid response")
+)
@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/auth/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+	"github.com/m04kA/SMC-UserService/pkg/authtoken"
+	"github.com/m04kA/SMC-UserService/pkg/telegram"
+)
+
+const tokenTypeBearer = "Bearer"
+
+var (
+	ErrServiceLogin       = errors.New("service: failed to login")
+	ErrServiceIssueTokens = errors.New("service: failed to issue tokens")
+	ErrServiceRevokeToken = errors.New("service: failed to revoke token")
+)
+
+type Service struct {
+	userRepo       UserRepository
+	tokenRepo      RefreshTokenRepository
+	issuer         *authtoken.Issuer
+	botToken       string
+	initDataMaxAge time.Duration
+}
+
+func NewAuthService(ur UserRepository, tr RefreshTokenRepository, issuer *authtoken.Issuer, botToken string, initDataMaxAge time.Duration) *Service {
+	return &Service{
+		userRepo:       ur,
+		tokenRepo:      tr,
+		issuer:         issuer,
+		botToken:       botToken,
+		initDataMaxAge: initDataMaxAge,
+	}
+}
+
+// LoginTelegram проверяет initData Telegram Mini App и выдаёт пару токенов
+// Пользователь, которого ещё нет в системе, регистрируется с ролью client
+func (s *Service) LoginTelegram(ctx context.Context, input models.TelegramLoginInputDTO) (*models.TelegramLoginDTO, error) {
+	now := time.Now()
+
+	initData, err := telegram.ValidateInitData(input.InitData, s.botToken, s.initDataMaxAge, now)
+	if err != nil {
+		if errors.Is(err, telegram.ErrInitDataExpired) {
+			return nil, ErrInitDataExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInitData, err)
+	}
+
+	isNewUser := false
+	user, err := s.userRepo.GetByTGID(ctx, initData.User.ID)
+	if err != nil {
+		if !errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrServiceLogin, err)
+		}
+
+		user = newTelegramUser(initData.User, now)
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("%w: create user: %v", ErrServiceLogin, err)
+		}
+		isNewUser = true
+	}
+
+	pair, err := s.issueTokens(ctx, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TelegramLoginDTO{
+		TokenPairDTO: *pair,
+		User: usermodels.UserDTO{
+			TGUserID:            user.TGUserID,
+			Name:                user.Name,
+			PhoneNumber:         user.PhoneNumber,
+			PhoneVerifiedAt:     user.PhoneVerifiedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			TGLink:              user.TGLink,
+			Role:                user.Role,
+			CreatedAt:           user.CreatedAt,
+		},
+		IsNewUser: isNewUser,
+	}, nil
+}
+
+// Refresh обменивает refresh токен на новую пару токенов (refresh токен одноразовый)
+// Роль перечитывается из БД, поэтому её изменение применяется при следующем обновлении
+func (s *Service) Refresh(ctx context.Context, input models.RefreshTokenInputDTO) (*models.TokenPairDTO, error) {
+	now := time.Now()
+
+	stored, err := s.getStoredToken(ctx, input.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Повторное использование отозванного токена - признак утечки: отзываем все сессии пользователя
+	if stored.RevokedAt != nil {
+		if err := s.tokenRepo.RevokeAllByUserID(ctx, stored.TGUserID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrServiceRevokeToken, err)
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if !stored.IsActive(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByTGID(ctx, stored.TGUserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	access, refresh, err := s.newTokens(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tokenRepo.Rotate(ctx, stored.JTI, &domain.RefreshToken{
+		JTI:       refresh.ID,
+		TGUserID:  user.TGUserID,
+		ExpiresAt: refresh.ExpiresAt,
+		CreatedAt: now,
+	})
+	if err != nil {
+		// Токен уже обменян параллельным запросом
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	return tokenPair(access, refresh), nil
+}
+
+// Revoke отзывает refresh токен (или все refresh токены пользователя)
+// Выданные access токены действуют до истечения своего короткого срока
+func (s *Service) Revoke(ctx context.Context, input models.RevokeTokenInputDTO) error {
+	stored, err := s.getStoredToken(ctx, input.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if input.AllSessions {
+		err = s.tokenRepo.RevokeAllByUserID(ctx, stored.TGUserID)
+	} else {
+		err = s.tokenRepo.Revoke(ctx, stored.JTI)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceRevokeToken, err)
+	}
+
+	return nil
+}
+
+// getStoredToken проверяет подпись refresh токена и находит его запись
+func (s *Service) getStoredToken(ctx context.Context, refreshToken string) (*domain.RefreshToken, error) {
+	claims, err := s.issuer.Parse(refreshToken, authtoken.TypeRefresh)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := s.tokenRepo.GetByJTI(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	return stored, nil
+}
+
+// issueTokens выпускает пару токенов и сохраняет refresh токен
+func (s *Service) issueTokens(ctx context.Context, user *domain.User, now time.Time) (*models.TokenPairDTO, error) {
+	access, refresh, err := s.newTokens(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tokenRepo.Create(ctx, &domain.RefreshToken{
+		JTI:       refresh.ID,
+		TGUserID:  user.TGUserID,
+		ExpiresAt: refresh.ExpiresAt,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	return tokenPair(access, refresh), nil
+}
+
+func (s *Service) newTokens(user *domain.User, now time.Time) (*authtoken.Token, *authtoken.Token, error) {
+	access, err := s.issuer.IssueAccess(user.TGUserID, string(user.Role), now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	refresh, err := s.issuer.IssueRefresh(user.TGUserID, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrServiceIssueTokens, err)
+	}
+
+	return access, refresh, nil
+}
+
+func tokenPair(access, refresh *authtoken.Token) *models.TokenPairDTO {
+	return &models.TokenPairDTO{
+		TokenType:             tokenTypeBearer,
+		AccessToken:           access.Value,
+		AccessTokenExpiresAt:  access.ExpiresAt,
+		RefreshToken:          refresh.Value,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}
+}
+
+// newTelegramUser создаёт клиента из данных профиля Telegram
+func newTelegramUser(tgUser telegram.WebAppUser, now time.Time) *domain.User {
+	name := tgUser.FullName()
+	if name == "" {
+		name = tgUser.Username
+	}
+
+	var tgLink *string
+	if tgUser.Username != "" {
+		link := "https://t.me/" + tgUser.Username
+		tgLink = &link
+	}
+
+	return &domain.User{
+		TGUserID:  tgUser.ID,
+		Name:      name,
+		TGLink:    tgLink,
+		RoleID:    domain.RoleIDClient,
+		Role:      domain.RoleClient,
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+var (
+	ErrInvalidInitData      = errors.New("invalid telegram init data")
+	ErrInitDataExpired      = errors.New("telegram init data expired")
+	ErrInvalidRefreshToken  = errors.New("invalid or revoked refresh token")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+)
+
+// UserRepository определяет контракт для работы с хранилищем пользователей.
+type UserRepository interface {
+	Create(ctx context.Context, user *domain.User) error
+	GetByTGID(ctx context.Context, tgID int64) (*domain.User, error)
+}
+
+// RefreshTokenRepository определяет контракт для работы с хранилищем refresh токенов.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByJTI(ctx context.Context, jti string) (*domain.RefreshToken, error)
+	// Rotate атомарно отзывает активный токен oldJTI и сохраняет новый
+	// Возвращает ErrRefreshTokenNotFound, если oldJTI уже отозван
+	Rotate(ctx context.Context, oldJTI string, newToken *domain.RefreshToken) error
+	Revoke(ctx context.Context, jti string) error
+	RevokeAllByUserID(ctx context.Context, tgUserID int64) error
+}
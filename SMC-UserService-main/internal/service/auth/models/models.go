@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+// Auth DTOs
+
+type TelegramLoginInputDTO struct {
+	InitData string `json:"init_data" validate:"required"`
+}
+
+type RefreshTokenInputDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RevokeTokenInputDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	AllSessions  bool   `json:"all_sessions"` // отозвать все refresh токены пользователя
+}
+
+type TokenPairDTO struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type TelegramLoginDTO struct {
+	TokenPairDTO
+	User      usermodels.UserDTO `json:"user"`
+	IsNewUser bool               `json:"is_new_user"`
+}
@@ -0,0 +1,61 @@
+package phone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+var (
+	ErrPhoneRequired        = errors.New("phone number is required")
+	ErrInvalidPhone         = errors.New("invalid phone number: expected E.164 format")
+	ErrPhoneAlreadyVerified = errors.New("phone number is already verified")
+	ErrResendTooSoon        = errors.New("verification code was sent recently")
+	ErrSendLimitExceeded    = errors.New("too many verification codes sent to this phone number")
+	ErrVerificationNotFound = errors.New("verification not found")
+	ErrCodeExpired          = errors.New("verification code expired")
+	ErrTooManyAttempts      = errors.New("too many invalid attempts")
+	ErrInvalidCode          = errors.New("invalid verification code")
+)
+
+// RateLimitError ошибка ограничения частоты отправки кодов с временем, через которое можно повторить
+type RateLimitError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: retry after %s", e.Err, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// UserRepository определяет контракт для работы с хранилищем пользователей.
+type UserRepository interface {
+	GetByTGID(ctx context.Context, tgID int64) (*domain.User, error)
+}
+
+// VerificationRepository определяет контракт для работы с хранилищем кодов подтверждения.
+type VerificationRepository interface {
+	// Create сохраняет код и завершает срок действия предыдущих кодов пользователя
+	Create(ctx context.Context, verification *domain.PhoneVerification) error
+	// GetLatestPending возвращает ErrVerificationNotFound, если неподтверждённых кодов нет
+	GetLatestPending(ctx context.Context, tgUserID int64) (*domain.PhoneVerification, error)
+	GetSendStats(ctx context.Context, phoneNumber string, since time.Time) (*domain.PhoneSendStats, error)
+	// IncrementAttempts атомарно расходует попытку ввода кода и возвращает новое число попыток
+	// Возвращает ErrVerificationNotFound, если код истёк, уже использован или попытки исчерпаны
+	IncrementAttempts(ctx context.Context, id int64, maxAttempts int) (int, error)
+	// Confirm помечает код использованным и сохраняет подтверждённый номер пользователя
+	// Возвращает ErrVerificationNotFound, если код уже использован, истёк или попытки исчерпаны
+	Confirm(ctx context.Context, verification *domain.PhoneVerification, maxAttempts int, confirmedAt time.Time) error
+}
+
+// SMSSender отправляет SMS (реализация выбирается в конфигурации)
+type SMSSender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
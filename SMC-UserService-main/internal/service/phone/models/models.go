@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// StartVerificationInputDTO запрос кода подтверждения
+// Если номер не передан, подтверждается номер из профиля пользователя
+type StartVerificationInputDTO struct {
+	PhoneNumber *string `json:"phone_number" validate:"omitempty,e164"`
+}
+
+// ConfirmVerificationInputDTO ввод кода подтверждения
+type ConfirmVerificationInputDTO struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// VerificationDTO информация об отправленном коде (сам код не возвращается)
+type VerificationDTO struct {
+	PhoneNumber       string    `json:"phone_number"`
+	CodeLength        int       `json:"code_length"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	ResendAvailableAt time.Time `json:"resend_available_at"`
+}
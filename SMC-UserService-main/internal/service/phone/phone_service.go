@@ -0,0 +1,245 @@
+package phone
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/phone/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+	usermodels "github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+var (
+	ErrServiceStartVerification   = errors.New("service: failed to start phone verification")
+	ErrServiceConfirmVerification = errors.New("service: failed to confirm phone verification")
+	ErrServiceSendSMS             = errors.New("service: failed to send sms")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+
+// Config ограничения кодов подтверждения
+type Config struct {
+	CodeLength        int
+	CodeTTL           time.Duration
+	MaxAttempts       int           // неверных попыток на один код
+	ResendInterval    time.Duration // минимальный интервал между кодами на один номер
+	SendWindow        time.Duration // окно ограничения количества кодов на один номер
+	MaxSendsPerWindow int
+	CodeSecret        []byte // ключ HMAC для хранения кодов
+}
+
+type Service struct {
+	userRepo         UserRepository
+	verificationRepo VerificationRepository
+	sender           SMSSender
+	cfg              Config
+}
+
+func NewPhoneService(ur UserRepository, vr VerificationRepository, sender SMSSender, cfg Config) *Service {
+	return &Service{
+		userRepo:         ur,
+		verificationRepo: vr,
+		sender:           sender,
+		cfg:              cfg,
+	}
+}
+
+// StartVerification отправляет код подтверждения на номер из запроса или из профиля пользователя
+func (s *Service) StartVerification(ctx context.Context, tgUserID int64, input models.StartVerificationInputDTO) (*models.VerificationDTO, error) {
+	user, err := s.userRepo.GetByTGID(ctx, tgUserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceStartVerification, err)
+	}
+
+	phoneNumber, err := s.resolvePhone(user, input.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.checkSendLimits(ctx, phoneNumber, now); err != nil {
+		return nil, err
+	}
+
+	code, err := generateCode(s.cfg.CodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("%w: generate code: %v", ErrServiceStartVerification, err)
+	}
+
+	verification := &domain.PhoneVerification{
+		TGUserID:    tgUserID,
+		PhoneNumber: phoneNumber,
+		CodeHash:    s.hashCode(tgUserID, phoneNumber, code),
+		ExpiresAt:   now.Add(s.cfg.CodeTTL),
+		CreatedAt:   now,
+	}
+
+	// Код сохраняется до отправки: неудачная отправка тоже учитывается в ограничениях
+	if err := s.verificationRepo.Create(ctx, verification); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceStartVerification, err)
+	}
+
+	message := fmt.Sprintf("Код подтверждения SMC: %s. Никому не сообщайте его.", code)
+	if err := s.sender.Send(ctx, phoneNumber, message); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceSendSMS, err)
+	}
+
+	return &models.VerificationDTO{
+		PhoneNumber:       phoneNumber,
+		CodeLength:        s.cfg.CodeLength,
+		ExpiresAt:         verification.ExpiresAt,
+		ResendAvailableAt: now.Add(s.cfg.ResendInterval),
+	}, nil
+}
+
+// ConfirmVerification проверяет код и сохраняет подтверждённый номер в профиле пользователя
+func (s *Service) ConfirmVerification(ctx context.Context, tgUserID int64, input models.ConfirmVerificationInputDTO) (*usermodels.UserDTO, error) {
+	code := strings.TrimSpace(input.Code)
+	if code == "" {
+		return nil, ErrInvalidCode
+	}
+
+	verification, err := s.verificationRepo.GetLatestPending(ctx, tgUserID)
+	if err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceConfirmVerification, err)
+	}
+
+	now := time.Now()
+	if verification.IsExpired(now) {
+		return nil, ErrCodeExpired
+	}
+	if verification.Attempts >= s.cfg.MaxAttempts {
+		return nil, ErrTooManyAttempts
+	}
+
+	// Попытка расходуется до сравнения кода одним условным UPDATE: параллельные запросы
+	// не могут все увидеть attempts < MaxAttempts и проверить больше кодов, чем разрешено
+	attempts, err := s.verificationRepo.IncrementAttempts(ctx, verification.ID, s.cfg.MaxAttempts)
+	if err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, s.unusableVerificationError(verification)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceConfirmVerification, err)
+	}
+
+	expected := s.hashCode(tgUserID, verification.PhoneNumber, code)
+	if !hmac.Equal([]byte(expected), []byte(verification.CodeHash)) {
+		if attempts >= s.cfg.MaxAttempts {
+			return nil, ErrTooManyAttempts
+		}
+		return nil, fmt.Errorf("%w: %d attempts left", ErrInvalidCode, s.cfg.MaxAttempts-attempts)
+	}
+
+	if err := s.verificationRepo.Confirm(ctx, verification, s.cfg.MaxAttempts, now); err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, s.unusableVerificationError(verification)
+		}
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceConfirmVerification, err)
+	}
+
+	user, err := s.userRepo.GetByTGID(ctx, tgUserID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceConfirmVerification, err)
+	}
+
+	return &usermodels.UserDTO{
+		TGUserID:            user.TGUserID,
+		Name:                user.Name,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		TGLink:              user.TGLink,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
+	}, nil
+}
+
+// unusableVerificationError объясняет, почему код нельзя использовать, когда условный UPDATE не нашёл его
+// Истёкший код - ErrCodeExpired, иначе попытки исчерпаны или код использован параллельным запросом
+func (s *Service) unusableVerificationError(verification *domain.PhoneVerification) error {
+	if verification.IsExpired(time.Now()) {
+		return ErrCodeExpired
+	}
+	return ErrTooManyAttempts
+}
+
+// resolvePhone выбирает номер для подтверждения: из запроса или из профиля
+func (s *Service) resolvePhone(user *domain.User, requested *string) (string, error) {
+	var phoneNumber string
+	switch {
+	case requested != nil && strings.TrimSpace(*requested) != "":
+		phoneNumber = strings.TrimSpace(*requested)
+	case user.PhoneNumber != nil && *user.PhoneNumber != "":
+		phoneNumber = *user.PhoneNumber
+	default:
+		return "", ErrPhoneRequired
+	}
+
+	if !e164Pattern.MatchString(phoneNumber) {
+		return "", ErrInvalidPhone
+	}
+	if user.PhoneVerifiedAt != nil && user.PhoneNumber != nil && *user.PhoneNumber == phoneNumber {
+		return "", ErrPhoneAlreadyVerified
+	}
+
+	return phoneNumber, nil
+}
+
+// checkSendLimits проверяет интервал между кодами и количество кодов на номер за окно
+func (s *Service) checkSendLimits(ctx context.Context, phoneNumber string, now time.Time) error {
+	stats, err := s.verificationRepo.GetSendStats(ctx, phoneNumber, now.Add(-s.cfg.SendWindow))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrServiceStartVerification, err)
+	}
+
+	if stats.LastSentAt != nil {
+		if wait := stats.LastSentAt.Add(s.cfg.ResendInterval).Sub(now); wait > 0 {
+			return &RateLimitError{Err: ErrResendTooSoon, RetryAfter: wait}
+		}
+	}
+	if stats.Count >= s.cfg.MaxSendsPerWindow {
+		// Точное время освобождения окна не считаем: повторить можно не позже чем через окно
+		return &RateLimitError{Err: ErrSendLimitExceeded, RetryAfter: s.cfg.SendWindow}
+	}
+
+	return nil
+}
+
+// hashCode вычисляет HMAC кода, привязанный к пользователю и номеру
+func (s *Service) hashCode(tgUserID int64, phoneNumber, code string) string {
+	mac := hmac.New(sha256.New, s.cfg.CodeSecret)
+	fmt.Fprintf(mac, "%d:%s:%s", tgUserID, phoneNumber, code)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateCode генерирует случайный цифровой код заданной длины
+func generateCode(length int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
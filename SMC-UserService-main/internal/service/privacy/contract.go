@@ -0,0 +1,64 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+var (
+	ErrDeletionAlreadyScheduled = errors.New("account deletion is already scheduled")
+	ErrDeletionNotScheduled     = errors.New("account deletion is not scheduled")
+	ErrExternalErasure          = errors.New("failed to erase user data in external service")
+)
+
+// UserRepository определяет контракт для работы с хранилищем пользователей.
+type UserRepository interface {
+	GetByTGID(ctx context.Context, tgID int64) (*domain.User, error)
+	GetSuperUsers(ctx context.Context) ([]int64, error)
+	GetRoleChanges(ctx context.Context, tgUserID int64) ([]*domain.RoleChange, error)
+	// ScheduleDeletion возвращает ErrDeletionAlreadyScheduled, если удаление уже запрошено
+	ScheduleDeletion(ctx context.Context, tgUserID int64, requestedAt, scheduledAt time.Time) error
+	// CancelDeletion возвращает ErrDeletionNotScheduled, если удаление не запрошено
+	CancelDeletion(ctx context.Context, tgUserID int64) error
+	ListDueForAnonymization(ctx context.Context, now time.Time, limit int) ([]int64, error)
+	// Anonymize в одной транзакции удаляет автомобили, коды подтверждения и сессии пользователя,
+	// стирает персональные данные и понижает роль до client (с записью в role_changes)
+	// Возвращает ErrDeletionNotScheduled, если удаление отменено или срок ещё не наступил
+	Anonymize(ctx context.Context, tgUserID int64, anonymizedAt time.Time) error
+}
+
+// CarRepository определяет контракт для работы с хранилищем автомобилей.
+type CarRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]*domain.Car, error)
+}
+
+// VerificationRepository определяет контракт для работы с хранилищем кодов подтверждения.
+type VerificationRepository interface {
+	ListByUserID(ctx context.Context, tgUserID int64) ([]*domain.PhoneVerification, error)
+}
+
+// TokenRepository определяет контракт для работы с хранилищем refresh токенов.
+type TokenRepository interface {
+	ListByUserID(ctx context.Context, tgUserID int64) ([]*domain.RefreshToken, error)
+	RevokeAllByUserID(ctx context.Context, tgUserID int64) error
+}
+
+// DataSource сервис, который хранит данные пользователя у себя (SellerService, NotificationService)
+// Вызывается через /internal/users/{tg_user_id}/export и DELETE /internal/users/{tg_user_id}
+type DataSource interface {
+	Name() string
+	ExportUserData(ctx context.Context, tgUserID int64) (json.RawMessage, error)
+	// EraseUserData должен быть идемпотентным: задача очистки повторяет вызов при ошибках
+	EraseUserData(ctx context.Context, tgUserID int64) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+)
+
+// Статусы разделов выгрузки, полученных из других сервисов
+const (
+	SectionStatusOK          = "ok"
+	SectionStatusUnavailable = "unavailable"
+)
+
+// DeletionDTO запрос на удаление аккаунта
+type DeletionDTO struct {
+	TGUserID            int64     `json:"tg_user_id"`
+	DeletionRequestedAt time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// UserDataExportDTO выгрузка персональных данных пользователя
+type UserDataExportDTO struct {
+	ExportedAt         time.Time                    `json:"exported_at"`
+	User               ExportUserDTO                `json:"user"`
+	Cars               []ExportCarDTO               `json:"cars"`
+	RoleChanges        []ExportRoleChangeDTO        `json:"role_changes"`
+	PhoneVerifications []ExportPhoneVerificationDTO `json:"phone_verifications"`
+	Sessions           []ExportSessionDTO           `json:"sessions"`
+	// Services данные из других сервисов по имени сервиса
+	Services map[string]ExternalSectionDTO `json:"services"`
+}
+
+type ExportUserDTO struct {
+	TGUserID            int64       `json:"tg_user_id"`
+	Name                string      `json:"name"`
+	PhoneNumber         *string     `json:"phone_number"`
+	PhoneVerifiedAt     *time.Time  `json:"phone_verified_at"`
+	TGLink              *string     `json:"tg_link"`
+	Role                domain.Role `json:"role"`
+	CreatedAt           time.Time   `json:"created_at"`
+	DeletionRequestedAt *time.Time  `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time  `json:"deletion_scheduled_at"`
+}
+
+type ExportCarDTO struct {
+	ID           int64   `json:"id"`
+	Brand        string  `json:"brand"`
+	Model        string  `json:"model"`
+	LicensePlate string  `json:"license_plate"`
+	Color        *string `json:"color"`
+	Size         *string `json:"size"`
+	IsSelected   bool    `json:"is_selected"`
+}
+
+type ExportRoleChangeDTO struct {
+	ChangedBy *int64      `json:"changed_by"`
+	FromRole  domain.Role `json:"from_role"`
+	ToRole    domain.Role `json:"to_role"`
+	Reason    string      `json:"reason"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ExportPhoneVerificationDTO запрос кода подтверждения (HMAC кода не выгружается)
+type ExportPhoneVerificationDTO struct {
+	PhoneNumber string     `json:"phone_number"`
+	Attempts    int        `json:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ExportSessionDTO выданный refresh токен (идентификатор токена не выгружается)
+type ExportSessionDTO struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// ExternalSectionDTO раздел выгрузки из другого сервиса
+// При недоступности сервиса выгрузка формируется без его данных со статусом unavailable
+type ExternalSectionDTO struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
@@ -0,0 +1,247 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/privacy/models"
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+var (
+	ErrServiceGetUser  = errors.New("service: failed to get user")
+	ErrServiceExport   = errors.New("service: failed to export user data")
+	ErrServiceDeletion = errors.New("service: failed to process account deletion")
+)
+
+// Config настройки удаления аккаунта
+type Config struct {
+	GracePeriod    time.Duration // время между запросом удаления и анонимизацией
+	PurgeBatchSize int           // максимум пользователей за один запуск задачи очистки
+}
+
+type Service struct {
+	userRepo         UserRepository
+	carRepo          CarRepository
+	verificationRepo VerificationRepository
+	tokenRepo        TokenRepository
+	sources          []DataSource
+	cfg              Config
+	now              func() time.Time
+}
+
+func NewPrivacyService(
+	ur UserRepository,
+	cr CarRepository,
+	vr VerificationRepository,
+	tr TokenRepository,
+	sources []DataSource,
+	cfg Config,
+) *Service {
+	return &Service{
+		userRepo:         ur,
+		carRepo:          cr,
+		verificationRepo: vr,
+		tokenRepo:        tr,
+		sources:          sources,
+		cfg:              cfg,
+		now:              time.Now,
+	}
+}
+
+// RequestDeletion планирует анонимизацию аккаунта через GracePeriod и завершает все сессии
+// До наступления срока удаление можно отменить через CancelDeletion
+func (s *Service) RequestDeletion(ctx context.Context, tgID int64) (*models.DeletionDTO, error) {
+	user, err := s.getUser(ctx, tgID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionScheduledAt != nil {
+		return nil, ErrDeletionAlreadyScheduled
+	}
+
+	// Последний суперпользователь не может удалить аккаунт: иначе управлять ролями будет некому
+	if user.Role == domain.RoleSuperUser {
+		superUsers, err := s.userRepo.GetSuperUsers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrServiceDeletion, err)
+		}
+		if len(superUsers) <= 1 {
+			return nil, userservice.ErrLastSuperUser
+		}
+	}
+
+	requestedAt := s.now()
+	scheduledAt := requestedAt.Add(s.cfg.GracePeriod)
+	if err := s.userRepo.ScheduleDeletion(ctx, tgID, requestedAt, scheduledAt); err != nil {
+		if errors.Is(err, ErrDeletionAlreadyScheduled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceDeletion, err)
+	}
+
+	if err := s.tokenRepo.RevokeAllByUserID(ctx, tgID); err != nil {
+		return nil, fmt.Errorf("%w: revoke sessions: %v", ErrServiceDeletion, err)
+	}
+
+	return &models.DeletionDTO{
+		TGUserID:            tgID,
+		DeletionRequestedAt: requestedAt,
+		DeletionScheduledAt: scheduledAt,
+	}, nil
+}
+
+// CancelDeletion отменяет запрос на удаление аккаунта
+func (s *Service) CancelDeletion(ctx context.Context, tgID int64) error {
+	if _, err := s.getUser(ctx, tgID); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.CancelDeletion(ctx, tgID); err != nil {
+		if errors.Is(err, ErrDeletionNotScheduled) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceDeletion, err)
+	}
+
+	return nil
+}
+
+// DueForAnonymization возвращает пользователей, у которых истёк срок ожидания удаления
+func (s *Service) DueForAnonymization(ctx context.Context) ([]int64, error) {
+	userIDs, err := s.userRepo.ListDueForAnonymization(ctx, s.now(), s.cfg.PurgeBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceDeletion, err)
+	}
+	return userIDs, nil
+}
+
+// AnonymizeUser удаляет данные пользователя в других сервисах, затем анонимизирует аккаунт
+// При ошибке любого сервиса аккаунт не анонимизируется и будет обработан при следующем запуске
+func (s *Service) AnonymizeUser(ctx context.Context, tgID int64) error {
+	for _, source := range s.sources {
+		if err := source.EraseUserData(ctx, tgID); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrExternalErasure, source.Name(), err)
+		}
+	}
+
+	if err := s.userRepo.Anonymize(ctx, tgID, s.now()); err != nil {
+		if errors.Is(err, ErrDeletionNotScheduled) || errors.Is(err, userservice.ErrLastSuperUser) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrServiceDeletion, err)
+	}
+
+	return nil
+}
+
+// ExportUserData собирает персональные данные пользователя из UserService и других сервисов
+func (s *Service) ExportUserData(ctx context.Context, tgID int64) (*models.UserDataExportDTO, error) {
+	user, err := s.getUser(ctx, tgID)
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.carRepo.GetByUserID(ctx, tgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cars: %v", ErrServiceExport, err)
+	}
+
+	roleChanges, err := s.userRepo.GetRoleChanges(ctx, tgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: role changes: %v", ErrServiceExport, err)
+	}
+
+	verifications, err := s.verificationRepo.ListByUserID(ctx, tgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: phone verifications: %v", ErrServiceExport, err)
+	}
+
+	tokens, err := s.tokenRepo.ListByUserID(ctx, tgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sessions: %v", ErrServiceExport, err)
+	}
+
+	export := &models.UserDataExportDTO{
+		ExportedAt: s.now(),
+		User: models.ExportUserDTO{
+			TGUserID:            user.TGUserID,
+			Name:                user.Name,
+			PhoneNumber:         user.PhoneNumber,
+			PhoneVerifiedAt:     user.PhoneVerifiedAt,
+			TGLink:              user.TGLink,
+			Role:                user.Role,
+			CreatedAt:           user.CreatedAt,
+			DeletionRequestedAt: user.DeletionRequestedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+		},
+		Cars:               make([]models.ExportCarDTO, 0, len(cars)),
+		RoleChanges:        make([]models.ExportRoleChangeDTO, 0, len(roleChanges)),
+		PhoneVerifications: make([]models.ExportPhoneVerificationDTO, 0, len(verifications)),
+		Sessions:           make([]models.ExportSessionDTO, 0, len(tokens)),
+		Services:           make(map[string]models.ExternalSectionDTO, len(s.sources)),
+	}
+
+	for _, car := range cars {
+		export.Cars = append(export.Cars, models.ExportCarDTO{
+			ID:           car.ID,
+			Brand:        car.Brand,
+			Model:        car.Model,
+			LicensePlate: car.LicensePlate,
+			Color:        car.Color,
+			Size:         car.Size,
+			IsSelected:   car.IsSelected,
+		})
+	}
+	for _, change := range roleChanges {
+		export.RoleChanges = append(export.RoleChanges, models.ExportRoleChangeDTO{
+			ChangedBy: change.ChangedBy,
+			FromRole:  change.FromRole,
+			ToRole:    change.ToRole,
+			Reason:    change.Reason,
+			CreatedAt: change.CreatedAt,
+		})
+	}
+	for _, v := range verifications {
+		export.PhoneVerifications = append(export.PhoneVerifications, models.ExportPhoneVerificationDTO{
+			PhoneNumber: v.PhoneNumber,
+			Attempts:    v.Attempts,
+			ExpiresAt:   v.ExpiresAt,
+			ConfirmedAt: v.ConfirmedAt,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+	for _, token := range tokens {
+		export.Sessions = append(export.Sessions, models.ExportSessionDTO{
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+			RevokedAt: token.RevokedAt,
+		})
+	}
+
+	// Недоступность другого сервиса не прерывает выгрузку: раздел помечается как unavailable
+	for _, source := range s.sources {
+		data, err := source.ExportUserData(ctx, tgID)
+		if err != nil {
+			export.Services[source.Name()] = models.ExternalSectionDTO{Status: models.SectionStatusUnavailable}
+			continue
+		}
+		export.Services[source.Name()] = models.ExternalSectionDTO{Status: models.SectionStatusOK, Data: data}
+	}
+
+	return export, nil
+}
+
+func (s *Service) getUser(ctx context.Context, tgID int64) (*domain.User, error) {
+	user, err := s.userRepo.GetByTGID(ctx, tgID)
+	if err != nil {
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+	return user, nil
+}
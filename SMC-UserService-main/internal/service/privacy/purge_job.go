@@ -0,0 +1,73 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userservice "github.com/m04kA/SMC-UserService/internal/service/user"
+)
+
+// PurgeJob периодически анонимизирует аккаунты, у которых истёк срок ожидания удаления
+type PurgeJob struct {
+	service  *Service
+	interval time.Duration
+	log      Logger
+}
+
+func NewPurgeJob(service *Service, interval time.Duration, log Logger) *PurgeJob {
+	return &PurgeJob{
+		service:  service,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run выполняет очистку сразу и затем с периодом interval до отмены ctx
+func (j *PurgeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *PurgeJob) runOnce(ctx context.Context) {
+	userIDs, err := j.service.DueForAnonymization(ctx)
+	if err != nil {
+		j.log.Error("Account purge - Failed to list accounts due for anonymization: %v", err)
+		return
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	anonymized := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := j.service.AnonymizeUser(ctx, userID)
+		switch {
+		case err == nil:
+			anonymized++
+		case errors.Is(err, ErrDeletionNotScheduled):
+			// Удаление отменено между выборкой и анонимизацией
+			j.log.Info("Account purge - Deletion cancelled: user_id=%d", userID)
+		case errors.Is(err, userservice.ErrLastSuperUser):
+			j.log.Warn("Account purge - Skipped last superuser: user_id=%d", userID)
+		default:
+			j.log.Error("Account purge - Failed to anonymize account: user_id=%d, error=%v", userID, err)
+		}
+	}
+
+	j.log.Info("Account purge - Anonymized %d of %d accounts", anonymized, len(userIDs))
+}
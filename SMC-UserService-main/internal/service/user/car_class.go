@@ -0,0 +1,51 @@
+package user
+
+import (
+	"strings"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+// ClassifyCar подсказывает класс автомобиля по марке и модели
+func (s *Service) ClassifyCar(brand, model string) *models.CarClassDTO {
+	return &models.CarClassDTO{
+		Brand: brand,
+		Model: model,
+		Size:  s.classifyCar(brand, model),
+	}
+}
+
+func (s *Service) classifyCar(brand, model string) *string {
+	if s.carClassifier == nil {
+		return nil
+	}
+	class, ok := s.carClassifier.Classify(brand, model)
+	if !ok {
+		return nil
+	}
+	size := string(class)
+	return &size
+}
+
+// parseCarSize проверяет класс автомобиля и приводит его к каноническому виду ("c" -> "C")
+// nil и пустая строка означают, что класс не указан
+func parseCarSize(size *string) (*string, error) {
+	if size == nil || strings.TrimSpace(*size) == "" {
+		return nil, nil
+	}
+	class, ok := domain.ParseVehicleClass(*size)
+	if !ok {
+		return nil, ErrInvalidCarSize
+	}
+	normalized := string(class)
+	return &normalized, nil
+}
+
+// differentSize возвращает класс по справочнику, если он отличается от сохранённого
+func differentSize(size, suggested *string) *string {
+	if size == nil || suggested == nil || *size == *suggested {
+		return nil
+	}
+	return suggested
+}
@@ -12,15 +12,32 @@ var (
 	ErrUserAlreadyExists = errors.New("user with this telegram id already exists")
 	ErrCarNotFound       = errors.New("car not found")
 	ErrCarAccessDenied   = errors.New("access denied to this car")
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrRoleNotAllowed    = errors.New("self-registration is allowed only with client role")
+	ErrReasonRequired    = errors.New("reason is required")
+	ErrRoleUnchanged     = errors.New("user already has this role")
+	ErrSelfRoleChange    = errors.New("superuser cannot change own role")
+	ErrLastSuperUser     = errors.New("cannot revoke role of the last superuser")
+	ErrInvalidCarSize    = errors.New("invalid car size: expected vehicle class A, B, C, D, E, F, J, M or S")
+	ErrInvalidPlate      = errors.New("invalid license plate")
+	ErrCarAlreadyExists  = errors.New("car with this license plate already exists")
+	ErrInvalidSearch     = errors.New("invalid search query")
+	ErrInvalidBatch      = errors.New("invalid batch request")
 )
 
 // UserRepository определяет контракт для работы с хранилищем пользователей.
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByTGID(ctx context.Context, tgID int64) (*domain.User, error)
+	GetByTGIDs(ctx context.Context, tgIDs []int64) ([]*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
-	Delete(ctx context.Context, tgID int64) error
 	GetSuperUsers(ctx context.Context) ([]int64, error)
+	List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error)
+	Search(ctx context.Context, filter domain.UserSearchFilter) ([]*domain.UserSearchResult, error)
+	// ChangeRole в одной транзакции меняет роль пользователя и пишет запись в журнал
+	// Возвращает ErrUserNotFound, ErrRoleUnchanged или ErrLastSuperUser
+	ChangeRole(ctx context.Context, tgUserID int64, toRoleID int, changedBy int64, reason string) (*domain.RoleChange, error)
+	GetRoleChanges(ctx context.Context, tgUserID int64) ([]*domain.RoleChange, error)
 }
 
 // CarRepository определяет контракт для работы с хранилищем автомобилей.
@@ -29,7 +46,14 @@ type CarRepository interface {
 	GetByID(ctx context.Context, carID int64) (*domain.Car, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*domain.Car, error)
 	GetSelectedByUserID(ctx context.Context, userID int64) (*domain.Car, error)
+	GetSelectedByUserIDs(ctx context.Context, userIDs []int64) ([]*domain.Car, error)
 	Update(ctx context.Context, car *domain.Car) error
 	Delete(ctx context.Context, carID int64) error
 	UnselectAllByUserID(ctx context.Context, userID int64) error
+	GetByLicensePlate(ctx context.Context, normalizedPlate string) ([]*domain.Car, error)
+}
+
+// CarClassifier определяет класс автомобиля по марке и модели (справочник классов)
+type CarClassifier interface {
+	Classify(brand, model string) (domain.VehicleClass, bool)
 }
@@ -0,0 +1,65 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+// FindCarsByPlate ищет автомобили всех пользователей по номеру (только для суперпользователя)
+// Номер нормализуется так же, как при сохранении: "а123вс 77" найдёт "А123ВС77"
+func (s *Service) FindCarsByPlate(ctx context.Context, plate string) (*models.CarSearchDTO, error) {
+	normalizedPlate, err := normalizePlate(plate)
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.carRepo.GetByLicensePlate(ctx, normalizedPlate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	response := &models.CarSearchDTO{
+		LicensePlateNormalized: normalizedPlate,
+		Cars:                   make([]models.CarDTO, 0, len(cars)),
+	}
+	for _, car := range cars {
+		response.Cars = append(response.Cars, models.CarDTO{
+			ID:                     car.ID,
+			UserID:                 car.UserID,
+			Brand:                  car.Brand,
+			Model:                  car.Model,
+			LicensePlate:           car.LicensePlate,
+			LicensePlateNormalized: car.LicensePlateNormalized,
+			Color:                  car.Color,
+			Size:                   car.Size,
+			IsSelected:             car.IsSelected,
+		})
+	}
+
+	return response, nil
+}
+
+// normalizePlate проверяет номер и возвращает его канонический вид
+func normalizePlate(plate string) (string, error) {
+	normalized, _, ok := domain.NormalizeLicensePlate(plate)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPlate, plate)
+	}
+	return normalized, nil
+}
+
+// hasPlate проверяет, есть ли среди автомобилей другой (не exceptCarID) с таким же номером
+func hasPlate(cars []*domain.Car, normalizedPlate string, exceptCarID int64) bool {
+	for _, car := range cars {
+		if car.ID == exceptCarID || car.LicensePlateNormalized == nil {
+			continue
+		}
+		if *car.LicensePlateNormalized == normalizedPlate {
+			return true
+		}
+	}
+	return false
+}
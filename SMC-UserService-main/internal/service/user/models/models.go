@@ -13,7 +13,7 @@ type CreateUserInputDTO struct {
 	Name        string      `json:"name" validate:"required"`
 	PhoneNumber *string     `json:"phone_number" validate:"omitempty,e164"`
 	TGLink      *string     `json:"tg_link"`
-	Role        domain.Role `json:"role" validate:"required,oneof=client manager superuser"`
+	Role        domain.Role `json:"role" validate:"omitempty,oneof=client"` // самостоятельная регистрация только как client
 }
 
 type UpdateUserInputDTO struct {
@@ -23,22 +23,83 @@ type UpdateUserInputDTO struct {
 }
 
 type UserDTO struct {
-	TGUserID    int64       `json:"tg_user_id"`
-	Name        string      `json:"name"`
-	PhoneNumber *string     `json:"phone_number,omitempty"`
-	TGLink      *string     `json:"tg_link,omitempty"`
-	Role        domain.Role `json:"role"`
-	CreatedAt   time.Time   `json:"created_at"`
+	TGUserID            int64       `json:"tg_user_id"`
+	Name                string      `json:"name"`
+	PhoneNumber         *string     `json:"phone_number,omitempty"`
+	PhoneVerifiedAt     *time.Time  `json:"phone_verified_at,omitempty"`
+	DeletionScheduledAt *time.Time  `json:"deletion_scheduled_at,omitempty"` // запрошено удаление аккаунта
+	TGLink              *string     `json:"tg_link,omitempty"`
+	Role                domain.Role `json:"role"`
+	CreatedAt           time.Time   `json:"created_at"`
 }
 
 type UserWithCarsDTO struct {
-	TGUserID    int64       `json:"tg_user_id"`
-	Name        string      `json:"name"`
-	PhoneNumber *string     `json:"phone_number,omitempty"`
-	TGLink      *string     `json:"tg_link,omitempty"`
-	Role        domain.Role `json:"role"`
-	CreatedAt   time.Time   `json:"created_at"`
-	Cars        []CarDTO    `json:"cars"`
+	TGUserID            int64       `json:"tg_user_id"`
+	Name                string      `json:"name"`
+	PhoneNumber         *string     `json:"phone_number,omitempty"`
+	PhoneVerifiedAt     *time.Time  `json:"phone_verified_at,omitempty"`
+	DeletionScheduledAt *time.Time  `json:"deletion_scheduled_at,omitempty"` // запрошено удаление аккаунта
+	TGLink              *string     `json:"tg_link,omitempty"`
+	Role                domain.Role `json:"role"`
+	CreatedAt           time.Time   `json:"created_at"`
+	Cars                []CarDTO    `json:"cars"`
+}
+
+// UserDirectoryQueryDTO параметры поиска в каталоге пользователей
+type UserDirectoryQueryDTO struct {
+	Name        *string // подстрока имени
+	Phone       *string // начало номера телефона
+	Plate       *string // начало номера автомобиля
+	Role        *domain.Role
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Sort        string // created_at | name
+	Order       string // asc | desc
+	Cursor      string // next_cursor предыдущей страницы
+	Limit       int
+}
+
+type UserDirectoryEntryDTO struct {
+	UserDTO
+	CarsCount int `json:"cars_count"`
+}
+
+type UserDirectoryDTO struct {
+	Users      []UserDirectoryEntryDTO `json:"users"`
+	Limit      int                     `json:"limit"`
+	NextCursor *string                 `json:"next_cursor,omitempty"` // nil - последняя страница
+}
+
+type UserListDTO struct {
+	Users  []UserDTO `json:"users"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+}
+
+// Role DTOs
+
+type GrantRoleInputDTO struct {
+	Role   domain.Role `json:"role" validate:"required,oneof=client manager superuser"`
+	Reason string      `json:"reason" validate:"required"`
+}
+
+type RevokeRoleInputDTO struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type RoleChangeDTO struct {
+	ID        int64       `json:"id"`
+	TGUserID  int64       `json:"tg_user_id"`
+	ChangedBy *int64      `json:"changed_by"`
+	FromRole  domain.Role `json:"from_role"`
+	ToRole    domain.Role `json:"to_role"`
+	Reason    string      `json:"reason"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type RoleHistoryDTO struct {
+	TGUserID int64           `json:"tg_user_id"`
+	Changes  []RoleChangeDTO `json:"changes"`
 }
 
 // Car DTOs
@@ -60,12 +121,43 @@ type UpdateCarInputDTO struct {
 }
 
 type CarDTO struct {
-	ID           int64   `json:"id"`
-	UserID       int64   `json:"user_id"`
-	Brand        string  `json:"brand"`
-	Model        string  `json:"model"`
-	LicensePlate string  `json:"license_plate"`
-	Color        *string `json:"color,omitempty"`
-	Size         *string `json:"size,omitempty"`
-	IsSelected   bool    `json:"is_selected"`
+	ID                     int64   `json:"id"`
+	UserID                 int64   `json:"user_id"`
+	Brand                  string  `json:"brand"`
+	Model                  string  `json:"model"`
+	LicensePlate           string  `json:"license_plate"`
+	LicensePlateNormalized *string `json:"license_plate_normalized,omitempty"`
+	Color                  *string `json:"color,omitempty"`
+	Size                   *string `json:"size,omitempty"`
+	IsSelected             bool    `json:"is_selected"`
+	SuggestedSize          *string `json:"suggested_size,omitempty"` // класс по справочнику, если отличается от указанного
+}
+
+// UserBatchInputDTO запрос данных нескольких пользователей
+type UserBatchInputDTO struct {
+	TGUserIDs []int64 `json:"tg_user_ids"`
+}
+
+type UserBatchEntryDTO struct {
+	UserDTO
+	SelectedCar *CarDTO `json:"selected_car"` // nil - у пользователя нет выбранного автомобиля
+}
+
+// UserBatchDTO найденные пользователи и ID, которых нет (или они удалены)
+type UserBatchDTO struct {
+	Users      []UserBatchEntryDTO `json:"users"`
+	MissingIDs []int64             `json:"missing_ids"`
+}
+
+// CarSearchDTO результат поиска автомобилей по номеру
+type CarSearchDTO struct {
+	LicensePlateNormalized string   `json:"license_plate_normalized"`
+	Cars                   []CarDTO `json:"cars"`
+}
+
+// CarClassDTO результат определения класса автомобиля по справочнику
+type CarClassDTO struct {
+	Brand string  `json:"brand"`
+	Model string  `json:"model"`
+	Size  *string `json:"size"` // nil - марка и модель отсутствуют в справочнике
 }
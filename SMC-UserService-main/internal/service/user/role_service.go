@@ -0,0 +1,132 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+const (
+	defaultUserListLimit = 50
+	maxUserListLimit     = 200
+)
+
+// GrantRole назначает пользователю роль (только для суперпользователя)
+func (s *Service) GrantRole(ctx context.Context, tgUserID, changedBy int64, input models.GrantRoleInputDTO) (*models.RoleChangeDTO, error) {
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	return s.changeRole(ctx, tgUserID, changedBy, input.Role, input.Reason)
+}
+
+// RevokeRole возвращает пользователю роль client (только для суперпользователя)
+func (s *Service) RevokeRole(ctx context.Context, tgUserID, changedBy int64, input models.RevokeRoleInputDTO) (*models.RoleChangeDTO, error) {
+	return s.changeRole(ctx, tgUserID, changedBy, domain.RoleClient, input.Reason)
+}
+
+func (s *Service) changeRole(ctx context.Context, tgUserID, changedBy int64, role domain.Role, reason string) (*models.RoleChangeDTO, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	// Суперпользователь не может понизить сам себя и потерять доступ к управлению ролями
+	if tgUserID == changedBy {
+		return nil, ErrSelfRoleChange
+	}
+
+	change, err := s.userRepo.ChangeRole(ctx, tgUserID, roleToID(role), changedBy, reason)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrRoleUnchanged) || errors.Is(err, ErrLastSuperUser) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrServiceChangeRole, err)
+	}
+
+	response := toRoleChangeDTO(change)
+	return &response, nil
+}
+
+// GetRoleHistory возвращает журнал изменений роли пользователя (новые записи первыми)
+func (s *Service) GetRoleHistory(ctx context.Context, tgUserID int64) (*models.RoleHistoryDTO, error) {
+	changes, err := s.userRepo.GetRoleChanges(ctx, tgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	// Журнал сохраняется и для удалённых пользователей, поэтому 404 только если записей нет и пользователя нет
+	if len(changes) == 0 {
+		if _, err := s.userRepo.GetByTGID(ctx, tgUserID); err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+		}
+	}
+
+	response := &models.RoleHistoryDTO{
+		TGUserID: tgUserID,
+		Changes:  make([]models.RoleChangeDTO, 0, len(changes)),
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, toRoleChangeDTO(change))
+	}
+
+	return response, nil
+}
+
+// ListUsers возвращает список пользователей с фильтром по роли (только для суперпользователя)
+func (s *Service) ListUsers(ctx context.Context, role *domain.Role, limit, offset int) (*models.UserListDTO, error) {
+	if role != nil && !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if limit <= 0 {
+		limit = defaultUserListLimit
+	}
+	if limit > maxUserListLimit {
+		limit = maxUserListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, err := s.userRepo.List(ctx, domain.UserFilter{Role: role, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	response := &models.UserListDTO{
+		Users:  make([]models.UserDTO, 0, len(users)),
+		Limit:  limit,
+		Offset: offset,
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, models.UserDTO{
+			TGUserID:            user.TGUserID,
+			Name:                user.Name,
+			PhoneNumber:         user.PhoneNumber,
+			PhoneVerifiedAt:     user.PhoneVerifiedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			TGLink:              user.TGLink,
+			Role:                user.Role,
+			CreatedAt:           user.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func toRoleChangeDTO(change *domain.RoleChange) models.RoleChangeDTO {
+	return models.RoleChangeDTO{
+		ID:        change.ID,
+		TGUserID:  change.TGUserID,
+		ChangedBy: change.ChangedBy,
+		FromRole:  change.FromRole,
+		ToRole:    change.ToRole,
+		Reason:    change.Reason,
+		CreatedAt: change.CreatedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+// MaxBatchSize максимальное количество ID в одном пакетном запросе
+const MaxBatchSize = 100
+
+// GetUsersBatch получает нескольких пользователей с выбранными автомобилями за два запроса к БД
+// Повторяющиеся ID схлопываются, не найденные возвращаются в MissingIDs в порядке запроса
+func (s *Service) GetUsersBatch(ctx context.Context, input models.UserBatchInputDTO) (*models.UserBatchDTO, error) {
+	ids := make([]int64, 0, len(input.TGUserIDs))
+	seen := make(map[int64]struct{}, len(input.TGUserIDs))
+	for _, id := range input.TGUserIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: invalid tg_user_id %d", ErrInvalidBatch, id)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: tg_user_ids is empty", ErrInvalidBatch)
+	}
+	if len(ids) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d tg_user_ids allowed", ErrInvalidBatch, MaxBatchSize)
+	}
+
+	users, err := s.userRepo.GetByTGIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	cars, err := s.carRepo.GetSelectedByUserIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+	}
+
+	selected := make(map[int64]*models.CarDTO, len(cars))
+	for _, car := range cars {
+		selected[car.UserID] = &models.CarDTO{
+			ID:                     car.ID,
+			UserID:                 car.UserID,
+			Brand:                  car.Brand,
+			Model:                  car.Model,
+			LicensePlate:           car.LicensePlate,
+			LicensePlateNormalized: car.LicensePlateNormalized,
+			Color:                  car.Color,
+			Size:                   car.Size,
+			IsSelected:             car.IsSelected,
+		}
+	}
+
+	response := &models.UserBatchDTO{
+		Users:      make([]models.UserBatchEntryDTO, 0, len(users)),
+		MissingIDs: make([]int64, 0),
+	}
+
+	found := make(map[int64]struct{}, len(users))
+	for _, user := range users {
+		found[user.TGUserID] = struct{}{}
+		response.Users = append(response.Users, models.UserBatchEntryDTO{
+			UserDTO: models.UserDTO{
+				TGUserID:            user.TGUserID,
+				Name:                user.Name,
+				PhoneNumber:         user.PhoneNumber,
+				PhoneVerifiedAt:     user.PhoneVerifiedAt,
+				DeletionScheduledAt: user.DeletionScheduledAt,
+				TGLink:              user.TGLink,
+				Role:                user.Role,
+				CreatedAt:           user.CreatedAt,
+			},
+			SelectedCar: selected[user.TGUserID],
+		})
+	}
+
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			response.MissingIDs = append(response.MissingIDs, id)
+		}
+	}
+
+	return response, nil
+}
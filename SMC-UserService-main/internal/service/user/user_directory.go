@@ -0,0 +1,202 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/m04kA/SMC-UserService/internal/domain"
+	"github.com/m04kA/SMC-UserService/internal/service/user/models"
+)
+
+const (
+	defaultDirectoryLimit = 20
+	maxDirectoryLimit     = 100
+
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
+// directoryCursor содержимое next_cursor: сортировка страницы и ключ её последней записи
+type directoryCursor struct {
+	Sort      domain.UserSortField `json:"s"`
+	Desc      bool                 `json:"d"`
+	CreatedAt *time.Time           `json:"c,omitempty"`
+	Name      *string              `json:"n,omitempty"`
+	TGUserID  int64                `json:"id"`
+}
+
+// SearchUsers ищет пользователей по имени, телефону и номеру автомобиля (только для суперпользователя)
+// По умолчанию новые пользователи первыми; следующая страница запрашивается по next_cursor
+func (s *Service) SearchUsers(ctx context.Context, input models.UserDirectoryQueryDTO) (*models.UserDirectoryDTO, error) {
+	filter, err := buildSearchFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы узнать, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	results, err := s.userRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceGetUser, err)
+	}
+
+	response := &models.UserDirectoryDTO{
+		Users: make([]models.UserDirectoryEntryDTO, 0, len(results)),
+		Limit: limit,
+	}
+	if len(results) > limit {
+		results = results[:limit]
+		cursor := encodeDirectoryCursor(filter, results[len(results)-1])
+		response.NextCursor = &cursor
+	}
+
+	for _, result := range results {
+		response.Users = append(response.Users, models.UserDirectoryEntryDTO{
+			UserDTO: models.UserDTO{
+				TGUserID:            result.TGUserID,
+				Name:                result.Name,
+				PhoneNumber:         result.PhoneNumber,
+				PhoneVerifiedAt:     result.PhoneVerifiedAt,
+				DeletionScheduledAt: result.DeletionScheduledAt,
+				TGLink:              result.TGLink,
+				Role:                result.Role,
+				CreatedAt:           result.CreatedAt,
+			},
+			CarsCount: result.CarsCount,
+		})
+	}
+
+	return response, nil
+}
+
+func buildSearchFilter(input models.UserDirectoryQueryDTO) (domain.UserSearchFilter, error) {
+	filter := domain.UserSearchFilter{
+		Role:        input.Role,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		Sort:        domain.UserSortCreatedAt,
+		Desc:        true,
+		Limit:       input.Limit,
+	}
+
+	if input.Role != nil && !input.Role.IsValid() {
+		return filter, ErrInvalidRole
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return filter, fmt.Errorf("%w: empty name", ErrInvalidSearch)
+		}
+		filter.Name = &name
+	}
+
+	// Телефоны хранятся в E.164: оставляем только цифры и добавляем "+"
+	if input.Phone != nil {
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, *input.Phone)
+		if digits == "" {
+			return filter, fmt.Errorf("%w: phone must contain digits", ErrInvalidSearch)
+		}
+		prefix := "+" + digits
+		filter.PhonePrefix = &prefix
+	}
+
+	if input.Plate != nil {
+		prefix, ok := domain.NormalizeLicensePlatePrefix(*input.Plate)
+		if !ok {
+			return filter, ErrInvalidPlate
+		}
+		filter.PlatePrefix = &prefix
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, fmt.Errorf("%w: created_from must be before created_to", ErrInvalidSearch)
+	}
+
+	if input.Sort != "" {
+		filter.Sort = domain.UserSortField(input.Sort)
+		if !filter.Sort.IsValid() {
+			return filter, fmt.Errorf("%w: unsupported sort %q", ErrInvalidSearch, input.Sort)
+		}
+		// Имена по умолчанию по алфавиту, даты - новые первыми
+		filter.Desc = filter.Sort != domain.UserSortName
+	}
+	switch input.Order {
+	case "":
+	case sortOrderAsc:
+		filter.Desc = false
+	case sortOrderDesc:
+		filter.Desc = true
+	default:
+		return filter, fmt.Errorf("%w: order must be %s or %s", ErrInvalidSearch, sortOrderAsc, sortOrderDesc)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDirectoryLimit
+	}
+	if filter.Limit > maxDirectoryLimit {
+		filter.Limit = maxDirectoryLimit
+	}
+
+	if input.Cursor != "" {
+		after, err := decodeDirectoryCursor(input.Cursor, filter)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = after
+	}
+
+	return filter, nil
+}
+
+func encodeDirectoryCursor(filter domain.UserSearchFilter, last *domain.UserSearchResult) string {
+	cursor := directoryCursor{Sort: filter.Sort, Desc: filter.Desc, TGUserID: last.TGUserID}
+	if filter.Sort == domain.UserSortName {
+		cursor.Name = &last.Name
+	} else {
+		cursor.CreatedAt = &last.CreatedAt
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeDirectoryCursor разбирает next_cursor; курсор действителен только для той же сортировки
+func decodeDirectoryCursor(value string, filter domain.UserSearchFilter) (*domain.UserCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+
+	var cursor directoryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+	if cursor.Sort != filter.Sort || cursor.Desc != filter.Desc {
+		return nil, fmt.Errorf("%w: cursor does not match sort order", ErrInvalidSearch)
+	}
+
+	after := &domain.UserCursor{TGUserID: cursor.TGUserID}
+	switch {
+	case filter.Sort == domain.UserSortName && cursor.Name != nil:
+		after.Name = *cursor.Name
+	case filter.Sort == domain.UserSortCreatedAt && cursor.CreatedAt != nil:
+		after.CreatedAt = *cursor.CreatedAt
+	default:
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+
+	return after, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/m04kA/SMC-UserService/internal/domain"
@@ -14,24 +15,37 @@ var (
 	ErrServiceCreateUser = errors.New("service: failed to create user")
 	ErrServiceGetUser    = errors.New("service: failed to get user")
 	ErrServiceUpdateUser = errors.New("service: failed to update user")
-	ErrServiceDeleteUser = errors.New("service: failed to delete user")
 	ErrServiceCreateCar  = errors.New("service: failed to create car")
 	ErrServiceGetCar     = errors.New("service: failed to get car")
 	ErrServiceUpdateCar  = errors.New("service: failed to update car")
 	ErrServiceDeleteCar  = errors.New("service: failed to delete car")
+	ErrServiceChangeRole = errors.New("service: failed to change role")
 )
 
 type Service struct {
-	userRepo UserRepository
-	carRepo  CarRepository
+	userRepo      UserRepository
+	carRepo       CarRepository
+	carClassifier CarClassifier
+	autoFillSize  bool // заполнять класс автомобиля по справочнику, если пользователь его не указал
 }
 
-func NewUserService(ur UserRepository, cr CarRepository) *Service {
-	return &Service{userRepo: ur, carRepo: cr}
+func NewUserService(ur UserRepository, cr CarRepository, classifier CarClassifier, autoFillSize bool) *Service {
+	return &Service{userRepo: ur, carRepo: cr, carClassifier: classifier, autoFillSize: autoFillSize}
 }
 
 // CreateUser создает нового пользователя
+// Самостоятельная регистрация возможна только с ролью client, остальные роли выдаёт суперпользователь
 func (s *Service) CreateUser(ctx context.Context, input models.CreateUserInputDTO) (*models.UserDTO, error) {
+	if input.Role == "" {
+		input.Role = domain.RoleClient
+	}
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if input.Role != domain.RoleClient {
+		return nil, ErrRoleNotAllowed
+	}
+
 	_, err := s.userRepo.GetByTGID(ctx, input.TGUserID)
 	if err == nil {
 		return nil, ErrUserAlreadyExists
@@ -54,16 +68,21 @@ func (s *Service) CreateUser(ctx context.Context, input models.CreateUserInputDT
 	}
 
 	if err = s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, ErrUserAlreadyExists) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("%w: %v", ErrServiceCreateUser, err)
 	}
 
 	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
+		TGUserID:            user.TGUserID,
+		Name:                user.Name,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		TGLink:              user.TGLink,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
 	}
 
 	return response, nil
@@ -84,6 +103,10 @@ func (s *Service) UpdateUser(ctx context.Context, tgID int64, input models.Updat
 		user.Name = *input.Name
 	}
 	if input.PhoneNumber != nil {
+		// Новый номер нужно подтвердить заново
+		if user.PhoneNumber == nil || *user.PhoneNumber != *input.PhoneNumber {
+			user.PhoneVerifiedAt = nil
+		}
 		user.PhoneNumber = input.PhoneNumber
 	}
 	if input.TGLink != nil {
@@ -95,29 +118,19 @@ func (s *Service) UpdateUser(ctx context.Context, tgID int64, input models.Updat
 	}
 
 	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
+		TGUserID:            user.TGUserID,
+		Name:                user.Name,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		TGLink:              user.TGLink,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
 	}
 
 	return response, nil
 }
 
-// DeleteUser удаляет пользователя
-func (s *Service) DeleteUser(ctx context.Context, tgID int64) error {
-	err := s.userRepo.Delete(ctx, tgID)
-	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
-			return err
-		}
-		return fmt.Errorf("%w: %v", ErrServiceDeleteUser, err)
-	}
-	return nil
-}
-
 // GetUserByID получает пользователя по ID
 func (s *Service) GetUserByID(ctx context.Context, tgID int64) (*models.UserDTO, error) {
 	user, err := s.userRepo.GetByTGID(ctx, tgID)
@@ -129,12 +142,14 @@ func (s *Service) GetUserByID(ctx context.Context, tgID int64) (*models.UserDTO,
 	}
 
 	response := &models.UserDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
+		TGUserID:            user.TGUserID,
+		Name:                user.Name,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		TGLink:              user.TGLink,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
 	}
 
 	return response, nil
@@ -158,25 +173,28 @@ func (s *Service) GetUserWithCars(ctx context.Context, tgID int64) (*models.User
 	carDTOs := make([]models.CarDTO, 0, len(cars))
 	for _, car := range cars {
 		carDTOs = append(carDTOs, models.CarDTO{
-			ID:           car.ID,
-			UserID:       car.UserID,
-			Brand:        car.Brand,
-			Model:        car.Model,
-			LicensePlate: car.LicensePlate,
-			Color:        car.Color,
-			Size:         car.Size,
-			IsSelected:   car.IsSelected,
+			ID:                     car.ID,
+			UserID:                 car.UserID,
+			Brand:                  car.Brand,
+			Model:                  car.Model,
+			LicensePlate:           car.LicensePlate,
+			LicensePlateNormalized: car.LicensePlateNormalized,
+			Color:                  car.Color,
+			Size:                   car.Size,
+			IsSelected:             car.IsSelected,
 		})
 	}
 
 	response := &models.UserWithCarsDTO{
-		TGUserID:    user.TGUserID,
-		Name:        user.Name,
-		PhoneNumber: user.PhoneNumber,
-		TGLink:      user.TGLink,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
-		Cars:        carDTOs,
+		TGUserID:            user.TGUserID,
+		Name:                user.Name,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneVerifiedAt:     user.PhoneVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		TGLink:              user.TGLink,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
+		Cars:                carDTOs,
 	}
 
 	return response, nil
@@ -207,7 +225,23 @@ func roleToID(role domain.Role) int {
 
 // CreateCar создает новый автомобиль
 func (s *Service) CreateCar(ctx context.Context, tgID int64, input models.CreateCarInputDTO) (*models.CarDTO, error) {
-	_, err := s.userRepo.GetByTGID(ctx, tgID)
+	size, err := parseCarSize(input.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedPlate, err := normalizePlate(input.LicensePlate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Класс по справочнику: заполняет пустой размер или возвращается как подсказка
+	suggestedSize := s.classifyCar(input.Brand, input.Model)
+	if size == nil && s.autoFillSize {
+		size = suggestedSize
+	}
+
+	_, err = s.userRepo.GetByTGID(ctx, tgID)
 	if err != nil {
 		if errors.Is(err, ErrUserNotFound) {
 			return nil, err
@@ -221,33 +255,44 @@ func (s *Service) CreateCar(ctx context.Context, tgID int64, input models.Create
 		return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
 	}
 
+	// Один номер (после нормализации) может быть только у одного автомобиля пользователя
+	if hasPlate(existingCars, normalizedPlate, 0) {
+		return nil, ErrCarAlreadyExists
+	}
+
 	// Если это первый автомобиль, он автоматически становится выбранным
 	isSelected := len(existingCars) == 0
 
 	car := &domain.Car{
-		UserID:       tgID,
-		Brand:        input.Brand,
-		Model:        input.Model,
-		LicensePlate: input.LicensePlate,
-		Color:        input.Color,
-		Size:         input.Size,
-		IsSelected:   isSelected,
+		UserID:                 tgID,
+		Brand:                  input.Brand,
+		Model:                  input.Model,
+		LicensePlate:           strings.TrimSpace(input.LicensePlate),
+		LicensePlateNormalized: &normalizedPlate,
+		Color:                  input.Color,
+		Size:                   size,
+		IsSelected:             isSelected,
 	}
 
 	createdCar, err := s.carRepo.Create(ctx, car)
 	if err != nil {
+		if errors.Is(err, ErrCarAlreadyExists) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("%w: %v", ErrServiceCreateCar, err)
 	}
 
 	response := &models.CarDTO{
-		ID:           createdCar.ID,
-		UserID:       createdCar.UserID,
-		Brand:        createdCar.Brand,
-		Model:        createdCar.Model,
-		LicensePlate: createdCar.LicensePlate,
-		Color:        createdCar.Color,
-		Size:         createdCar.Size,
-		IsSelected:   createdCar.IsSelected,
+		ID:                     createdCar.ID,
+		UserID:                 createdCar.UserID,
+		Brand:                  createdCar.Brand,
+		Model:                  createdCar.Model,
+		LicensePlate:           createdCar.LicensePlate,
+		LicensePlateNormalized: createdCar.LicensePlateNormalized,
+		Color:                  createdCar.Color,
+		Size:                   createdCar.Size,
+		IsSelected:             createdCar.IsSelected,
+		SuggestedSize:          differentSize(createdCar.Size, suggestedSize),
 	}
 
 	return response, nil
@@ -255,6 +300,12 @@ func (s *Service) CreateCar(ctx context.Context, tgID int64, input models.Create
 
 // UpdateCar обновляет автомобиль (PATCH) с проверкой роли
 func (s *Service) UpdateCar(ctx context.Context, tgID int64, carID int64, input models.UpdateCarInputDTO, role domain.Role) (*models.CarDTO, error) {
+	// Пустая строка в size очищает класс автомобиля
+	size, err := parseCarSize(input.Size)
+	if err != nil {
+		return nil, err
+	}
+
 	car, err := s.carRepo.GetByID(ctx, carID)
 	if err != nil {
 		if errors.Is(err, ErrCarNotFound) {
@@ -275,29 +326,53 @@ func (s *Service) UpdateCar(ctx context.Context, tgID int64, carID int64, input
 		car.Model = *input.Model
 	}
 	if input.LicensePlate != nil {
-		car.LicensePlate = *input.LicensePlate
+		normalizedPlate, err := normalizePlate(*input.LicensePlate)
+		if err != nil {
+			return nil, err
+		}
+
+		userCars, err := s.carRepo.GetByUserID(ctx, car.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrServiceGetCar, err)
+		}
+		if hasPlate(userCars, normalizedPlate, car.ID) {
+			return nil, ErrCarAlreadyExists
+		}
+
+		car.LicensePlate = strings.TrimSpace(*input.LicensePlate)
+		car.LicensePlateNormalized = &normalizedPlate
 	}
 	if input.Color != nil {
 		car.Color = input.Color
 	}
 	if input.Size != nil {
-		car.Size = input.Size
+		car.Size = size
+	}
+
+	suggestedSize := s.classifyCar(car.Brand, car.Model)
+	if car.Size == nil && input.Size == nil && s.autoFillSize {
+		car.Size = suggestedSize
 	}
 
 	err = s.carRepo.Update(ctx, car)
 	if err != nil {
+		if errors.Is(err, ErrCarAlreadyExists) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("%w: %v", ErrServiceUpdateCar, err)
 	}
 
 	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+		ID:                     car.ID,
+		UserID:                 car.UserID,
+		Brand:                  car.Brand,
+		Model:                  car.Model,
+		LicensePlate:           car.LicensePlate,
+		LicensePlateNormalized: car.LicensePlateNormalized,
+		Color:                  car.Color,
+		Size:                   car.Size,
+		IsSelected:             car.IsSelected,
+		SuggestedSize:          differentSize(car.Size, suggestedSize),
 	}
 
 	return response, nil
@@ -354,14 +429,15 @@ func (s *Service) GetSelectedCar(ctx context.Context, tgID int64) (*models.CarDT
 	}
 
 	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+		ID:                     car.ID,
+		UserID:                 car.UserID,
+		Brand:                  car.Brand,
+		Model:                  car.Model,
+		LicensePlate:           car.LicensePlate,
+		LicensePlateNormalized: car.LicensePlateNormalized,
+		Color:                  car.Color,
+		Size:                   car.Size,
+		IsSelected:             car.IsSelected,
 	}
 
 	return response, nil
@@ -385,14 +461,15 @@ func (s *Service) SetSelectedCar(ctx context.Context, tgID int64, carID int64, r
 	// Если уже выбран, просто возвращаем
 	if car.IsSelected {
 		response := &models.CarDTO{
-			ID:           car.ID,
-			UserID:       car.UserID,
-			Brand:        car.Brand,
-			Model:        car.Model,
-			LicensePlate: car.LicensePlate,
-			Color:        car.Color,
-			Size:         car.Size,
-			IsSelected:   car.IsSelected,
+			ID:                     car.ID,
+			UserID:                 car.UserID,
+			Brand:                  car.Brand,
+			Model:                  car.Model,
+			LicensePlate:           car.LicensePlate,
+			LicensePlateNormalized: car.LicensePlateNormalized,
+			Color:                  car.Color,
+			Size:                   car.Size,
+			IsSelected:             car.IsSelected,
 		}
 		return response, nil
 	}
@@ -409,14 +486,15 @@ func (s *Service) SetSelectedCar(ctx context.Context, tgID int64, carID int64, r
 	}
 
 	response := &models.CarDTO{
-		ID:           car.ID,
-		UserID:       car.UserID,
-		Brand:        car.Brand,
-		Model:        car.Model,
-		LicensePlate: car.LicensePlate,
-		Color:        car.Color,
-		Size:         car.Size,
-		IsSelected:   car.IsSelected,
+		ID:                     car.ID,
+		UserID:                 car.UserID,
+		Brand:                  car.Brand,
+		Model:                  car.Model,
+		LicensePlate:           car.LicensePlate,
+		LicensePlateNormalized: car.LicensePlateNormalized,
+		Color:                  car.Color,
+		Size:                   car.Size,
+		IsSelected:             car.IsSelected,
 	}
 
 	return response, nil
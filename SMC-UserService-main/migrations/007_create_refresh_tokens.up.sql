@@ -0,0 +1,22 @@
+-- Refresh токены, выданные при входе через Telegram Mini App
+-- Храним только jti: сам токен проверяется по подписи, запись нужна для отзыва и ротации
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    replaced_by VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_refresh_tokens_user
+        FOREIGN KEY(tg_user_id)
+        REFERENCES users(tg_user_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_tg_user_id ON refresh_tokens(tg_user_id);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+COMMENT ON TABLE refresh_tokens IS 'Выданные refresh токены (для отзыва и ротации)';
+COMMENT ON COLUMN refresh_tokens.jti IS 'Идентификатор токена (claim jti)';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'Время отзыва (NULL - токен активен)';
+COMMENT ON COLUMN refresh_tokens.replaced_by IS 'jti токена, выданного взамен при обновлении';
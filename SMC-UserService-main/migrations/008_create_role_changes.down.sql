@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS role_changes_no_update_delete ON role_changes;
+DROP FUNCTION IF EXISTS role_changes_append_only();
+DROP TABLE IF EXISTS role_changes;
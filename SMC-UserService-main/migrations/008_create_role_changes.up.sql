@@ -0,0 +1,30 @@
+-- Журнал изменений ролей пользователей (append-only)
+-- Внешний ключ на users намеренно отсутствует: записи сохраняются после удаления пользователя
+CREATE TABLE IF NOT EXISTS role_changes (
+    id BIGSERIAL PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL,
+    changed_by BIGINT,
+    from_role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE RESTRICT,
+    to_role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE RESTRICT,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_role_changes_tg_user_id ON role_changes(tg_user_id, created_at DESC);
+CREATE INDEX idx_role_changes_changed_by ON role_changes(changed_by);
+
+-- Запрещаем изменение и удаление записей журнала
+CREATE OR REPLACE FUNCTION role_changes_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'role_changes is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER role_changes_no_update_delete
+    BEFORE UPDATE OR DELETE ON role_changes
+    FOR EACH ROW EXECUTE FUNCTION role_changes_append_only();
+
+COMMENT ON TABLE role_changes IS 'Журнал изменений ролей пользователей (только добавление)';
+COMMENT ON COLUMN role_changes.changed_by IS 'tg_user_id суперпользователя, изменившего роль (NULL - системное изменение)';
+COMMENT ON COLUMN role_changes.reason IS 'Причина изменения роли';
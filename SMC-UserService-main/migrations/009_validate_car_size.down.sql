@@ -0,0 +1,5 @@
+ALTER TABLE cars DROP CONSTRAINT IF EXISTS chk_cars_size_vehicle_class;
+
+ALTER TABLE cars ALTER COLUMN size TYPE VARCHAR(50);
+
+COMMENT ON COLUMN cars.size IS NULL;
@@ -0,0 +1,14 @@
+-- Класс автомобиля (size) должен совпадать с классами PriceService: A, B, C, D, E, F, J, M, S
+-- Приводим существующие значения к верхнему регистру, некорректные очищаем
+UPDATE cars SET size = UPPER(TRIM(size)) WHERE size IS NOT NULL;
+
+UPDATE cars SET size = NULL
+WHERE size IS NOT NULL
+  AND size NOT IN ('A', 'B', 'C', 'D', 'E', 'F', 'J', 'M', 'S');
+
+ALTER TABLE cars ALTER COLUMN size TYPE VARCHAR(1);
+
+ALTER TABLE cars ADD CONSTRAINT chk_cars_size_vehicle_class
+    CHECK (size IS NULL OR size IN ('A', 'B', 'C', 'D', 'E', 'F', 'J', 'M', 'S'));
+
+COMMENT ON COLUMN cars.size IS 'Класс автомобиля: A, B, C, D, E, F, J, M, S (NULL - не указан)';
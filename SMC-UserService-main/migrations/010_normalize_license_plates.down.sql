@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cars_license_plate_normalized;
+DROP INDEX IF EXISTS uq_cars_user_license_plate;
+
+ALTER TABLE cars DROP COLUMN IF EXISTS license_plate_normalized;
@@ -0,0 +1,24 @@
+-- Нормализованный номер автомобиля: верхний регистр, без пробелов и разделителей,
+-- кириллические буквы заменены латинскими двойниками (А123ВС77 и a123bc 77 -> A123BC77)
+ALTER TABLE cars ADD COLUMN license_plate_normalized VARCHAR(20);
+
+UPDATE cars SET license_plate_normalized = TRANSLATE(
+    REGEXP_REPLACE(UPPER(license_plate), '[[:space:]._|-]', '', 'g'),
+    'АВЕКМНОРСТУХ',
+    'ABEKMHOPCTYX'
+);
+
+-- У существующих дубликатов (один номер у пользователя несколько раз) нормализованный номер
+-- остаётся только у самой старой записи, остальные нужно исправить вручную
+UPDATE cars c SET license_plate_normalized = NULL
+WHERE EXISTS (
+    SELECT 1 FROM cars d
+    WHERE d.user_id = c.user_id
+      AND d.license_plate_normalized = c.license_plate_normalized
+      AND d.id < c.id
+);
+
+CREATE UNIQUE INDEX uq_cars_user_license_plate ON cars(user_id, license_plate_normalized);
+CREATE INDEX idx_cars_license_plate_normalized ON cars(license_plate_normalized);
+
+COMMENT ON COLUMN cars.license_plate_normalized IS 'Номер в каноническом виде для поиска и проверки дубликатов (уникален в пределах пользователя)';
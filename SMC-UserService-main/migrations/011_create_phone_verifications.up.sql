@@ -0,0 +1,27 @@
+-- Подтверждение номера телефона одноразовым кодом из SMS
+ALTER TABLE users ADD COLUMN phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN users.phone_verified_at IS 'Время подтверждения номера телефона кодом (NULL - не подтверждён)';
+
+-- Храним только HMAC кода: по записи нельзя восстановить код
+CREATE TABLE IF NOT EXISTS phone_verifications (
+    id BIGSERIAL PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_phone_verifications_user
+        FOREIGN KEY(tg_user_id)
+        REFERENCES users(tg_user_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_phone_verifications_user_created ON phone_verifications(tg_user_id, created_at DESC);
+CREATE INDEX idx_phone_verifications_phone_created ON phone_verifications(phone_number, created_at DESC);
+
+COMMENT ON TABLE phone_verifications IS 'Одноразовые коды подтверждения номера телефона';
+COMMENT ON COLUMN phone_verifications.code_hash IS 'HMAC-SHA256 кода (hex)';
+COMMENT ON COLUMN phone_verifications.attempts IS 'Количество неверных попыток ввода кода';
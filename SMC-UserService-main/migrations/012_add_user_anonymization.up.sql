@@ -0,0 +1,14 @@
+-- Удаление аккаунта по запросу пользователя: запрос, отложенная анонимизация и её результат
+-- Строка пользователя не удаляется: tg_user_id остаётся ключом журнала role_changes
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deletion_scheduled_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN anonymized_at TIMESTAMP WITH TIME ZONE;
+
+-- Задача очистки выбирает пользователей, у которых истёк срок ожидания
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL AND anonymized_at IS NULL;
+
+COMMENT ON COLUMN users.deletion_requested_at IS 'Время запроса удаления аккаунта (NULL - удаление не запрошено)';
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'Время, после которого аккаунт будет анонимизирован';
+COMMENT ON COLUMN users.anonymized_at IS 'Время анонимизации (NULL - активный аккаунт)';
@@ -0,0 +1,13 @@
+-- Индексы каталога пользователей (GET /admin/users)
+
+-- Поиск по подстроке имени (ILIKE '%...%')
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_users_name_trgm ON users USING GIN (name gin_trgm_ops);
+
+-- Поиск по началу номера телефона и номера автомобиля (LIKE '...%' при любой collation)
+CREATE INDEX idx_users_phone_number_prefix ON users(phone_number varchar_pattern_ops);
+CREATE INDEX idx_cars_license_plate_normalized_prefix ON cars(license_plate_normalized varchar_pattern_ops);
+
+-- Keyset пагинация: сортировка по полю и tg_user_id для однозначного порядка
+CREATE INDEX idx_users_created_at_tg_user_id ON users(created_at, tg_user_id) WHERE anonymized_at IS NULL;
+CREATE INDEX idx_users_name_tg_user_id ON users(name, tg_user_id) WHERE anonymized_at IS NULL;
@@ -0,0 +1,12 @@
+-- Nonce подписанных межсервисных запросов (svcauth): общие для всех экземпляров сервиса,
+-- поэтому подписанный запрос нельзя повторить на другом экземпляре или после рестарта
+CREATE TABLE IF NOT EXISTS service_nonces (
+    nonce VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_service_nonces_expires_at ON service_nonces(expires_at);
+
+COMMENT ON TABLE service_nonces IS 'Использованные nonce межсервисных запросов';
+COMMENT ON COLUMN service_nonces.nonce IS 'Имя сервиса и nonce запроса (service:nonce)';
+COMMENT ON COLUMN service_nonces.expires_at IS 'После этого времени запрос с nonce отклоняется по timestamp, запись можно удалить';
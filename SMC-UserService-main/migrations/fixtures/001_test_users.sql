@@ -27,9 +27,9 @@ VALUES (
     123456789,
     'BMW',
     'X5',
-    'А123БВ799',
+    'А123ВВ799',
     'Черный',
-    'L',
+    'J',
     true
 )
 ON CONFLICT (id) DO UPDATE SET
@@ -318,6 +318,16 @@ ON CONFLICT (tg_user_id) DO UPDATE SET
 
 -- У этого пользователя НЕТ автомобилей!
 
+-- ==========================================
+-- Нормализованные номера (так же, как в миграции 010)
+-- ==========================================
+UPDATE cars SET license_plate_normalized = TRANSLATE(
+    REGEXP_REPLACE(UPPER(license_plate), '[[:space:]._|-]', '', 'g'),
+    'АВЕКМНОРСТУХ',
+    'ABEKMHOPCTYX'
+)
+WHERE id IN (1001, 2001, 3001, 4001, 5001, 6001, 7001);
+
 -- ==========================================
 -- Сброс последовательностей ID (если нужно)
 -- ==========================================
@@ -0,0 +1,126 @@
+package authtoken
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Типы токенов (claim token_type)
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+var (
+	ErrInvalidToken   = errors.New("authtoken: invalid token")
+	ErrWrongTokenType = errors.New("authtoken: wrong token type")
+)
+
+// Claims claims токенов SMC: tg_user_id и роль пользователя
+type Claims struct {
+	TGUserID  int64  `json:"tg_user_id"`
+	Role      string `json:"role,omitempty"` // только в access токене
+	TokenType string `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+// Token подписанный токен и его параметры
+type Token struct {
+	Value     string
+	ID        string // jti
+	ExpiresAt time.Time
+}
+
+// Issuer выпускает и проверяет токены, подписанные HS256
+type Issuer struct {
+	secret     []byte
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewIssuer создаёт новый Issuer
+func NewIssuer(secret, issuer string, accessTTL, refreshTTL time.Duration) *Issuer {
+	return &Issuer{
+		secret:     []byte(secret),
+		issuer:     issuer,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// IssueAccess выпускает access токен с tg_user_id и ролью
+func (i *Issuer) IssueAccess(tgUserID int64, role string, now time.Time) (*Token, error) {
+	return i.issue(Claims{TGUserID: tgUserID, Role: role, TokenType: TypeAccess}, i.accessTTL, now)
+}
+
+// IssueRefresh выпускает refresh токен; роль в нём не хранится и перечитывается при обновлении
+func (i *Issuer) IssueRefresh(tgUserID int64, now time.Time) (*Token, error) {
+	return i.issue(Claims{TGUserID: tgUserID, TokenType: TypeRefresh}, i.refreshTTL, now)
+}
+
+// Parse проверяет подпись, срок действия и тип токена
+func (i *Issuer) Parse(value, tokenType string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(value, claims, func(token *jwt.Token) (interface{}, error) {
+		return i.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrWrongTokenType
+	}
+	if claims.TGUserID <= 0 {
+		return nil, fmt.Errorf("%w: tg_user_id is missing", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (i *Issuer) issue(claims Claims, ttl time.Duration, now time.Time) (*Token, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(ttl)
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        id,
+		Issuer:    i.issuer,
+		Subject:   strconv.FormatInt(claims.TGUserID, 10),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	value, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return nil, fmt.Errorf("authtoken: sign token: %w", err)
+	}
+
+	return &Token{
+		Value:     value,
+		ID:        id,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// newTokenID генерирует случайный идентификатор токена (jti)
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("authtoken: generate token id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
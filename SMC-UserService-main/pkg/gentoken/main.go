@@ -1,37 +1,50 @@
+// gentoken выпускает access токен для локальной разработки
+//
+// Использование: JWT_SECRET=... go run ./pkg/gentoken [tg_user_id] [role]
 package main
 
-// import (
-// 	"fmt"
-// 	"os"
-// 	"strconv"
-// 	"time"
-
-// 	"github.com/golang-jwt/jwt/v5"
-// )
-
-// func main() {
-// 	secret := "your-secret-key-change-in-production"
-
-// 	userID := int64(12345678999)
-// 	if len(os.Args) > 1 {
-// 		id, err := strconv.ParseInt(os.Args[1], 10, 64)
-// 		if err == nil {
-// 			userID = id
-// 		}
-// 	}
-
-// 	claims := jwt.MapClaims{
-// 		"tg_user_id": userID,
-// 		"exp":        time.Now().Add(24 * time.Hour).Unix(),
-// 		"iat":        time.Now().Unix(),
-// 	}
-
-// 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-// 	tokenString, err := token.SignedString([]byte(secret))
-// 	if err != nil {
-// 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-// 		os.Exit(1)
-// 	}
-
-// 	fmt.Println(tokenString)
-// }
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-UserService/pkg/authtoken"
+)
+
+const (
+	defaultSecret = "your-secret-key-change-in-production"
+	defaultIssuer = "smc-userservice"
+	tokenTTL      = 24 * time.Hour
+)
+
+func main() {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		secret = defaultSecret
+	}
+
+	userID := int64(12345678999)
+	if len(os.Args) > 1 {
+		id, err := strconv.ParseInt(os.Args[1], 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid tg_user_id: %v\n", err)
+			os.Exit(1)
+		}
+		userID = id
+	}
+
+	role := "client"
+	if len(os.Args) > 2 {
+		role = os.Args[2]
+	}
+
+	issuer := authtoken.NewIssuer(secret, defaultIssuer, tokenTTL, tokenTTL)
+	token, err := issuer.IssueAccess(userID, role, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(token.Value)
+}
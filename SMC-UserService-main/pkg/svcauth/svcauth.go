@@ -0,0 +1,70 @@
+// Package svcauth аутентификация межсервисных запросов SMC к /internal эндпоинтам
+//
+// Пакет одинаковый во всех сервисах SMC. Поддерживаются режимы:
+//   - hmac: запрос подписывается HMAC-SHA256 от метода, пути, тела, времени и nonce (защита от повтора)
+//   - api_key: в запросе передаётся ключ сервиса
+//   - none: без аутентификации (только для локальной разработки)
+package svcauth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Режимы аутентификации
+const (
+	ModeHMAC   = "hmac"
+	ModeAPIKey = "api_key"
+	ModeNone   = "none"
+)
+
+// Заголовки межсервисной аутентификации
+const (
+	HeaderServiceName = "X-Service-Name"
+	HeaderTimestamp   = "X-Service-Timestamp"
+	HeaderNonce       = "X-Service-Nonce"
+	HeaderSignature   = "X-Service-Signature"
+	HeaderAPIKey      = "X-Service-Key"
+)
+
+var (
+	ErrMissingCredentials = errors.New("svcauth: missing service credentials")
+	ErrUnknownService     = errors.New("svcauth: unknown service")
+	ErrInvalidCredentials = errors.New("svcauth: invalid service credentials")
+	ErrExpiredTimestamp   = errors.New("svcauth: request timestamp is out of allowed window")
+	ErrReplayedNonce      = errors.New("svcauth: nonce has already been used")
+	ErrNonceStore         = errors.New("svcauth: nonce store is unavailable")
+)
+
+// Credentials учётные данные вызывающего сервиса
+type Credentials struct {
+	Mode        string // hmac | api_key | none
+	ServiceName string // имя сервиса, под которым его знает вызываемый сервис
+	Secret      string // общий секрет (hmac) или ключ (api_key)
+}
+
+// Validate проверяет учётные данные
+func (c Credentials) Validate() error {
+	if err := validateMode(c.Mode); err != nil {
+		return err
+	}
+	if c.Mode == ModeNone {
+		return nil
+	}
+	if c.ServiceName == "" {
+		return errors.New("svcauth: service name is required")
+	}
+	if c.Secret == "" {
+		return errors.New("svcauth: secret is required")
+	}
+	return nil
+}
+
+func validateMode(mode string) error {
+	switch mode {
+	case ModeHMAC, ModeAPIKey, ModeNone:
+		return nil
+	default:
+		return fmt.Errorf("svcauth: unknown mode %q (allowed: %s, %s, %s)", mode, ModeHMAC, ModeAPIKey, ModeNone)
+	}
+}
@@ -0,0 +1,111 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webAppDataKey ключ для получения секрета из токена бота (см. документацию Telegram Mini Apps)
+const webAppDataKey = "WebAppData"
+
+var (
+	ErrInitDataMalformed = errors.New("telegram: malformed init data")
+	ErrInitDataSignature = errors.New("telegram: invalid init data signature")
+	ErrInitDataExpired   = errors.New("telegram: init data expired")
+)
+
+// WebAppUser пользователь из initData Telegram Mini App
+type WebAppUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name,omitempty"`
+	Username     string `json:"username,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+}
+
+// FullName возвращает имя и фамилию пользователя через пробел
+func (u WebAppUser) FullName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// InitData проверенные данные запуска Telegram Mini App
+type InitData struct {
+	User     WebAppUser
+	AuthDate time.Time
+	QueryID  string
+}
+
+// ValidateInitData проверяет подпись initData токеном бота и свежесть auth_date
+// maxAge <= 0 отключает проверку свежести
+func ValidateInitData(initData, botToken string, maxAge time.Duration, now time.Time) (*InitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInitDataMalformed, err)
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, fmt.Errorf("%w: hash is missing", ErrInitDataMalformed)
+	}
+
+	// Строка для проверки: все поля кроме hash, отсортированные по ключу, в формате key=value через \n
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+values.Get(key))
+	}
+	dataCheckString := strings.Join(pairs, "\n")
+
+	secret := hmacSHA256([]byte(webAppDataKey), []byte(botToken))
+	expected := hmacSHA256(secret, []byte(dataCheckString))
+
+	received, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(expected, received) {
+		return nil, ErrInitDataSignature
+	}
+
+	authDateUnix, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid auth_date", ErrInitDataMalformed)
+	}
+	authDate := time.Unix(authDateUnix, 0)
+	if maxAge > 0 && now.Sub(authDate) > maxAge {
+		return nil, ErrInitDataExpired
+	}
+
+	var user WebAppUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil {
+		return nil, fmt.Errorf("%w: invalid user: %v", ErrInitDataMalformed, err)
+	}
+	if user.ID <= 0 {
+		return nil, fmt.Errorf("%w: user id is missing", ErrInitDataMalformed)
+	}
+
+	return &InitData{
+		User:     user,
+		AuthDate: authDate,
+		QueryID:  values.Get("query_id"),
+	}, nil
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}